@@ -127,24 +127,35 @@ func (c *BoundContract) Call(opts *CallOpts, result interface{}, method string,
 	if err != nil {
 		return err
 	}
+	output, err := c.call(opts, input)
+	if err != nil {
+		return err
+	}
+	return c.abi.Unpack(result, method, output)
+}
+
+// call executes the packed input as a call, ensuring the contract has code
+// if the call returns nothing.
+func (c *BoundContract) call(opts *CallOpts, input []byte) ([]byte, error) {
 	var (
 		msg    = ethereum.CallMsg{From: opts.From, To: &c.address, Data: input}
 		ctx    = ensureContext(opts.Context)
 		code   []byte
 		output []byte
+		err    error
 	)
 	if opts.Pending {
 		pb, ok := c.caller.(PendingContractCaller)
 		if !ok {
-			return ErrNoPendingState
+			return nil, ErrNoPendingState
 		}
 		output, err = pb.PendingCallContract(ctx, msg)
 		if err == nil && len(output) == 0 {
 			// Make sure we have a contract to operate on, and bail out otherwise.
 			if code, err = pb.PendingCodeAt(ctx, c.address); err != nil {
-				return err
+				return nil, err
 			} else if len(code) == 0 {
-				return ErrNoCode
+				return nil, ErrNoCode
 			}
 		}
 	} else {
@@ -152,16 +163,13 @@ func (c *BoundContract) Call(opts *CallOpts, result interface{}, method string,
 		if err == nil && len(output) == 0 {
 			// Make sure we have a contract to operate on, and bail out otherwise.
 			if code, err = c.caller.CodeAt(ctx, c.address, nil); err != nil {
-				return err
+				return nil, err
 			} else if len(code) == 0 {
-				return ErrNoCode
+				return nil, ErrNoCode
 			}
 		}
 	}
-	if err != nil {
-		return err
-	}
-	return c.abi.Unpack(result, method, output)
+	return output, err
 }
 
 // Transact invokes the (paid) contract method with params as input values.
@@ -253,7 +261,11 @@ func (c *BoundContract) FilterLogs(opts *FilterOpts, name string, query ...[]int
 	}
 	// Append the event selector to the query parameters and construct the topic set
 	query = append([][]interface{}{{c.abi.Events[name].Id()}}, query...)
+	return c.filterLogs(opts, query...)
+}
 
+// filterLogs filters contract logs matching the topic query for past blocks.
+func (c *BoundContract) filterLogs(opts *FilterOpts, query ...[]interface{}) (chan types.Log, event.Subscription, error) {
 	topics, err := makeTopics(query...)
 	if err != nil {
 		return nil, nil, err
@@ -302,7 +314,11 @@ func (c *BoundContract) WatchLogs(opts *WatchOpts, name string, query ...[]inter
 	}
 	// Append the event selector to the query parameters and construct the topic set
 	query = append([][]interface{}{{c.abi.Events[name].Id()}}, query...)
+	return c.watchLogs(opts, query...)
+}
 
+// watchLogs subscribes to contract logs matching the topic query for future blocks.
+func (c *BoundContract) watchLogs(opts *WatchOpts, query ...[]interface{}) (chan types.Log, event.Subscription, error) {
 	topics, err := makeTopics(query...)
 	if err != nil {
 		return nil, nil, err
@@ -39,6 +39,7 @@ const (
 	LangGo Lang = iota
 	LangJava
 	LangObjC
+	LangWasm // Go bindings of the WASM contracts
 )
 
 // Bind generates a Go wrapper around a contract ABI. This wrapper isn't meant
@@ -46,6 +47,9 @@ const (
 // enforces compile time type safety and naming convention opposed to having to
 // manually maintain hard coded strings that break on runtime.
 func Bind(types []string, abis []string, bytecodes []string, pkg string, lang Lang) (string, error) {
+	if lang == LangWasm {
+		return bindWasm(types, abis, bytecodes, pkg)
+	}
 	// Process each individual contract requested binding
 	contracts := make(map[string]*tmplContract)
 
@@ -0,0 +1,179 @@
+package bind
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/PlatONnetwork/PlatON-Go/accounts/abi"
+	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/PlatONnetwork/PlatON-Go/core/types"
+	"github.com/PlatONnetwork/PlatON-Go/crypto"
+	"github.com/PlatONnetwork/PlatON-Go/event"
+	"github.com/PlatONnetwork/PlatON-Go/life/utils"
+	"github.com/PlatONnetwork/PlatON-Go/rlp"
+)
+
+// The transaction types of the WASM payload rlp([txType][funcName][args...]).
+const (
+	WasmTxTypeDeploy int64 = 1 // rlp([txType][code][abi]) creating a contract
+	WasmTxTypeInvoke int64 = 2 // the transaction invoking a contract method
+	WasmTxTypeCall   int64 = 9 // the call of a constant contract method
+)
+
+// ParseWasmABI parses the JSON ABI of a WASM contract, the legacy
+// array format and the versioned one are both accepted.
+func ParseWasmABI(abiJSON string) (*utils.WasmAbi, error) {
+	parsed := new(utils.WasmAbi)
+	if err := parsed.FromJson([]byte(abiJSON)); err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
+
+// WasmBoundContract is the base wrapper object that reflects a WASM contract on
+// the PlatON network. The payloads are encoded in the rlp format expected by the
+// WASM interpreter instead of the Solidity ABI.
+type WasmBoundContract struct {
+	base *BoundContract // Generic contract wrapper reused for the low level calls
+	abi  *utils.WasmAbi // Parsed WASM ABI to encode the payloads with
+}
+
+// NewWasmBoundContract creates a low level WASM contract interface through which
+// calls and transactions may be made through.
+func NewWasmBoundContract(address common.Address, wasmAbi *utils.WasmAbi, caller ContractCaller, transactor ContractTransactor, filterer ContractFilterer) *WasmBoundContract {
+	return &WasmBoundContract{
+		base: NewBoundContract(address, abi.ABI{}, caller, transactor, filterer),
+		abi:  wasmAbi,
+	}
+}
+
+// DeployWasmContract deploys a WASM contract onto the PlatON blockchain and binds
+// the deployment address with a Go wrapper. The ABI is deployed along with the code.
+func DeployWasmContract(opts *TransactOpts, abiJSON string, code []byte, backend ContractBackend) (common.Address, *types.Transaction, *WasmBoundContract, error) {
+	parsed, err := ParseWasmABI(abiJSON)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	c := NewWasmBoundContract(common.Address{}, parsed, backend, backend, backend)
+
+	input, err := rlp.EncodeToBytes([][]byte{utils.Int64ToBytes(WasmTxTypeDeploy), code, []byte(abiJSON)})
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	tx, err := c.base.transact(opts, nil, input)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	c.base.address = crypto.CreateAddress(opts.From, tx.Nonce())
+	return c.base.address, tx, c, nil
+}
+
+// Address returns the deployment address of the contract.
+func (c *WasmBoundContract) Address() common.Address {
+	return c.base.address
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result, which must be a pointer to the Go type of the output.
+func (c *WasmBoundContract) Call(opts *CallOpts, result interface{}, method string, params ...interface{}) error {
+	// Don't crash on a lazy user
+	if opts == nil {
+		opts = new(CallOpts)
+	}
+	input, err := c.abi.Pack(WasmTxTypeCall, method, params...)
+	if err != nil {
+		return err
+	}
+	output, err := c.base.call(opts, input)
+	if err != nil {
+		return err
+	}
+	value, err := c.abi.Unpack(method, output)
+	if err != nil || result == nil || value == nil {
+		return err
+	}
+	return assignValue(reflect.ValueOf(result), value)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (c *WasmBoundContract) Transact(opts *TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	input, err := c.abi.Pack(WasmTxTypeInvoke, method, params...)
+	if err != nil {
+		return nil, err
+	}
+	return c.base.transact(opts, &c.base.address, input)
+}
+
+// FilterLogs filters the contract event logs for past blocks, returning the necessary
+// channels to construct a strongly typed bound iterator on top of them.
+func (c *WasmBoundContract) FilterLogs(opts *FilterOpts, name string) (chan types.Log, event.Subscription, error) {
+	// Don't crash on a lazy user
+	if opts == nil {
+		opts = new(FilterOpts)
+	}
+	topic, err := c.eventTopic(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.base.filterLogs(opts, []interface{}{topic})
+}
+
+// WatchLogs subscribes to the contract event logs for future blocks, returning a
+// subscription object that can be used to tear down the watcher.
+func (c *WasmBoundContract) WatchLogs(opts *WatchOpts, name string) (chan types.Log, event.Subscription, error) {
+	// Don't crash on a lazy user
+	if opts == nil {
+		opts = new(WatchOpts)
+	}
+	topic, err := c.eventTopic(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.base.watchLogs(opts, []interface{}{topic})
+}
+
+// UnpackLog unpacks a retrieved event log into the provided output structure,
+// the arguments are assigned to the leading fields of the structure in order.
+func (c *WasmBoundContract) UnpackLog(out interface{}, event string, log types.Log) error {
+	values, err := c.abi.UnpackEvent(event, log.Data)
+	if err != nil {
+		return err
+	}
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("abi: unpack log into a non struct pointer")
+	}
+	rv = rv.Elem()
+	if rv.NumField() < len(values) {
+		return fmt.Errorf("abi: event %s has %d arguments, the structure has %d fields", event, len(values), rv.NumField())
+	}
+	for i, value := range values {
+		if err := assignValue(rv.Field(i).Addr(), value); err != nil {
+			return fmt.Errorf("abi: argument %d of %s: %v", i, event, err)
+		}
+	}
+	return nil
+}
+
+// eventTopic returns the topic of the event, which is the hash of the event name.
+func (c *WasmBoundContract) eventTopic(name string) (common.Hash, error) {
+	ev, ok := c.abi.Event(name)
+	if !ok {
+		return common.Hash{}, fmt.Errorf("abi: event %s not found", name)
+	}
+	return crypto.Keccak256Hash([]byte(ev.Name)), nil
+}
+
+// assignValue sets the decoded value to the pointer.
+func assignValue(ptr reflect.Value, value interface{}) error {
+	if ptr.Kind() != reflect.Ptr || ptr.IsNil() {
+		return errors.New("abi: unpack into a non pointer")
+	}
+	v := reflect.ValueOf(value)
+	if !v.Type().AssignableTo(ptr.Elem().Type()) {
+		return fmt.Errorf("abi: cannot unmarshal %v in to %v", v.Type(), ptr.Elem().Type())
+	}
+	ptr.Elem().Set(v)
+	return nil
+}
@@ -0,0 +1,152 @@
+package bind
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/PlatONnetwork/PlatON-Go/life/utils"
+	"golang.org/x/tools/imports"
+)
+
+// bindWasm generates a Go wrapper around a WASM contract ABI in the cpp.abi.json
+// format. The generated methods build their payloads in the rlp format expected by
+// the WASM interpreter, the bytecodes are the hex encoded WASM binaries.
+func bindWasm(types []string, abis []string, bytecodes []string, pkg string) (string, error) {
+	// Process each individual contract requested binding
+	contracts := make(map[string]*tmplWasmContract)
+
+	for i := 0; i < len(types); i++ {
+		wasmABI := new(utils.WasmAbi)
+		if err := wasmABI.FromJson([]byte(abis[i])); err != nil {
+			return "", err
+		}
+		// Strip any whitespace from the JSON ABI
+		strippedABI := strings.Map(func(r rune) rune {
+			if unicode.IsSpace(r) {
+				return -1
+			}
+			return r
+		}, abis[i])
+
+		var (
+			calls     = make(map[string]*tmplWasmMethod)
+			transacts = make(map[string]*tmplWasmMethod)
+			events    = make(map[string]*tmplWasmEvent)
+		)
+		for j := range wasmABI.AbiArr {
+			original := &wasmABI.AbiArr[j]
+			switch strings.ToLower(original.Type) {
+			case "function":
+				// The interpreter dispatches to the first function of the name
+				if calls[original.Name] != nil || transacts[original.Name] != nil {
+					continue
+				}
+				inputs, err := bindWasmArgs(original, false)
+				if err != nil {
+					return "", err
+				}
+				output, err := original.OutputType()
+				if err != nil {
+					return "", err
+				}
+				method := &tmplWasmMethod{
+					Original:   original.Name,
+					Normalized: capitalise(original.Name),
+					Signature:  wasmSignature(original, output.Name),
+					Inputs:     inputs,
+				}
+				if output.Kind != utils.VoidKind {
+					method.Output = output.GoType().String()
+				}
+				if original.Constant == "true" {
+					calls[original.Name] = method
+				} else {
+					transacts[original.Name] = method
+				}
+			case "event":
+				fields, err := bindWasmArgs(original, true)
+				if err != nil {
+					return "", err
+				}
+				events[original.Name] = &tmplWasmEvent{
+					Original:   original.Name,
+					Normalized: capitalise(original.Name),
+					Signature:  wasmSignature(original, ""),
+					Inputs:     fields,
+				}
+			}
+		}
+		contracts[types[i]] = &tmplWasmContract{
+			Type:      capitalise(types[i]),
+			InputABI:  strings.Replace(strippedABI, "\"", "\\\"", -1),
+			InputBin:  strings.TrimSpace(bytecodes[i]),
+			Calls:     calls,
+			Transacts: transacts,
+			Events:    events,
+		}
+	}
+	// Generate the contract template data content and render it
+	data := &tmplWasmData{
+		Package:   pkg,
+		Contracts: contracts,
+	}
+	buffer := new(bytes.Buffer)
+
+	tmpl := template.Must(template.New("").Parse(tmplSourceWasm))
+	if err := tmpl.Execute(buffer, data); err != nil {
+		return "", err
+	}
+	code, err := imports.Process(".", buffer.Bytes(), nil)
+	if err != nil {
+		return "", fmt.Errorf("%v\n%s", err, buffer)
+	}
+	return string(code), nil
+}
+
+// wasmReservedNames are the identifiers used by the generated methods, which
+// can't be the parameter names.
+var wasmReservedNames = map[string]bool{
+	"opts": true, "ret": true, "err": true, "big": true, "ethereum": true,
+	"bind": true, "common": true, "types": true, "event": true,
+}
+
+// bindWasmArgs resolves the Go names and types of the inputs. The names are the
+// parameter names of the methods, or the capitalized field names of the events.
+func bindWasmArgs(original *utils.AbiStruct, capitalised bool) ([]*tmplWasmArg, error) {
+	types, err := original.InputTypes()
+	if err != nil {
+		return nil, err
+	}
+	args := make([]*tmplWasmArg, len(types))
+	for i, t := range types {
+		name := original.Inputs[i].Name
+		if capitalised {
+			name = capitalise(name)
+		}
+		if name == "" || token.Lookup(name).IsKeyword() || (!capitalised && wasmReservedNames[name]) {
+			name = fmt.Sprintf("arg%d", i)
+			if capitalised {
+				name = capitalise(name)
+			}
+		}
+		args[i] = &tmplWasmArg{Name: name, Type: t.GoType().String()}
+	}
+	return args, nil
+}
+
+// wasmSignature returns the human readable signature of the ABI function or event.
+func wasmSignature(original *utils.AbiStruct, output string) string {
+	inputs := make([]string, len(original.Inputs))
+	for i, input := range original.Inputs {
+		inputs[i] = strings.TrimSpace(input.Type + " " + input.Name)
+	}
+	signature := fmt.Sprintf("%s(%s)", original.Name, strings.Join(inputs, ", "))
+	if output != "" && output != "void" {
+		signature += " " + output
+	}
+	return signature
+}
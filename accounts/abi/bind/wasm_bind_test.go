@@ -0,0 +1,206 @@
+package bind
+
+import (
+	"context"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/PlatONnetwork/PlatON-Go"
+	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/PlatONnetwork/PlatON-Go/core/types"
+	"github.com/PlatONnetwork/PlatON-Go/crypto"
+	"github.com/PlatONnetwork/PlatON-Go/life/utils"
+	"github.com/PlatONnetwork/PlatON-Go/rlp"
+)
+
+const testWasmABI = `{"version": 2, "abiArr": [
+	{"name": "balanceOf", "type": "function", "constant": "true", "inputs": [{"name": "owner", "type": "address"}], "outputs": [{"name": "", "type": "uint256"}]},
+	{"name": "transfer", "type": "function", "constant": "false", "inputs": [{"name": "to", "type": "address"}, {"name": "type", "type": "uint64"}], "outputs": []},
+	{"name": "Transfer", "type": "event", "inputs": [{"name": "to", "type": "address"}, {"name": "value", "type": "uint64"}]}
+]}`
+
+// wasmTestBackend records the sent payloads and returns the canned call output.
+type wasmTestBackend struct {
+	output []byte
+	calls  [][]byte
+	txs    []*types.Transaction
+	logs   []types.Log
+}
+
+func (b *wasmTestBackend) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{1}, nil
+}
+func (b *wasmTestBackend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	b.calls = append(b.calls, call.Data)
+	return b.output, nil
+}
+func (b *wasmTestBackend) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return []byte{1}, nil
+}
+func (b *wasmTestBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return uint64(len(b.txs)), nil
+}
+func (b *wasmTestBackend) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(1), nil
+}
+func (b *wasmTestBackend) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	return 100000, nil
+}
+func (b *wasmTestBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	b.txs = append(b.txs, tx)
+	return nil
+}
+func (b *wasmTestBackend) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	var logs []types.Log
+	for _, log := range b.logs {
+		if len(query.Topics) > 0 && len(query.Topics[0]) > 0 && query.Topics[0][0] != log.Topics[0] {
+			continue
+		}
+		logs = append(logs, log)
+	}
+	return logs, nil
+}
+func (b *wasmTestBackend) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return nil, ethereum.NotFound
+}
+
+// testWasmBindTester checks the signatures of the generated binding of testWasmABI.
+const testWasmBindTester = `package bindtest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/PlatONnetwork/PlatON-Go/accounts/abi/bind"
+	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/PlatONnetwork/PlatON-Go/core/types"
+	"github.com/PlatONnetwork/PlatON-Go/event"
+)
+
+func TestToken(t *testing.T) {
+	var (
+		_ func(*bind.TransactOpts, bind.ContractBackend) (common.Address, *types.Transaction, *Token, error) = DeployToken
+		_ func(*TokenCaller, *bind.CallOpts, common.Address) (*big.Int, error)                              = (*TokenCaller).BalanceOf
+		_ func(*TokenSession, common.Address) (*big.Int, error)                                             = (*TokenSession).BalanceOf
+		_ func(*TokenTransactor, *bind.TransactOpts, common.Address, uint64) (*types.Transaction, error)    = (*TokenTransactor).Transfer
+		_ func(*TokenFilterer, *bind.FilterOpts) (*TokenTransferIterator, error)                            = (*TokenFilterer).FilterTransfer
+		_ func(*TokenFilterer, *bind.WatchOpts, chan<- *TokenTransfer) (event.Subscription, error)          = (*TokenFilterer).WatchTransfer
+	)
+	var transfer TokenTransfer
+	_, _ = transfer.To, transfer.Value
+}
+`
+
+// Tests that the wasm binding can be successfully compiled with the expected signatures.
+func TestBindWasm(t *testing.T) {
+	// Skip the test if no Go command can be found
+	gocmd := runtime.GOROOT() + "/bin/go"
+	if !common.FileExist(gocmd) {
+		t.Skip("go sdk not found for testing")
+	}
+	ws, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("failed to create temporary workspace: %v", err)
+	}
+	defer os.RemoveAll(ws)
+
+	pkg := filepath.Join(ws, "bindtest")
+	if err = os.MkdirAll(pkg, 0700); err != nil {
+		t.Fatalf("failed to create package: %v", err)
+	}
+	code, err := Bind([]string{"token"}, []string{testWasmABI}, []string{"0061736d"}, "bindtest", LangWasm)
+	if err != nil {
+		t.Fatalf("failed to generate the binding: %v", err)
+	}
+	if err = ioutil.WriteFile(filepath.Join(pkg, "token.go"), []byte(code), 0600); err != nil {
+		t.Fatalf("failed to write binding: %v", err)
+	}
+	if err = ioutil.WriteFile(filepath.Join(pkg, "token_test.go"), []byte(testWasmBindTester), 0600); err != nil {
+		t.Fatalf("failed to write tests: %v", err)
+	}
+	cmd := exec.Command(gocmd, "test", "-v", "-count", "1")
+	cmd.Dir = pkg
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("failed to run binding test: %v\n%s", err, out)
+	}
+}
+
+func TestWasmBoundContract(t *testing.T) {
+	parsed, err := ParseWasmABI(testWasmABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		backend = new(wasmTestBackend)
+		key, _  = crypto.GenerateKey()
+		auth    = NewKeyedTransactor(key)
+		owner   = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	)
+	backend.output, _ = rlp.EncodeToBytes(common.LeftPadBytes([]byte{0x10}, 32))
+
+	address, _, contract, err := DeployWasmContract(auth, testWasmABI, []byte{0, 'a', 's', 'm'}, backend)
+	if err != nil {
+		t.Fatalf("failed to deploy: %v", err)
+	}
+	if address != crypto.CreateAddress(auth.From, 0) || contract.Address() != address {
+		t.Fatalf("deploy address mismatch: %x", address)
+	}
+	var deploy [][]byte
+	if err := rlp.DecodeBytes(backend.txs[0].Data(), &deploy); err != nil || len(deploy) != 3 {
+		t.Fatalf("invalid deploy payload: %v", err)
+	}
+	if common.BytesToInt64(deploy[0]) != WasmTxTypeDeploy || string(deploy[2]) != testWasmABI {
+		t.Fatalf("deploy payload mismatch: %x", backend.txs[0].Data())
+	}
+
+	// Calls are encoded with the call type and the output is decoded by the ABI
+	var balance *big.Int
+	if err := contract.Call(nil, &balance, "balanceOf", owner); err != nil {
+		t.Fatalf("failed to call: %v", err)
+	}
+	if balance.Int64() != 0x10 {
+		t.Errorf("balance mismatch, have %v", balance)
+	}
+	want, _ := parsed.Pack(WasmTxTypeCall, "balanceOf", owner)
+	if common.Bytes2Hex(backend.calls[0]) != common.Bytes2Hex(want) {
+		t.Errorf("call payload mismatch, want %x, have %x", want, backend.calls[0])
+	}
+
+	// Transactions are encoded with the invoke type
+	tx, err := contract.Transact(auth, "transfer", owner, uint64(7))
+	if err != nil {
+		t.Fatalf("failed to transact: %v", err)
+	}
+	want, _ = parsed.Pack(WasmTxTypeInvoke, "transfer", owner, uint64(7))
+	if *tx.To() != address || common.Bytes2Hex(tx.Data()) != common.Bytes2Hex(want) {
+		t.Errorf("transaction payload mismatch, want %x, have %x", want, tx.Data())
+	}
+
+	// Events are filtered by the hash of the name and unpacked in order
+	data, _ := rlp.EncodeToBytes([]interface{}{owner.Bytes(), utils.Uint64ToBytes(7)})
+	backend.logs = []types.Log{
+		{Address: address, Topics: []common.Hash{crypto.Keccak256Hash([]byte("Approval"))}},
+		{Address: address, Topics: []common.Hash{crypto.Keccak256Hash([]byte("Transfer"))}, Data: data},
+	}
+	logs, sub, err := contract.FilterLogs(nil, "Transfer")
+	if err != nil {
+		t.Fatalf("failed to filter logs: %v", err)
+	}
+	defer sub.Unsubscribe()
+	var event struct {
+		To    common.Address
+		Value uint64
+		Raw   types.Log
+	}
+	if err := contract.UnpackLog(&event, "Transfer", <-logs); err != nil {
+		t.Fatalf("failed to unpack log: %v", err)
+	}
+	if event.To != owner || event.Value != 7 {
+		t.Errorf("event mismatch: %+v", event)
+	}
+}
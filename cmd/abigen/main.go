@@ -17,6 +17,7 @@
 package main
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -30,7 +31,7 @@ import (
 
 var (
 	abiFlag = flag.String("abi", "", "Path to the Ethereum contract ABI json to bind, - for STDIN")
-	binFlag = flag.String("bin", "", "Path to the Ethereum contract bytecode (generate deploy method)")
+	binFlag = flag.String("bin", "", "Path to the Ethereum contract bytecode, or the WASM binary for --lang wasm (generate deploy method)")
 	typFlag = flag.String("type", "", "Struct name for the binding (default = package name)")
 
 	solFlag  = flag.String("sol", "", "Path to the Ethereum contract Solidity source to build and bind")
@@ -39,7 +40,7 @@ var (
 
 	pkgFlag  = flag.String("pkg", "", "Package name to generate the binding into")
 	outFlag  = flag.String("out", "", "Output file for the generated binding (default = stdout)")
-	langFlag = flag.String("lang", "go", "Destination language for the bindings (go, java, objc)")
+	langFlag = flag.String("lang", "go", "Destination language for the bindings (go, java, objc, wasm for the Go bindings of a WASM contract ABI)")
 )
 
 func main() {
@@ -65,6 +66,12 @@ func main() {
 		lang = bind.LangJava
 	case "objc":
 		lang = bind.LangObjC
+	case "wasm":
+		lang = bind.LangWasm
+		if *solFlag != "" || *abiFlag == "-" {
+			fmt.Printf("WASM bindings are only generated from the contract ABI file (--abi)\n")
+			os.Exit(-1)
+		}
 	default:
 		fmt.Printf("Unsupported destination language \"%s\" (--lang)\n", *langFlag)
 		os.Exit(-1)
@@ -125,6 +132,10 @@ func main() {
 				os.Exit(-1)
 			}
 		}
+		if lang == bind.LangWasm {
+			// The WASM binary is embedded in hex
+			bin = []byte(hex.EncodeToString(bin))
+		}
 		bins = append(bins, string(bin))
 
 		kind := *typFlag
@@ -31,7 +31,7 @@ import (
 )
 
 const (
-	ipcAPIs  = "admin:1.0 debug:1.0 miner:1.0 net:1.0 personal:1.0 platon:1.0 rpc:1.0 shh:1.0 txpool:1.0 web3:1.0"
+	ipcAPIs  = "admin:1.0 cbft:1.0 debug:1.0 miner:1.0 net:1.0 personal:1.0 platon:1.0 ppos:1.0 rpc:1.0 shh:1.0 txpool:1.0 web3:1.0"
 	httpAPIs = "net:1.0 platon:1.0 rpc:1.0 web3:1.0"
 )
 
@@ -80,6 +80,11 @@ var (
 		utils.TxPoolLifetimeFlag,
 		utils.SyncModeFlag,
 		utils.GCModeFlag,
+		utils.SnapshotDBArchiveFlag,
+		utils.SnapshotDBRetentionFlag,
+		utils.SnapshotDBCompactBlocksFlag,
+		utils.SnapshotDBCompactKVsFlag,
+		utils.SnapshotDBCompactIntervalFlag,
 		utils.LightServFlag,
 		utils.LightPeersFlag,
 		utils.LightKDFFlag,
@@ -195,6 +200,8 @@ func init() {
 		copydbCommand,
 		removedbCommand,
 		dumpCommand,
+		snapshotdbCommand,
+		walCommand,
 		// See monitorcmd.go:
 		monitorCommand,
 		// See accountcmd.go:
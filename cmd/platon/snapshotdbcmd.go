@@ -0,0 +1,230 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/PlatONnetwork/PlatON-Go/cmd/utils"
+	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/PlatONnetwork/PlatON-Go/core/rawdb"
+	"github.com/PlatONnetwork/PlatON-Go/core/snapshotdb"
+	"github.com/PlatONnetwork/PlatON-Go/ethdb"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	snapshotdbNumberFlag = cli.Uint64Flag{
+		Name:  "number",
+		Usage: "Number of the committed block to export, the current head block if not set",
+	}
+	snapshotdbRepairFlag = cli.BoolFlag{
+		Name:  "repair",
+		Usage: "Truncate the snapshotdb to the last consistent block and remove the bad journals",
+	}
+	snapshotdbCommand = cli.Command{
+		Name:     "snapshotdb",
+		Usage:    "Export, import and verify the snapshotdb",
+		Category: "BLOCKCHAIN COMMANDS",
+		Description: `
+The snapshotdb keeps the state of the ppos plugins (staking, restricting, gov...).
+A checkpoint of it can be exported from a synced node and imported by a new node,
+so the state of the plugins doesn't need to be rebuilt by replaying every block.`,
+		Subcommands: []cli.Command{
+			{
+				Action:    utils.MigrateFlags(exportSnapshotDB),
+				Name:      "export",
+				Usage:     "Export the snapshotdb state at a committed block into a checkpoint file",
+				ArgsUsage: "<filename>",
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+					snapshotdbNumberFlag,
+				},
+				Description: `
+The export command writes baseDB and the journals of the committed blocks up to the block
+into one file, tagged with the block hashes and the kv hashes of the blocks and protected by
+a checksum. The block must not be compacted into baseDB yet.`,
+			},
+			{
+				Action:    utils.MigrateFlags(importSnapshotDB),
+				Name:      "import",
+				Usage:     "Import a snapshotdb checkpoint file",
+				ArgsUsage: "<filename>",
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+				},
+				Description: `
+The import command verifies the checksum of the checkpoint, replays the journals of the
+committed blocks in it and checks their kv hashes against the ones committed in the canonical
+headers, then adopts it as the base state of the snapshotdb. The headers up to the block must
+be synced and the snapshotdb must not have committed blocks.`,
+			},
+			{
+				Action: utils.MigrateFlags(verifySnapshotDB),
+				Name:   "verify",
+				Usage:  "Verify the snapshotdb journals against the chain database",
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+					snapshotdbRepairFlag,
+				},
+				Description: `
+The verify command reads every journal of the snapshotdb strictly, checks the journal
+headers, the kv hash chains and the hash chain of the blocks, and checks the committed
+blocks are the canonical ones of the local chain. The first divergent block is reported.
+With --repair the snapshotdb is truncated to the last consistent block and the journals
+after it or failed to verify are removed. The node must be stopped.`,
+			},
+		},
+	}
+)
+
+// exportSnapshotDB writes the checkpoint of the snapshotdb at the block into the file.
+func exportSnapshotDB(ctx *cli.Context) error {
+	if len(ctx.Args()) < 1 {
+		utils.Fatalf("This command requires an argument.")
+	}
+	stack, _ := makeConfigNode(ctx)
+	chainDb := utils.MakeChainDatabase(ctx, stack)
+	defer chainDb.Close()
+
+	var number uint64
+	if ctx.IsSet(snapshotdbNumberFlag.Name) {
+		number = ctx.Uint64(snapshotdbNumberFlag.Name)
+	} else {
+		head := rawdb.ReadHeadBlockHash(chainDb)
+		headNumber := rawdb.ReadHeaderNumber(chainDb, head)
+		if headNumber == nil {
+			utils.Fatalf("Failed to find the head block")
+		}
+		number = *headNumber
+	}
+	hash := rawdb.ReadCanonicalHash(chainDb, number)
+	if hash == (common.Hash{}) {
+		utils.Fatalf("Failed to find the canonical block #%d", number)
+	}
+
+	snapshotdb.SetDBPathWithNode(stack)
+	db := snapshotdb.Instance()
+	defer db.Close()
+
+	fh, err := os.Create(ctx.Args().First())
+	if err != nil {
+		utils.Fatalf("Failed to create the checkpoint file: %v", err)
+	}
+	defer fh.Close()
+	writer := bufio.NewWriter(fh)
+
+	start := time.Now()
+	header, err := db.ExportCheckpoint(writer, new(big.Int).SetUint64(number), hash)
+	if err != nil {
+		utils.Fatalf("Export error: %v", err)
+	}
+	if err := writer.Flush(); err != nil {
+		utils.Fatalf("Export error: %v", err)
+	}
+	fmt.Printf("Export snapshotdb at block #%d [%s], kv hash %s, done in %v\n", header.Number, header.BlockHash.TerminalString(), header.KVHash.TerminalString(), time.Since(start))
+	return nil
+}
+
+// importSnapshotDB adopts the checkpoint file as the base state of the snapshotdb.
+func importSnapshotDB(ctx *cli.Context) error {
+	if len(ctx.Args()) < 1 {
+		utils.Fatalf("This command requires an argument.")
+	}
+	stack, _ := makeConfigNode(ctx)
+	chainDb := utils.MakeChainDatabase(ctx, stack)
+	defer chainDb.Close()
+
+	snapshotdb.SetDBPathWithNode(stack)
+	db := snapshotdb.Instance()
+	defer db.Close()
+
+	fh, err := os.Open(ctx.Args().First())
+	if err != nil {
+		utils.Fatalf("Failed to open the checkpoint file: %v", err)
+	}
+	defer fh.Close()
+
+	start := time.Now()
+	header, err := db.ImportCheckpoint(bufio.NewReader(fh), func(header *snapshotdb.CheckpointHeader) error {
+		return verifyCheckpointHeader(chainDb, header)
+	})
+	if err != nil {
+		utils.Fatalf("Import error: %v", err)
+	}
+	fmt.Printf("Import snapshotdb at block #%d [%s] done in %v\n", header.Number, header.BlockHash.TerminalString(), time.Since(start))
+	return nil
+}
+
+// verifyCheckpointHeader checks the blocks of the checkpoint are the canonical ones of the local chain
+// and their kv hashes are the ones committed in the MixDigest of the headers.
+func verifyCheckpointHeader(chainDb ethdb.Database, header *snapshotdb.CheckpointHeader) error {
+	for _, block := range header.Blocks {
+		number := block.Number.Uint64()
+		hash := rawdb.ReadCanonicalHash(chainDb, number)
+		if hash == (common.Hash{}) {
+			return fmt.Errorf("the header #%d is not found, sync the headers first", number)
+		}
+		if hash != block.BlockHash {
+			return fmt.Errorf("the checkpoint block #%d hash mismatched, want %s, have %s", number, hash.Hex(), block.BlockHash.Hex())
+		}
+		h := rawdb.ReadHeader(chainDb, hash, number)
+		if h == nil {
+			return fmt.Errorf("the header #%d is not found, sync the headers first", number)
+		}
+		if h.MixDigest != block.KVHash {
+			return fmt.Errorf("the checkpoint block #%d kv hash mismatched, want %s, have %s", number, h.MixDigest.Hex(), block.KVHash.Hex())
+		}
+	}
+	return nil
+}
+
+// chainDBReader reads the canonical chain from the chain database for the snapshotdb verification.
+type chainDBReader struct {
+	db ethdb.Database
+}
+
+func (c chainDBReader) CanonicalHash(number uint64) common.Hash {
+	return rawdb.ReadCanonicalHash(c.db, number)
+}
+
+func (c chainDBReader) HeadNumber() uint64 {
+	number := rawdb.ReadHeaderNumber(c.db, rawdb.ReadHeadBlockHash(c.db))
+	if number == nil {
+		return 0
+	}
+	return *number
+}
+
+// verifySnapshotDB checks the snapshotdb journals against the chain database and repairs them if asked.
+func verifySnapshotDB(ctx *cli.Context) error {
+	stack, _ := makeConfigNode(ctx)
+	chainDb := utils.MakeChainDatabase(ctx, stack)
+	defer chainDb.Close()
+
+	repair := ctx.Bool(snapshotdbRepairFlag.Name)
+	start := time.Now()
+	result, err := snapshotdb.Verify(stack.ResolvePath(snapshotdb.DBPath), chainDBReader{chainDb}, repair)
+	if result != nil {
+		fmt.Printf("Snapshotdb base block #%d, highest block #%d, %d journals\n", result.BaseNum, result.HighestNum, result.Journals)
+		for _, issue := range result.Issues {
+			fmt.Println(issue)
+		}
+		if result.Divergent != nil {
+			fmt.Printf("Divergent at %s\n", result.Divergent)
+		}
+		if result.Repaired {
+			fmt.Println("Snapshotdb repaired")
+		}
+	}
+	if err != nil {
+		utils.Fatalf("Verify error: %v", err)
+	}
+	if result.Divergent != nil && !repair {
+		utils.Fatalf("Snapshotdb is inconsistent with the chain, run with --repair to truncate it")
+	}
+	fmt.Printf("Verify snapshotdb done in %v\n", time.Since(start))
+	return nil
+}
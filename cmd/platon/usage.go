@@ -76,6 +76,11 @@ var AppHelpFlagGroups = []flagGroup{
 			utils.BetanetFlag,
 			utils.SyncModeFlag,
 			utils.GCModeFlag,
+			utils.SnapshotDBArchiveFlag,
+			utils.SnapshotDBRetentionFlag,
+			utils.SnapshotDBCompactBlocksFlag,
+			utils.SnapshotDBCompactKVsFlag,
+			utils.SnapshotDBCompactIntervalFlag,
 			utils.EthStatsURLFlag,
 			utils.IdentityFlag,
 			utils.LightServFlag,
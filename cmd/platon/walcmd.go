@@ -0,0 +1,259 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/PlatONnetwork/PlatON-Go/cmd/utils"
+	"github.com/PlatONnetwork/PlatON-Go/consensus/cbft"
+	"github.com/PlatONnetwork/PlatON-Go/core"
+	"github.com/PlatONnetwork/PlatON-Go/core/vm"
+	"github.com/PlatONnetwork/PlatON-Go/eth"
+	"github.com/PlatONnetwork/PlatON-Go/ethdb"
+	"github.com/PlatONnetwork/PlatON-Go/event"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	walCommand = cli.Command{
+		Name:     "wal",
+		Usage:    "Inspect and replay the cbft WAL journals",
+		Category: "BLOCKCHAIN COMMANDS",
+		Description: `
+The cbft WAL journals every consensus message received or sent by the node.
+These commands read the journals offline for the post-mortem of the consensus,
+the node must be stopped. The wal directory of the datadir is used if not given.`,
+		Subcommands: []cli.Command{
+			{
+				Action:    utils.MigrateFlags(dumpWal),
+				Name:      "dump",
+				Usage:     "Print the journal messages in JSON",
+				ArgsUsage: "[<waldir>]",
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+				},
+				Description: `
+The dump command prints one JSON object per journal message with its position
+in the journal, the type, the block, the view and the signer of the message.`,
+			},
+			{
+				Action:    utils.MigrateFlags(statWal),
+				Name:      "stat",
+				Usage:     "Print the count and the time range of the journal messages per type",
+				ArgsUsage: "[<waldir>]",
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+				},
+			},
+			{
+				Action:    utils.MigrateFlags(replayWal),
+				Name:      "replay",
+				Usage:     "Replay the journal messages into a cbft engine backed by the local chain",
+				ArgsUsage: "[<waldir>]",
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+				},
+				Description: `
+The replay command feeds the journal messages into a cbft engine started on a
+temporary copy of the local chain. The messages from peers are processed again
+and the recorded local decisions (view changes and sealed blocks) are checked
+against the engine, every divergence is printed in JSON. The blocks confirmed by
+the replay are written into the copy only, the local chain is not changed.`,
+			},
+		},
+	}
+)
+
+// walPath returns the wal directory from the arguments or the one of the datadir.
+func walPath(ctx *cli.Context) string {
+	if len(ctx.Args()) > 0 {
+		return ctx.Args().First()
+	}
+	stack, _ := makeConfigNode(ctx)
+	// the wal directory of cbft
+	return stack.ResolvePath("wal")
+}
+
+// dumpWal prints the journal messages in JSON.
+func dumpWal(ctx *cli.Context) error {
+	encoder := json.NewEncoder(os.Stdout)
+	err := cbft.ReadWal(walPath(ctx), func(record *cbft.WalRecord) error {
+		return encoder.Encode(record.Summary())
+	})
+	if err != nil {
+		utils.Fatalf("Dump error: %v", err)
+	}
+	return nil
+}
+
+type walTypeStat struct {
+	count       int
+	first, last uint64
+}
+
+// statWal prints the count and the time range of the journal messages per type.
+func statWal(ctx *cli.Context) error {
+	var (
+		stats = make(map[string]*walTypeStat)
+		total walTypeStat
+	)
+	add := func(stat *walTypeStat, timestamp uint64) {
+		if stat.count == 0 || timestamp < stat.first {
+			stat.first = timestamp
+		}
+		if timestamp > stat.last {
+			stat.last = timestamp
+		}
+		stat.count++
+	}
+	err := cbft.ReadWal(walPath(ctx), func(record *cbft.WalRecord) error {
+		summary := record.Summary()
+		if stats[summary.Type] == nil {
+			stats[summary.Type] = new(walTypeStat)
+		}
+		add(stats[summary.Type], summary.Timestamp)
+		add(&total, summary.Timestamp)
+		return nil
+	})
+	if err != nil {
+		utils.Fatalf("Stat error: %v", err)
+	}
+
+	types := make([]string, 0, len(stats))
+	for t := range stats {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	format := func(timestamp uint64) string {
+		return time.Unix(0, int64(timestamp)).Format("2006-01-02 15:04:05.000")
+	}
+	for _, t := range types {
+		fmt.Printf("%-24s %8d  %s - %s\n", t, stats[t].count, format(stats[t].first), format(stats[t].last))
+	}
+	if total.count > 0 {
+		fmt.Printf("%-24s %8d  %s - %s\n", "total", total.count, format(total.first), format(total.last))
+	}
+	return nil
+}
+
+// copyChainDatabase copies the chain database into a temporary directory,
+// the returned function closes and removes the copy.
+func copyChainDatabase(db *ethdb.LDBDatabase) (*ethdb.LDBDatabase, func(), error) {
+	dir, err := ioutil.TempDir("", "platon-wal-replay")
+	if err != nil {
+		return nil, nil, err
+	}
+	copied, err := ethdb.NewLDBDatabase(dir, 0, 0)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, nil, err
+	}
+	release := func() {
+		copied.Close()
+		os.RemoveAll(dir)
+	}
+	it := db.NewIterator()
+	defer it.Release()
+	batch := copied.NewBatch()
+	for it.Next() {
+		if err := batch.Put(it.Key(), it.Value()); err != nil {
+			release()
+			return nil, nil, err
+		}
+		if batch.ValueSize() >= ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				release()
+				return nil, nil, err
+			}
+			batch.Reset()
+		}
+	}
+	if err := it.Error(); err != nil {
+		release()
+		return nil, nil, err
+	}
+	if err := batch.Write(); err != nil {
+		release()
+		return nil, nil, err
+	}
+	return copied, release, nil
+}
+
+// replayWal replays the journal messages into a cbft engine started on a copy of the local chain.
+func replayWal(ctx *cli.Context) error {
+	path := walPath(ctx)
+	stack, cfg := makeConfigNode(ctx)
+	localDb, ok := utils.MakeChainDatabase(ctx, stack).(*ethdb.LDBDatabase)
+	if !ok {
+		utils.Fatalf("The chain database can't be copied")
+	}
+	// the engine writes the blocks it confirms, it must run on a copy of the chain
+	chainDb, release, err := copyChainDatabase(localDb)
+	localDb.Close()
+	if err != nil {
+		utils.Fatalf("Failed to copy the chain database: %v", err)
+	}
+	defer release()
+
+	chainConfig, _, err := core.SetupGenesisBlock(chainDb, cfg.Eth.Genesis)
+	if err != nil {
+		utils.Fatalf("Failed to load the chain config: %v", err)
+	}
+	if chainConfig.Cbft == nil {
+		utils.Fatalf("The chain is not a cbft chain")
+	}
+	// the replaying engine must not journal the messages again
+	cfg.Eth.CbftConfig.WalMode = false
+	engine, ok := eth.CreateConsensusEngine(nil, chainConfig, nil, false, chainDb, &cfg.Eth.CbftConfig, new(event.TypeMux)).(*cbft.Cbft)
+	if !ok {
+		utils.Fatalf("Failed to create the cbft engine")
+	}
+	engine.SetPrivateKey(cfg.Node.NodeKey())
+
+	chain, err := core.NewBlockChain(chainDb, nil, chainConfig, engine, vm.Config{}, nil)
+	if err != nil {
+		utils.Fatalf("Can't create BlockChain: %v", err)
+	}
+	defer chain.Stop()
+	cache := core.NewBlockChainCache(chain)
+	engine.SetBlockChainCache(cache)
+
+	txPoolConfig := cfg.Eth.TxPool
+	txPoolConfig.Journal = ""
+	txPool := core.NewTxPool(txPoolConfig, chainConfig, core.NewTxPoolBlockChain(cache))
+	defer txPool.Stop()
+
+	var agency cbft.Agency
+	switch chainConfig.Cbft.ValidatorMode {
+	case "", "static":
+		agency = cbft.NewStaticAgency(chainConfig.Cbft.InitialNodes)
+	case "inner":
+		blocksPerNode := int(int64(chainConfig.Cbft.Duration) / int64(chainConfig.Cbft.Period))
+		agency = cbft.NewInnerAgency(chainConfig.Cbft.InitialNodes, chain, blocksPerNode, blocksPerNode*2)
+	default:
+		utils.Fatalf("Replay doesn't support the validator mode %s", chainConfig.Cbft.ValidatorMode)
+	}
+	if err := engine.Start(chain, txPool, agency); err != nil {
+		utils.Fatalf("Failed to start the cbft engine: %v", err)
+	}
+	defer engine.Close()
+
+	var (
+		start       = time.Now()
+		encoder     = json.NewEncoder(os.Stdout)
+		divergences int
+	)
+	count, err := engine.ReplayWal(path, func(divergence *cbft.WalDivergence) {
+		divergences++
+		encoder.Encode(divergence)
+	})
+	if err != nil {
+		utils.Fatalf("Replay error: %v", err)
+	}
+	fmt.Printf("Replay %d messages based on block #%d, %d divergences, done in %v\n", count, chain.CurrentBlock().NumberU64(), divergences, time.Since(start))
+	return nil
+}
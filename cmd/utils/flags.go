@@ -183,6 +183,30 @@ var (
 		Usage: `Blockchain garbage collection mode ("full", "archive")`,
 		Value: "full",
 	}
+	SnapshotDBArchiveFlag = cli.BoolFlag{
+		Name:  "snapshotdb.archive",
+		Usage: "Keep the history of the snapshotdb, so the ppos data can be read at any committed block",
+	}
+	SnapshotDBRetentionFlag = cli.Uint64Flag{
+		Name:  "snapshotdb.retention",
+		Usage: "Number of recent blocks whose snapshotdb history is kept in archive mode (0 = keep all)",
+		Value: eth.DefaultConfig.SnapshotDBRetention,
+	}
+	SnapshotDBCompactBlocksFlag = cli.Uint64Flag{
+		Name:  "snapshotdb.compaction.blocks",
+		Usage: "Compact the snapshotdb once the committed blocks not compacted reach it",
+		Value: eth.DefaultConfig.SnapshotDBCompaction.BlockLag,
+	}
+	SnapshotDBCompactKVsFlag = cli.IntFlag{
+		Name:  "snapshotdb.compaction.kvs",
+		Usage: "Maximum number of keys written by one snapshotdb compaction",
+		Value: eth.DefaultConfig.SnapshotDBCompaction.KVLimit,
+	}
+	SnapshotDBCompactIntervalFlag = cli.DurationFlag{
+		Name:  "snapshotdb.compaction.interval",
+		Usage: "Time interval to compact the snapshotdb if it is not compacted by the blocks",
+		Value: eth.DefaultConfig.SnapshotDBCompaction.Interval,
+	}
 	LightServFlag = cli.IntFlag{
 		Name:  "lightserv",
 		Usage: "Maximum percentage of time allowed for serving LES requests (0-90)",
@@ -1186,6 +1210,27 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *eth.Config) {
 	if ctx.GlobalIsSet(SyncModeFlag.Name) {
 		cfg.SyncMode = *GlobalTextMarshaler(ctx, SyncModeFlag.Name).(*downloader.SyncMode)
 	}
+	if ctx.GlobalIsSet(SnapshotDBArchiveFlag.Name) {
+		cfg.SnapshotDBArchive = true
+	}
+	if ctx.GlobalIsSet(SnapshotDBRetentionFlag.Name) {
+		cfg.SnapshotDBRetention = ctx.GlobalUint64(SnapshotDBRetentionFlag.Name)
+	}
+	if ctx.GlobalIsSet(SnapshotDBCompactBlocksFlag.Name) {
+		cfg.SnapshotDBCompaction.BlockLag = ctx.GlobalUint64(SnapshotDBCompactBlocksFlag.Name)
+	}
+	if ctx.GlobalIsSet(SnapshotDBCompactKVsFlag.Name) {
+		cfg.SnapshotDBCompaction.KVLimit = ctx.GlobalInt(SnapshotDBCompactKVsFlag.Name)
+	}
+	if ctx.GlobalIsSet(SnapshotDBCompactIntervalFlag.Name) {
+		cfg.SnapshotDBCompaction.Interval = ctx.GlobalDuration(SnapshotDBCompactIntervalFlag.Name)
+	}
+	if cfg.SnapshotDBCompaction.BlockLag == 0 {
+		Fatalf("Option %q: must be at least 1", SnapshotDBCompactBlocksFlag.Name)
+	}
+	if cfg.SnapshotDBCompaction.Interval < time.Second {
+		Fatalf("Option %q: must be at least 1s, got %v", SnapshotDBCompactIntervalFlag.Name, cfg.SnapshotDBCompaction.Interval)
+	}
 	if ctx.GlobalIsSet(LightServFlag.Name) {
 		cfg.LightServ = ctx.GlobalInt(LightServFlag.Name)
 	}
@@ -1,8 +1,8 @@
 package main
 
 import (
-	"github.com/PlatONnetwork/PlatON-Go/common"
 	"github.com/PlatONnetwork/PlatON-Go/core/lru"
+	"github.com/PlatONnetwork/PlatON-Go/crypto"
 	"github.com/PlatONnetwork/PlatON-Go/life/exec"
 	"bytes"
 	"errors"
@@ -104,11 +104,11 @@ func benchmark(wasmFile string, outDir string, loop int) error {
 		return err
 	}
 
-	addr := common.HexToAddress("0x43355c787c50b647c425f594b441d4bd751951c1")
-	lru.WasmCache().Add(addr, &lru.WasmModule{m, functionCode})
+	codeHash := crypto.Keccak256Hash(code)
+	lru.WasmCache().Add(codeHash, &lru.WasmModule{Code: code, Module: m, FunctionCode: functionCode})
 
 	for i := 0; i < loop; i++ {
-		m, ok := lru.WasmCache().Get(addr)
+		m, ok := lru.WasmCache().Get(codeHash)
 		if !ok {
 			return errors.New("get wasm cache error")
 		}
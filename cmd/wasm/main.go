@@ -58,6 +58,14 @@ var (
 		Name:  "input",
 		Usage: "input for the wasm",
 	}
+	MethodFlag = cli.StringFlag{
+		Name:  "method",
+		Usage: "The method to call, the input is encoded with --txtype by the abi instead of --input",
+	}
+	ArgsFlag = cli.StringFlag{
+		Name:  "args",
+		Usage: "JSON array of the method arguments, e.g. '[1, \"0x01\", [\"a\", \"b\"]]'",
+	}
 	VerbosityFlag = cli.IntFlag{
 		Name:  "verbosity",
 		Usage: "sets the verbosity level",
@@ -96,6 +104,11 @@ func init() {
 		GasPriceFlag,
 		ValueFlag,
 		InputFlag,
+		TxTypeFlag,
+		AbiFlag,
+		AbiFileFlag,
+		MethodFlag,
+		ArgsFlag,
 		MemProfileFlag,
 		StatDumpFlag,
 		GenesisFlag,
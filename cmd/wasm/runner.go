@@ -18,6 +18,7 @@ import (
 	"io/ioutil"
 	"math/big"
 	"os"
+	"strings"
 	"time"
 
 	covert "github.com/PlatONnetwork/PlatON-Go/life/utils"
@@ -170,6 +171,13 @@ func runCmd(ctx *cli.Context) error {
 	}
 
 	txType := ctx.GlobalInt64(TxTypeFlag.Name)
+	method := ctx.GlobalString(MethodFlag.Name)
+	wasmabi := new(covert.WasmAbi)
+	if method != "" {
+		if err := wasmabi.FromJson(abi); err != nil {
+			utils.Fatalf("invalid abi: %v", err)
+		}
+	}
 
 	tstart := time.Now()
 	var leftOverGas uint64
@@ -193,6 +201,9 @@ func runCmd(ctx *cli.Context) error {
 		}
 		// input : rlp.encoded format.
 		input := common.Hex2Bytes(ctx.GlobalString(InputFlag.Name))
+		if method != "" {
+			input = packInput(wasmabi, txType, method, ctx.GlobalString(ArgsFlag.Name))
+		}
 		ret, leftOverGas, err = runtime.Call(receiver, input, &runtimeConfig)
 	}
 	execTime := time.Since(tstart)
@@ -217,8 +228,38 @@ Gas used:           %d
 		fmt.Printf("0x%x\n", ret)
 		if err != nil {
 			fmt.Printf(" error: %v\n", err)
+		} else if method != "" && (wasmabi.Version >= covert.AbiVersion2 || txType == vm.CALL_CANTRACT_FLAG) {
+			printOutput(wasmabi, method, ret)
 		}
 	}
 
 	return nil
 }
+
+// packInput encodes the call input of the method with the JSON array arguments.
+func packInput(wasmabi *covert.WasmAbi, txType int64, method string, jsonArgs string) []byte {
+	var args []interface{}
+	if jsonArgs != "" {
+		decoder := json.NewDecoder(strings.NewReader(jsonArgs))
+		decoder.UseNumber()
+		if err := decoder.Decode(&args); err != nil {
+			utils.Fatalf("invalid args: %v", err)
+		}
+	}
+	input, err := wasmabi.Pack(txType, method, args...)
+	if err != nil {
+		utils.Fatalf("pack input fail: %v", err)
+	}
+	return input
+}
+
+// printOutput prints the value returned by the method in JSON.
+func printOutput(wasmabi *covert.WasmAbi, method string, ret []byte) {
+	value, err := wasmabi.Unpack(method, ret)
+	if err != nil {
+		fmt.Printf(" unpack error: %v\n", err)
+		return
+	}
+	output, _ := json.Marshal(value)
+	fmt.Println(string(output))
+}
@@ -215,3 +215,19 @@ func Uint32ToBytes(val uint32) []byte {
 	binary.BigEndian.PutUint32(buf, val)
 	return buf[:]
 }
+
+// BytesToUint64 converts the big endian bytes to uint64, the bytes longer than 8
+// are truncated to the last 8 bytes.
+func BytesToUint64(b []byte) uint64 {
+	if len(b) > 8 {
+		b = b[len(b)-8:]
+	}
+	b = append(make([]byte, 8-len(b)), b...)
+	return binary.BigEndian.Uint64(b)
+}
+
+func Uint64ToBytes(val uint64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, val)
+	return buf[:]
+}
@@ -103,3 +103,20 @@ func TestNoPrefixShortHexOddLength(t *testing.T) {
 		t.Errorf("Expected %x got %x", expected, result)
 	}
 }
+
+func TestBytesToUint64(t *testing.T) {
+	tests := []struct {
+		input    []byte
+		expected uint64
+	}{
+		{nil, 0},
+		{[]byte{1, 2}, 0x0102},
+		{[]byte{1, 2, 3, 4, 5, 6, 7, 8}, 0x0102030405060708},
+		{[]byte{9, 1, 2, 3, 4, 5, 6, 7, 8}, 0x0102030405060708},
+	}
+	for _, test := range tests {
+		if result := BytesToUint64(test.input); result != test.expected {
+			t.Errorf("Expected %x got %x", test.expected, result)
+		}
+	}
+}
@@ -9,5 +9,7 @@ var (
 	AwardMgrContractAddr = common.HexToAddress("0x1000000000000000000000000000000000000003")
 	SlashingContractAddr = common.HexToAddress("0x1000000000000000000000000000000000000004")
 	GovContractAddr = common.HexToAddress("0x1000000000000000000000000000000000000005")
+	// the delegate reward is kept here until the delegator withdraws it
+	DelegateRewardPoolAddr = common.HexToAddress("0x1000000000000000000000000000000000000006")
 	ValidatorInnerContractAddr = common.HexToAddress("0x2000000000000000000000000000000000000000")
 )
@@ -0,0 +1,218 @@
+package cbft
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/PlatONnetwork/PlatON-Go/p2p/discover"
+)
+
+var errCbftStopped = errors.New("cbft is stopped")
+
+// ViewInfo is the view of cbft
+type ViewInfo struct {
+	Timestamp     uint64         `json:"timestamp"`
+	ProposalIndex uint32         `json:"proposal_index"`
+	ProposalAddr  common.Address `json:"proposal_address"`
+	BaseBlockNum  uint64         `json:"base_block_number"`
+	BaseBlockHash common.Hash    `json:"base_block_hash"`
+	Votes         int            `json:"votes"`
+}
+
+func newViewInfo(view *viewChange, votes ViewChangeVotes) *ViewInfo {
+	if view == nil {
+		return nil
+	}
+	return &ViewInfo{
+		Timestamp:     view.Timestamp,
+		ProposalIndex: view.ProposalIndex,
+		ProposalAddr:  view.ProposalAddr,
+		BaseBlockNum:  view.BaseBlockNum,
+		BaseBlockHash: view.BaseBlockHash,
+		Votes:         len(votes),
+	}
+}
+
+// ViewChangeRecord is a view confirmed with 2f+1 view change votes
+type ViewChangeRecord struct {
+	*ViewInfo
+	Master      bool  `json:"master"`
+	ConfirmedAt int64 `json:"confirmed_at"` // milliseconds
+}
+
+// BlockInfo is a block in the block tree of cbft
+type BlockInfo struct {
+	Number        uint64         `json:"number"`
+	Hash          common.Hash    `json:"hash"`
+	ParentHash    common.Hash    `json:"parent_hash"`
+	Timestamp     uint64         `json:"timestamp"`
+	ProposalIndex uint32         `json:"proposal_index"`
+	ProposalAddr  common.Address `json:"proposal_address"`
+	Votes         int            `json:"votes"`
+	IsExecuted    bool           `json:"is_executed"`
+	IsSigned      bool           `json:"is_signed"`
+	IsConfirmed   bool           `json:"is_confirmed"`
+}
+
+func newBlockInfo(ext *BlockExt) *BlockInfo {
+	if ext == nil || ext.block == nil {
+		return nil
+	}
+	info := &BlockInfo{
+		Number:        ext.number,
+		Hash:          ext.block.Hash(),
+		ParentHash:    ext.block.ParentHash(),
+		Timestamp:     ext.timestamp,
+		ProposalIndex: ext.proposalIndex,
+		ProposalAddr:  ext.proposalAddr,
+		IsExecuted:    ext.isExecuted,
+		IsSigned:      ext.isSigned,
+		IsConfirmed:   ext.isConfirmed,
+	}
+	if ext.prepareVotes != nil {
+		info.Votes = ext.prepareVotes.Len()
+	}
+	return info
+}
+
+// BlockStatus is the highest blocks of cbft
+type BlockStatus struct {
+	HighestConfirmed *BlockInfo `json:"highest_confirmed"`
+	HighestLogical   *BlockInfo `json:"highest_logical"`
+	RootIrreversible *BlockInfo `json:"root_irreversible"`
+}
+
+// ValidatorInfo is a validator of the current validator set
+type ValidatorInfo struct {
+	NodeID  discover.NodeID `json:"node_id"`
+	Index   int             `json:"index"`
+	Address common.Address  `json:"address"`
+	Latency int64           `json:"latency"` // the average net latency in milliseconds
+}
+
+// ValidatorSet is the current validator set of cbft
+type ValidatorSet struct {
+	ValidBlockNumber uint64           `json:"valid_block_number"`
+	Validators       []*ValidatorInfo `json:"validators"`
+}
+
+// recordViewChange appends the confirmed view to the history
+func (cbft *Cbft) recordViewChange() {
+	cbft.viewChangeHistory = append(cbft.viewChangeHistory, &ViewChangeRecord{
+		ViewInfo:    newViewInfo(cbft.viewChange, cbft.viewChangeVotes),
+		Master:      cbft.master,
+		ConfirmedAt: time.Now().UnixNano() / 1e6,
+	})
+	if len(cbft.viewChangeHistory) > maxViewChangeHistory {
+		cbft.viewChangeHistory = append(cbft.viewChangeHistory[:0], cbft.viewChangeHistory[1:]...)
+	}
+}
+
+// callInLoop runs the function in the receiveLoop,
+// so the state of cbft can be read without race.
+func (cbft *Cbft) callInLoop(fn func()) error {
+	done := make(chan struct{})
+	select {
+	case cbft.debugCh <- func() {
+		fn()
+		close(done)
+	}:
+	case <-cbft.exitCh:
+		return errCbftStopped
+	}
+	<-done
+	return nil
+}
+
+// PublicCbftAPI provides the structured information of cbft.
+type PublicCbftAPI struct {
+	cbft *Cbft
+}
+
+// NewPublicCbftAPI creates a new cbft API.
+func NewPublicCbftAPI(cbft *Cbft) *PublicCbftAPI {
+	return &PublicCbftAPI{cbft: cbft}
+}
+
+// Evidences returns the double sign evidences committed by the node, detected by itself
+// or gossiped by the peers. The json format is same as the evidences of the slashing plugin,
+// so they can be submitted for slashing by any node.
+func (api *PublicCbftAPI) Evidences() *EvidenceData {
+	return ClassifyEvidence(api.cbft.evPool.Evidences())
+}
+
+// CurrentView returns the current view, nil if there is no view.
+func (api *PublicCbftAPI) CurrentView() (view *ViewInfo, err error) {
+	err = api.cbft.callInLoop(func() {
+		view = newViewInfo(api.cbft.viewChange, api.cbft.viewChangeVotes)
+	})
+	return
+}
+
+// Blocks returns the highest confirmed, the highest logical and the root irreversible block.
+func (api *PublicCbftAPI) Blocks() (status *BlockStatus, err error) {
+	err = api.cbft.callInLoop(func() {
+		status = &BlockStatus{
+			HighestConfirmed: newBlockInfo(api.cbft.getHighestConfirmed()),
+			HighestLogical:   newBlockInfo(api.cbft.getHighestLogical()),
+			RootIrreversible: newBlockInfo(api.cbft.getRootIrreversible()),
+		}
+	})
+	return
+}
+
+// BlockTree returns the pending blocks from the root irreversible block, ordered by number.
+func (api *PublicCbftAPI) BlockTree() (blocks []*BlockInfo, err error) {
+	err = api.cbft.callInLoop(func() {
+		blocks = make([]*BlockInfo, 0)
+		for _, extMap := range api.cbft.blockExtMap.blocks {
+			for _, ext := range extMap {
+				if info := newBlockInfo(ext); info != nil {
+					blocks = append(blocks, info)
+				}
+			}
+		}
+	})
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].Number < blocks[j].Number
+	})
+	return
+}
+
+// Validators returns the current validator set with the average net latency of each validator.
+func (api *PublicCbftAPI) Validators() (set *ValidatorSet, err error) {
+	err = api.cbft.callInLoop(func() {
+		validators := api.cbft.getValidators()
+		set = &ValidatorSet{
+			ValidBlockNumber: validators.ValidBlockNumber,
+			Validators:       make([]*ValidatorInfo, 0, validators.Len()),
+		}
+		api.cbft.netLatencyLock.RLock()
+		defer api.cbft.netLatencyLock.RUnlock()
+		for id, node := range validators.Nodes {
+			set.Validators = append(set.Validators, &ValidatorInfo{
+				NodeID:  id,
+				Index:   node.Index,
+				Address: node.Address,
+				Latency: api.cbft.avgLatency(id),
+			})
+		}
+	})
+	if set != nil {
+		sort.Slice(set.Validators, func(i, j int) bool {
+			return set.Validators[i].Index < set.Validators[j].Index
+		})
+	}
+	return
+}
+
+// ViewChangeHistory returns the latest confirmed views, the oldest first.
+func (api *PublicCbftAPI) ViewChangeHistory() (history []*ViewChangeRecord, err error) {
+	err = api.cbft.callInLoop(func() {
+		history = make([]*ViewChangeRecord, len(api.cbft.viewChangeHistory))
+		copy(history, api.cbft.viewChangeHistory)
+	})
+	return
+}
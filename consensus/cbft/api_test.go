@@ -0,0 +1,62 @@
+package cbft
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublicCbftAPI(t *testing.T) {
+	path := path()
+	defer os.RemoveAll(path)
+
+	engine, _, v := randomCBFT(path, 4)
+	go engine.receiveLoop()
+	api := NewPublicCbftAPI(engine)
+
+	view, err := api.CurrentView()
+	assert.Nil(t, err)
+	assert.Nil(t, view)
+
+	timestamp := uint64(common.Millis(time.Now()))
+	genesis := engine.blockChain.Genesis()
+	assert.Nil(t, engine.callInLoop(func() {
+		engine.viewChange = makeViewChange(v.validator(0).privateKey, timestamp, 0, genesis.Hash(), 0, v.validator(0).address, nil)
+		engine.recordViewChange()
+	}))
+	view, err = api.CurrentView()
+	assert.Nil(t, err)
+	assert.Equal(t, timestamp, view.Timestamp)
+	assert.Equal(t, v.validator(0).address, view.ProposalAddr)
+	assert.Equal(t, genesis.Hash(), view.BaseBlockHash)
+
+	history, err := api.ViewChangeHistory()
+	assert.Nil(t, err)
+	assert.Len(t, history, 1)
+	assert.Equal(t, timestamp, history[0].Timestamp)
+
+	status, err := api.Blocks()
+	assert.Nil(t, err)
+	assert.Equal(t, genesis.Hash(), status.HighestConfirmed.Hash)
+	assert.Equal(t, genesis.Hash(), status.HighestLogical.Hash)
+	assert.Equal(t, genesis.Hash(), status.RootIrreversible.Hash)
+
+	blocks, err := api.BlockTree()
+	assert.Nil(t, err)
+	assert.Len(t, blocks, 1)
+	assert.Equal(t, uint64(0), blocks[0].Number)
+
+	assert.Nil(t, engine.OnPong(v.validator(1).nodeID, 100))
+	set, err := api.Validators()
+	assert.Nil(t, err)
+	assert.Len(t, set.Validators, 4)
+	for i, validator := range set.Validators {
+		assert.Equal(t, i, validator.Index)
+		assert.Equal(t, v.validator(uint32(i)).nodeID, validator.NodeID)
+	}
+	assert.Equal(t, int64(100), set.Validators[1].Latency)
+	assert.Equal(t, engine.config.MaxLatency, set.Validators[2].Latency)
+}
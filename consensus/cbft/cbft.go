@@ -34,6 +34,8 @@ import (
 	"github.com/PlatONnetwork/PlatON-Go/p2p/discover"
 	"github.com/PlatONnetwork/PlatON-Go/params"
 	"github.com/PlatONnetwork/PlatON-Go/rpc"
+	"github.com/PlatONnetwork/PlatON-Go/x/xcom"
+	"github.com/PlatONnetwork/PlatON-Go/x/xutil"
 	lru "github.com/hashicorp/golang-lru"
 )
 
@@ -85,6 +87,9 @@ var (
 	maxBlockDist = uint64(192)
 
 	maxQueuesLimit = 4096
+
+	// maxViewChangeHistory is the number of the confirmed views kept for the debug API
+	maxViewChangeHistory = 64
 )
 
 func NewFaker() consensus.Engine {
@@ -116,6 +121,7 @@ type Cbft struct {
 	statusCh                chan chan string
 	getBlockByHashCh        chan *GetBlock
 	fastSyncCommitHeadCh    chan chan error
+	debugCh                 chan func()
 	needPending             bool
 	RoundState
 
@@ -149,6 +155,9 @@ type Cbft struct {
 
 	evPool  EvidencePool
 	tracing *tracing
+
+	// the latest confirmed views, only accessed in the receiveLoop
+	viewChangeHistory []*ViewChangeRecord
 }
 
 // New creates a concurrent BFT consensus engine
@@ -173,6 +182,7 @@ func New(config *params.CbftConfig, eventMux *event.TypeMux, ctx *node.ServiceCo
 		statusCh:                make(chan chan string, peerMsgQueueSize),
 		getBlockByHashCh:        make(chan *GetBlock),
 		fastSyncCommitHeadCh:    make(chan chan error),
+		debugCh:                 make(chan func()),
 		netLatencyMap:           make(map[discover.NodeID]*list.List),
 		log:                     log.New(),
 		nodeServiceContext:      ctx,
@@ -341,6 +351,8 @@ func (cbft *Cbft) scheduleHighestPrepareBlock() {
 }
 
 func (cbft *Cbft) receiveLoop() {
+	checkpoint := time.NewTicker(walCheckpointInterval)
+	defer checkpoint.Stop()
 	for {
 		select {
 		case msg := <-cbft.peerMsgCh:
@@ -380,13 +392,16 @@ func (cbft *Cbft) receiveLoop() {
 			cbft.OnGetBlockByHash(block.hash, block.ch)
 		case fastSync := <-cbft.fastSyncCommitHeadCh:
 			cbft.OnFastSyncCommitHead(fastSync)
+		case <-checkpoint.C:
+			cbft.OnWalCheckpoint()
+		case fn := <-cbft.debugCh:
+			fn()
 		}
 	}
 }
 
 func (cbft *Cbft) handleMsg(info *MsgInfo) {
 	msg, peerID := info.Msg, info.PeerID
-	var err error
 
 	// record the message for received
 	cbft.tracing.RecordReceive(cbft.config.NodeID.TerminalString(),
@@ -405,7 +420,18 @@ func (cbft *Cbft) handleMsg(info *MsgInfo) {
 			return
 		}
 	}
-	isWriteWal := true
+	isWriteWal, err := cbft.dispatchMsg(peerID, msg)
+	if err != nil {
+		cbft.log.Error("Handle msg Failed", "error", err, "type", reflect.TypeOf(msg), "peer", peerID)
+	} else if !cbft.isLoading() && isWriteWal {
+		// write journal msg if cbft is not loading
+		cbft.wal.Write(info)
+	}
+}
+
+// dispatchMsg processes the consensus message, isWriteWal is false if the message needn't be written to wal
+func (cbft *Cbft) dispatchMsg(peerID discover.NodeID, msg Message) (isWriteWal bool, err error) {
+	isWriteWal = true
 	switch msg := msg.(type) {
 	case *prepareBlock:
 		err = cbft.OnNewPrepareBlock(peerID, msg, true)
@@ -435,14 +461,12 @@ func (cbft *Cbft) handleMsg(info *MsgInfo) {
 	case *latestStatus:
 		err = cbft.OnLatestStatus(peerID, msg)
 		isWriteWal = false
+	case *evidences:
+		err = cbft.OnEvidences(peerID, msg)
+		isWriteWal = false
 	}
 
-	if err != nil {
-		cbft.log.Error("Handle msg Failed", "error", err, "type", reflect.TypeOf(msg), "peer", peerID)
-	} else if !cbft.isLoading() && isWriteWal {
-		// write journal msg if cbft is not loading
-		cbft.wal.Write(info)
-	}
+	return isWriteWal, err
 }
 
 func (cbft *Cbft) isRunning() bool {
@@ -770,6 +794,71 @@ func (cbft *Cbft) OnPrepareBlockHash(peerID discover.NodeID, msg *prepareBlockHa
 	return nil
 }
 
+// OnEvidences commits the evidences detected by the other nodes and forwards the unknown ones,
+// the votes of the evidence must be signed by the validators of the block.
+func (cbft *Cbft) OnEvidences(peerID discover.NodeID, msg *evidences) error {
+	cbft.log.Debug("Received message of evidences", "FromPeerId", peerID.TerminalString(), "evidences", msg.String())
+	fresh := make([]Evidence, 0)
+	for _, e := range msg.Flatten() {
+		if err := cbft.verifyEvidence(e); err != nil {
+			cbft.log.Error("Verify evidence failed", "FromPeerId", peerID.TerminalString(), "err", err)
+			return err
+		}
+		if cbft.evPool.Has(e) {
+			continue
+		}
+		if err := cbft.evPool.AddEvidence(e); err != nil {
+			if err == errExpiredEvidence {
+				continue
+			}
+			return err
+		}
+		fresh = append(fresh, e)
+	}
+	if len(fresh) > 0 {
+		cbft.log.Warn("Receive evidences from peer", "FromPeerId", peerID.TerminalString(), "count", len(fresh))
+		go cbft.handler.SendBroadcast(newEvidences(fresh))
+	}
+	return nil
+}
+
+// verifyEvidence verifies the signatures of both votes with the validators of the block
+func (cbft *Cbft) verifyEvidence(e Evidence) error {
+	switch e := e.(type) {
+	case *DuplicatePrepareVoteEvidence:
+		if e.VoteA == nil || e.VoteB == nil {
+			return errInvalidPrepareVotes
+		}
+		if err := cbft.verifyValidatorSign(e.VoteA.Number, e.VoteA.ValidatorIndex, e.VoteA.ValidatorAddr, e.VoteA, e.VoteA.Signature[:]); err != nil {
+			return err
+		}
+		return cbft.verifyValidatorSign(e.VoteB.Number, e.VoteB.ValidatorIndex, e.VoteB.ValidatorAddr, e.VoteB, e.VoteB.Signature[:])
+	case *DuplicateViewChangeVoteEvidence:
+		return cbft.verifyViewChangeVoteEvidence(e.VoteA, e.VoteB)
+	case *TimestampViewChangeVoteEvidence:
+		return cbft.verifyViewChangeVoteEvidence(e.VoteA, e.VoteB)
+	}
+	return fmt.Errorf("unknown evidence type %T", e)
+}
+
+func (cbft *Cbft) verifyViewChangeVoteEvidence(voteA, voteB *viewChangeVote) error {
+	if voteA == nil || voteB == nil {
+		return errInvalidViewChangeVotes
+	}
+	if err := cbft.verifyValidatorSign(voteA.BlockNum, voteA.ValidatorIndex, voteA.ValidatorAddr, voteA, voteA.Signature[:]); err != nil {
+		return err
+	}
+	return cbft.verifyValidatorSign(voteB.BlockNum, voteB.ValidatorIndex, voteB.ValidatorAddr, voteB, voteB.Signature[:])
+}
+
+// broadcastEvidence gossips the evidence detected by the local evidence pool
+func (cbft *Cbft) broadcastEvidence(e Evidence) {
+	if cbft.isLoading() {
+		return
+	}
+	go cbft.handler.SendBroadcast(newEvidences([]Evidence{e}))
+}
+
 func (cbft *Cbft) OnGetLatestStatus(peerID discover.NodeID, msg *getLatestStatus) error {
 	cbft.log.Debug("Received message of getHighestConfirmedStatus", "FromPeerId", peerID.TerminalString(), "Number", msg.Highest, "Type", msg.Type, "msgHash", msg.MsgHash().TerminalString())
 	curConfirmedNum, curLogicNum := cbft.getHighestConfirmed().number, cbft.getHighestLogical().number
@@ -877,6 +966,14 @@ func (cbft *Cbft) Seal(chain consensus.ChainReader, block *types.Block, sealResu
 
 	sealedBlock := block.WithSeal(header)
 
+	// the snapshotdb writes of the block are recorded by its hash from now on
+	if reactor := core.GetReactorInstance(); nil != reactor {
+		if err := reactor.Flush(header); err != nil {
+			log.Error("Seal block flush snapshotdb failed", "err", err)
+			return err
+		}
+	}
+
 	cbft.sealBlockCh <- NewSealBlock(sealedBlock, sealResultCh, stopCh)
 
 	return nil
@@ -1131,10 +1228,22 @@ func (cbft *Cbft) flushReadyBlock() bool {
 	blockConfirmedTimer.UpdateSince(common.MillisToTime(newRoot.rcvTime))
 
 	cbft.evPool.Clear(cbft.viewChange.Timestamp, cbft.viewChange.BaseBlockNum)
+	cbft.evPool.Prune(xutil.CalculateEpoch(newRoot.number), cbft.evidenceValidEpoch(newRoot.block))
 	return true
 
 }
 
+// evidenceValidEpoch returns the valid epochs of the evidences in the state of the block,
+// which may be changed by the param proposal
+func (cbft *Cbft) evidenceValidEpoch(block *types.Block) uint64 {
+	state, err := cbft.blockChain.StateAt(block.Root())
+	if err != nil {
+		cbft.log.Warn("Failed to read the valid epochs of the evidences, use the default value", "number", block.NumberU64(), "hash", block.Hash(), "err", err)
+		return xcom.EvidenceValidEpoch
+	}
+	return xcom.GovernUint64(state, xcom.ParamEvidenceValidEpoch)
+}
+
 // Receive prepare block from the other consensus node.
 // Need check something ,such as validator index, address, view is equal local view , and last verify signature
 func (cbft *Cbft) OnNewPrepareBlock(nodeId discover.NodeID, request *prepareBlock, propagation bool) error {
@@ -1221,6 +1330,7 @@ func (cbft *Cbft) OnNewPrepareBlock(nodeId discover.NodeID, request *prepareBloc
 		//receive 2f+1 view vote , clear last view state
 		if cbft.agreeViewChange() {
 			viewChangeConfirmedTimer.UpdateSince(time.Unix(int64(cbft.viewChange.Timestamp), 0))
+			cbft.recordViewChange()
 			cbft.bp.ViewChangeBP().TwoThirdViewChangeVotes(bpCtx, cbft.viewChange, cbft.viewChangeVotes, cbft)
 			var newHeader *types.Header
 			viewBlock := cbft.blockExtMap.findBlock(cbft.viewChange.BaseBlockHash, cbft.viewChange.BaseBlockNum)
@@ -1753,10 +1863,17 @@ func (cbft *Cbft) Prepare(chain consensus.ChainReader, header *types.Header) err
 }
 
 // Finalize implements consensus.Engine, no block
-// rewards given, and returns the final block.
+// rewards given, commits the kv hash of the snapshotdb and returns the final block.
 func (cbft *Cbft) Finalize(chain consensus.ChainReader, header *types.Header, state *state.StateDB, txs []*types.Transaction, receipts []*types.Receipt) (*types.Block, error) {
 	cbft.log.Debug("finalize block", "hash", header.Hash(), "number", header.Number.Uint64(), "txs", len(txs), "receipts", len(receipts))
 	header.Root = state.IntermediateRoot(chain.Config().IsEIP158(header.Number))
+	// the MixDigest commits the kv hash of the snapshotdb writes, it is set only when the block is produced,
+	// the one of a received block is verified by the block validator.
+	if len(header.Extra) > 32 && bytes.Equal(header.Extra[32:], make([]byte, consensus.ExtraSeal)) {
+		if kvHash, ok := core.SnapshotKVHash(header); ok {
+			header.MixDigest = kvHash
+		}
+	}
 	return types.NewBlock(header, txs, receipts), nil
 }
 
@@ -1821,7 +1938,14 @@ func (cbft *Cbft) update() {
 // APIs implements consensus.Engine, returning the user facing RPC API to allow
 // controlling the signer voting.
 func (cbft *Cbft) APIs(chain consensus.ChainReader) []rpc.API {
-	return []rpc.API{}
+	return []rpc.API{
+		{
+			Namespace: "cbft",
+			Version:   "1.0",
+			Service:   NewPublicCbftAPI(cbft),
+			Public:    true,
+		},
+	}
 }
 
 func (cbft *Cbft) Protocols() []p2p.Protocol {
@@ -1845,8 +1969,9 @@ func (cbft *Cbft) OnPrepareVote(peerID discover.NodeID, vote *prepareVote, propa
 		cbft.log.Debug("Accept block vote", "vote", vote.String())
 		cbft.bp.PrepareBP().AcceptVote(bpCtx, vote, cbft)
 		if err := cbft.evPool.AddPrepareVote(vote); err != nil {
-			if _, ok := err.(*DuplicatePrepareVoteEvidence); ok {
+			if evidence, ok := err.(*DuplicatePrepareVoteEvidence); ok {
 				cbft.log.Warn("Receive DuplicatePrepareVoteEvidence msg", "err", err.Error())
+				cbft.broadcastEvidence(evidence)
 				return err
 			}
 		}
@@ -2375,6 +2500,9 @@ func (cbft *Cbft) AddJournal(info *MsgInfo) {
 	case *sendViewChange:
 		log.Debug("Load journal message from wal", "msgType", reflect.TypeOf(msg), "sendViewChange", msg.ViewChange.String(), "master", msg.Master)
 		cbft.newViewChangeProcess(msg.ViewChange)
+	case *walCheckpoint:
+		log.Debug("Load journal message from wal", "msgType", reflect.TypeOf(msg), "walCheckpoint", msg.String())
+		cbft.restoreWalCheckpoint(msg)
 	case *confirmedViewChange:
 		log.Debug("Load journal message from wal", "msgType", reflect.TypeOf(msg), "confirmedViewChange", msg.ViewChange.String())
 		if msg.Master {
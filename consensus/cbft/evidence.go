@@ -4,15 +4,19 @@ import (
 	"bytes"
 	"crypto/ecdsa"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"github.com/PlatONnetwork/PlatON-Go/common"
 	"github.com/PlatONnetwork/PlatON-Go/crypto"
 	"github.com/PlatONnetwork/PlatON-Go/node"
 	"github.com/PlatONnetwork/PlatON-Go/rlp"
+	"github.com/PlatONnetwork/PlatON-Go/x/xcom"
+	"github.com/PlatONnetwork/PlatON-Go/x/xutil"
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/opt"
 	"math/big"
 	"sort"
+	"sync"
 )
 
 var (
@@ -23,6 +27,7 @@ var (
 	//errDuplicatePrepareVoteEvidence    = errors.New("duplicate prepare vote")
 	//errDuplicateViewChangeVoteEvidence = errors.New("duplicate view change")
 	//errTimestampViewChangeVoteEvidence = errors.New("view change timestamp out of order")
+	errExpiredEvidence = errors.New("the evidence has expired")
 
 	evidenceDir = "evidenceDir"
 )
@@ -65,6 +70,27 @@ func ClassifyEvidence(evds []Evidence) *EvidenceData {
 	return ed
 }
 
+// Flatten returns all of the evidences
+func (ed *EvidenceData) Flatten() []Evidence {
+	evds := make([]Evidence, 0, len(ed.DP)+len(ed.DV)+len(ed.TV))
+	for _, e := range ed.DP {
+		evds = append(evds, e)
+	}
+	for _, e := range ed.DV {
+		evds = append(evds, e)
+	}
+	for _, e := range ed.TV {
+		evds = append(evds, e)
+	}
+	return evds
+}
+
+// isExpiredEvidence returns whether the evidence of the block number can't be used
+// for slashing in the epoch, it must be same as the slashing plugin.
+func isExpiredEvidence(number, epoch, validEpoch uint64) bool {
+	return epoch > xutil.CalculateEpoch(number)+validEpoch
+}
+
 //Evidence A.Number == B.Number but A.Hash != B.Hash
 type DuplicatePrepareVoteEvidence struct {
 	VoteA *prepareVote
@@ -559,8 +585,15 @@ func (vt PrepareEvidence) Clear(number uint64) {
 type EvidencePool interface {
 	AddViewChangeVote(v *viewChangeVote) error
 	AddPrepareVote(p *prepareVote) error
+	// AddEvidence commits the evidence detected by the other nodes
+	AddEvidence(e Evidence) error
+	// Has returns whether the evidence has been committed
+	Has(e Evidence) bool
 	Evidences() []Evidence
 	Clear(timestamp, blockNum uint64)
+	// Prune removes the committed evidences which have expired in the epoch,
+	// the evidences can be used for slashing in validEpoch epochs after the offence
+	Prune(epoch, validEpoch uint64)
 	Close()
 }
 type emptyEvidencePool struct {
@@ -574,6 +607,14 @@ func (emptyEvidencePool) AddPrepareVote(p *prepareVote) error {
 	return nil
 }
 
+func (emptyEvidencePool) AddEvidence(e Evidence) error {
+	return nil
+}
+
+func (emptyEvidencePool) Has(e Evidence) bool {
+	return false
+}
+
 func (emptyEvidencePool) Evidences() []Evidence {
 	return nil
 }
@@ -581,6 +622,9 @@ func (emptyEvidencePool) Evidences() []Evidence {
 func (emptyEvidencePool) Clear(timestamp, blockNum uint64) {
 }
 
+func (emptyEvidencePool) Prune(epoch, validEpoch uint64) {
+}
+
 func (emptyEvidencePool) Close() {
 }
 
@@ -589,6 +633,11 @@ type baseEvidencePool struct {
 	vn ViewNumberEvidence
 	pe PrepareEvidence
 	db *leveldb.DB
+
+	// the latest pruned epoch and the valid epochs of the evidences in it
+	epoch      uint64
+	validEpoch uint64
+	lock       sync.Mutex
 }
 
 func NewEvidencePoolByCtx(ctx *node.ServiceContext) (EvidencePool, error) {
@@ -617,6 +666,8 @@ func NewBaseEvidencePool(path string) (*baseEvidencePool, error) {
 		vn: make(ViewNumberEvidence),
 		pe: make(PrepareEvidence),
 		db: db,
+
+		validEpoch: xcom.EvidenceValidEpoch,
 	}, nil
 }
 
@@ -689,6 +740,45 @@ func (ev *baseEvidencePool) commit(e Evidence) error {
 	return err
 }
 
+func (ev *baseEvidencePool) AddEvidence(e Evidence) error {
+	if err := e.Validate(); err != nil {
+		return err
+	}
+	ev.lock.Lock()
+	expired := isExpiredEvidence(e.BlockNumber(), ev.epoch, ev.validEpoch)
+	ev.lock.Unlock()
+	if expired {
+		return errExpiredEvidence
+	}
+	return ev.commit(e)
+}
+
+func (ev *baseEvidencePool) Has(e Evidence) bool {
+	ok, _ := ev.db.Has(encodeKey(e), nil)
+	return ok
+}
+
+func (ev *baseEvidencePool) Prune(epoch, validEpoch uint64) {
+	ev.lock.Lock()
+	defer ev.lock.Unlock()
+	if epoch <= ev.epoch {
+		return
+	}
+	ev.epoch, ev.validEpoch = epoch, validEpoch
+
+	it := ev.db.NewIterator(nil, nil)
+	defer it.Release()
+	for it.Next() {
+		// the key is prefix + [number] + address + hash
+		if len(it.Key()) < 9 {
+			continue
+		}
+		if isExpiredEvidence(binary.BigEndian.Uint64(it.Key()[1:9]), epoch, validEpoch) {
+			ev.db.Delete(it.Key(), nil)
+		}
+	}
+}
+
 func (ev *baseEvidencePool) Clear(timestamp, blockNum uint64) {
 	ev.vt.Clear(timestamp)
 	ev.vn.Clear(blockNum)
@@ -708,17 +798,17 @@ func (ev *baseEvidencePool) Evidences() []Evidence {
 		case prepareDualPrefix:
 			var e DuplicatePrepareVoteEvidence
 			if err := rlp.DecodeBytes(it.Value(), &e); err == nil {
-				evds = append(evds, e)
+				evds = append(evds, &e)
 			}
 		case viewDualPrefix:
 			var e DuplicateViewChangeVoteEvidence
 			if err := rlp.DecodeBytes(it.Value(), &e); err == nil {
-				evds = append(evds, e)
+				evds = append(evds, &e)
 			}
 		case viewTimestampPrefix:
 			var e TimestampViewChangeVoteEvidence
 			if err := rlp.DecodeBytes(it.Value(), &e); err == nil {
-				evds = append(evds, e)
+				evds = append(evds, &e)
 			}
 		}
 	}
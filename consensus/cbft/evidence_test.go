@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"github.com/PlatONnetwork/PlatON-Go/common"
 	"github.com/PlatONnetwork/PlatON-Go/crypto"
+	"github.com/PlatONnetwork/PlatON-Go/rlp"
+	"github.com/PlatONnetwork/PlatON-Go/x/xcom"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
 	"os"
@@ -363,3 +365,72 @@ func TestJson(t *testing.T) {
 	b2, _ := json.MarshalIndent(eds2, "", "  ")
 	assert.Equal(t, b, b2)
 }
+
+func TestEvidencePool_AddEvidence(t *testing.T) {
+	name := path()
+	defer os.RemoveAll(name)
+	pool, err := NewBaseEvidencePool(name)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer pool.Close()
+
+	account := createAccount(1)[0]
+	address := crypto.PubkeyToAddress(account.PublicKey)
+	voteA := makePrepareVote(account, 0, uint64(1), common.BytesToHash(Rand32Bytes(32)), uint32(1), address)
+	voteB := makePrepareVote(account, 0, uint64(1), common.BytesToHash(Rand32Bytes(32)), uint32(1), address)
+
+	// the evidence is gossiped as a message
+	msg := newEvidences([]Evidence{&DuplicatePrepareVoteEvidence{VoteA: voteA, VoteB: voteB}})
+	buf, err := rlp.EncodeToBytes(msg)
+	assert.Nil(t, err)
+	var decoded evidences
+	assert.Nil(t, rlp.DecodeBytes(buf, &decoded))
+	assert.Equal(t, msg.MsgHash(), decoded.MsgHash())
+
+	evs := decoded.Flatten()
+	assert.Len(t, evs, 1)
+	assert.False(t, pool.Has(evs[0]))
+	assert.Nil(t, pool.AddEvidence(evs[0]))
+	assert.True(t, pool.Has(evs[0]))
+	assert.Len(t, pool.Evidences(), 1)
+	assert.Len(t, ClassifyEvidence(pool.Evidences()).DP, 1)
+
+	// invalid evidence is rejected
+	assert.NotNil(t, pool.AddEvidence(&DuplicatePrepareVoteEvidence{VoteA: voteA, VoteB: voteA}))
+}
+
+func TestEvidencePool_Prune(t *testing.T) {
+	name := path()
+	defer os.RemoveAll(name)
+	pool, err := NewBaseEvidencePool(name)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer pool.Close()
+
+	account := createAccount(1)[0]
+	address := crypto.PubkeyToAddress(account.PublicKey)
+	epochSize := xcom.ConsensusSize * xcom.EpochSize
+	newEvidence := func(number uint64) Evidence {
+		return &DuplicatePrepareVoteEvidence{
+			VoteA: makePrepareVote(account, 0, number, common.BytesToHash(Rand32Bytes(32)), uint32(1), address),
+			VoteB: makePrepareVote(account, 0, number, common.BytesToHash(Rand32Bytes(32)), uint32(1), address),
+		}
+	}
+	old, recent := newEvidence(1), newEvidence(epochSize+1)
+	assert.Nil(t, pool.AddEvidence(old))
+	assert.Nil(t, pool.AddEvidence(recent))
+
+	// the evidences are valid in the next EvidenceValidEpoch epochs
+	pool.Prune(1+xcom.EvidenceValidEpoch, xcom.EvidenceValidEpoch)
+	assert.Len(t, pool.Evidences(), 2)
+
+	pool.Prune(2+xcom.EvidenceValidEpoch, xcom.EvidenceValidEpoch)
+	assert.Len(t, pool.Evidences(), 1)
+	assert.False(t, pool.Has(old))
+	assert.True(t, pool.Has(recent))
+	assert.Equal(t, errExpiredEvidence, pool.AddEvidence(newEvidence(2)))
+}
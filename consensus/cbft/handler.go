@@ -392,6 +392,17 @@ func (h *baseHandler) handleMsg(p *peer) error {
 			PeerID: p.ID(),
 		})
 		return nil
+	case msg.Code == EvidencesMsg:
+		var request evidences
+		if err := msg.Decode(&request); err != nil {
+			return errResp(ErrDecode, "%v: %v", msg, err)
+		}
+		p.MarkMessageHash((&request).MsgHash())
+		h.cbft.ReceivePeerMsg(&MsgInfo{
+			Msg:    &request,
+			PeerID: p.ID(),
+		})
+		return nil
 	default:
 	}
 
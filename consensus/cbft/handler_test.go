@@ -103,6 +103,7 @@ func TestHandlerMsg(t *testing.T) {
 		{GetHighestPrepareBlockMsg, &getHighestPrepareBlock{}, nil},
 		{HighestPrepareBlockMsg, &highestPrepareBlock{}, nil},
 		{PrepareBlockHashMsg, &prepareBlockHash{}, nil},
+		{EvidencesMsg, &evidences{}, nil},
 	}
 
 	for _, v := range testCases {
@@ -31,6 +31,7 @@ const (
 	PrepareBlockHashMsg = 0x0b
 	GetLatestStatusMsg  = 0x0c
 	LatestStatusMsg     = 0x0d
+	EvidencesMsg        = 0x0e
 )
 
 const (
@@ -695,6 +696,45 @@ func (s *latestStatus) BHash() common.Hash {
 	return common.Hash{}
 }
 
+// evidences is the double sign evidences verified by the sender
+type evidences struct {
+	DP []*DuplicatePrepareVoteEvidence
+	DV []*DuplicateViewChangeVoteEvidence
+	TV []*TimestampViewChangeVoteEvidence
+}
+
+func newEvidences(evds []Evidence) *evidences {
+	ed := ClassifyEvidence(evds)
+	return &evidences{DP: ed.DP, DV: ed.DV, TV: ed.TV}
+}
+
+func (e *evidences) Flatten() []Evidence {
+	return (&EvidenceData{DP: e.DP, DV: e.DV, TV: e.TV}).Flatten()
+}
+
+func (e *evidences) String() string {
+	if e == nil {
+		return ""
+	}
+	return fmt.Sprintf("[DP:%d DV:%d TV:%d]", len(e.DP), len(e.DV), len(e.TV))
+}
+
+func (e *evidences) MsgHash() common.Hash {
+	if e == nil {
+		return common.Hash{}
+	}
+	// the first byte is replaced with the message type
+	hashes := [][]byte{{EvidencesMsg}}
+	for _, ev := range e.Flatten() {
+		hashes = append(hashes, ev.Hash())
+	}
+	return produceHash(EvidencesMsg, combineBytes(hashes...))
+}
+
+func (e *evidences) BHash() common.Hash {
+	return common.Hash{}
+}
+
 var (
 	messages = []interface{}{
 		prepareBlock{},
@@ -711,6 +751,7 @@ var (
 		prepareBlockHash{},
 		getLatestStatus{},
 		latestStatus{},
+		evidences{},
 	}
 )
 
@@ -744,6 +785,8 @@ func MessageType(msg interface{}) uint64 {
 		return GetLatestStatusMsg
 	case *latestStatus:
 		return LatestStatusMsg
+	case *evidences:
+		return EvidencesMsg
 	}
 	panic(fmt.Sprintf("invalid msg type %v", reflect.TypeOf(msg)))
 }
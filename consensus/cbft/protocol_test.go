@@ -137,6 +137,7 @@ func TestMessageType(t *testing.T) {
 		{msgType: &highestPrepareBlock{}, want: HighestPrepareBlockMsg},
 		{msgType: &cbftStatusData{}, want: CBFTStatusMsg},
 		{msgType: &prepareBlockHash{}, want: PrepareBlockHashMsg},
+		{msgType: &evidences{}, want: EvidencesMsg},
 	}
 	for _, v := range testCases {
 		if MessageType(v.msgType) != uint64(v.want) {
@@ -39,7 +39,7 @@ func (r *router) gossip(m *MsgPackage) {
 	msgHash := m.msg.MsgHash()
 
 	switch msgType {
-	case ConfirmedPrepareBlockMsg, PrepareBlockHashMsg:
+	case ConfirmedPrepareBlockMsg, PrepareBlockHashMsg, EvidencesMsg:
 		// check the message is repeated
 		if r.repeatedCheck(m.peerID, msgHash) {
 			log.Debug("The message is repeated, not to forward again", "msgType", reflect.TypeOf(m.msg), "msgHash", msgHash.TerminalString())
@@ -88,7 +88,7 @@ func (r *router) selectNodesByMsgType(msgType uint64, condition interface{}) ([]
 	defer r.routerLock.RUnlock()
 	switch msgType {
 	case PrepareBlockMsg, PrepareVoteMsg, ConfirmedPrepareBlockMsg,
-		PrepareBlockHashMsg:
+		PrepareBlockHashMsg, EvidencesMsg:
 		return r.kMixingRandomNodes(msgType, condition)
 	case ViewChangeMsg, GetPrepareBlockMsg, GetHighestPrepareBlockMsg, ViewChangeVoteMsg:
 		return r.kConsensusRandomNodes(msgType, condition)
@@ -708,10 +708,12 @@ func (cbft *Cbft) OnViewChangeVote(peerID discover.NodeID, vote *viewChangeVote)
 	}
 
 	if err := cbft.evPool.AddViewChangeVote(vote); err != nil {
-		switch err.(type) {
+		switch evidence := err.(type) {
 		case *DuplicateViewChangeVoteEvidence:
+			cbft.broadcastEvidence(evidence)
 		case *TimestampViewChangeVoteEvidence:
 			cbft.log.Warn("Receive TimestampViewChangeVoteEvidence msg", "err", err.Error())
+			cbft.broadcastEvidence(evidence)
 			return err
 		}
 	}
@@ -731,6 +733,7 @@ func (cbft *Cbft) OnViewChangeVote(peerID discover.NodeID, vote *viewChangeVote)
 			})
 		}
 
+		cbft.recordViewChange()
 		cbft.bp.ViewChangeBP().TwoThirdViewChangeVotes(bpCtx, cbft.viewChange, cbft.viewChangeVotes, cbft)
 		cbft.confirmedViewChangeProcess(cbft.viewChangeVotes)
 		//cbft.flushReadyBlock()
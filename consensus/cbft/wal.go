@@ -18,16 +18,22 @@ const (
 
 	// WAL Database name
 	metaDBName = "wal_meta"
+
+	// The interval to write the consensus checkpoint
+	walCheckpointInterval = 30 * time.Second
 )
 
 var (
 	viewChangeKey = []byte("view-change")
+	checkpointKey = []byte("checkpoint")
 )
 
 var (
 	errCreateWalDir         = errors.New("Failed to create wal directory")
 	errUpdateViewChangeMeta = errors.New("Failed to update viewChange meta")
 	errGetViewChangeMeta    = errors.New("Failed to get viewChange meta")
+	errUpdateCheckpointMeta = errors.New("Failed to update checkpoint meta")
+	errGetCheckpointMeta    = errors.New("Failed to get checkpoint meta")
 )
 
 type ViewChangeMessage struct {
@@ -42,10 +48,18 @@ type ViewChangeMeta struct {
 	Seq    uint64
 }
 
+// CheckpointMeta is the position of the latest consensus checkpoint in the journal
+type CheckpointMeta struct {
+	Timestamp uint64
+	FileID    uint32
+	Seq       uint64
+}
+
 type Wal interface {
 	Write(info *MsgInfo) error
 	WriteSync(info *MsgInfo) error
 	UpdateViewChange(info *ViewChangeMessage) error
+	WriteCheckpoint(info *MsgInfo) error
 	Load(add func(info *MsgInfo)) error
 	Close()
 }
@@ -64,6 +78,10 @@ func (w *emptyWal) WriteSync(info *MsgInfo) error {
 func (w *emptyWal) UpdateViewChange(info *ViewChangeMessage) error {
 	return nil
 }
+
+func (w *emptyWal) WriteCheckpoint(info *MsgInfo) error {
+	return nil
+}
 func (w *emptyWal) Load(add func(info *MsgInfo)) error {
 	return nil
 }
@@ -150,21 +168,74 @@ func (wal *baseWal) UpdateViewChange(info *ViewChangeMessage) error {
 	return wal.updateViewChangeMeta(info)
 }
 
+// Load replays the journal messages from the later one of the viewChange and the checkpoint,
+// the checkpoint message is the first one replayed if it's the later one.
 func (wal *baseWal) Load(add func(info *MsgInfo)) error {
+	var (
+		fileID uint32
+		seq    uint64
+		found  bool
+	)
 	// open wal database
-	data, err := wal.metaDB.Get(viewChangeKey)
-	if err != nil {
+	if data, err := wal.metaDB.Get(viewChangeKey); err == nil {
+		var v ViewChangeMeta
+		if err := rlp.DecodeBytes(data, &v); err != nil {
+			log.Error("Failed to decode viewChange meta")
+			return errGetViewChangeMeta
+		}
+		fileID, seq, found = v.FileID, v.Seq, true
+	}
+	if data, err := wal.metaDB.Get(checkpointKey); err == nil {
+		var c CheckpointMeta
+		if err := rlp.DecodeBytes(data, &c); err != nil {
+			log.Error("Failed to decode checkpoint meta")
+			return errGetCheckpointMeta
+		}
+		if !found || c.FileID > fileID || (c.FileID == fileID && c.Seq > seq) {
+			log.Debug("Load wal from checkpoint", "fileID", c.FileID, "seq", c.Seq, "timestamp", c.Timestamp)
+			fileID, seq, found = c.FileID, c.Seq, true
+		}
+	}
+	if !found {
 		log.Warn("Failed to get viewChange meta from db,may be the first time to run platon")
 		return nil
 	}
-	var v ViewChangeMeta
-	err = rlp.DecodeBytes(data, &v)
+
+	return wal.journal.LoadJournal(fileID, seq, add)
+}
+
+// WriteCheckpoint writes the consensus checkpoint to the journal and records its position,
+// the journal files before it are expired.
+func (wal *baseWal) WriteCheckpoint(info *MsgInfo) error {
+	fileID, seq, err := wal.journal.CurrentJournal()
 	if err != nil {
-		log.Error("Failed to decode viewChange meta")
-		return errGetViewChangeMeta
+		log.Error("Failed to update checkpoint meta", "err", err)
+		return errUpdateCheckpointMeta
+	}
+	timestamp := uint64(time.Now().UnixNano())
+	if err := wal.journal.Insert(&JournalMessage{
+		Timestamp: timestamp,
+		Data:      info,
+	}, true); err != nil {
+		return err
 	}
 
-	return wal.journal.LoadJournal(v.FileID, v.Seq, add)
+	data, err := rlp.EncodeToBytes(&CheckpointMeta{
+		Timestamp: timestamp,
+		FileID:    fileID,
+		Seq:       seq,
+	})
+	if err != nil {
+		return err
+	}
+	// Write the CheckpointMeta to the WAL database
+	if err := wal.metaDB.Put(checkpointKey, data, &opt.WriteOptions{Sync: true}); err != nil {
+		return err
+	}
+	log.Debug("success to update checkpoint meta", "fileID", fileID, "seq", seq)
+	// Delete previous journal logs
+	go wal.journal.ExpireJournalFile(fileID)
+	return nil
 }
 
 // Update the ViewChange Meta Data to the database.
@@ -0,0 +1,111 @@
+package cbft
+
+import (
+	"sort"
+)
+
+// newWalCheckpoint snapshots the block tree above the root irreversible block and the current viewChange.
+func (cbft *Cbft) newWalCheckpoint() *walCheckpoint {
+	root, confirmed, logical := cbft.getRootIrreversible(), cbft.getHighestConfirmed(), cbft.getHighestLogical()
+	cp := &walCheckpoint{
+		ViewChange:                 cbft.viewChange,
+		ViewChangeResp:             cbft.viewChangeResp,
+		ViewChangeVotes:            cbft.viewChangeVotes.Flatten(),
+		Master:                     cbft.master,
+		LocalHighestPrepareVoteNum: cbft.localHighestPrepareVoteNum,
+		HighestConfirmed:           walHashNumber{Hash: confirmed.block.Hash(), Number: confirmed.number},
+		HighestLogical:             walHashNumber{Hash: logical.block.Hash(), Number: logical.number},
+	}
+
+	exts := cbft.blockExtMap.findBlockExtByNumber(root.number+1, logical.number)
+	sort.Slice(exts, func(i, j int) bool {
+		return exts[i].number < exts[j].number
+	})
+	for _, ext := range exts {
+		// the blocks not executed will be received again
+		if !ext.isExecuted {
+			continue
+		}
+		cp.Blocks = append(cp.Blocks, &walBlock{
+			Block:           ext.block,
+			PrepareBlock:    ext.prepareBlock,
+			View:            ext.view,
+			ViewChangeVotes: ext.viewChangeVotes,
+			Timestamp:       ext.timestamp,
+			ProposalIndex:   ext.proposalIndex,
+			ProposalAddr:    ext.proposalAddr,
+			PrepareVotes:    ext.prepareVotes.Votes(),
+			IsSigned:        ext.isSigned,
+		})
+	}
+	return cp
+}
+
+// OnWalCheckpoint writes the consensus checkpoint to the wal,
+// the journal messages before it needn't be replayed after restart.
+func (cbft *Cbft) OnWalCheckpoint() {
+	if !cbft.config.WalEnabled || cbft.isLoading() {
+		return
+	}
+	cp := cbft.newWalCheckpoint()
+	if err := cbft.wal.WriteCheckpoint(&MsgInfo{
+		Msg:    cp,
+		PeerID: cbft.config.NodeID,
+	}); err != nil {
+		cbft.log.Error("Write wal checkpoint failed", "err", err)
+		return
+	}
+	cbft.log.Debug("Write wal checkpoint", "checkpoint", cp.String())
+}
+
+// restoreWalCheckpoint rebuilds the block tree and the viewChange from the checkpoint,
+// the blocks are executed again based on the root irreversible block.
+func (cbft *Cbft) restoreWalCheckpoint(cp *walCheckpoint) {
+	cbft.viewChange, cbft.viewChangeResp, cbft.master = cp.ViewChange, cp.ViewChangeResp, cp.Master
+	cbft.viewChangeVotes = make(ViewChangeVotes)
+	for _, v := range cp.ViewChangeVotes {
+		cbft.viewChangeVotes[v.ValidatorAddr] = v
+	}
+	cbft.SetLocalHighestPrepareNum(cp.LocalHighestPrepareVoteNum)
+
+	root := cbft.getRootIrreversible()
+	for _, b := range cp.Blocks {
+		hash, number := b.Block.Hash(), b.Block.NumberU64()
+		if number <= root.number {
+			continue
+		}
+		ext := NewBlockExt(b.Block, number, cbft.nodeLength())
+		ext.prepareBlock, ext.view, ext.viewChangeVotes = b.PrepareBlock, b.View, b.ViewChangeVotes
+		ext.timestamp, ext.proposalIndex, ext.proposalAddr = b.Timestamp, b.ProposalIndex, b.ProposalAddr
+		for _, v := range b.PrepareVotes {
+			ext.prepareVotes.Add(v)
+		}
+		cbft.blockExtMap.Add(hash, number, ext)
+
+		if ext = cbft.blockExtMap.findBlock(hash, number); ext == nil || ext.parent == nil || !ext.parent.isExecuted {
+			cbft.log.Warn("Discard the block of wal checkpoint, the parent is not found", "hash", hash, "number", number)
+			continue
+		}
+		if !ext.isExecuted {
+			if err := cbft.execute(ext, ext.parent); err != nil {
+				cbft.log.Error("Discard the block of wal checkpoint, execute failed", "hash", hash, "number", number, "err", err)
+				continue
+			}
+			ext.inTree, ext.executing, ext.isExecuted = true, true, true
+		}
+		if b.IsSigned {
+			ext.isSigned = true
+			cbft.signedSet[number] = struct{}{}
+			cbft.AddPrepareBlock(ext.block)
+		}
+	}
+
+	if ext := cbft.blockExtMap.findBlock(cp.HighestConfirmed.Hash, cp.HighestConfirmed.Number); ext != nil && ext.isExecuted {
+		cbft.highestConfirmed.Store(ext)
+	}
+	if ext := cbft.blockExtMap.findBlock(cp.HighestLogical.Hash, cp.HighestLogical.Number); ext != nil && ext.isExecuted {
+		cbft.highestLogical.Store(ext)
+		cbft.reset(ext.block)
+	}
+	cbft.log.Info("Restore wal checkpoint", "checkpoint", cp.String(), "state", cbft.blockState())
+}
@@ -205,6 +205,28 @@ func buildConfirmedViewChange() *confirmedViewChange {
 	}
 }
 
+func buildWalCheckpoint() *walCheckpoint {
+	votes := make([]*viewChangeVote, 0, 2)
+	votes = append(votes, buildviewChangeVote())
+	votes = append(votes, buildviewChangeVote())
+	return &walCheckpoint{
+		ViewChange:       buildViewChange(),
+		ViewChangeVotes:  votes,
+		Master:           true,
+		HighestConfirmed: walHashNumber{Hash: block.Hash(), Number: block.NumberU64()},
+		HighestLogical:   walHashNumber{Hash: block.Hash(), Number: block.NumberU64()},
+		Blocks: []*walBlock{
+			{
+				Block:        block,
+				PrepareBlock: buildPrepareBlock(),
+				Timestamp:    uint64(time.Now().UnixNano()),
+				PrepareVotes: []*prepareVote{buildPrepareVote()},
+				IsSigned:     true,
+			},
+		},
+	}
+}
+
 func ordinalMessages() int {
 	if ordinal == len(wal_messages) {
 		ordinal = 0
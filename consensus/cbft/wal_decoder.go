@@ -156,6 +156,16 @@ type JournalMessageConfirmedViewChange struct {
 	Data      *MsgInfoConfirmedViewChange
 }
 
+type MsgInfoWalCheckpoint struct {
+	Msg    *walCheckpoint
+	PeerID discover.NodeID
+}
+
+type JournalMessageWalCheckpoint struct {
+	Timestamp uint64
+	Data      *MsgInfoWalCheckpoint
+}
+
 func WALDecode(pack []byte, msgType uint16) (*MsgInfo, error) {
 	switch msgType {
 	case PrepareBlockMsg:
@@ -311,6 +321,16 @@ func WALDecode(pack []byte, msgType uint16) (*MsgInfo, error) {
 		} else {
 			return nil, err
 		}
+	case WalCheckpointMsg:
+		var j JournalMessageWalCheckpoint
+		if err := rlp.DecodeBytes(pack, &j); err == nil {
+			return &MsgInfo{
+				Msg:    j.Data.Msg,
+				PeerID: j.Data.PeerID,
+			}, nil
+		} else {
+			return nil, err
+		}
 	}
 	panic(fmt.Sprintf("invalid msg type %d", msgType))
 }
@@ -1,13 +1,17 @@
 package cbft
 
 import (
+	"fmt"
+
 	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/PlatONnetwork/PlatON-Go/core/types"
 )
 
 const (
 	SendPrepareBlockMsg    = 0x64
 	SendViewChangeMsg      = 0x65
 	ConfirmedViewChangeMsg = 0x66
+	WalCheckpointMsg       = 0x67
 )
 
 type sendPrepareBlock struct {
@@ -63,6 +67,50 @@ func (c *confirmedViewChange) BHash() common.Hash {
 	return common.Hash{}
 }
 
+// walBlock is a block of the block tree saved in the checkpoint
+type walBlock struct {
+	Block           *types.Block
+	PrepareBlock    *prepareBlock `rlp:"nil"`
+	View            *viewChange   `rlp:"nil"`
+	ViewChangeVotes []*viewChangeVote
+	Timestamp       uint64
+	ProposalIndex   uint32
+	ProposalAddr    common.Address
+	PrepareVotes    []*prepareVote
+	IsSigned        bool
+}
+
+type walHashNumber struct {
+	Hash   common.Hash
+	Number uint64
+}
+
+// walCheckpoint is a snapshot of the consensus state,
+// loading the wal restores it and replays the journal messages after it only
+type walCheckpoint struct {
+	ViewChange                 *viewChange     `rlp:"nil"`
+	ViewChangeResp             *viewChangeVote `rlp:"nil"`
+	ViewChangeVotes            []*viewChangeVote
+	Master                     bool
+	LocalHighestPrepareVoteNum uint64
+	HighestConfirmed           walHashNumber
+	HighestLogical             walHashNumber
+	// the executed blocks above the root irreversible block, ordered by number
+	Blocks []*walBlock
+}
+
+func (w *walCheckpoint) String() string {
+	return fmt.Sprintf("[highestConfirmed:%d highestLogical:%d blocks:%d]", w.HighestConfirmed.Number, w.HighestLogical.Number, len(w.Blocks))
+}
+
+func (w *walCheckpoint) MsgHash() common.Hash {
+	return common.Hash{}
+}
+
+func (w *walCheckpoint) BHash() common.Hash {
+	return common.Hash{}
+}
+
 var (
 	wal_messages = []interface{}{
 		prepareBlock{},
@@ -80,6 +128,7 @@ var (
 		sendPrepareBlock{},
 		sendViewChange{},
 		confirmedViewChange{},
+		walCheckpoint{},
 	}
 )
 
@@ -91,6 +140,8 @@ func WalMessageType(msg interface{}) uint64 {
 		return SendViewChangeMsg
 	case *confirmedViewChange:
 		return ConfirmedViewChangeMsg
+	case *walCheckpoint:
+		return WalCheckpointMsg
 	}
 	return MessageType(msg)
 }
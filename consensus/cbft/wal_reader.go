@@ -0,0 +1,176 @@
+package cbft
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/PlatONnetwork/PlatON-Go/rlp"
+)
+
+// WalRecord is a journal message read from the wal
+type WalRecord struct {
+	FileID    uint32
+	Seq       uint64 // offset of the message in the journal file
+	Timestamp uint64
+	Type      uint16
+	Info      *MsgInfo
+}
+
+// WalView is the view which the journal message belongs to
+type WalView struct {
+	Timestamp    uint64         `json:"timestamp"`
+	BaseBlockNum uint64         `json:"base_block_number"`
+	ProposalAddr common.Address `json:"proposal_address"`
+}
+
+// WalSummary is the readable summary of a journal message
+type WalSummary struct {
+	FileID    uint32         `json:"file_id"`
+	Seq       uint64         `json:"seq"`
+	Timestamp uint64         `json:"timestamp"`
+	Type      string         `json:"type"`
+	Peer      string         `json:"peer"`
+	Number    uint64         `json:"block_number"`
+	Hash      common.Hash    `json:"block_hash"`
+	View      *WalView       `json:"view,omitempty"`
+	Signer    common.Address `json:"signer"`
+}
+
+func newWalView(v *viewChange) *WalView {
+	if v == nil {
+		return nil
+	}
+	return &WalView{Timestamp: v.Timestamp, BaseBlockNum: v.BaseBlockNum, ProposalAddr: v.ProposalAddr}
+}
+
+// Summary extracts the block, the view and the signer of the message
+func (r *WalRecord) Summary() *WalSummary {
+	s := &WalSummary{
+		FileID:    r.FileID,
+		Seq:       r.Seq,
+		Timestamp: r.Timestamp,
+		Type:      reflect.TypeOf(r.Info.Msg).Elem().Name(),
+		Peer:      r.Info.PeerID.TerminalString(),
+	}
+	switch msg := r.Info.Msg.(type) {
+	case *prepareBlock:
+		s.Number, s.Hash = msg.Block.NumberU64(), msg.Block.Hash()
+		s.View, s.Signer = newWalView(msg.View), msg.ProposalAddr
+	case *sendPrepareBlock:
+		s.Number, s.Hash = msg.PrepareBlock.Block.NumberU64(), msg.PrepareBlock.Block.Hash()
+		s.View, s.Signer = newWalView(msg.PrepareBlock.View), msg.PrepareBlock.ProposalAddr
+	case *prepareVote:
+		s.Number, s.Hash, s.Signer = msg.Number, msg.Hash, msg.ValidatorAddr
+		s.View = &WalView{Timestamp: msg.Timestamp}
+	case *viewChange:
+		s.Number, s.Hash = msg.BaseBlockNum, msg.BaseBlockHash
+		s.View, s.Signer = newWalView(msg), msg.ProposalAddr
+	case *sendViewChange:
+		s.Number, s.Hash = msg.ViewChange.BaseBlockNum, msg.ViewChange.BaseBlockHash
+		s.View, s.Signer = newWalView(msg.ViewChange), msg.ViewChange.ProposalAddr
+	case *confirmedViewChange:
+		s.Number, s.Hash = msg.ViewChange.BaseBlockNum, msg.ViewChange.BaseBlockHash
+		s.View, s.Signer = newWalView(msg.ViewChange), msg.ViewChange.ProposalAddr
+		if !msg.Master && msg.ViewChangeResp != nil {
+			s.Signer = msg.ViewChangeResp.ValidatorAddr
+		}
+	case *viewChangeVote:
+		s.Number, s.Hash, s.Signer = msg.BlockNum, msg.BlockHash, msg.ValidatorAddr
+		s.View = &WalView{Timestamp: msg.Timestamp, BaseBlockNum: msg.BlockNum, ProposalAddr: msg.ProposalAddr}
+	case *confirmedPrepareBlock:
+		s.Number, s.Hash = msg.Number, msg.Hash
+	case *getPrepareVote:
+		s.Number, s.Hash = msg.Number, msg.Hash
+	case *prepareVotes:
+		s.Number, s.Hash = msg.Number, msg.Hash
+	case *getPrepareBlock:
+		s.Number, s.Hash = msg.Number, msg.Hash
+	case *prepareBlockHash:
+		s.Number, s.Hash = msg.Number, msg.Hash
+	case *getHighestPrepareBlock:
+		s.Number = msg.Lowest
+	case *walCheckpoint:
+		s.Number, s.Hash = msg.HighestLogical.Number, msg.HighestLogical.Hash
+		s.View = newWalView(msg.ViewChange)
+	}
+	return s
+}
+
+// ReadWal reads every journal file in the wal directory in order,
+// the wal database is not touched so it can be used while the node is stopped.
+func ReadWal(path string, fn func(record *WalRecord) error) error {
+	files := listJournalFiles(path)
+	if files.Len() == 0 {
+		return fmt.Errorf("no journal file found in %s", path)
+	}
+	for _, file := range files {
+		if err := readJournalFile(filepath.Join(path, file.name), file.num, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readJournalFile(name string, fileID uint32, fn func(record *WalRecord) error) error {
+	file, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var (
+		reader = bufio.NewReaderSize(file, readBufferLimitSize)
+		seq    uint64
+		index  = make([]byte, 10)
+	)
+	for {
+		if _, err := io.ReadFull(reader, index); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("read journal %d at %d failed, the tail may be partially written: %v", fileID, seq, err)
+		}
+		crc := binary.BigEndian.Uint32(index[0:4])      // 4 byte
+		length := binary.BigEndian.Uint32(index[4:8])   // 4 byte
+		msgType := binary.BigEndian.Uint16(index[8:10]) // 2 byte
+
+		pack := make([]byte, length)
+		if _, err := io.ReadFull(reader, pack); err != nil {
+			return fmt.Errorf("read journal %d at %d failed, the tail may be partially written: %v", fileID, seq, err)
+		}
+		if crc32.Checksum(pack, crc32c) != crc {
+			return fmt.Errorf("read journal %d at %d failed: %v", fileID, seq, errLoadJournal)
+		}
+		var j struct {
+			Timestamp uint64
+			Data      rlp.RawValue
+		}
+		if err := rlp.DecodeBytes(pack, &j); err != nil {
+			return fmt.Errorf("decode journal %d at %d failed: %v", fileID, seq, err)
+		}
+		info, err := walDecode(pack, msgType)
+		if err != nil {
+			return fmt.Errorf("decode journal %d at %d failed: %v", fileID, seq, err)
+		}
+		if err := fn(&WalRecord{FileID: fileID, Seq: seq, Timestamp: j.Timestamp, Type: msgType, Info: info}); err != nil {
+			return err
+		}
+		seq += uint64(length) + 10
+	}
+}
+
+// walDecode is WALDecode which returns an error instead of panic on the unknown message type
+func walDecode(pack []byte, msgType uint16) (info *MsgInfo, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return WALDecode(pack, msgType)
+}
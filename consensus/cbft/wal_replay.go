@@ -0,0 +1,76 @@
+package cbft
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// WalDivergence is a recorded message which the replaying engine disagrees with
+type WalDivergence struct {
+	Record *WalSummary `json:"record"`
+	Reason string      `json:"reason"`
+}
+
+// ReplayWal feeds the journal messages in the wal directory into the started engine in order.
+// The messages from peers are processed as they were received, the local decisions
+// (sendViewChange, confirmedViewChange and sendPrepareBlock) are checked against the state
+// of the engine before they are applied. Every record is applied in the receiveLoop,
+// every disagreement is reported, the number of the replayed messages is returned.
+func (cbft *Cbft) ReplayWal(path string, report func(divergence *WalDivergence)) (int, error) {
+	atomic.StoreInt32(&cbft.loading, 1)
+	defer atomic.StoreInt32(&cbft.loading, 0)
+
+	count := 0
+	err := ReadWal(path, func(record *WalRecord) error {
+		count++
+		var reason string
+		if err := cbft.callInLoop(func() { reason = cbft.replayWalRecord(record) }); err != nil {
+			return err
+		}
+		if reason != "" {
+			report(&WalDivergence{Record: record.Summary(), Reason: reason})
+		}
+		return nil
+	})
+	return count, err
+}
+
+// replayWalRecord applies the record and returns why the engine disagrees with it, empty if agrees.
+func (cbft *Cbft) replayWalRecord(record *WalRecord) string {
+	var reason string
+	switch msg := record.Info.Msg.(type) {
+	case *sendViewChange:
+		if confirmed := cbft.getHighestConfirmed(); confirmed.number != msg.ViewChange.BaseBlockNum || confirmed.block.Hash() != msg.ViewChange.BaseBlockHash {
+			reason = fmt.Sprintf("send view based on block %d [%s], the highest confirmed block is %d [%s]",
+				msg.ViewChange.BaseBlockNum, msg.ViewChange.BaseBlockHash.TerminalString(), confirmed.number, confirmed.block.Hash().TerminalString())
+		}
+		cbft.AddJournal(record.Info)
+	case *confirmedViewChange:
+		switch {
+		case cbft.viewChange == nil || cbft.viewChange.Timestamp != msg.ViewChange.Timestamp || cbft.viewChange.BaseBlockHash != msg.ViewChange.BaseBlockHash:
+			reason = fmt.Sprintf("confirm view %s, the current view is %s", msg.ViewChange.String(), cbft.viewChange.String())
+		// the view change vote which makes 2f+1 votes is journaled after the confirmed view
+		case len(cbft.viewChangeVotes)+1 < cbft.getThreshold():
+			reason = fmt.Sprintf("confirm view with %d votes, the engine has %d votes, threshold %d",
+				len(msg.ViewChangeVotes), len(cbft.viewChangeVotes), cbft.getThreshold())
+		}
+		cbft.AddJournal(record.Info)
+	case *sendPrepareBlock:
+		if logical := cbft.getHighestLogical(); logical.block.Hash() != msg.PrepareBlock.Block.ParentHash() {
+			reason = fmt.Sprintf("seal block %d based on [%s], the highest logical block is %d [%s]",
+				msg.PrepareBlock.Block.NumberU64(), msg.PrepareBlock.Block.ParentHash().TerminalString(), logical.number, logical.block.Hash().TerminalString())
+		}
+		cbft.AddJournal(record.Info)
+	case *walCheckpoint:
+		cbft.AddJournal(record.Info)
+	default:
+		// the messages were journaled only if they were accepted,
+		// the ones of the blocks already in the local chain are ignored
+		if _, err := cbft.dispatchMsg(record.Info.PeerID, record.Info.Msg); err != nil {
+			if number := record.Summary().Number; number == 0 || number > cbft.getRootIrreversible().number {
+				reason = fmt.Sprintf("the message is rejected: %v", err)
+			}
+		}
+	}
+	return reason
+}
@@ -127,6 +127,11 @@ func TestWalWrite(t *testing.T) {
 				Msg:    buildConfirmedViewChange(),
 				PeerID: buildPeerId(),
 			})
+		} else if ordinal == 15 {
+			err = getWal().WriteSync(&MsgInfo{
+				Msg:    buildWalCheckpoint(),
+				PeerID: buildPeerId(),
+			})
 		}
 		if err != nil {
 			t.Log("write error", err)
@@ -198,4 +203,108 @@ func TestEmptyWal(t *testing.T) {
 
 	}))
 	assert.Nil(t, wal.UpdateViewChange(nil))
-}
\ No newline at end of file
+	assert.Nil(t, wal.WriteCheckpoint(nil))
+}
+
+func TestWalCheckpoint(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "wal-checkpoint")
+	defer os.RemoveAll(dir)
+
+	w, err := NewWal(nil, dir)
+	assert.Nil(t, err)
+	for i := 0; i < 10; i++ {
+		assert.Nil(t, w.Write(&MsgInfo{Msg: buildPrepareVote(), PeerID: buildPeerId()}))
+	}
+	assert.Nil(t, w.UpdateViewChange(&ViewChangeMessage{Hash: viewChangeHash, Number: viewChangeNumber}))
+	for i := 0; i < 5; i++ {
+		assert.Nil(t, w.Write(&MsgInfo{Msg: buildPrepareVote(), PeerID: buildPeerId()}))
+	}
+	cp := buildWalCheckpoint()
+	assert.Nil(t, w.WriteCheckpoint(&MsgInfo{Msg: cp, PeerID: buildPeerId()}))
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, w.Write(&MsgInfo{Msg: buildPrepareVote(), PeerID: buildPeerId()}))
+	}
+	w.Close()
+
+	// the journal is replayed from the checkpoint
+	w, err = NewWal(nil, dir)
+	assert.Nil(t, err)
+	var infos []*MsgInfo
+	assert.Nil(t, w.Load(func(info *MsgInfo) {
+		infos = append(infos, info)
+	}))
+	assert.Equal(t, 4, len(infos))
+	loaded, ok := infos[0].Msg.(*walCheckpoint)
+	assert.True(t, ok)
+	assert.Equal(t, cp.HighestLogical, loaded.HighestLogical)
+	assert.Equal(t, 1, len(loaded.Blocks))
+	assert.Equal(t, block.Hash(), loaded.Blocks[0].Block.Hash())
+	assert.Equal(t, 1, len(loaded.Blocks[0].PrepareVotes))
+	assert.Nil(t, loaded.Blocks[0].View)
+
+	// the later viewChange is preferred to the checkpoint
+	assert.Nil(t, w.UpdateViewChange(&ViewChangeMessage{Hash: viewChangeHash, Number: viewChangeNumber + 1}))
+	assert.Nil(t, w.Write(&MsgInfo{Msg: buildPrepareVote(), PeerID: buildPeerId()}))
+	w.Close()
+	w, err = NewWal(nil, dir)
+	assert.Nil(t, err)
+	infos = infos[:0]
+	assert.Nil(t, w.Load(func(info *MsgInfo) {
+		infos = append(infos, info)
+	}))
+	assert.Equal(t, 1, len(infos))
+	w.Close()
+}
+func TestReadWal(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "wal-reader")
+	defer os.RemoveAll(dir)
+
+	w, err := NewWal(nil, dir)
+	assert.Nil(t, err)
+	vote, view := buildPrepareVote(), buildViewChange()
+	assert.Nil(t, w.Write(&MsgInfo{Msg: vote, PeerID: buildPeerId()}))
+	assert.Nil(t, w.Write(&MsgInfo{Msg: view, PeerID: buildPeerId()}))
+	assert.Nil(t, w.WriteSync(&MsgInfo{Msg: buildSendPrepareBlock(), PeerID: buildPeerId()}))
+	w.Close()
+
+	var records []*WalRecord
+	assert.Nil(t, ReadWal(dir, func(record *WalRecord) error {
+		records = append(records, record)
+		return nil
+	}))
+	assert.Equal(t, 3, len(records))
+	assert.Equal(t, uint64(0), records[0].Seq)
+	assert.True(t, records[1].Seq > records[0].Seq)
+
+	summary := records[0].Summary()
+	assert.Equal(t, "prepareVote", summary.Type)
+	assert.Equal(t, vote.Hash, summary.Hash)
+	assert.Equal(t, vote.ValidatorAddr, summary.Signer)
+	summary = records[1].Summary()
+	assert.Equal(t, "viewChange", summary.Type)
+	assert.Equal(t, view.BaseBlockNum, summary.View.BaseBlockNum)
+	assert.Equal(t, "sendPrepareBlock", records[2].Summary().Type)
+
+	assert.NotNil(t, ReadWal(filepath.Join(dir, "none"), func(record *WalRecord) error { return nil }))
+}
+
+func TestReplayWalStopped(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "wal-replay")
+	defer os.RemoveAll(dir)
+
+	w, err := NewWal(nil, dir)
+	assert.Nil(t, err)
+	assert.Nil(t, w.Write(&MsgInfo{Msg: buildPrepareVote(), PeerID: buildPeerId()}))
+	w.Close()
+
+	// the records are applied in the receiveLoop, nothing is replayed by the stopped engine
+	path := path()
+	defer os.RemoveAll(path)
+	engine, _, _ := randomCBFT(path, 4)
+	close(engine.exitCh)
+	count, err := engine.ReplayWal(dir, func(divergence *WalDivergence) {
+		t.Errorf("unexpected divergence: %s", divergence.Reason)
+	})
+	assert.Equal(t, errCbftStopped, err)
+	assert.Equal(t, 1, count)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/PlatONnetwork/PlatON-Go/common"
 	cvm "github.com/PlatONnetwork/PlatON-Go/common/vm"
 	"github.com/PlatONnetwork/PlatON-Go/core/cbfttypes"
+	"github.com/PlatONnetwork/PlatON-Go/core/snapshotdb"
 	"github.com/PlatONnetwork/PlatON-Go/core/vm"
 	"github.com/PlatONnetwork/PlatON-Go/crypto/vrf"
 	"github.com/PlatONnetwork/PlatON-Go/p2p/discover"
@@ -30,6 +31,9 @@ type BlockChainReactor struct {
 	beginRule []int
 	// Order rules for xxPlugins called in EndBlocker
 	endRule []int
+
+	// the snapshotdb written by xxPlugins, its kv hash of every block is committed into the header
+	snapshotDB snapshotdb.DB
 }
 
 var bcr *BlockChainReactor
@@ -49,10 +53,10 @@ func NewBlockChainReactor(pri *ecdsa.PrivateKey, mux *event.TypeMux) *BlockChain
 	return bcr
 }
 
-// Getting the global bcr single instance
-//func GetReactorInstance () *BlockChainReactor {
-//	return bcr
-//}
+// Getting the global bcr single instance, nil if the ppos plugins are not used
+func GetReactorInstance() *BlockChainReactor {
+	return bcr
+}
 
 func (brc *BlockChainReactor) loop() {
 
@@ -61,7 +65,7 @@ func (brc *BlockChainReactor) loop() {
 		case obj := <-bcr.bftResultSub.Chan():
 			if obj == nil {
 				log.Error("BlockChainReactor receive nil bftResultEvent maybe channel is closed")
-				continue
+				return
 			}
 			cbftResult, ok := obj.Data.(cbfttypes.CbftResult)
 			if !ok {
@@ -79,29 +83,35 @@ func (brc *BlockChainReactor) loop() {
 			TODO flush the seed and the package ratio
 			*/
 
-			if plugin, ok := brc.basePluginMap[xcom.StakingRule]; ok {
-				if err := plugin.Confirmed(block); nil != err {
-					log.Error("Failed to call Staking Confirmed", "blockNumber", block.Number(), "blockHash", block.Hash().Hex(), "err", err.Error())
-				}
+			brc.confirmed(block)
+		}
+	}
 
-			}
+}
 
-			/*// TODO Slashing
-			if plugin, ok := brc.basePluginMap[common.SlashingRule]; ok {
-				if err := plugin.Confirmed(block); nil != err {
-					log.Error("Failed to call Staking Confirmed", "blockNumber", block.Number(), "blockHash", block.Hash().Hex(), "err", err.Error())
-				}
+// confirmed notifies the plugins of the confirmed block and commits its snapshotdb writes
+func (brc *BlockChainReactor) confirmed(block *types.Block) {
+	if plugin, ok := brc.basePluginMap[xcom.StakingRule]; ok {
+		if err := plugin.Confirmed(block); nil != err {
+			log.Error("Failed to call Staking Confirmed", "blockNumber", block.Number(), "blockHash", block.Hash().Hex(), "err", err.Error())
+		}
 
-			}
-			}*/
+	}
 
+	/*// TODO Slashing
+	if plugin, ok := brc.basePluginMap[common.SlashingRule]; ok {
+		if err := plugin.Confirmed(block); nil != err {
+			log.Error("Failed to call Staking Confirmed", "blockNumber", block.Number(), "blockHash", block.Hash().Hex(), "err", err.Error())
+		}
 
-		default:
-			return
+	}
+	}*/
 
+	if nil != brc.snapshotDB {
+		if err := brc.snapshotDB.Commit(block.Hash()); nil != err {
+			log.Error("Failed to commit the snapshotdb", "blockNumber", block.Number(), "blockHash", block.Hash().Hex(), "err", err.Error())
 		}
 	}
-
 }
 
 func (bcr *BlockChainReactor) RegisterPlugin(pluginRule int, plugin plugin.BasePlugin) {
@@ -113,6 +123,33 @@ func (bcr *BlockChainReactor) SetBeginRule(rule []int) {
 func (bcr *BlockChainReactor) SetEndRule(rule []int) {
 	bcr.endRule = rule
 }
+func (bcr *BlockChainReactor) SetSnapshotDB(db snapshotdb.DB) {
+	bcr.snapshotDB = db
+}
+
+// Flush moves the snapshotdb writes of the produced block to its hash after it is sealed,
+// so it can be committed when it is confirmed and the next block can be produced on it.
+func (bcr *BlockChainReactor) Flush(header *types.Header) error {
+	if nil == bcr.snapshotDB {
+		return nil
+	}
+	return bcr.snapshotDB.Flush(header.Hash(), header.Number)
+}
+
+// SnapshotKVHash returns the last kv hash of the snapshotdb writes of the block,
+// the block is read from the unRecognized data before it is signed, the same as EndBlocker.
+// It returns false if no snapshotdb is used, then the kv hash needn't be committed or verified.
+func SnapshotKVHash(header *types.Header) (common.Hash, bool) {
+	if nil == bcr || nil == bcr.snapshotDB {
+		return common.ZeroHash, false
+	}
+
+	blockHash := common.ZeroHash
+	if len(header.Extra) == 32+common.ExtraSeal && !bytes.Equal(header.Extra[32:], make([]byte, common.ExtraSeal)) {
+		blockHash = header.Hash()
+	}
+	return common.BytesToHash(bcr.snapshotDB.GetLastKVHash(blockHash)), true
+}
 
 // Called before every block has not executed all txs
 func (bcr *BlockChainReactor) BeginBlocker(header *types.Header, state xcom.StateDB) (bool, error) {
@@ -129,6 +166,15 @@ func (bcr *BlockChainReactor) BeginBlocker(header *types.Header, state xcom.Stat
 		}
 	}
 
+	// the writes of the block are recorded by its hash if it is signed,
+	// else by the unRecognized data until it is sealed and flushed
+	if nil != bcr.snapshotDB {
+		if err := bcr.snapshotDB.NewBlock(header.Number, header.ParentHash, blockHash); nil != err {
+			log.Error("Failed to create the block of the snapshotdb", "blockNumber", header.Number, "blockHash", blockHash.Hex(), "err", err)
+			return false, err
+		}
+	}
+
 	// todo maybe vrf
 
 	for _, pluginName := range bcr.beginRule {
@@ -176,11 +222,11 @@ func (bcr *BlockChainReactor) Verify_tx(tx *types.Transaction, from common.Addre
 	case cvm.StakingContractAddr:
 		contract = vm.PlatONPrecompiledContracts[cvm.StakingContractAddr]
 	case cvm.RestrictingContractAddr:
-		// TODO
-	case cvm.AwardMgrContractAddr:
-		// TODO
+		contract = vm.PlatONPrecompiledContracts[cvm.RestrictingContractAddr]
 	case cvm.SlashingContractAddr:
-		// TODO
+		contract = vm.PlatONPrecompiledContracts[cvm.SlashingContractAddr]
+	case cvm.GovContractAddr:
+		contract = vm.PlatONPrecompiledContracts[cvm.GovContractAddr]
 	default:
 		return nil
 	}
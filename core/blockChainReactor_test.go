@@ -0,0 +1,129 @@
+package core
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/PlatONnetwork/PlatON-Go/consensus"
+	"github.com/PlatONnetwork/PlatON-Go/core/snapshotdb"
+	"github.com/PlatONnetwork/PlatON-Go/core/types"
+	"github.com/PlatONnetwork/PlatON-Go/core/vm"
+	"github.com/PlatONnetwork/PlatON-Go/ethdb"
+	"github.com/PlatONnetwork/PlatON-Go/params"
+	"github.com/PlatONnetwork/PlatON-Go/x/plugin"
+	"github.com/PlatONnetwork/PlatON-Go/x/xcom"
+)
+
+// numberPlugin writes the block number into the snapshotdb at the end of every block
+type numberPlugin struct {
+	db snapshotdb.DB
+}
+
+func (p *numberPlugin) BeginBlock(blockHash common.Hash, header *types.Header, state xcom.StateDB) (bool, error) {
+	return false, nil
+}
+
+func (p *numberPlugin) EndBlock(blockHash common.Hash, header *types.Header, state xcom.StateDB) (bool, error) {
+	return false, p.db.Put(blockHash, []byte("number"), header.Number.Bytes())
+}
+
+func (p *numberPlugin) Confirmed(block *types.Block) error {
+	return nil
+}
+
+func newTestReactor(t *testing.T) *BlockChainReactor {
+	db, err := snapshotdb.New(snapshotdb.Options{InMemory: true})
+	if nil != err {
+		t.Fatal(err)
+	}
+	return &BlockChainReactor{
+		basePluginMap: map[int]plugin.BasePlugin{xcom.StakingRule: &numberPlugin{db}},
+		endRule:       []int{xcom.StakingRule},
+		snapshotDB:    db,
+	}
+}
+
+func TestSnapshotKVHash(t *testing.T) {
+	var (
+		chainDb = ethdb.NewMemDatabase()
+		genesis = new(Genesis).MustCommit(chainDb)
+	)
+	chain, err := NewBlockChain(chainDb, nil, params.TestChainConfig, &consensus.BftMock{}, vm.Config{}, nil)
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer chain.Stop()
+	defer func() { bcr = nil }()
+
+	newHeader := func() *types.Header {
+		return &types.Header{
+			ParentHash:  genesis.Hash(),
+			Number:      big.NewInt(1),
+			Root:        genesis.Root(),
+			ReceiptHash: types.EmptyRootHash,
+			GasLimit:    genesis.GasLimit(),
+			Time:        big.NewInt(1),
+			Extra:       make([]byte, 32+common.ExtraSeal),
+		}
+	}
+	header := newHeader()
+	if _, ok := SnapshotKVHash(header); ok {
+		t.Fatal("the kv hash must not be used without snapshotdb")
+	}
+
+	// the producer records the writes by the unRecognized data until the block is sealed
+	bcr = newTestReactor(t)
+	producer := bcr.snapshotDB
+	defer producer.Clear()
+	state, err := chain.StateAt(genesis.Root())
+	if nil != err {
+		t.Fatal(err)
+	}
+	if _, err := bcr.BeginBlocker(header, state); nil != err {
+		t.Fatal(err)
+	}
+	if _, err := bcr.EndBlocker(header, state); nil != err {
+		t.Fatal(err)
+	}
+	kvHash, ok := SnapshotKVHash(header)
+	if !ok || kvHash == common.ZeroHash {
+		t.Fatalf("unexpected kv hash of the unsigned block: %x", kvHash)
+	}
+	header.MixDigest = kvHash
+	header.Extra[40] = 1
+	if err := bcr.Flush(header); nil != err {
+		t.Fatal(err)
+	}
+	if got := producer.GetLastKVHash(header.Hash()); !bytes.Equal(got, kvHash.Bytes()) {
+		t.Fatalf("the writes must be flushed to the block hash, got kv hash: %x", got)
+	}
+	block := types.NewBlockWithHeader(header)
+	bcr.confirmed(block)
+	if highest, _ := producer.HighestNum(); highest.Cmp(header.Number) != 0 {
+		t.Fatalf("the confirmed block must be committed, the highest block: %v", highest)
+	}
+
+	// the receivers execute the block and verify the committed kv hash
+	execute := func(block *types.Block) error {
+		bcr = newTestReactor(t)
+		defer bcr.snapshotDB.Clear()
+		state, err := chain.StateAt(genesis.Root())
+		if nil != err {
+			t.Fatal(err)
+		}
+		_, err = chain.ProcessDirectly(block, state, genesis)
+		return err
+	}
+	if err := execute(block); nil != err {
+		t.Fatalf("the kv hash of the block must be verified, err: %v", err)
+	}
+	tampered := newHeader()
+	tampered.MixDigest = common.HexToHash("0x01")
+	tampered.Extra[40] = 1
+	if err := execute(types.NewBlockWithHeader(tampered)); nil == err || !strings.Contains(err.Error(), "invalid snapshotdb kv hash") {
+		t.Fatalf("the mismatched kv hash must be rejected, err: %v", err)
+	}
+}
@@ -92,6 +92,10 @@ func (v *BlockValidator) ValidateState(block, parent *types.Block, statedb *stat
 	if root := statedb.IntermediateRoot(v.config.IsEIP158(header.Number)); header.Root != root {
 		return fmt.Errorf("invalid merkle root (remote: %x local: %x)", header.Root, root)
 	}
+	// Validate the kv hash of the snapshotdb writes, the plugin state diverges if they don't match.
+	if kvHash, ok := SnapshotKVHash(header); ok && header.MixDigest != kvHash {
+		return fmt.Errorf("invalid snapshotdb kv hash (remote: %x local: %x)", header.MixDigest, kvHash)
+	}
 	return nil
 }
 
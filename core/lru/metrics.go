@@ -0,0 +1,11 @@
+package lru
+
+import (
+	"github.com/PlatONnetwork/PlatON-Go/metrics"
+)
+
+var (
+	wasmCacheHitMeter   = metrics.NewRegisteredMeter("wasm/cache/hit", nil)
+	wasmCacheMissMeter  = metrics.NewRegisteredMeter("wasm/cache/miss", nil)
+	wasmCacheEvictMeter = metrics.NewRegisteredMeter("wasm/cache/evict", nil)
+)
@@ -3,9 +3,12 @@ package lru
 import (
 	"github.com/PlatONnetwork/PlatON-Go/common"
 	"github.com/PlatONnetwork/PlatON-Go/life/compiler"
+	"github.com/PlatONnetwork/PlatON-Go/life/exec"
+	"github.com/PlatONnetwork/PlatON-Go/life/utils"
 	"github.com/PlatONnetwork/PlatON-Go/log"
 	"bytes"
 	"encoding/gob"
+	"errors"
 	"github.com/hashicorp/golang-lru/simplelru"
 	"github.com/syndtr/goleveldb/leveldb"
 	"path/filepath"
@@ -16,8 +19,18 @@ var (
 	DefaultWasmCacheSize = 1024
 	wasmCache, _         = NewWasmCache(DefaultWasmCacheSize)
 	DefaultWasmCacheDir  = "wasmcache"
+
+	// wasmCacheVersion is the format of the modules persisted in the leveldb, the
+	// cache written by another format is dropped when the db is opened.
+	wasmCacheVersion    = []byte{2}
+	wasmCacheVersionKey = []byte("wasmCacheVersion")
 )
 
+// WasmLDBCache caches the parsed modules keyed by the code hash, so a code is parsed
+// once however many contracts are deployed with it. The cache is never stale: the
+// code of an address replaced by an upgrade or gone with a self-destruct has another
+// hash, its module is no longer looked up and is evicted as any unused module.
+// The evicted modules are persisted in the leveldb and reloaded on a miss.
 type WasmLDBCache struct {
 	lru  *simplelru.LRU
 	db   *leveldb.DB
@@ -25,8 +38,57 @@ type WasmLDBCache struct {
 }
 
 type WasmModule struct {
+	Code         []byte // the wasm code of the module, required to persist the module
 	Module       *compiler.Module
 	FunctionCode []compiler.InterpreterCode
+	Abi          *utils.WasmAbi // nil if the abi of the code is invalid
+
+	// the module compiled by the JIT holds closures, it's kept in memory only and
+	// compiled again after the module is reloaded from the leveldb
+	compileOnce sync.Once
+	compiled    *exec.CompiledModule
+}
+
+// Compiled returns the module compiled by the JIT, the module is compiled on the
+// first call. The functions failed to compile are run by the interpreter.
+func (m *WasmModule) Compiled() *exec.CompiledModule {
+	m.compileOnce.Do(func() {
+		m.compiled = exec.CompileModule(m.FunctionCode)
+	})
+	return m.compiled
+}
+
+// storedWasmModule is the module persisted in the leveldb. The parsed module holds
+// reflect values which can't be encoded, so the code is kept and parsed again on
+// loading, the function code is loaded without compiling.
+type storedWasmModule struct {
+	Code         []byte
+	FunctionCode []compiler.InterpreterCode
+	Abi          *utils.WasmAbi
+}
+
+func encodeWasmModule(module *WasmModule) ([]byte, error) {
+	if module.Code == nil {
+		return nil, errors.New("no code of the module")
+	}
+	buffer := new(bytes.Buffer)
+	enc := gob.NewEncoder(buffer)
+	if err := enc.Encode(&storedWasmModule{Code: module.Code, FunctionCode: module.FunctionCode, Abi: module.Abi}); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func decodeWasmModule(data []byte) (*WasmModule, error) {
+	var dec storedWasmModule
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&dec); err != nil {
+		return nil, err
+	}
+	m, err := compiler.LoadModule(dec.Code)
+	if err != nil {
+		return nil, err
+	}
+	return &WasmModule{Code: dec.Code, Module: m, FunctionCode: dec.FunctionCode, Abi: dec.Abi}, nil
 }
 
 func WasmCache() *WasmLDBCache {
@@ -40,34 +102,63 @@ func SetWasmDB(dataDir string) error {
 	if err != nil {
 		return err
 	}
+	if err := checkWasmDBVersion(db); err != nil {
+		db.Close()
+		return err
+	}
 	wasmCache.SetDB(db)
 	return nil
 }
 
+// checkWasmDBVersion drops the modules persisted by another format, which happens
+// after the node upgrades to a version changing the cached module.
+func checkWasmDBVersion(db *leveldb.DB) error {
+	version, err := db.Get(wasmCacheVersionKey, nil)
+	if err == nil && bytes.Equal(version, wasmCacheVersion) {
+		return nil
+	}
+	if err != nil && err != leveldb.ErrNotFound {
+		return err
+	}
+	log.Info("Drop the wasm cache of another version", "version", version, "expect", wasmCacheVersion)
+
+	batch := new(leveldb.Batch)
+	it := db.NewIterator(nil, nil)
+	for it.Next() {
+		batch.Delete(common.CopyBytes(it.Key()))
+	}
+	it.Release()
+	if err := it.Error(); err != nil {
+		return err
+	}
+	batch.Put(wasmCacheVersionKey, wasmCacheVersion)
+	return db.Write(batch, nil)
+}
+
 func NewWasmCache(size int) (*WasmLDBCache, error) {
 	w := &WasmLDBCache{}
 
 	onEvicted := func(k interface{}, v interface{}) {
-		var addr common.Address
+		var codeHash common.Hash
 		var module *WasmModule
 		var ok bool
 
-		if addr, ok = k.(common.Address); !ok {
+		if codeHash, ok = k.(common.Hash); !ok {
 			return
 		}
 
 		if module, ok = v.(*WasmModule); !ok {
 			return
 		}
+		wasmCacheEvictMeter.Mark(1)
 		if w.db != nil {
-			if ok, err := w.db.Has(addr.Bytes(), nil); err != nil || !ok {
-				buffer := new(bytes.Buffer)
-				enc := gob.NewEncoder(buffer)
-				if err := enc.Encode(module); err != nil {
+			if ok, err := w.db.Has(codeHash.Bytes(), nil); err != nil || !ok {
+				data, err := encodeWasmModule(module)
+				if err != nil {
 					log.Error("encode module err:", err)
 					return
 				}
-				w.db.Put(addr.Bytes(), buffer.Bytes(), nil)
+				w.db.Put(codeHash.Bytes(), data, nil)
 			}
 		}
 	}
@@ -87,6 +178,9 @@ func NewWasmLDBCache(size int, db *leveldb.DB) (*WasmLDBCache, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := checkWasmDBVersion(db); err != nil {
+		return nil, err
+	}
 	w.db = db
 	return w, nil
 }
@@ -103,39 +197,50 @@ func (w *WasmLDBCache) Purge() {
 }
 
 // Add adds a value to the cache.  Returns true if an eviction occurred.
-func (w *WasmLDBCache) Add(key common.Address, value *WasmModule) bool {
+func (w *WasmLDBCache) Add(key common.Hash, value *WasmModule) bool {
 	w.lock.Lock()
 	defer w.lock.Unlock()
 	return w.lru.Add(key, value)
 }
 
 // Get looks up a key's value from the cache.
-func (w *WasmLDBCache) Get(key common.Address) (*WasmModule, bool) {
+func (w *WasmLDBCache) Get(key common.Hash) (*WasmModule, bool) {
 	w.lock.Lock()
 	defer w.lock.Unlock()
 	value, ok := w.lru.Get(key)
 	if !ok {
-		if w.db != nil {
-			if value, err := w.db.Get(key.Bytes(), nil); err == nil {
-				module := WasmModule{}
-				buffer := bytes.NewReader(value)
-				dec := gob.NewDecoder(buffer)
-				if err := dec.Decode(&module); err != nil {
-					log.Error("decode module err:", err)
-					return nil, false
-				}
-				w.lru.Add(key, &module)
-				return &module, true
-			}
+		if module, ok := w.load(key); ok {
+			wasmCacheHitMeter.Mark(1)
+			w.lru.Add(key, module)
+			return module, true
 		}
+		wasmCacheMissMeter.Mark(1)
 		return nil, false
 	}
+	wasmCacheHitMeter.Mark(1)
 	return value.(*WasmModule), ok
 }
 
+// load reads the module persisted in the leveldb.
+func (w *WasmLDBCache) load(key common.Hash) (*WasmModule, bool) {
+	if w.db == nil {
+		return nil, false
+	}
+	value, err := w.db.Get(key.Bytes(), nil)
+	if err != nil {
+		return nil, false
+	}
+	module, err := decodeWasmModule(value)
+	if err != nil {
+		log.Error("decode module err:", err)
+		return nil, false
+	}
+	return module, true
+}
+
 // Check if a key is in the cache, without updating the recent-ness
 // or deleting it for being stale.
-func (w *WasmLDBCache) Contains(key common.Address) bool {
+func (w *WasmLDBCache) Contains(key common.Hash) bool {
 	w.lock.RLock()
 	defer w.lock.RUnlock()
 	if !w.lru.Contains(key) {
@@ -150,21 +255,12 @@ func (w *WasmLDBCache) Contains(key common.Address) bool {
 
 // Returns the key value (or undefined if not found) without updating
 // the "recently used"-ness of the key.
-func (w *WasmLDBCache) Peek(key common.Address) (*WasmModule, bool) {
+func (w *WasmLDBCache) Peek(key common.Hash) (*WasmModule, bool) {
 	w.lock.Lock()
 	defer w.lock.Unlock()
 	value, ok := w.lru.Peek(key)
 	if !ok {
-		if w.db != nil {
-			if value, err := w.db.Get(key.Bytes(), nil); err == nil {
-				var module WasmModule
-				buffer := bytes.NewReader(value)
-				dec := gob.NewDecoder(buffer)
-				dec.Decode(module)
-				return &module, true
-			}
-		}
-		return nil, false
+		return w.load(key)
 	}
 	return value.(*WasmModule), ok
 }
@@ -172,7 +268,7 @@ func (w *WasmLDBCache) Peek(key common.Address) (*WasmModule, bool) {
 // ContainsOrAdd checks if a key is in the cache  without updating the
 // recent-ness or deleting it for being stale,  and if not, adds the value.
 // Returns whether found and whether an eviction occurred.
-func (w *WasmLDBCache) ContainsOrAdd(key common.Address, value *WasmModule) (ok, evict bool) {
+func (w *WasmLDBCache) ContainsOrAdd(key common.Hash, value *WasmModule) (ok, evict bool) {
 	w.lock.Lock()
 	defer w.lock.Unlock()
 
@@ -185,7 +281,7 @@ func (w *WasmLDBCache) ContainsOrAdd(key common.Address, value *WasmModule) (ok,
 }
 
 // Remove removes the provided key from the cache.
-func (w *WasmLDBCache) Remove(key common.Address) {
+func (w *WasmLDBCache) Remove(key common.Hash) {
 	w.lock.Lock()
 	w.lru.Remove(key)
 	if w.db != nil {
@@ -0,0 +1,96 @@
+package lru
+
+import (
+	"testing"
+
+	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/PlatONnetwork/PlatON-Go/life/exec"
+	"github.com/PlatONnetwork/PlatON-Go/life/utils"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/storage"
+)
+
+// an empty module with the exported function "f"
+var testWasmCode = common.Hex2Bytes("0061736d0100000001040160000003020100070501016600000a040102000b")
+
+func newTestWasmModule(t *testing.T) *WasmModule {
+	m, functionCode, err := exec.ParseModuleAndFunc(testWasmCode, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &WasmModule{Code: testWasmCode, Module: m, FunctionCode: functionCode, Abi: &utils.WasmAbi{Version: utils.AbiVersion2}}
+}
+
+func TestWasmLDBCache(t *testing.T) {
+	db, _ := leveldb.Open(storage.NewMemStorage(), nil)
+	cache, err := NewWasmLDBCache(1, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash1, hash2 := common.BytesToHash([]byte{1}), common.BytesToHash([]byte{2})
+	cache.Add(hash1, newTestWasmModule(t))
+	if _, ok := cache.Get(hash2); ok {
+		t.Fatal("get the module not added")
+	}
+	// the evicted module is persisted and reloaded with its abi
+	if evict := cache.Add(hash2, newTestWasmModule(t)); !evict {
+		t.Fatal("no eviction of the full cache")
+	}
+	module, ok := cache.Get(hash1)
+	if !ok {
+		t.Fatal("the evicted module is not reloaded")
+	}
+	if module.Abi == nil || module.Abi.Version != utils.AbiVersion2 {
+		t.Fatalf("the abi of the module is not reloaded: %v", module.Abi)
+	}
+	if _, ok := module.Module.Base.Export.Entries["f"]; !ok {
+		t.Fatal("the export of the module is not reloaded")
+	}
+
+	// the modules of another format are dropped on the restart
+	db.Put(hash1.Bytes(), []byte("legacy"), nil)
+	db.Put(wasmCacheVersionKey, []byte{1}, nil)
+	cache, err = NewWasmLDBCache(1, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.Get(hash1); ok {
+		t.Fatal("get the module of another format")
+	}
+	if version, _ := db.Get(wasmCacheVersionKey, nil); string(version) != string(wasmCacheVersion) {
+		t.Fatalf("version mismatch, expect %x, get %x", wasmCacheVersion, version)
+	}
+}
+
+func TestWasmModuleCompiled(t *testing.T) {
+	db, _ := leveldb.Open(storage.NewMemStorage(), nil)
+	cache, err := NewWasmLDBCache(1, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash1, hash2 := common.BytesToHash([]byte{1}), common.BytesToHash([]byte{2})
+	module := newTestWasmModule(t)
+	compiled := module.Compiled()
+	if len(compiled.Functions) != len(module.FunctionCode) {
+		t.Fatalf("compiled %d functions, expect %d", len(compiled.Functions), len(module.FunctionCode))
+	}
+	if module.Compiled() != compiled {
+		t.Fatal("the module is compiled again")
+	}
+
+	// the compiled module isn't persisted, the reloaded module is compiled again
+	cache.Add(hash1, module)
+	cache.Add(hash2, newTestWasmModule(t))
+	reloaded, ok := cache.Get(hash1)
+	if !ok {
+		t.Fatal("the evicted module is not reloaded")
+	}
+	if reloaded.compiled != nil {
+		t.Fatal("the compiled module is reloaded")
+	}
+	if reloaded.Compiled() == nil || reloaded.Compiled() == compiled {
+		t.Fatal("the reloaded module is not compiled again")
+	}
+}
@@ -0,0 +1,207 @@
+package snapshotdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/memdb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// the layout of the archive data in baseDB
+// version: archiveVersionPrefix + len(key) + key + blockNumber -> value
+// prune:   archivePrunePrefix + supersededAt + len(key) + key + blockNumber -> nil,
+// the version of the key at blockNumber can be removed once the retention horizon reaches supersededAt
+// base:    archiveBaseKey -> the lowest block number which can be read by GetAt
+var (
+	archiveVersionPrefix = []byte("snapshotdb-archive-v")
+	archivePrunePrefix   = []byte("snapshotdb-archive-p")
+	archiveBaseKey       = []byte("snapshotdb-archive-base")
+
+	archiveEnabled   bool
+	archiveRetention uint64
+
+	//ErrArchiveDisabled when GetAt is called without archive mode
+	ErrArchiveDisabled = errors.New("snapshotDB: archive mode is disabled")
+
+	//ErrArchivePruned when the block is out of the archive,pruned or before archive mode was enabled
+	ErrArchivePruned = errors.New("snapshotDB: the block is pruned from archive")
+)
+
+//SetArchive set the archive mode of the Instance,it must be called before Instance
+//retention is the count of committed blocks whose history is kept, 0 means keep all
+func SetArchive(enabled bool, retention uint64) {
+	archiveEnabled = enabled
+	archiveRetention = retention
+}
+
+func encodeUint64(num uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, num)
+	return b
+}
+
+// the length of the key is encoded, so the versions of a key never mix with the versions of the keys it prefixes
+func archiveKeyPrefix(key []byte) []byte {
+	var buf bytes.Buffer
+	buf.Write(archiveVersionPrefix)
+	l := make([]byte, 4)
+	binary.BigEndian.PutUint32(l, uint32(len(key)))
+	buf.Write(l)
+	buf.Write(key)
+	return buf.Bytes()
+}
+
+func archiveVersionKey(key []byte, blockNumber uint64) []byte {
+	return append(archiveKeyPrefix(key), encodeUint64(blockNumber)...)
+}
+
+func archivePruneKey(supersededAt uint64, key []byte, blockNumber uint64) []byte {
+	var buf bytes.Buffer
+	buf.Write(archivePrunePrefix)
+	buf.Write(encodeUint64(supersededAt))
+	buf.Write(archiveVersionKey(key, blockNumber)[len(archiveVersionPrefix):])
+	return buf.Bytes()
+}
+
+// archiveVersionFromPruneKey return the version key which the prune key points to
+func archiveVersionFromPruneKey(pruneKey []byte) []byte {
+	return append(append([]byte{}, archiveVersionPrefix...), pruneKey[len(archivePrunePrefix)+8:]...)
+}
+
+func (s *snapshotDB) archiveBase() (uint64, bool, error) {
+	v, err := s.baseDB.Get(archiveBaseKey, nil)
+	if err == leveldb.ErrNotFound {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, err
+	}
+	return binary.BigEndian.Uint64(v), true, nil
+}
+
+// latestArchiveVersion find the latest version of the key which is not higher than blockNumber
+func (s *snapshotDB) latestArchiveVersion(key []byte, blockNumber uint64) (uint64, []byte, bool, error) {
+	prefix := archiveKeyPrefix(key)
+	itr := s.baseDB.NewIterator(&util.Range{Start: prefix, Limit: archiveVersionKey(key, blockNumber+1)}, nil)
+	defer itr.Release()
+	if !itr.Last() {
+		return 0, nil, false, itr.Error()
+	}
+	num := binary.BigEndian.Uint64(itr.Key()[len(prefix):])
+	return num, copyBytes(itr.Value()), true, nil
+}
+
+func copyBytes(v []byte) []byte {
+	return append([]byte{}, v...)
+}
+
+// writeArchive add the versions of the compacting blocks to the batch,
+// the keys written more than once in the blocks are superseded inside the batch
+func (s *snapshotDB) writeArchive(batch *leveldb.Batch, blocks []blockData) error {
+	if _, ok, err := s.archiveBase(); err != nil {
+		return err
+	} else if !ok && len(blocks) > 0 {
+		// the history before the first archived block is unknown,unless nothing was compacted yet
+		var base uint64
+		if s.current.BaseNum.Sign() > 0 {
+			base = blocks[0].Number.Uint64()
+		}
+		batch.Put(archiveBaseKey, encodeUint64(base))
+	}
+	latest := make(map[string]uint64)
+	for _, block := range blocks {
+		num := block.Number.Uint64()
+		itr := block.data.NewIterator(nil)
+		for itr.Next() {
+			key := itr.Key()
+			batch.Put(archiveVersionKey(key, num), itr.Value())
+			if prev, ok := latest[string(key)]; ok {
+				batch.Put(archivePruneKey(num, key, prev), nil)
+			} else if num > 0 {
+				prev, _, ok, err := s.latestArchiveVersion(key, num-1)
+				if err != nil {
+					itr.Release()
+					return err
+				}
+				if ok {
+					batch.Put(archivePruneKey(num, key, prev), nil)
+				}
+			}
+			latest[string(key)] = num
+		}
+		itr.Release()
+	}
+	return nil
+}
+
+// pruneArchive remove the versions which are superseded not later than the horizon,
+// so the latest version of every key at the horizon is still kept
+func (s *snapshotDB) pruneArchive(batch *leveldb.Batch, horizon uint64) error {
+	base, ok, err := s.archiveBase()
+	if err != nil {
+		return err
+	}
+	if !ok || base >= horizon {
+		return nil
+	}
+	itr := s.baseDB.NewIterator(&util.Range{Start: archivePrunePrefix, Limit: append(append([]byte{}, archivePrunePrefix...), encodeUint64(horizon+1)...)}, nil)
+	defer itr.Release()
+	for itr.Next() {
+		batch.Delete(archiveVersionFromPruneKey(itr.Key()))
+		batch.Delete(copyBytes(itr.Key()))
+	}
+	if err := itr.Error(); err != nil {
+		return err
+	}
+	batch.Put(archiveBaseKey, encodeUint64(horizon))
+	return nil
+}
+
+// GetAt get the value of the key as of the committed block
+// committed blockdata > archive in baseDB
+// it needs archive mode,the block must not be higher than HighestNum or pruned out of the retention window
+func (s *snapshotDB) GetAt(blockNumber *big.Int, key []byte) ([]byte, error) {
+	if !s.archive {
+		return nil, ErrArchiveDisabled
+	}
+	s.commitLock.RLock()
+	defer s.commitLock.RUnlock()
+	if blockNumber.Cmp(s.current.HighestNum) > 0 {
+		return nil, fmt.Errorf("[SnapshotDB]the block %v is not committed,HighestNum %v", blockNumber, s.current.HighestNum)
+	}
+	for i := len(s.committed) - 1; i >= 0; i-- {
+		if s.committed[i].Number.Cmp(blockNumber) > 0 {
+			continue
+		}
+		if v, err := s.committed[i].data.Get(key); err == nil {
+			return v, nil
+		} else if err != memdb.ErrNotFound {
+			return nil, err
+		}
+	}
+	base, ok, err := s.archiveBase()
+	if err != nil {
+		return nil, err
+	}
+	num := blockNumber.Uint64()
+	if !ok {
+		// nothing is compacted since archive mode was enabled,all of the history is in committed
+		if s.current.BaseNum.Sign() == 0 {
+			return nil, ErrNotFound
+		}
+		return nil, ErrArchivePruned
+	}
+	if num < base {
+		return nil, ErrArchivePruned
+	}
+	if _, v, ok, err := s.latestArchiveVersion(key, num); err != nil {
+		return nil, err
+	} else if ok {
+		return v, nil
+	}
+	return nil, ErrNotFound
+}
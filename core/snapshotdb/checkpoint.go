@@ -0,0 +1,390 @@
+package snapshotdb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path"
+
+	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/PlatONnetwork/PlatON-Go/crypto/sha3"
+	"github.com/PlatONnetwork/PlatON-Go/rlp"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/journal"
+	lstorage "github.com/syndtr/goleveldb/leveldb/storage"
+)
+
+const (
+	checkpointVersion = 2
+
+	checkpointKindKV    = 1
+	checkpointKindSum   = 2
+	checkpointKindBlock = 3
+	checkpointKindPut   = 4
+	checkpointKindDel   = 5
+
+	// the records are written to the staging db in batches of this size
+	checkpointBatchSize = 100 * 1024
+
+	checkpointStagingPath = "checkpoint"
+)
+
+var (
+	//ErrCheckpointChecksum when the checksum of the checkpoint file mismatched
+	ErrCheckpointChecksum = errors.New("snapshotDB: checkpoint checksum mismatched")
+
+	//ErrCheckpointNotEmpty when import a checkpoint to a db which has committed blocks
+	ErrCheckpointNotEmpty = errors.New("snapshotDB: can't import checkpoint,the db has committed blocks")
+
+	//ErrCheckpointKVHash when the writes of a block in the checkpoint mismatched its kv hash
+	ErrCheckpointKVHash = errors.New("snapshotDB: checkpoint kv hash mismatched")
+)
+
+// CheckpointBlock is a committed block whose writes are exported by its journal
+type CheckpointBlock struct {
+	Number    *big.Int
+	BlockHash common.Hash
+	// KVHash is the last kv hash of the writes of the block,it is committed into the block header
+	KVHash common.Hash
+}
+
+// CheckpointHeader describes the committed block which a checkpoint is taken at
+type CheckpointHeader struct {
+	Version   uint64
+	Number    *big.Int
+	BlockHash common.Hash
+	// KVHash is the last kv hash of the block
+	KVHash common.Hash
+	// BaseNum is the block number of the baseDB part of the checkpoint
+	BaseNum *big.Int
+	// Blocks are the committed blocks after BaseNum up to Number,
+	// their writes are replayed on import and the kv hashes are checked,
+	// so they can be verified against the kv hashes committed in the chain
+	Blocks []CheckpointBlock
+}
+
+// the checkpoint file is a rlp stream,the header first,then the records.
+// the kv records are baseDB,then every committed block starts with a block record followed by
+// its journal in order,the later one overwrites the earlier one and the empty value deletes the key.
+// the last record is the keccak256 checksum of all the encoded items before it
+type checkpointRecord struct {
+	Kind  uint8
+	Key   []byte
+	Value []byte
+}
+
+func isArchiveKey(key []byte) bool {
+	return bytes.HasPrefix(key, archiveVersionPrefix) || bytes.HasPrefix(key, archivePrunePrefix) || bytes.Equal(key, archiveBaseKey)
+}
+
+// readJournalBodies reads the journal of the block strictly and calls f with every body in order
+func readJournalBodies(stor storage, fd fileDesc, f func(body *journalData) error) error {
+	reader, err := stor.Open(fd)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	journals := journal.NewReader(reader, nil, true, true)
+	// skip the header
+	if _, err := journals.Next(); err != nil {
+		return err
+	}
+	for {
+		j, err := journals.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var body journalData
+		if err := decode(j, &body); err != nil {
+			return err
+		}
+		if err := f(&body); err != nil {
+			return err
+		}
+	}
+}
+
+// ExportCheckpoint write the state of the committed block to w,
+// the block must not be compacted,that is BaseNum < blockNumber <= HighestNum
+// the history of archive mode is not exported
+func (s *snapshotDB) ExportCheckpoint(w io.Writer, blockNumber *big.Int, blockHash common.Hash) (*CheckpointHeader, error) {
+	s.commitLock.RLock()
+	defer s.commitLock.RUnlock()
+	if blockNumber.Cmp(s.current.BaseNum) <= 0 || blockNumber.Cmp(s.current.HighestNum) > 0 {
+		return nil, fmt.Errorf("[SnapshotDB]the block %v is out of the committed range (%v,%v]", blockNumber, s.current.BaseNum, s.current.HighestNum)
+	}
+	header := &CheckpointHeader{
+		Version:   checkpointVersion,
+		Number:    new(big.Int).Set(blockNumber),
+		BlockHash: blockHash,
+		BaseNum:   new(big.Int).Set(s.current.BaseNum),
+	}
+	for _, block := range s.committed {
+		if block.Number.Cmp(blockNumber) > 0 {
+			break
+		}
+		header.Blocks = append(header.Blocks, CheckpointBlock{Number: block.Number, BlockHash: block.BlockHash, KVHash: block.kvHash})
+		if block.Number.Cmp(blockNumber) == 0 {
+			if block.BlockHash != blockHash {
+				return nil, fmt.Errorf("[SnapshotDB]the hash of block %v mismatched,want %s,have %s", blockNumber, blockHash.String(), block.BlockHash.String())
+			}
+			header.KVHash = block.kvHash
+		}
+	}
+
+	hasher := sha3.NewKeccak256()
+	hw := io.MultiWriter(w, hasher)
+	if err := rlp.Encode(hw, header); err != nil {
+		return nil, err
+	}
+	if err := s.WalkBaseDB(nil, func(num *big.Int, itr iterator.Iterator) error {
+		defer itr.Release()
+		for itr.Next() {
+			if isArchiveKey(itr.Key()) {
+				continue
+			}
+			if err := rlp.Encode(hw, &checkpointRecord{Kind: checkpointKindKV, Key: itr.Key(), Value: itr.Value()}); err != nil {
+				return err
+			}
+		}
+		return itr.Error()
+	}); err != nil {
+		return nil, err
+	}
+	for _, block := range header.Blocks {
+		if err := rlp.Encode(hw, &checkpointRecord{Kind: checkpointKindBlock, Key: block.BlockHash.Bytes()}); err != nil {
+			return nil, err
+		}
+		fd := fileDesc{Type: TypeJournal, Num: block.Number.Int64(), BlockHash: block.BlockHash}
+		if err := readJournalBodies(s.storage, fd, func(body *journalData) error {
+			kind := uint8(checkpointKindPut)
+			if body.FuncType == funcTypeDel {
+				kind = checkpointKindDel
+			}
+			return rlp.Encode(hw, &checkpointRecord{Kind: kind, Key: body.Key, Value: body.Value})
+		}); err != nil {
+			return nil, fmt.Errorf("[SnapshotDB]read the journal of block %v fail:%v", block.Number, err)
+		}
+	}
+	var sum common.Hash
+	hasher.Sum(sum[:0])
+	if err := rlp.Encode(w, &checkpointRecord{Kind: checkpointKindSum, Value: sum.Bytes()}); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+// verifyBlocks checks the blocks of the header are linked from BaseNum to the checkpoint block
+func (h *CheckpointHeader) verifyBlocks() error {
+	if h.BaseNum == nil || h.Number == nil || len(h.Blocks) == 0 {
+		return errors.New("[SnapshotDB]the checkpoint has no committed block")
+	}
+	for i, block := range h.Blocks {
+		if expect := new(big.Int).Add(h.BaseNum, big.NewInt(int64(i+1))); block.Number == nil || block.Number.Cmp(expect) != 0 {
+			return fmt.Errorf("[SnapshotDB]the checkpoint block %d is not continuous,want %v", i, expect)
+		}
+	}
+	last := h.Blocks[len(h.Blocks)-1]
+	if last.Number.Cmp(h.Number) != 0 || last.BlockHash != h.BlockHash || last.KVHash != h.KVHash {
+		return errors.New("[SnapshotDB]the last checkpoint block mismatched the header")
+	}
+	return nil
+}
+
+// openCheckpointStaging opens an empty db which the checkpoint is written to before it is verified
+func (s *snapshotDB) openCheckpointStaging() (*leveldb.DB, error) {
+	if s.path == "" {
+		return leveldb.Open(lstorage.NewMemStorage(), nil)
+	}
+	stagingPath := path.Join(s.path, checkpointStagingPath)
+	if err := os.RemoveAll(stagingPath); err != nil {
+		return nil, err
+	}
+	return leveldb.OpenFile(stagingPath, nil)
+}
+
+// discardCheckpointStaging closes and removes the staging db
+func (s *snapshotDB) discardCheckpointStaging(staging *leveldb.DB) {
+	staging.Close()
+	if s.path != "" {
+		os.RemoveAll(path.Join(s.path, checkpointStagingPath))
+	}
+}
+
+// adoptCheckpointStaging replaces baseDB with the staging db
+func (s *snapshotDB) adoptCheckpointStaging(staging *leveldb.DB) error {
+	if s.path == "" {
+		if err := s.baseDB.Close(); err != nil {
+			return err
+		}
+		s.baseDB = staging
+		return nil
+	}
+	if err := staging.Close(); err != nil {
+		return err
+	}
+	if err := s.baseDB.Close(); err != nil {
+		return err
+	}
+	basePath := getBaseDBPath(s.path)
+	if err := os.RemoveAll(basePath); err != nil {
+		return err
+	}
+	if err := os.Rename(path.Join(s.path, checkpointStagingPath), basePath); err != nil {
+		return err
+	}
+	baseDB, err := leveldb.OpenFile(basePath, nil)
+	if err != nil {
+		return err
+	}
+	s.baseDB = baseDB
+	return nil
+}
+
+// ImportCheckpoint read a checkpoint from r and adopt it as the base state of the db,
+// the db must not have any committed block.
+// The records are written to a staging db in batches,the checksum and the kv hashes of the blocks are checked,
+// then verify is called with the header,the checkpoint replaces baseDB only if it returns nil.
+// verify should check the blocks of the header are the canonical ones and their kv hashes are the committed ones.
+func (s *snapshotDB) ImportCheckpoint(r io.Reader, verify func(header *CheckpointHeader) error) (*CheckpointHeader, error) {
+	s.commitLock.Lock()
+	defer s.commitLock.Unlock()
+	if s.current.HighestNum.Sign() > 0 || len(s.committed) > 0 {
+		return nil, ErrCheckpointNotEmpty
+	}
+
+	hasher := sha3.NewKeccak256()
+	stream := rlp.NewStream(r, 0)
+	raw, err := stream.Raw()
+	if err != nil {
+		return nil, fmt.Errorf("[SnapshotDB]read checkpoint header fail:%v", err)
+	}
+	hasher.Write(raw)
+	header := new(CheckpointHeader)
+	if err := rlp.DecodeBytes(raw, header); err != nil {
+		return nil, fmt.Errorf("[SnapshotDB]decode checkpoint header fail:%v", err)
+	}
+	if header.Version != checkpointVersion {
+		return nil, fmt.Errorf("[SnapshotDB]unsupported checkpoint version %d", header.Version)
+	}
+	if err := header.verifyBlocks(); err != nil {
+		return nil, err
+	}
+
+	staging, err := s.openCheckpointStaging()
+	if err != nil {
+		return nil, fmt.Errorf("[SnapshotDB]open checkpoint staging db fail:%v", err)
+	}
+	adopted := false
+	defer func() {
+		if !adopted {
+			s.discardCheckpointStaging(staging)
+		}
+	}()
+
+	var (
+		batch  = new(leveldb.Batch)
+		blocks = -1
+		kvHash common.Hash
+	)
+	// endBlock checks the replayed writes of the current block match its kv hash
+	endBlock := func() error {
+		if blocks >= 0 && kvHash != header.Blocks[blocks].KVHash {
+			return ErrCheckpointKVHash
+		}
+		return nil
+	}
+	for {
+		raw, err := stream.Raw()
+		if err != nil {
+			return nil, fmt.Errorf("[SnapshotDB]read checkpoint record fail:%v", err)
+		}
+		var record checkpointRecord
+		if err := rlp.DecodeBytes(raw, &record); err != nil {
+			return nil, fmt.Errorf("[SnapshotDB]decode checkpoint record fail:%v", err)
+		}
+		if record.Kind == checkpointKindSum {
+			var sum common.Hash
+			hasher.Sum(sum[:0])
+			if !bytes.Equal(sum.Bytes(), record.Value) {
+				return nil, ErrCheckpointChecksum
+			}
+			break
+		}
+		hasher.Write(raw)
+		switch record.Kind {
+		case checkpointKindKV:
+			if blocks >= 0 {
+				return nil, errors.New("[SnapshotDB]the baseDB record is after the blocks")
+			}
+			if len(record.Value) > 0 {
+				batch.Put(record.Key, record.Value)
+			}
+		case checkpointKindBlock:
+			if err := endBlock(); err != nil {
+				return nil, err
+			}
+			blocks++
+			if blocks >= len(header.Blocks) || !bytes.Equal(record.Key, header.Blocks[blocks].BlockHash.Bytes()) {
+				return nil, errors.New("[SnapshotDB]the block record mismatched the header")
+			}
+			kvHash = common.ZeroHash
+		case checkpointKindPut, checkpointKindDel:
+			if blocks < 0 {
+				return nil, errors.New("[SnapshotDB]the journal record is before the blocks")
+			}
+			kvHash = s.generateKVHash(record.Key, record.Value, kvHash)
+			if record.Kind == checkpointKindDel || len(record.Value) == 0 {
+				batch.Delete(record.Key)
+			} else {
+				batch.Put(record.Key, record.Value)
+			}
+		default:
+			return nil, fmt.Errorf("[SnapshotDB]unknown checkpoint record kind %d", record.Kind)
+		}
+		if len(batch.Dump()) >= checkpointBatchSize {
+			if err := staging.Write(batch, nil); err != nil {
+				return nil, errors.New("[SnapshotDB]write checkpoint to staging db fail:" + err.Error())
+			}
+			batch.Reset()
+		}
+	}
+	if err := endBlock(); err != nil {
+		return nil, err
+	}
+	if blocks != len(header.Blocks)-1 {
+		return nil, errors.New("[SnapshotDB]the checkpoint misses the records of some blocks")
+	}
+	if err := staging.Write(batch, nil); err != nil {
+		return nil, errors.New("[SnapshotDB]write checkpoint to staging db fail:" + err.Error())
+	}
+	if verify != nil {
+		if err := verify(header); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.adoptCheckpointStaging(staging); err != nil {
+		return nil, errors.New("[SnapshotDB]replace baseDB with the checkpoint fail:" + err.Error())
+	}
+	adopted = true
+	s.current.BaseNum = new(big.Int).Set(header.Number)
+	s.current.HighestNum = new(big.Int).Set(header.Number)
+	if err := s.current.update(); err != nil {
+		return nil, errors.New("[SnapshotDB]update current fail:" + err.Error())
+	}
+	if err := s.removeJournalLessThanBaseNum(); err != nil {
+		return nil, err
+	}
+	if err := s.rmOldRecognizedBlockData(); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
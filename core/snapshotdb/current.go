@@ -25,6 +25,14 @@ func newCurrent(dir string) *current {
 	return c
 }
 
+// newMemCurrent create a current which is not persisted,used by the memory db
+func newMemCurrent() *current {
+	c := new(current)
+	c.HighestNum = big.NewInt(0)
+	c.BaseNum = big.NewInt(0)
+	return c
+}
+
 type current struct {
 	f            *os.File `rlp:"-"`
 	path         string   `rlp:"-"`
@@ -36,6 +44,9 @@ type current struct {
 func (c *current) update() error {
 	c.Lock()
 	defer c.Unlock()
+	if c.f == nil {
+		return nil
+	}
 	if err := c.f.Truncate(0); err != nil {
 		return err
 	}
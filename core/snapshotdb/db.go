@@ -18,25 +18,20 @@ func getBaseDBPath(dbpath string) string {
 	return path.Join(dbpath, DBBasePath)
 }
 
-func newDB(stor storage) (*snapshotDB, error) {
-	dbpath := stor.Path()
-	baseDB, err := leveldb.OpenFile(getBaseDBPath(dbpath), nil)
-	if err != nil {
-		return nil, fmt.Errorf("[SnapshotDB]open baseDB fail:%v", err)
-	}
+func newDB(stor storage, baseDB *leveldb.DB, c *current) *snapshotDB {
 	mu := sync.Mutex{}
 	return &snapshotDB{
-		path:          dbpath,
+		path:          stor.Path(),
 		storage:       stor,
 		unRecognized:  new(blockData),
 		recognized:    make(map[common.Hash]blockData),
 		committed:     make([]blockData, 0),
 		journalw:      make(map[common.Hash]*journalWriter),
 		baseDB:        baseDB,
-		current:       newCurrent(dbpath),
+		current:       c,
 		snapshotLock:  sync.NewCond(&mu),
 		snapshotLockC: false,
-	}, nil
+	}
 }
 
 func (s *snapshotDB) getBlockFromJournal(fd fileDesc) (*blockData, error) {
@@ -3,9 +3,35 @@ package snapshotdb
 import (
 	"fmt"
 	"sync/atomic"
+	"time"
 )
 
-var counter count32
+// CompactionPolicy decides when and how much the committed blocks are compacted to baseDB
+type CompactionPolicy struct {
+	// BlockLag compacts once HighestNum-BaseNum reaches it, it must be at least 1
+	BlockLag uint64
+	// KVLimit is the max count of kv written to baseDB by one compaction,
+	// at least one block is compacted even if it has more kv than the limit
+	KVLimit int
+	// Interval compacts if no compaction is done in it, it is counted in whole seconds
+	Interval time.Duration
+}
+
+var (
+	//DefaultCompactionPolicy is the compaction policy used if not set
+	DefaultCompactionPolicy = CompactionPolicy{
+		BlockLag: 100,
+		KVLimit:  2000,
+		Interval: 60 * time.Second,
+	}
+
+	compactionPolicy = DefaultCompactionPolicy
+)
+
+//SetCompactionPolicy set the compaction policy of the Instance,it must be called before Instance
+func SetCompactionPolicy(policy CompactionPolicy) {
+	compactionPolicy = policy
+}
 
 type count32 int32
 
@@ -21,16 +47,19 @@ func (c *count32) get() int32 {
 	return atomic.LoadInt32((*int32)(c))
 }
 
+// schedule is called every second
 func (s *snapshotDB) schedule() {
-	if counter.get() >= 60 || s.current.HighestNum.Int64()-s.current.BaseNum.Int64() >= 100 {
+	s.updateMetrics()
+	interval := int32(s.policy.Interval / time.Second)
+	if s.counter.get() >= interval || s.current.HighestNum.Uint64()-s.current.BaseNum.Uint64() >= s.policy.BlockLag {
 		if !s.snapshotLockC {
 			if err := s.Compaction(); err != nil {
 				logger.Error(fmt.Sprint("[SnapshotDB]compaction fail:", err))
 			}
-			counter.reset()
+			s.counter.reset()
 			return
 		}
 		logger.Info("snapshotDB is still Compaction Lock,wait for next schedule")
 	}
-	counter.increment()
+	s.counter.increment()
 }
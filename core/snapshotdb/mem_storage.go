@@ -0,0 +1,150 @@
+package snapshotdb
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"sync"
+)
+
+// memStorage is a memory-backed storage,the journals are lost after the process exits.
+type memStorage struct {
+	mu    sync.Mutex
+	files map[fileDesc]*memFile
+	open  bool
+}
+
+type memFile struct {
+	bytes.Buffer
+}
+
+// memReader reads a copy of the file,so the writer can go on appending
+type memReader struct {
+	*bytes.Reader
+}
+
+func (memReader) Close() error { return nil }
+
+type memWriter struct {
+	ms *memStorage
+	mf *memFile
+}
+
+func (w *memWriter) Write(p []byte) (int, error) {
+	w.ms.mu.Lock()
+	defer w.ms.mu.Unlock()
+	return w.mf.Write(p)
+}
+
+func (w *memWriter) Close() error { return nil }
+
+func newMemStorage() *memStorage {
+	return &memStorage{files: make(map[fileDesc]*memFile), open: true}
+}
+
+func (ms *memStorage) Path() string {
+	return ""
+}
+
+func (ms *memStorage) Create(fd fileDesc) (io.WriteCloser, error) {
+	if !FileDescOk(fd) {
+		return nil, ErrInvalidFile
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if !ms.open {
+		return nil, ErrClosed
+	}
+	mf := new(memFile)
+	ms.files[fd] = mf
+	return &memWriter{ms: ms, mf: mf}, nil
+}
+
+func (ms *memStorage) Append(fd fileDesc) (io.WriteCloser, error) {
+	if !FileDescOk(fd) {
+		return nil, ErrInvalidFile
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if !ms.open {
+		return nil, ErrClosed
+	}
+	mf, ok := ms.files[fd]
+	if !ok {
+		mf = new(memFile)
+		ms.files[fd] = mf
+	}
+	return &memWriter{ms: ms, mf: mf}, nil
+}
+
+func (ms *memStorage) Open(fd fileDesc) (Reader, error) {
+	if !FileDescOk(fd) {
+		return nil, ErrInvalidFile
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if !ms.open {
+		return nil, ErrClosed
+	}
+	mf, ok := ms.files[fd]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return memReader{bytes.NewReader(append([]byte{}, mf.Bytes()...))}, nil
+}
+
+func (ms *memStorage) Rename(oldfd, newfd fileDesc) error {
+	if !FileDescOk(oldfd) || !FileDescOk(newfd) {
+		return ErrInvalidFile
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if !ms.open {
+		return ErrClosed
+	}
+	mf, ok := ms.files[oldfd]
+	if !ok {
+		return os.ErrNotExist
+	}
+	delete(ms.files, oldfd)
+	ms.files[newfd] = mf
+	return nil
+}
+
+func (ms *memStorage) Remove(fd fileDesc) error {
+	if !FileDescOk(fd) {
+		return ErrInvalidFile
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if !ms.open {
+		return ErrClosed
+	}
+	if _, ok := ms.files[fd]; !ok {
+		return os.ErrNotExist
+	}
+	delete(ms.files, fd)
+	return nil
+}
+
+func (ms *memStorage) List(ft fileType) ([]fileDesc, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if !ms.open {
+		return nil, ErrClosed
+	}
+	var fds []fileDesc
+	for fd := range ms.files {
+		if fd.Type&ft != 0 {
+			fds = append(fds, fd)
+		}
+	}
+	return fds, nil
+}
+
+func (ms *memStorage) Close() error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.open = false
+	return nil
+}
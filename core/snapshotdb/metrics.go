@@ -0,0 +1,31 @@
+package snapshotdb
+
+import (
+	"github.com/PlatONnetwork/PlatON-Go/metrics"
+)
+
+var (
+	journalGauge    = metrics.NewRegisteredGauge("snapshotdb/journal/count", nil)
+	recognizedGauge = metrics.NewRegisteredGauge("snapshotdb/recognized/count", nil)
+	committedGauge  = metrics.NewRegisteredGauge("snapshotdb/committed/count", nil)
+
+	compactionTimer = metrics.NewRegisteredTimer("snapshotdb/compaction/time", nil)
+	compactionMeter = metrics.NewRegisteredMeter("snapshotdb/compaction/keys", nil)
+)
+
+// updateMetrics publishes the count of the journals and the blocks in memory
+func (s *snapshotDB) updateMetrics() {
+	if !metrics.Enabled {
+		return
+	}
+	if fds, err := s.storage.List(TypeJournal); err == nil {
+		journalGauge.Update(int64(len(fds)))
+	}
+	// the recognized blocks are added by Flush and removed by Commit
+	s.commitLock.RLock()
+	s.unRecognizedLock.RLock()
+	committedGauge.Update(int64(len(s.committed)))
+	recognizedGauge.Update(int64(len(s.recognized)))
+	s.unRecognizedLock.RUnlock()
+	s.commitLock.RUnlock()
+}
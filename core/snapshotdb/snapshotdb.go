@@ -11,14 +11,13 @@ import (
 	"github.com/syndtr/goleveldb/leveldb/comparer"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
 	"github.com/syndtr/goleveldb/leveldb/memdb"
+	lstorage "github.com/syndtr/goleveldb/leveldb/storage"
 	"github.com/syndtr/goleveldb/leveldb/util"
+	"io"
 	"math/big"
 	"os"
 	"sync"
-)
-
-const (
-	kvLIMIT = 2000
+	"time"
 )
 
 //DB the main snapshotdb interface
@@ -27,6 +26,7 @@ type DB interface {
 	NewBlock(blockNumber *big.Int, parentHash common.Hash, hash common.Hash) error
 	Get(hash common.Hash, key []byte) ([]byte, error)
 	GetFromCommittedBlock(key []byte) ([]byte, error)
+	GetAt(blockNumber *big.Int, key []byte) ([]byte, error)
 	Del(hash common.Hash, key []byte) error
 	Has(hash common.Hash, key []byte) (bool, error)
 	Flush(hash common.Hash, blocknumber *big.Int) error
@@ -38,8 +38,11 @@ type DB interface {
 	DelBaseDB(key []byte) error
 	GetLastKVHash(blockHash common.Hash) []byte
 	BaseNum() (*big.Int, error)
+	HighestNum() (*big.Int, error)
 	Close() error
 	Compaction() error
+	ExportCheckpoint(w io.Writer, blockNumber *big.Int, blockHash common.Hash) (*CheckpointHeader, error)
+	ImportCheckpoint(r io.Reader, verify func(header *CheckpointHeader) error) (*CheckpointHeader, error)
 }
 
 var (
@@ -76,7 +79,12 @@ type snapshotDB struct {
 	journalw map[common.Hash]*journalWriter
 	storage  storage
 
-	corn *cron.Cron
+	corn    *cron.Cron
+	counter count32
+	policy  CompactionPolicy
+
+	archive          bool
+	archiveRetention uint64
 
 	closed bool
 }
@@ -86,6 +94,11 @@ func SetDBPath(ctx *node.ServiceContext) {
 	dbpath = ctx.ResolvePath(DBPath)
 }
 
+//SetDBPathWithNode set db path by the node,used by the commands which don't start the node
+func SetDBPathWithNode(n *node.Node) {
+	dbpath = n.ResolvePath(DBPath)
+}
+
 //Instance return the Instance of the db
 func Instance() DB {
 	if dbInstance == nil || dbInstance.closed {
@@ -98,39 +111,90 @@ func Instance() DB {
 	return dbInstance
 }
 
-func initDB() error {
-	s, err := openFile(dbpath, false)
-	if err != nil {
-		logger.Error(fmt.Sprint("open db file fail:", err))
-		return err
-	}
-	fds, err := s.List(TypeCurrent)
-	if err != nil {
-		logger.Error(fmt.Sprint("get current file fail:", err))
-		return err
-	}
-	if len(fds) > 0 {
-		db := new(snapshotDB)
-		if err := db.recover(s); err != nil {
-			logger.Error(fmt.Sprint("recover  db fail:", err))
-			return err
+// Options are the options of a snapshotdb instance
+type Options struct {
+	// Path is the directory of the db,it is ignored by the memory db
+	Path string
+	// InMemory keeps the baseDB,the journals and the current in memory,nothing is written to the disk
+	InMemory bool
+	// Archive and ArchiveRetention set the archive mode of the db,see SetArchive
+	Archive          bool
+	ArchiveRetention uint64
+	// Schedule runs the compaction in background
+	Schedule bool
+	// Compaction is the compaction policy,DefaultCompactionPolicy if it is zero
+	Compaction CompactionPolicy
+}
+
+//New return an independent db,it doesn't share anything with the Instance
+func New(opts Options) (DB, error) {
+	return open(opts)
+}
+
+func open(opts Options) (*snapshotDB, error) {
+	var db *snapshotDB
+	if opts.InMemory {
+		baseDB, err := leveldb.Open(lstorage.NewMemStorage(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("[SnapshotDB]open memory baseDB fail:%v", err)
 		}
-		dbInstance = db
+		db = newDB(newMemStorage(), baseDB, newMemCurrent())
 	} else {
-		db, err := newDB(s)
+		s, err := openFile(opts.Path, false)
 		if err != nil {
-			logger.Error(fmt.Sprint("new db fail:", err))
-			return err
+			logger.Error(fmt.Sprint("open db file fail:", err))
+			return nil, err
+		}
+		fds, err := s.List(TypeCurrent)
+		if err != nil {
+			logger.Error(fmt.Sprint("get current file fail:", err))
+			return nil, err
+		}
+		if len(fds) > 0 {
+			db = new(snapshotDB)
+			if err := db.recover(s); err != nil {
+				logger.Error(fmt.Sprint("recover  db fail:", err))
+				return nil, err
+			}
+		} else {
+			baseDB, err := leveldb.OpenFile(getBaseDBPath(s.Path()), nil)
+			if err != nil {
+				logger.Error(fmt.Sprint("new db fail:", err))
+				return nil, fmt.Errorf("[SnapshotDB]open baseDB fail:%v", err)
+			}
+			db = newDB(s, baseDB, newCurrent(s.Path()))
+		}
+	}
+	db.archive = opts.Archive
+	db.archiveRetention = opts.ArchiveRetention
+	db.policy = opts.Compaction
+	if db.policy == (CompactionPolicy{}) {
+		db.policy = DefaultCompactionPolicy
+	}
+	if opts.Schedule {
+		db.corn = cron.New()
+		if err := db.corn.AddFunc("@every 1s", db.schedule); err != nil {
+			logger.Error(fmt.Sprint("new db fail", err))
+			return nil, err
 		}
-		dbInstance = db
+		db.corn.Start()
 	}
-	dbInstance.corn = cron.New()
-	if err := dbInstance.corn.AddFunc("@every 1s", dbInstance.schedule); err != nil {
-		logger.Error(fmt.Sprint("new db fail", err))
+	return db, nil
+}
+
+func initDB() error {
+	db, err := open(Options{
+		Path:             dbpath,
+		Archive:          archiveEnabled,
+		ArchiveRetention: archiveRetention,
+		Schedule:         true,
+		Compaction:       compactionPolicy,
+	})
+	if err != nil {
 		return err
 	}
-	dbInstance.corn.Start()
-	return err
+	dbInstance = db
+	return nil
 }
 
 // GetCommittedBlock    get value from committed blockdata > baseDB
@@ -176,6 +240,9 @@ func (s *snapshotDB) DelBaseDB(key []byte) error {
 // else, get recognized block lastkv  hash
 func (s *snapshotDB) GetLastKVHash(blockHash common.Hash) []byte {
 	if blockHash == common.ZeroHash {
+		if s.unRecognized == nil {
+			return nil
+		}
 		return s.unRecognized.kvHash.Bytes()
 	}
 	block, ok := s.recognized[blockHash]
@@ -196,7 +263,8 @@ func (s *snapshotDB) Del(hash common.Hash, key []byte) error {
 }
 
 // Compaction ,write commit to baseDB,and then removeJournal lessThan BaseNum
-// it will write to baseDB
+// it will write to baseDB,in archive mode the versions of the keys are written too
+// and the versions out of the retention window are pruned
 // case kv>2000 and block == 1
 // case kv<2000,block... <9
 // case kv<2000,block...=9
@@ -204,6 +272,7 @@ func (s *snapshotDB) Compaction() error {
 	if len(s.committed) == 0 {
 		return nil
 	}
+	start := time.Now()
 	s.commitLock.Lock()
 	s.snapshotLock.L.Lock()
 	s.snapshotLockC = true
@@ -219,7 +288,7 @@ func (s *snapshotDB) Compaction() error {
 	)
 	for i := 0; i < len(s.committed); i++ {
 		if i < 10 {
-			if kvsize > kvLIMIT {
+			if kvsize > s.policy.KVLimit {
 				commitNum = i - 1
 				break
 			}
@@ -239,6 +308,19 @@ func (s *snapshotDB) Compaction() error {
 			batch.Put(itr.Key(), itr.Value())
 		}
 	}
+	if s.archive {
+		if err := s.writeArchive(batch, s.committed[0:commitNum]); err != nil {
+			logger.Error(fmt.Sprint("write archive fail:", err))
+			return errors.New("[SnapshotDB]write archive fail:" + err.Error())
+		}
+		baseNum := s.current.BaseNum.Uint64() + uint64(commitNum)
+		if s.archiveRetention > 0 && baseNum > s.archiveRetention {
+			if err := s.pruneArchive(batch, baseNum-s.archiveRetention); err != nil {
+				logger.Error(fmt.Sprint("prune archive fail:", err))
+				return errors.New("[SnapshotDB]prune archive fail:" + err.Error())
+			}
+		}
+	}
 	if err := s.baseDB.Write(batch, nil); err != nil {
 		logger.Error(fmt.Sprint("write to baseDB fail:", err))
 		return errors.New("[SnapshotDB]write to baseDB fail:" + err.Error())
@@ -253,6 +335,8 @@ func (s *snapshotDB) Compaction() error {
 		logger.Error(fmt.Sprint("remove journal less than baseNum fail:", err))
 		return errors.New("[SnapshotDB]remove journal less than baseNum fail:" + err.Error())
 	}
+	compactionTimer.UpdateSince(start)
+	compactionMeter.Mark(int64(batch.Len()))
 	return nil
 }
 
@@ -368,6 +452,9 @@ func (s *snapshotDB) Has(hash common.Hash, key []byte) (bool, error) {
 
 // Flush move unRecognized to Recognized data
 func (s *snapshotDB) Flush(hash common.Hash, blocknumber *big.Int) error {
+	if s.unRecognized == nil {
+		return errors.New("[snapshotdb]the unRecognized block is not found")
+	}
 	if blocknumber.Int64() != s.unRecognized.Number.Int64() {
 		return errors.New("[snapshotdb]blocknumber not compare the unRecognized blocknumber")
 	}
@@ -427,6 +514,14 @@ func (s *snapshotDB) BaseNum() (*big.Int, error) {
 	return s.current.BaseNum, nil
 }
 
+// HighestNum returns the number of the highest committed block,
+// the blocks below it can not be read by the block hash
+func (s *snapshotDB) HighestNum() (*big.Int, error) {
+	s.commitLock.RLock()
+	defer s.commitLock.RUnlock()
+	return new(big.Int).Set(s.current.HighestNum), nil
+}
+
 // WalkBaseDB returns a latest snapshot of the underlying DB. A snapshot
 // is a frozen snapshot of a DB state at a particular point in time. The
 // content of snapshot are guaranteed to be consistent.
@@ -453,6 +548,9 @@ func (s *snapshotDB) Clear() error {
 	if err := s.Close(); err != nil {
 		return err
 	}
+	if s.path == "" {
+		return nil
+	}
 	logger.Info(fmt.Sprint("begin clear file:", s.path))
 	if err := os.RemoveAll(s.path); err != nil {
 		return err
@@ -553,7 +651,7 @@ func (s *snapshotDB) Close() error {
 	if err := s.storage.Close(); err != nil {
 		return fmt.Errorf("[snapshotdb]close storage fail:%v", err)
 	}
-	if s.current != nil {
+	if s.current != nil && s.current.f != nil {
 		s.current.f.Close()
 	}
 
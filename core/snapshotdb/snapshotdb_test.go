@@ -4,14 +4,19 @@ import (
 	"bytes"
 	"fmt"
 	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/PlatONnetwork/PlatON-Go/crypto/sha3"
+	"github.com/PlatONnetwork/PlatON-Go/rlp"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
 	"github.com/syndtr/goleveldb/leveldb/journal"
 	"github.com/syndtr/goleveldb/leveldb/memdb"
 	"github.com/syndtr/goleveldb/leveldb/util"
+	"io"
 	"io/ioutil"
 	"math/big"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 )
 
 var (
@@ -1065,3 +1070,416 @@ func TestCommit(t *testing.T) {
 	}
 
 }
+
+func TestSnapshotDB_GetAt(t *testing.T) {
+	db, err := open(Options{InMemory: true, Archive: true, ArchiveRetention: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var parenthash common.Hash
+	for i := 1; i <= 6; i++ {
+		currenthash := rlpHash(fmt.Sprint(i))
+		if err := db.NewBlock(big.NewInt(int64(i)), parenthash, currenthash); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.Put(currenthash, []byte("k"), []byte(fmt.Sprint("v", i))); err != nil {
+			t.Fatal(err)
+		}
+		if i == 1 {
+			if err := db.Put(currenthash, []byte("a"), []byte("a")); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := db.Commit(currenthash); err != nil {
+			t.Fatal(err)
+		}
+		parenthash = currenthash
+	}
+	checkGetAt := func(num int64, key string, expect string) {
+		v, err := db.GetAt(big.NewInt(num), []byte(key))
+		if err != nil {
+			t.Fatalf("get %s at %d fail:%v", key, num, err)
+		}
+		if string(v) != expect {
+			t.Fatalf("get %s at %d, expect %s, got %s", key, num, expect, v)
+		}
+	}
+
+	t.Run("from committed", func(t *testing.T) {
+		checkGetAt(3, "k", "v3")
+		checkGetAt(6, "a", "a")
+		if _, err := db.GetAt(big.NewInt(7), []byte("k")); err == nil {
+			t.Error("the block is not committed,must fail")
+		}
+	})
+	for len(db.committed) > 0 {
+		if err := db.Compaction(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Run("from archive", func(t *testing.T) {
+		checkGetAt(3, "k", "v3")
+		checkGetAt(5, "k", "v5")
+		checkGetAt(6, "a", "a")
+		if _, err := db.GetAt(big.NewInt(6), []byte("b")); err != ErrNotFound {
+			t.Errorf("expect %v,got %v", ErrNotFound, err)
+		}
+	})
+	t.Run("pruned", func(t *testing.T) {
+		if _, err := db.GetAt(big.NewInt(2), []byte("k")); err != ErrArchivePruned {
+			t.Errorf("expect %v,got %v", ErrArchivePruned, err)
+		}
+		for i, pruned := range []bool{true, true, false, false} {
+			_, err := db.baseDB.Get(archiveVersionKey([]byte("k"), uint64(i+1)), nil)
+			if pruned != (err != nil) {
+				t.Errorf("the version at %d,expect pruned %v,got %v", i+1, pruned, err)
+			}
+		}
+	})
+	t.Run("archive disabled", func(t *testing.T) {
+		db.archive = false
+		if _, err := db.GetAt(big.NewInt(5), []byte("k")); err != ErrArchiveDisabled {
+			t.Errorf("expect %v,got %v", ErrArchiveDisabled, err)
+		}
+	})
+}
+
+// rewriteCheckpoint applies f to every kv record of the checkpoint and updates the checksum
+func rewriteCheckpoint(t *testing.T, checkpoint []byte, f func(record *checkpointRecord)) []byte {
+	var (
+		buf    bytes.Buffer
+		hasher = sha3.NewKeccak256()
+		w      = io.MultiWriter(&buf, hasher)
+		stream = rlp.NewStream(bytes.NewReader(checkpoint), 0)
+	)
+	raw, err := stream.Raw()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write(raw)
+	for {
+		var record checkpointRecord
+		if err := stream.Decode(&record); err != nil {
+			t.Fatal(err)
+		}
+		if record.Kind == checkpointKindSum {
+			break
+		}
+		f(&record)
+		if err := rlp.Encode(w, &record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := rlp.Encode(&buf, &checkpointRecord{Kind: checkpointKindSum, Value: hasher.Sum(nil)}); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestSnapshotDB_Checkpoint(t *testing.T) {
+	db, err := open(Options{InMemory: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Clear()
+	for _, key := range []string{"base", "gone"} {
+		if err := db.PutBaseDB([]byte(key), []byte(key)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var (
+		parenthash common.Hash
+		hashes     []common.Hash
+	)
+	for i := 1; i <= 3; i++ {
+		currenthash := rlpHash(fmt.Sprint(i))
+		if err := db.NewBlock(big.NewInt(int64(i)), parenthash, currenthash); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.Put(currenthash, []byte("k"), []byte(fmt.Sprint("v", i))); err != nil {
+			t.Fatal(err)
+		}
+		if i == 2 {
+			// the empty value deletes the key of baseDB,the key put and deleted in the block is dropped
+			if err := db.Put(currenthash, []byte("gone"), nil); err != nil {
+				t.Fatal(err)
+			}
+			if err := db.Put(currenthash, []byte("tmp"), []byte("tmp")); err != nil {
+				t.Fatal(err)
+			}
+			if err := db.Del(currenthash, []byte("tmp")); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := db.Commit(currenthash); err != nil {
+			t.Fatal(err)
+		}
+		parenthash = currenthash
+		hashes = append(hashes, currenthash)
+	}
+	if _, err := db.ExportCheckpoint(new(bytes.Buffer), big.NewInt(2), hashes[0]); err == nil {
+		t.Fatal("the hash mismatched,must fail")
+	}
+	var buf bytes.Buffer
+	header, err := db.ExportCheckpoint(&buf, big.NewInt(2), hashes[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.KVHash != db.committed[1].kvHash {
+		t.Fatal("the kv hash must be the last kv hash of the block")
+	}
+	if len(header.Blocks) != 2 || header.Blocks[0].BlockHash != hashes[0] || header.Blocks[0].KVHash != db.committed[0].kvHash {
+		t.Fatal("the committed blocks up to the checkpoint must be exported")
+	}
+	checkpoint := buf.Bytes()
+
+	dir, err := ioutil.TempDir("", "snapshotdb_checkpoint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	target, err := open(Options{Path: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer target.Close()
+	t.Run("checksum mismatched", func(t *testing.T) {
+		corrupted := append([]byte{}, checkpoint...)
+		corrupted[len(corrupted)-40] ^= 0xff
+		if _, err := target.ImportCheckpoint(bytes.NewReader(corrupted), nil); err == nil {
+			t.Error("the checkpoint is corrupted,must fail")
+		}
+	})
+	t.Run("kv hash mismatched", func(t *testing.T) {
+		forged := rewriteCheckpoint(t, checkpoint, func(record *checkpointRecord) {
+			if record.Kind == checkpointKindPut && string(record.Key) == "k" {
+				record.Value = []byte("forged")
+			}
+		})
+		if _, err := target.ImportCheckpoint(bytes.NewReader(forged), nil); err != ErrCheckpointKVHash {
+			t.Errorf("expect %v,got %v", ErrCheckpointKVHash, err)
+		}
+	})
+	t.Run("verify fail", func(t *testing.T) {
+		_, err := target.ImportCheckpoint(bytes.NewReader(checkpoint), func(header *CheckpointHeader) error {
+			return ErrNotFound
+		})
+		if err != ErrNotFound {
+			t.Errorf("expect %v,got %v", ErrNotFound, err)
+		}
+		if _, err := target.GetFromCommittedBlock([]byte("k")); err != ErrNotFound {
+			t.Error("the checkpoint must not be adopted")
+		}
+	})
+	t.Run("import", func(t *testing.T) {
+		header, err := target.ImportCheckpoint(bytes.NewReader(checkpoint), func(header *CheckpointHeader) error {
+			if header.BlockHash != hashes[1] || len(header.Blocks) != 2 {
+				t.Errorf("the header mismatched")
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if header.Number.Cmp(big.NewInt(2)) != 0 || target.current.BaseNum.Cmp(big.NewInt(2)) != 0 || target.current.HighestNum.Cmp(big.NewInt(2)) != 0 {
+			t.Fatalf("the db must be at block 2,BaseNum %v,HighestNum %v", target.current.BaseNum, target.current.HighestNum)
+		}
+		for key, expect := range map[string]string{"base": "base", "k": "v2"} {
+			v, err := target.GetFromCommittedBlock([]byte(key))
+			if err != nil || string(v) != expect {
+				t.Errorf("get %s,expect %s,got %s,%v", key, expect, v, err)
+			}
+		}
+		for _, key := range []string{"gone", "tmp"} {
+			if _, err := target.GetFromCommittedBlock([]byte(key)); err != ErrNotFound {
+				t.Errorf("the key %s must be deleted,got %v", key, err)
+			}
+		}
+		if _, err := os.Stat(filepath.Join(dir, checkpointStagingPath)); !os.IsNotExist(err) {
+			t.Errorf("the staging db must be moved to baseDB,got %v", err)
+		}
+		if _, err := target.ImportCheckpoint(bytes.NewReader(checkpoint), nil); err != ErrCheckpointNotEmpty {
+			t.Errorf("expect %v,got %v", ErrCheckpointNotEmpty, err)
+		}
+	})
+}
+
+func TestNew_InMemory(t *testing.T) {
+	newMemDB := func() *snapshotDB {
+		db, err := New(Options{InMemory: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return db.(*snapshotDB)
+	}
+	db1, db2 := newMemDB(), newMemDB()
+	defer db1.Clear()
+	defer db2.Clear()
+
+	hash := rlpHash("hash")
+	if err := db1.NewBlock(big.NewInt(1), common.ZeroHash, hash); err != nil {
+		t.Fatal(err)
+	}
+	if err := db1.Put(hash, []byte("k"), []byte("v")); err != nil {
+		t.Fatal(err)
+	}
+	if err := db1.Commit(hash); err != nil {
+		t.Fatal(err)
+	}
+	if err := db1.Compaction(); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := db1.GetFromCommittedBlock([]byte("k")); err != nil || string(v) != "v" {
+		t.Fatalf("get from the memory db,expect v,got %s,%v", v, err)
+	}
+	if db1.current.BaseNum.Cmp(big.NewInt(1)) != 0 {
+		t.Fatalf("the base num must be 1,got %v", db1.current.BaseNum)
+	}
+	fds, err := db1.storage.List(TypeJournal)
+	if err != nil || len(fds) != 0 {
+		t.Fatalf("the journal must be removed after compaction,%v,%v", fds, err)
+	}
+	if _, err := db2.GetFromCommittedBlock([]byte("k")); err != ErrNotFound {
+		t.Fatalf("the instances must be independent,got %v", err)
+	}
+}
+
+func TestSnapshotDB_CompactionPolicy(t *testing.T) {
+	db, err := open(Options{InMemory: true, Compaction: CompactionPolicy{BlockLag: 5, KVLimit: 1, Interval: time.Hour}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	var parenthash common.Hash
+	commitBlocks := func(from, to int) {
+		for i := from; i <= to; i++ {
+			currenthash := rlpHash(fmt.Sprint(i))
+			if err := db.NewBlock(big.NewInt(int64(i)), parenthash, currenthash); err != nil {
+				t.Fatal(err)
+			}
+			if err := db.Put(currenthash, []byte(fmt.Sprint(i)), []byte(fmt.Sprint(i))); err != nil {
+				t.Fatal(err)
+			}
+			if err := db.Commit(currenthash); err != nil {
+				t.Fatal(err)
+			}
+			parenthash = currenthash
+		}
+	}
+
+	commitBlocks(1, 4)
+	db.schedule()
+	if db.current.BaseNum.Sign() != 0 {
+		t.Fatalf("the block lag is not reached,must not compact,BaseNum %v", db.current.BaseNum)
+	}
+	commitBlocks(5, 5)
+	db.schedule()
+	if db.current.BaseNum.Cmp(big.NewInt(1)) != 0 {
+		t.Fatalf("only 1 block can be compacted by the kv limit,BaseNum %v", db.current.BaseNum)
+	}
+	if db.counter.get() != 0 {
+		t.Fatalf("the counter must be reset after compaction,got %d", db.counter.get())
+	}
+}
+
+type mockChainReader map[uint64]common.Hash
+
+func (m mockChainReader) CanonicalHash(number uint64) common.Hash {
+	return m[number]
+}
+
+func (m mockChainReader) HeadNumber() uint64 {
+	var head uint64
+	for number := range m {
+		if number > head {
+			head = number
+		}
+	}
+	return head
+}
+
+func TestVerify(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshotdb_verify")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := open(Options{Path: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		parenthash common.Hash
+		chain      = mockChainReader{0: common.ZeroHash}
+	)
+	for i := 1; i <= 4; i++ {
+		currenthash := rlpHash(fmt.Sprint(i))
+		if err := db.NewBlock(big.NewInt(int64(i)), parenthash, currenthash); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.Put(currenthash, []byte(fmt.Sprint(i)), []byte(fmt.Sprint(i))); err != nil {
+			t.Fatal(err)
+		}
+		if i <= 3 {
+			if err := db.Commit(currenthash); err != nil {
+				t.Fatal(err)
+			}
+			chain[uint64(i)] = currenthash
+		}
+		parenthash = currenthash
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Verify(dir, chain, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.HighestNum != 3 || result.Journals != 4 || len(result.Issues) != 0 {
+		t.Fatalf("the db must be consistent,got %+v", result)
+	}
+
+	t.Run("corrupted journal", func(t *testing.T) {
+		fd := fileDesc{Type: TypeJournal, Num: 3, BlockHash: chain[3]}
+		name := filepath.Join(dir, fd.String())
+		content, err := ioutil.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(name, content[:len(content)-3], 0644); err != nil {
+			t.Fatal(err)
+		}
+		defer ioutil.WriteFile(name, content, 0644)
+		result, err := Verify(dir, chain, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Divergent == nil || result.Divergent.Number != 3 {
+			t.Fatalf("must diverge at the corrupted block 3,got %+v", result.Divergent)
+		}
+	})
+
+	t.Run("repair forked chain", func(t *testing.T) {
+		forked := mockChainReader{0: chain[0], 1: chain[1], 2: rlpHash("fork"), 3: rlpHash("fork3")}
+		result, err := Verify(dir, forked, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Divergent == nil || result.Divergent.Number != 2 || !result.Repaired {
+			t.Fatalf("must diverge at block 2 and be repaired,got %+v", result)
+		}
+		result, err = Verify(dir, forked, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.HighestNum != 1 || result.Journals != 1 || result.Divergent != nil {
+			t.Fatalf("the db must be truncated to block 1,got %+v", result)
+		}
+		if len(result.Issues) != 1 {
+			t.Fatalf("the db must only lag behind the chain,got %v", result.Issues)
+		}
+	})
+}
@@ -0,0 +1,227 @@
+package snapshotdb
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/syndtr/goleveldb/leveldb/journal"
+)
+
+// ChainReader reads the canonical chain which the journals are verified against
+type ChainReader interface {
+	// CanonicalHash returns the hash of the canonical block,zero hash if not found
+	CanonicalHash(number uint64) common.Hash
+	// HeadNumber returns the number of the chain head
+	HeadNumber() uint64
+}
+
+// VerifyIssue is an inconsistency found by Verify
+type VerifyIssue struct {
+	Number uint64
+	Hash   common.Hash
+	Reason string
+}
+
+func (i VerifyIssue) String() string {
+	return fmt.Sprintf("block %d [%s]: %s", i.Number, i.Hash.TerminalString(), i.Reason)
+}
+
+// VerifyResult is the result of Verify
+type VerifyResult struct {
+	BaseNum    uint64
+	HighestNum uint64
+	Journals   int
+	// Divergent is the first committed block which is inconsistent with the chain,nil if there is none
+	Divergent *VerifyIssue
+	// Issues are all of the inconsistencies,the stale and the recognized journals included
+	Issues []VerifyIssue
+	// Repaired is true if the db is truncated to the last consistent block and the bad journals are removed
+	Repaired bool
+}
+
+// Verify checks the db at the path,the db must not be opened.
+// Every journal is read strictly,its header,kv hash chain and the hash chain of the blocks are checked,
+// the committed blocks must be the canonical ones of the chain.
+// If repair is true,the committed blocks are truncated to the last consistent one,
+// the journals after it and the inconsistent ones are removed.
+func Verify(path string, chain ChainReader, repair bool) (*VerifyResult, error) {
+	stor, err := openFile(path, !repair)
+	if err != nil {
+		return nil, err
+	}
+	defer stor.Close()
+	c, err := loadCurrent(path)
+	if err != nil {
+		return nil, fmt.Errorf("[SnapshotDB]load current fail:%v", err)
+	}
+	defer c.f.Close()
+	fds, err := stor.List(TypeJournal)
+	if err != nil {
+		return nil, err
+	}
+	sortFds(fds)
+
+	result := &VerifyResult{
+		BaseNum:    c.BaseNum.Uint64(),
+		HighestNum: c.HighestNum.Uint64(),
+		Journals:   len(fds),
+	}
+	var (
+		head             = chain.HeadNumber()
+		bad              = make(map[fileDesc]bool)
+		byNum            = make(map[uint64][]fileDesc)
+		headers          = make(map[fileDesc]*journalHeader)
+		unRecognizedHash = rlpHash("CURRENT")
+	)
+	addIssue := func(fd fileDesc, reason string) {
+		result.Issues = append(result.Issues, VerifyIssue{Number: uint64(fd.Num), Hash: fd.BlockHash, Reason: reason})
+		bad[fd] = true
+	}
+	for _, fd := range fds {
+		header, err := readJournalStrict(stor, fd)
+		if err != nil {
+			addIssue(fd, err.Error())
+			continue
+		}
+		if uint64(fd.Num) <= result.BaseNum {
+			addIssue(fd, "the journal is stale,the block is compacted to baseDB")
+			continue
+		}
+		headers[fd] = header
+		byNum[uint64(fd.Num)] = append(byNum[uint64(fd.Num)], fd)
+	}
+
+	if result.BaseNum > head {
+		issue := VerifyIssue{Number: result.BaseNum, Reason: fmt.Sprintf("baseDB is ahead of the chain head %d", head)}
+		result.Issues = append(result.Issues, issue)
+		result.Divergent = &issue
+	}
+
+	// the committed blocks must be the canonical ones and linked one by one
+	parentHash := chain.CanonicalHash(result.BaseNum)
+	for num := result.BaseNum + 1; result.Divergent == nil && num <= result.HighestNum; num++ {
+		canonical := chain.CanonicalHash(num)
+		var found *fileDesc
+		for i, fd := range byNum[num] {
+			if fd.BlockHash == canonical && !bad[fd] {
+				found = &byNum[num][i]
+				break
+			}
+		}
+		var issue VerifyIssue
+		switch {
+		case canonical == common.ZeroHash:
+			issue = VerifyIssue{Number: num, Reason: fmt.Sprintf("the committed block is ahead of the chain head %d", head)}
+		case found == nil:
+			issue = VerifyIssue{Number: num, Hash: canonical, Reason: "the journal of the committed block is missing or corrupted"}
+		case headers[*found].ParentHash != parentHash:
+			issue = VerifyIssue{Number: num, Hash: canonical, Reason: fmt.Sprintf("the parent hash %s mismatched the committed one %s",
+				headers[*found].ParentHash.TerminalString(), parentHash.TerminalString())}
+		default:
+			parentHash = canonical
+			continue
+		}
+		result.Issues = append(result.Issues, issue)
+		result.Divergent = &issue
+	}
+	if result.Divergent == nil && result.HighestNum < head {
+		result.Issues = append(result.Issues, VerifyIssue{Number: result.HighestNum + 1, Hash: chain.CanonicalHash(result.HighestNum + 1),
+			Reason: fmt.Sprintf("the snapshotdb lags behind the chain head %d", head)})
+	}
+
+	// the recognized blocks must be linked to the committed or other recognized ones
+	if result.Divergent == nil {
+		var maxNum uint64
+		for num := range byNum {
+			if num > maxNum {
+				maxNum = num
+			}
+		}
+		known := map[common.Hash]bool{parentHash: true}
+		for num := result.HighestNum + 1; num <= maxNum; num++ {
+			next := make(map[common.Hash]bool)
+			for _, fd := range byNum[num] {
+				if !known[headers[fd].ParentHash] {
+					addIssue(fd, "the parent hash is not found,the hash chain is broken")
+					continue
+				}
+				if fd.BlockHash != unRecognizedHash {
+					next[fd.BlockHash] = true
+				}
+			}
+			known = next
+		}
+	}
+
+	if !repair || len(result.Issues) == 0 {
+		return result, nil
+	}
+	highest := result.HighestNum
+	if result.Divergent != nil {
+		if result.Divergent.Number <= result.BaseNum {
+			return result, fmt.Errorf("[SnapshotDB]can't repair,baseDB is divergent at block %d", result.Divergent.Number)
+		}
+		highest = result.Divergent.Number - 1
+	}
+	for _, fd := range fds {
+		if bad[fd] || uint64(fd.Num) > highest {
+			if err := stor.Remove(fd); err != nil {
+				return result, err
+			}
+		}
+	}
+	if highest != result.HighestNum {
+		c.HighestNum = new(big.Int).SetUint64(highest)
+		if err := c.update(); err != nil {
+			return result, err
+		}
+	}
+	result.Repaired = true
+	return result, nil
+}
+
+// readJournalStrict reads the journal with the checksum,
+// the header and the kv hash chain of the bodies are checked
+func readJournalStrict(stor storage, fd fileDesc) (*journalHeader, error) {
+	reader, err := stor.Open(fd)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	journals := journal.NewReader(reader, nil, true, true)
+	j, err := journals.Next()
+	if err != nil {
+		return nil, fmt.Errorf("read the journal header fail:%v", err)
+	}
+	header := new(journalHeader)
+	if err := decode(j, header); err != nil {
+		return nil, fmt.Errorf("decode the journal header fail:%v", err)
+	}
+	if header.BlockNumber == nil || header.BlockNumber.Int64() != fd.Num {
+		return nil, fmt.Errorf("the block number of the journal header %v mismatched", header.BlockNumber)
+	}
+	var (
+		kvHash common.Hash
+		s      = new(snapshotDB)
+	)
+	for i := 0; ; i++ {
+		j, err := journals.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read the journal body %d fail,it may be partially written:%v", i, err)
+		}
+		var body journalData
+		if err := decode(j, &body); err != nil {
+			return nil, fmt.Errorf("decode the journal body %d fail:%v", i, err)
+		}
+		if expect := s.generateKVHash(body.Key, body.Value, kvHash); body.Hash != expect {
+			return nil, fmt.Errorf("the kv hash of the journal body %d mismatched,want %s,have %s", i, expect.TerminalString(), body.Hash.TerminalString())
+		}
+		kvHash = body.Hash
+	}
+	return header, nil
+}
@@ -66,7 +66,13 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 		misc.ApplyDAOHardFork(statedb)
 	}
 
-	// TODO begin()
+	// the reactor calls the ppos plugins, their writes of the block are recorded by the snapshotdb
+	reactor := GetReactorInstance()
+	if nil != reactor {
+		if _, err := reactor.BeginBlocker(header, statedb); nil != err {
+			return nil, nil, 0, err
+		}
+	}
 
 	// Iterate over and process the individual transactions
 	for i, tx := range block.Transactions() {
@@ -79,7 +85,11 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 		allLogs = append(allLogs, receipt.Logs...)
 	}
 
-	// TODO end ()
+	if nil != reactor {
+		if _, err := reactor.EndBlocker(header, statedb); nil != err {
+			return nil, nil, 0, err
+		}
+	}
 
 	// Finalize the block, applying any consensus engine specific extras (e.g. block rewards)
 	p.engine.Finalize(p.bc, header, statedb, block.Transactions(), receipts)
@@ -75,6 +75,8 @@ var PrecompiledContracts = map[common.Address]PrecompiledContract{
 var PlatONPrecompiledContracts = map[common.Address]PlatONPrecompiledContract{
 	vm.StakingContractAddr: &stakingContract{},
 	vm.RestrictingContractAddr: &restrictingContract{},
+	vm.SlashingContractAddr: &slashingContract{},
+	vm.GovContractAddr: &govContract{},
 }
 
 // RunPrecompiledContract runs and evaluates the output of a precompiled contract.
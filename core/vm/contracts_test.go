@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/PlatONnetwork/PlatON-Go/params"
 )
 
 // precompiledTest defines the input/output pairs for precompiled contract tests.
@@ -481,3 +482,17 @@ func BenchmarkPrecompiledBn256Pairing(bench *testing.B) {
 		benchmarkPrecompiled("08", test, bench)
 	}
 }
+
+func TestPlatONPrecompiledContractNotReady(t *testing.T) {
+	for _, cfg := range []Config{{}, {Plugins: &PlatONPlugins{}}} {
+		evm := NewEVM(Context{BlockNumber: new(big.Int)}, nil, params.TestChainConfig, cfg)
+		for addr := range PlatONPrecompiledContracts {
+			codeAddr := addr
+			contract := NewContract(AccountRef(common.Address{}), AccountRef(addr), new(big.Int), 100000)
+			contract.CodeAddr = &codeAddr
+			if _, err := run(evm, contract, nil, false); err != ErrPlatONContractNotReady {
+				t.Errorf("the contract %s without initialized plugin, expect %v, got %v", addr.Hex(), ErrPlatONContractNotReady, err)
+			}
+		}
+	}
+}
@@ -17,6 +17,7 @@
 package vm
 
 import (
+	"github.com/PlatONnetwork/PlatON-Go/x/gov"
 	"github.com/PlatONnetwork/PlatON-Go/x/plugin"
 	"math/big"
 	"strings"
@@ -42,6 +43,15 @@ type (
 	GetHashFunc func(uint64) common.Hash
 )
 
+// PlatONPlugins are the ppos plugins called by the PlatON precompiled contracts,
+// the contracts return ErrPlatONContractNotReady on the chain without them.
+type PlatONPlugins struct {
+	Staking     *plugin.StakingPlugin
+	Restricting *plugin.RestrictingPlugin
+	Slashing    *plugin.SlashingPlugin
+	Gov         *gov.Gov
+}
+
 // run runs the given contract and takes care of running precompiles with a fallback to the byte code interpreter.
 func run(evm *EVM, contract *Contract, input []byte, readOnly bool) ([]byte, error) {
 	if contract.CodeAddr != nil {
@@ -61,21 +71,51 @@ func run(evm *EVM, contract *Contract, input []byte, readOnly bool) ([]byte, err
 		}
 
 		if p := PlatONPrecompiledContracts[*contract.CodeAddr]; p != nil {
+			plugins := evm.vmConfig.Plugins
+			if plugins == nil {
+				return nil, ErrPlatONContractNotReady
+			}
 			switch p.(type) {
 			case *stakingContract:
+				if plugins.Staking == nil {
+					return nil, ErrPlatONContractNotReady
+				}
 				staking := &stakingContract{
-					plugin:   plugin.StakingInstance(nil),
+					plugin:   plugins.Staking,
 					Contract: contract,
 					Evm:      evm,
 				}
 				return RunPlatONPrecompiledContract(staking, input, contract)
 			case *restrictingContract:
+				if plugins.Restricting == nil {
+					return nil, ErrPlatONContractNotReady
+				}
 				restricting := &restrictingContract{
-					plugin  : plugin.GetRestrictingInstance(),
+					plugin  : plugins.Restricting,
 					Contract: contract,
 					Evm:      evm,
 				}
 				return RunPlatONPrecompiledContract(restricting, input, contract)
+			case *slashingContract:
+				if plugins.Slashing == nil {
+					return nil, ErrPlatONContractNotReady
+				}
+				slashing := &slashingContract{
+					plugin:   plugins.Slashing,
+					Contract: contract,
+					Evm:      evm,
+				}
+				return RunPlatONPrecompiledContract(slashing, input, contract)
+			case *govContract:
+				if plugins.Gov == nil {
+					return nil, ErrPlatONContractNotReady
+				}
+				govc := &govContract{
+					gov:      plugins.Gov,
+					Contract: contract,
+					Evm:      evm,
+				}
+				return RunPlatONPrecompiledContract(govc, input, contract)
 			}
 		}
 
@@ -2,40 +2,49 @@ package vm
 
 import (
 	"encoding/hex"
+	"encoding/json"
+	"reflect"
+
 	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/PlatONnetwork/PlatON-Go/common/vm"
 	"github.com/PlatONnetwork/PlatON-Go/log"
 	"github.com/PlatONnetwork/PlatON-Go/p2p/discover"
+	"github.com/PlatONnetwork/PlatON-Go/rlp"
 	"github.com/PlatONnetwork/PlatON-Go/x/gov"
 	"github.com/PlatONnetwork/PlatON-Go/x/plugin"
-	"reflect"
-)
-
-const (
-	SubtmitProposalError        = "submit proposal error"
+	"github.com/PlatONnetwork/PlatON-Go/x/xcom"
 )
 
 const (
-	SubmitTextEvent   		= "2000"
-	SubmitVersionEvent   	= "2001"
-	VoteEvent   			= "2002"
-	DeclareEvent 			= "2003"
-	GetProposalEvent 		= "2100"
-	GetResultEvent 			= "2101"
-	ListProposalEvent 		= "2102"
+	SubmitTextEvent    = "2000"
+	SubmitVersionEvent = "2001"
+	VoteEvent          = "2002"
+	DeclareEvent       = "2003"
+	SubmitParamEvent   = "2004"
+	SubmitCancelEvent  = "2005"
+
+	SubmitProposalErrStr   = "submit proposal failed"
+	VoteErrStr             = "vote failed"
+	DeclareVersionErrStr   = "declare version failed"
+	GetProposalErrStr      = "get proposal failed"
+	GetTallyResultErrStr   = "get tally result failed"
+	ListProposalErrStr     = "list proposal failed"
+	GetActiveVersionErrStr = "get active version failed"
+	GetParamValueErrStr    = "get param value failed"
 )
 
-
 type govContract struct {
-	Contract   *Contract
-	Evm        *EVM
+	gov      *gov.Gov
+	Contract *Contract
+	Evm      *EVM
 }
 
-func (stkc *govContract) RequiredGas(input []byte) uint64 {
+func (gc *govContract) RequiredGas(input []byte) uint64 {
 	return 0
 }
 
-func (stkc *govContract) Run(input []byte) ([]byte, error) {
-	return stkc.execute(input)
+func (gc *govContract) Run(input []byte) ([]byte, error) {
+	return gc.execute(input)
 }
 
 func (gc *govContract) FnSigns() map[uint16]interface{} {
@@ -45,15 +54,19 @@ func (gc *govContract) FnSigns() map[uint16]interface{} {
 		2001: gc.submitVersion,
 		2002: gc.vote,
 		2003: gc.declareVersion,
+		2004: gc.submitParam,
+		2005: gc.submitCancel,
 
 		// Get
 		2100: gc.getProposal,
 		2101: gc.getTallyResult,
 		2102: gc.listProposal,
+		2103: gc.getActiveVersion,
+		2104: gc.getParamValue,
 	}
 }
 
-func (gc *govContract) execute(input []byte) (ret []byte, err error) {
+func (gc *govContract) execute(input []byte) ([]byte, error) {
 
 	// verify the tx data by contracts method
 	fn, params, err := plugin.Verify_tx_data(input, gc.FnSigns())
@@ -63,13 +76,12 @@ func (gc *govContract) execute(input []byte) (ret []byte, err error) {
 
 	// execute contracts method
 	result := reflect.ValueOf(fn).Call(params)
-	if _, ok := result[1].Interface().(error); !ok {
-		return result[0].Bytes(), nil
+	if err, ok := result[1].Interface().(error); ok {
+		return nil, err
 	}
-	return nil, result[1].Interface().(error)
+	return result[0].Bytes(), nil
 }
 
-
 func (gc *govContract) submitText(verifier discover.NodeID, githubID, topic, desc, url string, endVotingBlock uint64) ([]byte, error) {
 	from := gc.Contract.CallerAddress
 
@@ -79,7 +91,7 @@ func (gc *govContract) submitText(verifier discover.NodeID, githubID, topic, des
 		"blockNumber", gc.Evm.BlockNumber.Uint64(),
 		"verifierID", hex.EncodeToString(verifier.Bytes()[:8]))
 
-	p := gov.TextProposal{}
+	p := &gov.TextProposal{}
 	p.SetGithubID(githubID)
 	p.SetTopic(topic)
 	p.SetDesc(desc)
@@ -90,12 +102,11 @@ func (gc *govContract) submitText(verifier discover.NodeID, githubID, topic, des
 	p.SetProposalID(gc.Evm.StateDB.TxHash())
 	p.SetProposer(verifier)
 
-
-	gov.GovInstance().Submit(from, p, gc.Evm.BlockHash, gc.Evm.StateDB)
-	return nil, nil
+	success, err := gc.gov.Submit(gc.Evm.BlockNumber.Uint64(), from, p, gc.Evm.BlockHash, gc.Evm.StateDB)
+	return gc.handleTxResult(success, err, SubmitTextEvent, SubmitProposalErrStr, "submitText")
 }
 
-func (gc *govContract) submitVersion(verifier discover.NodeID, githubID, topic, desc, url string, newVersion uint, endVotingBlock, activeBlock uint64) ([]byte, error) {
+func (gc *govContract) submitVersion(verifier discover.NodeID, githubID, topic, desc, url string, newVersion uint32, endVotingBlock, activeBlock uint64) ([]byte, error) {
 	from := gc.Contract.CallerAddress
 
 	log.Info("Call submitVersion of govContract",
@@ -104,12 +115,12 @@ func (gc *govContract) submitVersion(verifier discover.NodeID, githubID, topic,
 		"blockNumber", gc.Evm.BlockNumber.Uint64(),
 		"verifierID", hex.EncodeToString(verifier.Bytes()[:8]))
 
-	p := gov.VersionProposal{}
+	p := &gov.VersionProposal{}
 	p.SetGithubID(githubID)
 	p.SetTopic(topic)
 	p.SetDesc(desc)
 	p.SetUrl(url)
-	p.SetProposalType(gov.Text)
+	p.SetProposalType(gov.Version)
 	p.SetEndVotingBlock(endVotingBlock)
 	p.SetSubmitBlock(gc.Evm.BlockNumber.Uint64())
 	p.SetProposalID(gc.Evm.StateDB.TxHash())
@@ -118,12 +129,67 @@ func (gc *govContract) submitVersion(verifier discover.NodeID, githubID, topic,
 	p.SetNewVersion(newVersion)
 	p.SetActiveBlock(activeBlock)
 
+	success, err := gc.gov.Submit(gc.Evm.BlockNumber.Uint64(), from, p, gc.Evm.BlockHash, gc.Evm.StateDB)
+	return gc.handleTxResult(success, err, SubmitVersionEvent, SubmitProposalErrStr, "submitVersion")
+}
 
-	gov.GovInstance().Submit(from, p, gc.Evm.BlockHash, gc.Evm.StateDB)
-	return nil, nil
+func (gc *govContract) submitParam(verifier discover.NodeID, githubID, topic, desc, url, paramName, newValue string, endVotingBlock uint64) ([]byte, error) {
+	from := gc.Contract.CallerAddress
+
+	log.Info("Call submitParam of govContract",
+		"from", from.Hex(),
+		"txHash", gc.Evm.StateDB.TxHash(),
+		"blockNumber", gc.Evm.BlockNumber.Uint64(),
+		"verifierID", hex.EncodeToString(verifier.Bytes()[:8]),
+		"paramName", paramName,
+		"newValue", newValue)
+
+	p := &gov.ParamProposal{}
+	p.SetGithubID(githubID)
+	p.SetTopic(topic)
+	p.SetDesc(desc)
+	p.SetUrl(url)
+	p.SetProposalType(gov.Param)
+	p.SetEndVotingBlock(endVotingBlock)
+	p.SetSubmitBlock(gc.Evm.BlockNumber.Uint64())
+	p.SetProposalID(gc.Evm.StateDB.TxHash())
+	p.SetProposer(verifier)
+
+	p.SetParamName(paramName)
+	p.SetNewValue(newValue)
+
+	success, err := gc.gov.Submit(gc.Evm.BlockNumber.Uint64(), from, p, gc.Evm.BlockHash, gc.Evm.StateDB)
+	return gc.handleTxResult(success, err, SubmitParamEvent, SubmitProposalErrStr, "submitParam")
+}
+
+func (gc *govContract) submitCancel(verifier discover.NodeID, githubID, topic, desc, url string, endVotingBlock uint64, tobeCanceled common.Hash) ([]byte, error) {
+	from := gc.Contract.CallerAddress
+
+	log.Info("Call submitCancel of govContract",
+		"from", from.Hex(),
+		"txHash", gc.Evm.StateDB.TxHash(),
+		"blockNumber", gc.Evm.BlockNumber.Uint64(),
+		"verifierID", hex.EncodeToString(verifier.Bytes()[:8]),
+		"tobeCanceled", tobeCanceled.Hex())
+
+	p := &gov.CancelProposal{}
+	p.SetGithubID(githubID)
+	p.SetTopic(topic)
+	p.SetDesc(desc)
+	p.SetUrl(url)
+	p.SetProposalType(gov.Cancel)
+	p.SetEndVotingBlock(endVotingBlock)
+	p.SetSubmitBlock(gc.Evm.BlockNumber.Uint64())
+	p.SetProposalID(gc.Evm.StateDB.TxHash())
+	p.SetProposer(verifier)
+
+	p.SetTobeCanceled(tobeCanceled)
+
+	success, err := gc.gov.Submit(gc.Evm.BlockNumber.Uint64(), from, p, gc.Evm.BlockHash, gc.Evm.StateDB)
+	return gc.handleTxResult(success, err, SubmitCancelEvent, SubmitProposalErrStr, "submitCancel")
 }
 
-func (gc *govContract) vote(verifier discover.NodeID, proposalID common.Hash, option gov.VoteOption) ([]byte, error) {
+func (gc *govContract) vote(verifier discover.NodeID, proposalID common.Hash, option uint8) ([]byte, error) {
 
 	from := gc.Contract.CallerAddress
 
@@ -136,14 +202,13 @@ func (gc *govContract) vote(verifier discover.NodeID, proposalID common.Hash, op
 	v := gov.Vote{}
 	v.ProposalID = proposalID
 	v.VoteNodeID = verifier
-	v.VoteOption = option
+	v.VoteOption = gov.VoteOption(option)
 
-
-	gov.GovInstance().Vote(from, v, gc.Evm.BlockHash, gc.Evm.StateDB)
-	return nil, nil
+	success, err := gc.gov.Vote(from, v, gc.Evm.BlockHash, gc.Evm.BlockNumber.Uint64(), gc.Evm.StateDB)
+	return gc.handleTxResult(success, err, VoteEvent, VoteErrStr, "vote")
 }
 
-func (gc *govContract) declareVersion(activeNode discover.NodeID, version uint) ([]byte, error) {
+func (gc *govContract) declareVersion(activeNode discover.NodeID, version uint32) ([]byte, error) {
 	from := gc.Contract.CallerAddress
 
 	log.Info("Call declareVersion of govContract",
@@ -152,8 +217,8 @@ func (gc *govContract) declareVersion(activeNode discover.NodeID, version uint)
 		"blockNumber", gc.Evm.BlockNumber.Uint64(),
 		"activeNode", hex.EncodeToString(activeNode.Bytes()[:8]))
 
-	gov.GovInstance().DeclareVersion(from, &activeNode, version, gc.Evm.BlockHash)
-	return nil, nil
+	success, err := gc.gov.DeclareVersion(from, activeNode, version, gc.Evm.BlockHash, gc.Evm.StateDB)
+	return gc.handleTxResult(success, err, DeclareEvent, DeclareVersionErrStr, "declareVersion")
 }
 
 func (gc *govContract) getProposal(proposalID common.Hash) ([]byte, error) {
@@ -165,8 +230,8 @@ func (gc *govContract) getProposal(proposalID common.Hash) ([]byte, error) {
 		"txHash", gc.Evm.StateDB.TxHash(),
 		"blockNumber", gc.Evm.BlockNumber.Uint64())
 
-	gov.GovInstance().GetProposal(proposalID, gc.Evm.StateDB)
-	return nil, nil
+	proposal, err := gc.gov.GetProposal(proposalID, gc.Evm.StateDB)
+	return gc.queryResult(proposal, err, GetProposalErrStr)
 }
 
 func (gc *govContract) getTallyResult(proposalID common.Hash) ([]byte, error) {
@@ -177,8 +242,8 @@ func (gc *govContract) getTallyResult(proposalID common.Hash) ([]byte, error) {
 		"txHash", gc.Evm.StateDB.TxHash(),
 		"blockNumber", gc.Evm.BlockNumber.Uint64())
 
-	gov.GovInstance().GetTallyResult(proposalID, gc.Evm.StateDB)
-	return nil, nil
+	tallyResult, err := gc.gov.GetTallyResult(proposalID, gc.Evm.StateDB)
+	return gc.queryResult(tallyResult, err, GetTallyResultErrStr)
 }
 
 func (gc *govContract) listProposal() ([]byte, error) {
@@ -189,9 +254,78 @@ func (gc *govContract) listProposal() ([]byte, error) {
 		"txHash", gc.Evm.StateDB.TxHash(),
 		"blockNumber", gc.Evm.BlockNumber.Uint64())
 
-	gov.GovInstance().ListProposal(gc.Evm.BlockHash, gc.Evm.StateDB)
+	proposals, err := gc.gov.ListProposal(gc.Evm.BlockHash, gc.Evm.StateDB)
+	return gc.queryResult(proposals, err, ListProposalErrStr)
+}
+
+func (gc *govContract) getActiveVersion() ([]byte, error) {
+	from := gc.Contract.CallerAddress
+
+	log.Info("Call getActiveVersion of govContract",
+		"from", from.Hex(),
+		"txHash", gc.Evm.StateDB.TxHash(),
+		"blockNumber", gc.Evm.BlockNumber.Uint64())
+
+	return gc.queryResult(gc.gov.GetActiveVersion(gc.Evm.StateDB), nil, GetActiveVersionErrStr)
+}
+
+func (gc *govContract) getParamValue(name string) ([]byte, error) {
+	from := gc.Contract.CallerAddress
+
+	log.Info("Call getParamValue of govContract",
+		"from", from.Hex(),
+		"txHash", gc.Evm.StateDB.TxHash(),
+		"blockNumber", gc.Evm.BlockNumber.Uint64(),
+		"name", name)
+
+	value, err := gc.gov.GetParamValue(name, gc.Evm.StateDB)
+	return gc.queryResult(value, err, GetParamValueErrStr)
+}
+
+// handleTxResult records the result of the tx into the receipt log,
+// only the system error will be returned and cause the tx failed
+func (gc *govContract) handleTxResult(success bool, err error, eventType, errStr, callFn string) ([]byte, error) {
+	txHash := gc.Evm.StateDB.TxHash()
+	blockNumber := gc.Evm.BlockNumber.Uint64()
+	state := gc.Evm.StateDB
 
+	if nil != err {
+		if success {
+			res := xcom.Result{Status: false, Data: "", ErrMsg: errStr + ":" + err.Error()}
+			event, _ := json.Marshal(res)
+			gc.badLog(state, blockNumber, txHash.Hex(), eventType, string(event), callFn)
+			return nil, nil
+		} else {
+			log.Error("Failed to "+callFn+" of govContract", "txHash", txHash.Hex(),
+				"blockNumber", blockNumber, "err", err)
+			return nil, err
+		}
+	}
+
+	res := xcom.Result{Status: true, Data: "", ErrMsg: ""}
+	event, _ := json.Marshal(res)
+	gc.goodLog(state, blockNumber, txHash.Hex(), eventType, string(event), callFn)
 	return nil, nil
 }
 
+func (gc *govContract) queryResult(data interface{}, err error, errStr string) ([]byte, error) {
+	if nil != err {
+		res := xcom.Result{Status: false, Data: "", ErrMsg: errStr + ":" + err.Error()}
+		result, _ := rlp.EncodeToBytes(res)
+		return result, nil
+	}
+	jsonByte, _ := json.Marshal(data)
+	res := xcom.Result{Status: true, Data: string(jsonByte), ErrMsg: ""}
+	result, _ := rlp.EncodeToBytes(res)
+	return result, nil
+}
+
+func (gc *govContract) goodLog(state xcom.StateDB, blockNumber uint64, txHash, eventType, eventData, callFn string) {
+	_ = xcom.AddLog(state, blockNumber, vm.GovContractAddr, eventType, eventData)
+	log.Info("Successed to "+callFn+" of govContract", "txHash", txHash, "blockNumber", blockNumber, "json: ", eventData)
+}
 
+func (gc *govContract) badLog(state xcom.StateDB, blockNumber uint64, txHash, eventType, eventData, callFn string) {
+	_ = xcom.AddLog(state, blockNumber, vm.GovContractAddr, eventType, eventData)
+	log.Error("Failed to "+callFn+" of govContract", "txHash", txHash, "blockNumber", blockNumber, "json: ", eventData)
+}
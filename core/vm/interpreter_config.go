@@ -21,4 +21,7 @@ type Config struct {
 	EVMInterpreter string
 
 	ConsoleOutput bool
+
+	// Plugins are called by the PlatON precompiled contracts
+	Plugins *PlatONPlugins
 }
\ No newline at end of file
@@ -8,6 +8,7 @@ import (
 	"github.com/PlatONnetwork/PlatON-Go/common"
 	"github.com/PlatONnetwork/PlatON-Go/common/math"
 	"github.com/PlatONnetwork/PlatON-Go/core/lru"
+	"github.com/PlatONnetwork/PlatON-Go/crypto"
 	"github.com/PlatONnetwork/PlatON-Go/life/utils"
 	"github.com/PlatONnetwork/PlatON-Go/log"
 	"github.com/PlatONnetwork/PlatON-Go/rlp"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/PlatONnetwork/PlatON-Go/life/exec"
 	"github.com/PlatONnetwork/PlatON-Go/life/resolver"
+	"github.com/PlatONnetwork/PlatON-Go/x/gov"
 )
 
 var (
@@ -89,9 +91,13 @@ func (in *WASMInterpreter) Run(contract *Contract, input []byte, readOnly bool)
 	if len(contract.Code) == 0 {
 		return nil, nil
 	}
-	_, abi, code, er := parseRlpData(contract.Code)
-	if er != nil {
-		return nil, er
+	codeHash := contract.CodeHash
+	if codeHash == (common.Hash{}) {
+		codeHash = crypto.Keccak256Hash(contract.Code)
+	}
+	module, err := wasmModule(codeHash, contract.Code)
+	if err != nil {
+		return nil, err
 	}
 
 	context := &exec.VMContext{
@@ -100,25 +106,17 @@ func (in *WASMInterpreter) Run(contract *Contract, input []byte, readOnly bool)
 		GasLimit: contract.Gas,
 		StateDB:  NewWasmStateDB(in.wasmStateDB, contract),
 		Log:      in.WasmLogger,
-	}
-
-	var lvm *exec.VirtualMachine
-	var module *lru.WasmModule
-	module, ok := lru.WasmCache().Get(contract.Address())
 
-	if !ok {
-		module = &lru.WasmModule{}
-		module.Module, module.FunctionCode, err = exec.ParseModuleAndFunc(code, nil)
-		if err != nil {
-			return nil, err
-		}
-		lru.WasmCache().Add(contract.Address(), module)
+		GasSchedule: exec.GasScheduleByVersion(gov.GetCurrentActiveVersion(in.evm.StateDB)),
 	}
 
-	lvm, err = exec.NewVirtualMachineWithModule(module.Module, module.FunctionCode, context, in.resolver, nil)
+	lvm, err := exec.NewVirtualMachineWithModule(module.Module, module.FunctionCode, context, in.resolver, nil)
 	if err != nil {
 		return nil, err
 	}
+	if context.Config.EnableJIT {
+		lvm.Compiled = module.Compiled()
+	}
 	defer func() {
 		lvm.Stop()
 	}()
@@ -129,13 +127,14 @@ func (in *WASMInterpreter) Run(contract *Contract, input []byte, readOnly bool)
 		txType     int
 		params     []int64
 		returnType string
+		retAbi     *utils.AbiType
 	)
 
 	if input == nil {
 		funcName = "init" // init function.
 	} else {
 		// parse input.
-		txType, funcName, params, returnType, err = parseInputFromAbi(lvm, input, abi)
+		txType, funcName, params, returnType, retAbi, err = parseInputFromAbi(lvm, input, module.Abi)
 		if err != nil {
 			if err == errReturnInsufficientParams && txType == 0 { // transfer to contract address.
 				return nil, nil
@@ -164,6 +163,10 @@ func (in *WASMInterpreter) Run(contract *Contract, input []byte, readOnly bool)
 	if input == nil {
 		return contract.Code, nil
 	}
+	// abi v2 returns the rlp encoding of the value for both the transaction and the call
+	if retAbi != nil {
+		return retAbi.WasmReturn(res, lvm.Memory.Memory)
+	}
 
 	// todo: more type need to be completed
 	switch returnType {
@@ -219,22 +222,23 @@ func (in *WASMInterpreter) CanRun(code []byte) bool {
 	return true
 }
 
-// parse input(payload)
-func parseInputFromAbi(vm *exec.VirtualMachine, input []byte, abi []byte) (txType int, funcName string, params []int64, returnType string, err error) {
+// parse input(payload), retAbi is the return type of the function for abi v2, nil for abi v1.
+// The wasmabi is nil if the abi of the contract is invalid.
+func parseInputFromAbi(vm *exec.VirtualMachine, input []byte, wasmabi *utils.WasmAbi) (txType int, funcName string, params []int64, returnType string, retAbi *utils.AbiType, err error) {
 	if input == nil || len(input) <= 1 {
-		return -1, "", nil, "", fmt.Errorf("invalid input.")
+		return -1, "", nil, "", nil, fmt.Errorf("invalid input.")
 	}
 	// [txType][funcName][args1][args2]
 	// rlp decode
 	ptr := new(interface{})
 	err = rlp.Decode(bytes.NewReader(input), &ptr)
 	if err != nil {
-		return -1, "", nil, "", err
+		return -1, "", nil, "", nil, err
 	}
 	rlpList := reflect.ValueOf(ptr).Elem().Interface()
 
 	if _, ok := rlpList.([]interface{}); !ok {
-		return -1, "", nil, "", errReturnInvalidRlpFormat
+		return -1, "", nil, "", nil, errReturnInvalidRlpFormat
 	}
 
 	iRlpList := rlpList.([]interface{})
@@ -246,13 +250,11 @@ func parseInputFromAbi(vm *exec.VirtualMachine, input []byte, abi []byte) (txTyp
 		} else {
 			txType = -1
 		}
-		return txType, "", nil, "", errReturnInsufficientParams
+		return txType, "", nil, "", nil, errReturnInsufficientParams
 	}
 
-	wasmabi := new(utils.WasmAbi)
-	err = wasmabi.FromJson(abi)
-	if err != nil {
-		return -1, "", nil, "", errReturnInvalidAbi
+	if wasmabi == nil {
+		return -1, "", nil, "", nil, errReturnInvalidAbi
 	}
 
 	params = make([]int64, 0)
@@ -262,6 +264,15 @@ func parseInputFromAbi(vm *exec.VirtualMachine, input []byte, abi []byte) (txTyp
 	if v, ok := iRlpList[1].([]byte); ok {
 		funcName = string(v)
 	}
+	argsRlp := iRlpList[2:]
+
+	if wasmabi.Version >= utils.AbiVersion2 {
+		params, retAbi, err = parseInputFromAbiV2(vm, wasmabi, funcName, argsRlp)
+		if err != nil {
+			return -1, "", nil, "", nil, err
+		}
+		return txType, funcName, params, retAbi.Name, retAbi, nil
+	}
 
 	var args []utils.InputParam
 	for _, v := range wasmabi.AbiArr {
@@ -275,13 +286,15 @@ func parseInputFromAbi(vm *exec.VirtualMachine, input []byte, abi []byte) (txTyp
 			break
 		}
 	}
-	argsRlp := iRlpList[2:]
 	if len(args) != len(argsRlp) {
-		return -1, "", nil, returnType, fmt.Errorf("invalid input or invalid abi.")
+		return -1, "", nil, returnType, nil, fmt.Errorf("invalid input or invalid abi.")
 	}
 	// uint64 uint32  uint16 uint8 int64 int32  int16 int8 float32 float64 string void
 	for i, v := range args {
-		bts := argsRlp[i].([]byte)
+		bts, ok := argsRlp[i].([]byte)
+		if !ok {
+			return -1, "", nil, returnType, nil, errReturnInvalidRlpFormat
+		}
 		switch v.Type {
 		case "string":
 			pos := resolver.MallocString(vm, string(bts))
@@ -304,7 +317,44 @@ func parseInputFromAbi(vm *exec.VirtualMachine, input []byte, abi []byte) (txTyp
 			params = append(params, int64(bts[0]))
 		}
 	}
-	return txType, funcName, params, returnType, nil
+	return txType, funcName, params, returnType, nil, nil
+}
+
+// parseInputFromAbiV2 converts the arguments to the wasm parameters by the abi v2,
+// the bool and integer types are passed by value, the others are copied into the memory.
+func parseInputFromAbiV2(vm *exec.VirtualMachine, wasmabi *utils.WasmAbi, funcName string, argsRlp []interface{}) ([]int64, *utils.AbiType, error) {
+	fn, ok := wasmabi.Function(funcName)
+	if !ok {
+		return nil, nil, fmt.Errorf("function %s not found in abi.", funcName)
+	}
+	types, err := fn.InputTypes()
+	if err != nil {
+		return nil, nil, err
+	}
+	retAbi, err := fn.OutputType()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(types) != len(argsRlp) {
+		return nil, nil, fmt.Errorf("invalid input or invalid abi.")
+	}
+	params := make([]int64, 0, len(types))
+	for i, t := range types {
+		if t.Scalar() {
+			v, err := t.WasmValue(argsRlp[i])
+			if err != nil {
+				return nil, nil, err
+			}
+			params = append(params, v)
+			continue
+		}
+		buf, err := t.WasmBuffer(argsRlp[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		params = append(params, resolver.MallocBytes(vm, buf))
+	}
+	return params, retAbi, nil
 }
 
 // rlpData=RLP([txType][code][abi])
@@ -343,6 +393,30 @@ func parseRlpData(rlpData []byte) (int64, []byte, []byte, error) {
 	return txType, abi, code, nil
 }
 
+// wasmModule returns the parsed module and abi of the code, the code is parsed once
+// and cached by its hash.
+func wasmModule(codeHash common.Hash, rlpCode []byte) (*lru.WasmModule, error) {
+	if module, ok := lru.WasmCache().Get(codeHash); ok {
+		return module, nil
+	}
+	_, abi, code, err := parseRlpData(rlpCode)
+	if err != nil {
+		return nil, err
+	}
+	module := &lru.WasmModule{Code: code}
+	module.Module, module.FunctionCode, err = exec.ParseModuleAndFunc(code, nil)
+	if err != nil {
+		return nil, err
+	}
+	// the invalid abi fails the invocations only, the code can still be deployed
+	wasmabi := new(utils.WasmAbi)
+	if err := wasmabi.FromJson(abi); err == nil {
+		module.Abi = wasmabi
+	}
+	lru.WasmCache().Add(codeHash, module)
+	return module, nil
+}
+
 func stack() string {
 	var buf [2 << 10]byte
 	return string(buf[:runtime.Stack(buf[:], true)])
@@ -7,10 +7,14 @@ import (
 	"github.com/PlatONnetwork/PlatON-Go/common"
 	"github.com/PlatONnetwork/PlatON-Go/common/vm"
 	"github.com/PlatONnetwork/PlatON-Go/log"
+	"github.com/PlatONnetwork/PlatON-Go/rlp"
 	"github.com/PlatONnetwork/PlatON-Go/x/plugin"
 	"github.com/PlatONnetwork/PlatON-Go/x/xcom"
 )
 
+const (
+	CreateRestrictingPlanEvent = "4000"
+)
 
 type restrictingContract struct {
 	plugin      *plugin.RestrictingPlugin
@@ -54,7 +58,7 @@ func (rc *restrictingContract) execute(input []byte) ([]byte, error) {
 	}
 }
 
-func (rc *restrictingContract) createRestrictingPlan(account common.Address, plan string) ([]byte, error) {
+func (rc *restrictingContract) createRestrictingPlan(account common.Address, plans []xcom.RestrictingPlan) ([]byte, error) {
 	sender := rc.Contract.Caller()
 	txHash := rc.Evm.StateDB.TxHash()
 	blockNum := rc.Evm.BlockNumber
@@ -62,11 +66,11 @@ func (rc *restrictingContract) createRestrictingPlan(account common.Address, pla
 
 	log.Info("Call createRestrictingPlan of restrictingContract", "txHash", txHash.Hex(), "blockNumber", blockNum.Uint64())
 
-	if success, err := rc.plugin.AddRestrictingRecord(sender, account, plan, state); err != nil {
+	if success, err := rc.plugin.AddRestrictingRecord(sender, account, plans, blockNum.Uint64(), state); err != nil {
 		if success {
-			res := xcom.Result{Status:false, Data:"", ErrMsg:"create lock repo plan:" + err.Error()}
+			res := xcom.Result{Status: false, Data: "", ErrMsg: "create lock repo plan:" + err.Error()}
 			event, _ := json.Marshal(res)
-			rc.badLog(state, blockNum.Uint64(), txHash.Hex(), "4000", string(event), "createRestrictingPlan")
+			rc.badLog(state, blockNum.Uint64(), txHash.Hex(), CreateRestrictingPlanEvent, string(event), "createRestrictingPlan")
 			return nil, nil
 		} else {
 			log.Error("AddRestrictingRecord failed to createRestrictingPlan", "txHash", txHash.Hex(), "blockNumber", blockNum.Uint64(), "error", err)
@@ -74,9 +78,9 @@ func (rc *restrictingContract) createRestrictingPlan(account common.Address, pla
 		}
 	}
 
-	res := xcom.Result{Status:true, Data:"", ErrMsg:""}
+	res := xcom.Result{Status: true, Data: "", ErrMsg: ""}
 	event, _ := json.Marshal(res)
-	rc.goodLog(state, blockNum.Uint64(), txHash.Hex(), "4000", string(event), "createRestrictingPlan")
+	rc.goodLog(state, blockNum.Uint64(), txHash.Hex(), CreateRestrictingPlanEvent, string(event), "createRestrictingPlan")
 
 	return nil, nil
 }
@@ -89,17 +93,25 @@ func (rc *restrictingContract) getRestrictingInfo(account common.Address) ([]byt
 
 	log.Info("Call getRestrictingInfo of restrictingContract", "txHash", txHash.Hex(), "blockNumber", currNumber.Uint64())
 
-	return rc.plugin.GetRestrictingInfo(account, state)
+	result, err := rc.plugin.GetRestrictingInfo(account, state)
+	if nil != err {
+		res := xcom.Result{Status: false, Data: "", ErrMsg: "get restricting info:" + err.Error()}
+		return rlp.EncodeToBytes(res)
+	}
+
+	data, _ := json.Marshal(result)
+	res := xcom.Result{Status: true, Data: string(data), ErrMsg: ""}
+	return rlp.EncodeToBytes(res)
 }
 
 
 func (rc *restrictingContract) goodLog(state xcom.StateDB, blockNumber uint64, txHash, eventType, eventData, callFn string) {
-	_ = xcom.AddLog(state, blockNumber, vm.StakingContractAddr, eventType, eventData)
+	_ = xcom.AddLog(state, blockNumber, vm.RestrictingContractAddr, eventType, eventData)
 	log.Info("Successed to " + callFn, "txHash", txHash, "blockNumber", blockNumber, "json: ", eventData)
 }
 
 
 func (rc *restrictingContract) badLog(state xcom.StateDB, blockNumber uint64, txHash, eventType, eventData, callFn string) {
-	_ = xcom.AddLog(state, blockNumber, vm.StakingContractAddr, eventType, eventData)
+	_ = xcom.AddLog(state, blockNumber, vm.RestrictingContractAddr, eventType, eventData)
 	log.Error("Failed to " + callFn, "txHash", txHash, "blockNumber", blockNumber, "json: ", eventData)
 }
@@ -0,0 +1,133 @@
+package vm
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/PlatONnetwork/PlatON-Go/common/vm"
+	"github.com/PlatONnetwork/PlatON-Go/log"
+	"github.com/PlatONnetwork/PlatON-Go/rlp"
+	"github.com/PlatONnetwork/PlatON-Go/x/plugin"
+	"github.com/PlatONnetwork/PlatON-Go/x/xcom"
+)
+
+const (
+	ReportDuplicateSignEvent = "3000"
+
+	ReportDuplicateSignErrStr = "report duplicate sign failed"
+	CheckDuplicateSignErrStr  = "check duplicate sign failed"
+)
+
+type slashingContract struct {
+	plugin   *plugin.SlashingPlugin
+	Contract *Contract
+	Evm      *EVM
+}
+
+func (sc *slashingContract) RequiredGas(input []byte) uint64 {
+	return 0
+}
+
+func (sc *slashingContract) Run(input []byte) ([]byte, error) {
+	return sc.execute(input)
+}
+
+func (sc *slashingContract) FnSigns() map[uint16]interface{} {
+	return map[uint16]interface{}{
+		// Set
+		3000: sc.reportDuplicateSign,
+
+		// Get
+		3100: sc.checkDuplicateSign,
+	}
+}
+
+func (sc *slashingContract) execute(input []byte) ([]byte, error) {
+	// verify the tx data by contracts method
+	fn, params, err := plugin.Verify_tx_data(input, sc.FnSigns())
+	if nil != err {
+		return nil, err
+	}
+
+	// execute contracts method
+	result := reflect.ValueOf(fn).Call(params)
+	if err, ok := result[1].Interface().(error); ok {
+		return nil, err
+	}
+	return result[0].Bytes(), nil
+}
+
+// Report the duplicate signing, the data is the json of the evidences which were collected by cbft
+func (sc *slashingContract) reportDuplicateSign(data string) ([]byte, error) {
+
+	txHash := sc.Evm.StateDB.TxHash()
+	blockNumber := sc.Evm.BlockNumber
+	blockHash := sc.Evm.BlockHash
+	state := sc.Evm.StateDB
+
+	log.Info("Call reportDuplicateSign of slashingContract", "txHash", txHash.Hex(),
+		"blockNumber", blockNumber.Uint64(), "blockHash", blockHash.Hex())
+
+	if success, err := sc.plugin.Slash(data, blockHash, blockNumber.Uint64(), state); nil != err {
+		if success {
+			res := xcom.Result{Status: false, Data: "", ErrMsg: ReportDuplicateSignErrStr + ":" + err.Error()}
+			event, _ := json.Marshal(res)
+			sc.badLog(state, blockNumber.Uint64(), txHash.Hex(), ReportDuplicateSignEvent, string(event), "reportDuplicateSign")
+			return nil, nil
+		} else {
+			log.Error("Failed to reportDuplicateSign by Slash", "txHash", txHash.Hex(),
+				"blockNumber", blockNumber.Uint64(), "err", err)
+			return nil, err
+		}
+	}
+
+	res := xcom.Result{Status: true, Data: "", ErrMsg: ""}
+	event, _ := json.Marshal(res)
+	sc.goodLog(state, blockNumber.Uint64(), txHash.Hex(), ReportDuplicateSignEvent, string(event), "reportDuplicateSign")
+	return nil, nil
+}
+
+// Check whether the duplicate signing of the node at the blockNumber has been slashed,
+// and returns the hash of the evidence
+func (sc *slashingContract) checkDuplicateSign(typ uint8, addr common.Address, blockNumber uint64) ([]byte, error) {
+
+	txHash := sc.Evm.StateDB.TxHash()
+	blockHash := sc.Evm.BlockHash
+
+	log.Info("Call checkDuplicateSign of slashingContract", "txHash", txHash.Hex(),
+		"blockNumber", sc.Evm.BlockNumber.Uint64(), "addr", addr.Hex(), "typ", typ, "evidenceBlockNumber", blockNumber)
+
+	val, err := sc.plugin.CheckDuplicateSign(blockHash, typ, addr, blockNumber)
+	if nil != err {
+		return sc.queryResult(nil, err, CheckDuplicateSignErrStr)
+	}
+
+	var evidenceHash string
+	if len(val) != 0 {
+		evidenceHash = common.BytesToHash(val).Hex()
+	}
+	return sc.queryResult(evidenceHash, nil, CheckDuplicateSignErrStr)
+}
+
+func (sc *slashingContract) queryResult(data interface{}, err error, errStr string) ([]byte, error) {
+	if nil != err {
+		res := xcom.Result{Status: false, Data: "", ErrMsg: errStr + ":" + err.Error()}
+		result, _ := rlp.EncodeToBytes(res)
+		return result, nil
+	}
+	jsonByte, _ := json.Marshal(data)
+	res := xcom.Result{Status: true, Data: string(jsonByte), ErrMsg: ""}
+	result, _ := rlp.EncodeToBytes(res)
+	return result, nil
+}
+
+func (sc *slashingContract) goodLog(state xcom.StateDB, blockNumber uint64, txHash, eventType, eventData, callFn string) {
+	_ = xcom.AddLog(state, blockNumber, vm.SlashingContractAddr, eventType, eventData)
+	log.Info("Successed to "+callFn, "txHash", txHash, "blockNumber", blockNumber, "json: ", eventData)
+}
+
+func (sc *slashingContract) badLog(state xcom.StateDB, blockNumber uint64, txHash, eventType, eventData, callFn string) {
+	_ = xcom.AddLog(state, blockNumber, vm.SlashingContractAddr, eventType, eventData)
+	log.Error("Failed to "+callFn, "txHash", txHash, "blockNumber", blockNumber, "json: ", eventData)
+}
@@ -2,6 +2,7 @@ package vm
 
 import (
 	"encoding/json"
+	"errors"
 	"github.com/PlatONnetwork/PlatON-Go/common"
 	"github.com/PlatONnetwork/PlatON-Go/common/vm"
 	"github.com/PlatONnetwork/PlatON-Go/log"
@@ -42,6 +43,16 @@ const (
 
 
 	GetVerifierListErrStr = "getting verifierList is failed"
+	GetValidatorListErrStr = "getting validatorList is failed"
+	GetCandidateListErrStr = "getting candidateList is failed"
+	GetDelegateListErrStr = "getting delegateList is failed"
+	QueryDelErrStr = "query delegate info err"
+	GetUnStakeQueueErrStr = "getting unStakeQueue is failed"
+	GetUnDelegateQueueErrStr = "getting unDelegateQueue is failed"
+
+	CommissionRateErrStr = "the commission rate is out of range"
+
+	WithdrawDelegateRewardErrStr = "withdraw delegate reward failed"
 
 
 )
@@ -53,6 +64,8 @@ const (
 	WithdrewCandidateEvent = "1003"
 	DelegateEvent          = "1004"
 	WithdrewDelegateEvent  = "1005"
+
+	WithdrawDelegateRewardEvent = "5000"
 )
 
 type stakingContract struct {
@@ -78,6 +91,7 @@ func (stkc *stakingContract) FnSigns() map[uint16]interface{} {
 		1003: stkc.withdrewCandidate,
 		1004: stkc.delegate,
 		1005: stkc.withdrewDelegate,
+		5000: stkc.withdrawDelegateReward,
 
 		// Get
 		1100: stkc.getVerifierList,
@@ -86,6 +100,8 @@ func (stkc *stakingContract) FnSigns() map[uint16]interface{} {
 		1103: stkc.getDelegateListByAddr,
 		1104: stkc.getDelegateInfo,
 		1105: stkc.getCandidateInfo,
+		1106: stkc.getUnStakeQueue,
+		1107: stkc.getUnDelegateQueue,
 	}
 }
 
@@ -107,7 +123,7 @@ func (stkc *stakingContract) execute(input []byte) (ret []byte, err error) {
 
 
 func (stkc *stakingContract) createStaking(typ uint16, benifitAddress common.Address, nodeId discover.NodeID,
-	externalId, nodeName, website, details string, amount *big.Int, processVersion uint32) ([]byte, error) {
+	externalId, nodeName, website, details string, amount *big.Int, processVersion uint32, commissionRate ...uint16) ([]byte, error) {
 
 	txHash := stkc.Evm.StateDB.TxHash()
 	txIndex := stkc.Evm.StateDB.TxIdx()
@@ -150,13 +166,25 @@ func (stkc *stakingContract) createStaking(typ uint16, benifitAddress common.Add
 		return nil, nil
 	}
 
-	if !plugin.CheckStakeThreshold(amount) {
+	if !plugin.CheckStakeThreshold(state, amount) {
 		res := xcom.Result{false, "", StakeVonTooLowStr}
 		event, _ := json.Marshal(res)
 		stkc.badLog(state, blockNumber.Uint64(), txHash.Hex(), CreateStakingEvent, string(event), "createStaking")
 		return nil, nil
 	}
 
+	// the candidate retains all of the staking reward if the commission rate is omitted by the old clients
+	rate := uint16(xcom.MaxCommissionRate)
+	if len(commissionRate) != 0 {
+		rate = commissionRate[0]
+	}
+	if rate > xcom.MaxCommissionRate {
+		res := xcom.Result{false, "", CommissionRateErrStr}
+		event, _ := json.Marshal(res)
+		stkc.badLog(state, blockNumber.Uint64(), txHash.Hex(), CreateStakingEvent, string(event), "createStaking")
+		return nil, nil
+	}
+
 	/**
 	init candidate info
 	*/
@@ -167,6 +195,7 @@ func (stkc *stakingContract) createStaking(typ uint16, benifitAddress common.Add
 		StakingBlockNum: blockNumber.Uint64(),
 		StakingTxIndex:  txIndex,
 		Shares:          amount,
+		CommissionRate:  rate,
 
 		Description: xcom.Description{
 			NodeName:   nodeName,
@@ -197,7 +226,7 @@ func (stkc *stakingContract) createStaking(typ uint16, benifitAddress common.Add
 }
 
 func (stkc *stakingContract) editorCandidate(benifitAddress common.Address, nodeId discover.NodeID,
-	externalId, nodeName, website, details string, amount *big.Int) ([]byte, error) {
+	externalId, nodeName, website, details string, amount *big.Int, commissionRate ...uint16) ([]byte, error) {
 
 	txHash := stkc.Evm.StateDB.TxHash()
 	blockNumber := stkc.Evm.BlockNumber
@@ -246,6 +275,17 @@ func (stkc *stakingContract) editorCandidate(benifitAddress common.Address, node
 		return nil, nil
 	}
 
+	// the commission rate is kept if it is omitted by the old clients
+	if len(commissionRate) != 0 {
+		if commissionRate[0] > xcom.MaxCommissionRate {
+			res := xcom.Result{false, "", CommissionRateErrStr}
+			event, _ := json.Marshal(res)
+			stkc.badLog(state, blockNumber.Uint64(), txHash.Hex(), EditorCandidateEvent, string(event), "editorCandidate")
+			return nil, nil
+		}
+		canOld.CommissionRate = commissionRate[0]
+	}
+
 	canOld.BenifitAddress = benifitAddress
 
 	canOld.NodeName = nodeName
@@ -253,7 +293,7 @@ func (stkc *stakingContract) editorCandidate(benifitAddress common.Address, node
 	canOld.Website = website
 	canOld.Details = details
 
-	success, err := stkc.plugin.EditorCandidate(blockHash, blockNumber, canOld)
+	success, err := stkc.plugin.EditorCandidate(state, blockHash, blockNumber, canOld)
 
 	if nil != err {
 
@@ -483,14 +523,20 @@ func (stkc *stakingContract) delegate(typ uint16, nodeId discover.NodeID, amount
 
 	if nil == del {
 
-		if !plugin.CheckDelegateThreshold(amount) {
+		if !plugin.CheckDelegateThreshold(state, amount) {
 			res := xcom.Result{false, "", DelegateVonTooLowStr}
 			event, _ := json.Marshal(res)
 			stkc.badLog(state, blockNumber.Uint64(), txHash.Hex(), DelegateEvent, string(event), "delegate")
 			return nil, nil
 		}
 
-		del = new(xcom.Delegation)
+		del = &xcom.Delegation{
+			Reduction:   new(big.Int),
+			Released:    new(big.Int),
+			ReleasedTmp: new(big.Int),
+			LockRepo:    new(big.Int),
+			LockRepoTmp: new(big.Int),
+		}
 	}
 
 	success, er := stkc.plugin.Delegate(state, blockHash, blockNumber, from, del, canOld, typ, amount)
@@ -567,6 +613,52 @@ func (stkc *stakingContract) withdrewDelegate(stakingBlockNum uint64, nodeId dis
 	return nil, nil
 }
 
+func (stkc *stakingContract) withdrawDelegateReward(stakingBlockNum uint64, nodeId discover.NodeID) ([]byte, error) {
+
+	txHash := stkc.Evm.StateDB.TxHash()
+	blockNumber := stkc.Evm.BlockNumber
+	blockHash := stkc.Evm.BlockHash
+
+	from := stkc.Contract.CallerAddress
+
+	state := stkc.Evm.StateDB
+
+	log.Info("Call withdrawDelegateReward of stakingContract", "txHash", txHash.Hex(),
+		"blockNumber", blockNumber.Uint64(), "delAddr", from.Hex(), "nodeId", nodeId.String())
+
+	del, err := stkc.plugin.GetDelegateInfo(blockHash, from, nodeId, stakingBlockNum)
+	if nil != err {
+		log.Error("Failed to withdrawDelegateReward by GetDelegateInfo",
+			"txHash", txHash.Hex(), "blockNumber", blockNumber, "err", err)
+		return nil, err
+	}
+
+	if nil == del {
+		res := xcom.Result{false, "", DelegateNotExistErrStr}
+		event, _ := json.Marshal(res)
+		stkc.badLog(state, blockNumber.Uint64(), txHash.Hex(), WithdrawDelegateRewardEvent, string(event), "withdrawDelegateReward")
+		return nil, nil
+	}
+
+	reward, success, err := stkc.plugin.WithdrawDelegateReward(state, blockHash, blockNumber, from, nodeId, stakingBlockNum, del)
+	if nil != err {
+		if success {
+			res := xcom.Result{false, "", WithdrawDelegateRewardErrStr + ":" + err.Error()}
+			event, _ := json.Marshal(res)
+			stkc.badLog(state, blockNumber.Uint64(), txHash.Hex(), WithdrawDelegateRewardEvent, string(event), "withdrawDelegateReward")
+			return nil, nil
+		} else {
+			log.Error("Failed to withdrawDelegateReward by WithdrawDelegateReward", "txHash", txHash, "blockNumber", blockNumber, "err", err)
+			return nil, err
+		}
+	}
+
+	res := xcom.Result{true, reward.String(), ""}
+	event, _ := json.Marshal(res)
+	stkc.goodLog(state, blockNumber.Uint64(), txHash.Hex(), WithdrawDelegateRewardEvent, string(event), "withdrawDelegateReward")
+	return nil, nil
+}
+
 func (stkc *stakingContract) getVerifierList() ([]byte, error) {
 
 	txHash := stkc.Evm.StateDB.TxHash()
@@ -597,32 +689,88 @@ func (stkc *stakingContract) getVerifierList() ([]byte, error) {
 
 func (stkc *stakingContract) getValidatorList() ([]byte, error) {
 
-	//txHash := stkc.Evm.StateDB.TxHash()
-	//blockNumber := stkc.Evm.BlockNumber
-	//blockHash := stkc.Evm.BlockHash
+	blockNumber := stkc.Evm.BlockNumber
+	blockHash := stkc.Evm.BlockHash
 
-	//stkc.plugin.GetValidatorList(blockHash, blockNumber.Uint64(), plugin.CurrentRound)
+	arr, success, err := stkc.plugin.GetValidatorList(blockHash, blockNumber.Uint64(), plugin.CurrentRound)
+	if nil != err && !success {
+		log.Error("Failed to getValidatorList", "blockNumber", blockNumber, "err", err)
+		return nil, err
+	}
+	return stkc.queryResult(arr, err, GetValidatorListErrStr)
+}
 
-	return nil, nil
+func (stkc *stakingContract) getCandidateList() ([]byte, error) {
+
+	blockHash := stkc.Evm.BlockHash
+
+	arr, err := stkc.plugin.GetCandidateList(blockHash)
+	return stkc.queryResult(arr, err, GetCandidateListErrStr)
 }
 
-func (stkc *stakingContract) getCandidateList() {
+func (stkc *stakingContract) getDelegateListByAddr(addr common.Address) ([]byte, error) {
 
+	blockHash := stkc.Evm.BlockHash
 
+	arr, err := stkc.plugin.GetDelegateListByAddr(blockHash, addr)
+	return stkc.queryResult(arr, err, GetDelegateListErrStr)
+}
 
+func (stkc *stakingContract) getDelegateInfo(stakingBlockNum uint64, addr common.Address, nodeId discover.NodeID) ([]byte, error) {
+
+	blockHash := stkc.Evm.BlockHash
+
+	del, err := stkc.plugin.GetDelegateInfo(blockHash, addr, nodeId, stakingBlockNum)
+	if nil == err && nil == del {
+		return stkc.queryResult(nil, errors.New(DelegateNotExistErrStr), QueryDelErrStr)
+	}
+	return stkc.queryResult(del, err, QueryDelErrStr)
 }
 
-// todo Maybe will implement
-func (stkc *stakingContract) getDelegateListByAddr(addr common.Address) {
+func (stkc *stakingContract) getCandidateInfo(nodeId discover.NodeID) ([]byte, error) {
+
+	blockHash := stkc.Evm.BlockHash
+
+	canAddr, err := xutil.NodeId2Addr(nodeId)
+	if nil != err {
+		return stkc.queryResult(nil, err, QueryCanErrStr)
+	}
 
+	can, err := stkc.plugin.GetCandidateInfo(blockHash, canAddr)
+	if nil == err && nil == can {
+		return stkc.queryResult(nil, errors.New(CanNotExistErrStr), QueryCanErrStr)
+	}
+	return stkc.queryResult(can, err, QueryCanErrStr)
 }
 
-func (stkc *stakingContract) getDelegateInfo(stakingBlockNum uint64, addr common.Address, nodeId discover.NodeID) {
+func (stkc *stakingContract) getUnStakeQueue() ([]byte, error) {
 
+	blockNumber := stkc.Evm.BlockNumber
+	blockHash := stkc.Evm.BlockHash
+
+	arr, err := stkc.plugin.GetUnStakeQueue(stkc.Evm.StateDB, blockHash, blockNumber.Uint64())
+	return stkc.queryResult(arr, err, GetUnStakeQueueErrStr)
 }
 
-func (stkc *stakingContract) getCandidateInfo(nodeId discover.NodeID) {
+func (stkc *stakingContract) getUnDelegateQueue() ([]byte, error) {
+
+	blockNumber := stkc.Evm.BlockNumber
+	blockHash := stkc.Evm.BlockHash
 
+	arr, err := stkc.plugin.GetUnDelegateQueue(stkc.Evm.StateDB, blockHash, blockNumber.Uint64())
+	return stkc.queryResult(arr, err, GetUnDelegateQueueErrStr)
+}
+
+func (stkc *stakingContract) queryResult(data interface{}, err error, errStr string) ([]byte, error) {
+	if nil != err {
+		res := xcom.Result{false, "", errStr + ":" + err.Error()}
+		result, _ := rlp.EncodeToBytes(res)
+		return result, nil
+	}
+	jsonByte, _ := json.Marshal(data)
+	res := xcom.Result{true, string(jsonByte), ""}
+	result, _ := rlp.EncodeToBytes(res)
+	return result, nil
 }
 
 func (stkc *stakingContract) goodLog(state xcom.StateDB, blockNumber uint64, txHash, eventType, eventData, callFn string) {
@@ -50,6 +50,7 @@ import (
 	"github.com/PlatONnetwork/PlatON-Go/params"
 	"github.com/PlatONnetwork/PlatON-Go/rlp"
 	"github.com/PlatONnetwork/PlatON-Go/rpc"
+	"github.com/PlatONnetwork/PlatON-Go/x/gov"
 	xplugin "github.com/PlatONnetwork/PlatON-Go/x/plugin"
 	"github.com/PlatONnetwork/PlatON-Go/x/xcom"
 )
@@ -138,6 +139,8 @@ func New(ctx *node.ServiceContext, config *Config) (*Ethereum, error) {
 
 	//set snapshotdb path
 	snapshotdb.SetDBPath(ctx)
+	snapshotdb.SetArchive(config.SnapshotDBArchive, config.SnapshotDBRetention)
+	snapshotdb.SetCompactionPolicy(config.SnapshotDBCompaction)
 
 	chainConfig, genesisHash, genesisErr := core.SetupGenesisBlock(chainDb, config.Genesis)
 	if _, ok := genesisErr.(*params.ConfigCompatError); genesisErr != nil && !ok {
@@ -178,6 +181,9 @@ func New(ctx *node.ServiceContext, config *Config) (*Ethereum, error) {
 		cacheConfig = &core.CacheConfig{Disabled: config.NoPruning, TrieNodeLimit: config.TrieCache, TrieTimeLimit: config.TrieTimeout}
 	)
 
+	if chainConfig.Cbft != nil && chainConfig.Cbft.ValidatorMode == "ppos" {
+		vmConfig.Plugins = newPlatONPlugins(snapshotdb.Instance())
+	}
 	eth.blockchain, err = core.NewBlockChain(chainDb, cacheConfig, eth.chainConfig, eth.engine, vmConfig, eth.shouldPreserve)
 	if err != nil {
 		return nil, err
@@ -242,7 +248,7 @@ func New(ctx *node.ServiceContext, config *Config) (*Ethereum, error) {
 			} else if chainConfig.Cbft.ValidatorMode == "ppos" {
 				// TODO init reactor
 				reactor := core.NewBlockChainReactor(chainConfig.Cbft.PrivateKey, eth.EventMux())
-				handlePlugin(reactor, nil)
+				handlePlugin(reactor, snapshotdb.Instance(), vmConfig.Plugins)
 				agency = reactor
 			}
 
@@ -580,7 +586,31 @@ func (s *Ethereum) Stop() error {
 	return nil
 }
 
+// newPlatONPlugins creates the ppos plugins on the db, they are shared by the reactor and the PlatON contracts
+func newPlatONPlugins(db snapshotdb.DB) *vm.PlatONPlugins {
+	restricting := xplugin.NewRestrictingPlugin()
+	staking := xplugin.NewStakingPlugin(db, restricting)
+	return &vm.PlatONPlugins{
+		Staking:     staking,
+		Restricting: restricting,
+		Slashing:    xplugin.NewSlashingPlugin(db, staking),
+		Gov:         gov.NewGov(gov.NewGovDB(db), staking, restricting),
+	}
+}
+
 // TODO RegisterPlugin one by one
-func handlePlugin(reactor *core.BlockChainReactor, db snapshotdb.DB) {
-	reactor.RegisterPlugin(xcom.StakingRule, xplugin.StakingInstance(db))
+func handlePlugin(reactor *core.BlockChainReactor, db snapshotdb.DB, plugins *vm.PlatONPlugins) {
+	reactor.SetSnapshotDB(db)
+	reactor.RegisterPlugin(xcom.StakingRule, plugins.Staking)
+	reactor.RegisterPlugin(xcom.SlashingRule, plugins.Slashing)
+	reactor.RegisterPlugin(xcom.AwardmgrRule, xplugin.NewAwardMgrPlugin(plugins.Staking))
+	reactor.RegisterPlugin(xcom.RestrictingRule, plugins.Restricting)
+	reactor.RegisterPlugin(xcom.GovernanceRule, gov.NewGovPlugin(plugins.Gov))
+
+	// the params changed by proposals must be loaded before the other plugins begin,
+	// the staking reward must be distributed before the election of next epoch,
+	// and the proposals must be tallied before the verifiers are changed,
+	// the restricting von returned by the staking repays the debt before the release
+	reactor.SetBeginRule([]int{xcom.GovernanceRule, xcom.StakingRule, xcom.SlashingRule})
+	reactor.SetEndRule([]int{xcom.AwardmgrRule, xcom.GovernanceRule, xcom.StakingRule, xcom.RestrictingRule})
 }
@@ -28,6 +28,7 @@ import (
 	"github.com/PlatONnetwork/PlatON-Go/common"
 	"github.com/PlatONnetwork/PlatON-Go/common/hexutil"
 	"github.com/PlatONnetwork/PlatON-Go/core"
+	"github.com/PlatONnetwork/PlatON-Go/core/snapshotdb"
 	"github.com/PlatONnetwork/PlatON-Go/eth/downloader"
 	"github.com/PlatONnetwork/PlatON-Go/eth/gasprice"
 	"github.com/PlatONnetwork/PlatON-Go/log"
@@ -55,6 +56,8 @@ var DefaultConfig = Config{
 	DatabaseCache: 768,
 	TrieCache:     256,
 	TrieTimeout:   60 * time.Minute,
+
+	SnapshotDBCompaction: snapshotdb.DefaultCompactionPolicy,
 	MinerGasFloor: 3150000000,
 	MinerGasCeil:  3150000000,
 	MinerGasPrice: big.NewInt(params.GWei),
@@ -104,6 +107,11 @@ type Config struct {
 	TrieCache          int
 	TrieTimeout        time.Duration
 
+	// Snapshotdb options
+	SnapshotDBArchive    bool   // Keep the history of the snapshotdb for GetAt
+	SnapshotDBRetention  uint64 // Number of committed blocks whose history is kept, 0 keeps all
+	SnapshotDBCompaction snapshotdb.CompactionPolicy
+
 	// Mining-related options
 	MinerNotify    []string       `toml:",omitempty"`
 	MinerExtraData []byte         `toml:",omitempty"`
@@ -8,6 +8,7 @@ import (
 
 	"github.com/PlatONnetwork/PlatON-Go/common/hexutil"
 	"github.com/PlatONnetwork/PlatON-Go/core"
+	"github.com/PlatONnetwork/PlatON-Go/core/snapshotdb"
 	"github.com/PlatONnetwork/PlatON-Go/eth/downloader"
 	"github.com/PlatONnetwork/PlatON-Go/eth/gasprice"
 )
@@ -29,6 +30,9 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		DatabaseCache           int
 		TrieCache               int
 		TrieTimeout             time.Duration
+		SnapshotDBArchive       bool
+		SnapshotDBRetention     uint64
+		SnapshotDBCompaction    snapshotdb.CompactionPolicy
 		MinerNotify             []string      `toml:",omitempty"`
 		MinerExtraData          hexutil.Bytes `toml:",omitempty"`
 		MinerGasFloor           uint64
@@ -59,6 +63,9 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.DatabaseCache = c.DatabaseCache
 	enc.TrieCache = c.TrieCache
 	enc.TrieTimeout = c.TrieTimeout
+	enc.SnapshotDBArchive = c.SnapshotDBArchive
+	enc.SnapshotDBRetention = c.SnapshotDBRetention
+	enc.SnapshotDBCompaction = c.SnapshotDBCompaction
 	enc.MinerNotify = c.MinerNotify
 	enc.MinerExtraData = c.MinerExtraData
 	enc.MinerGasFloor = c.MinerGasFloor
@@ -93,6 +100,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		DatabaseCache           *int
 		TrieCache               *int
 		TrieTimeout             *time.Duration
+		SnapshotDBArchive       *bool
+		SnapshotDBRetention     *uint64
+		SnapshotDBCompaction    *snapshotdb.CompactionPolicy
 		MinerNotify             []string       `toml:",omitempty"`
 		MinerExtraData          *hexutil.Bytes `toml:",omitempty"`
 		MinerGasFloor           *uint64
@@ -150,6 +160,15 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.TrieTimeout != nil {
 		c.TrieTimeout = *dec.TrieTimeout
 	}
+	if dec.SnapshotDBArchive != nil {
+		c.SnapshotDBArchive = *dec.SnapshotDBArchive
+	}
+	if dec.SnapshotDBRetention != nil {
+		c.SnapshotDBRetention = *dec.SnapshotDBRetention
+	}
+	if dec.SnapshotDBCompaction != nil {
+		c.SnapshotDBCompaction = *dec.SnapshotDBCompaction
+	}
 	if dec.MinerNotify != nil {
 		c.MinerNotify = dec.MinerNotify
 	}
@@ -0,0 +1,188 @@
+package ethapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/PlatONnetwork/PlatON-Go/common/hexutil"
+	"github.com/PlatONnetwork/PlatON-Go/core/types"
+	"github.com/PlatONnetwork/PlatON-Go/p2p/discover"
+	"github.com/PlatONnetwork/PlatON-Go/rpc"
+	"github.com/PlatONnetwork/PlatON-Go/x/plugin"
+	"github.com/PlatONnetwork/PlatON-Go/x/xcom"
+	"github.com/PlatONnetwork/PlatON-Go/x/xutil"
+)
+
+var (
+	errStakingUnavailable     = errors.New("the staking data is not available on this node")
+	errRestrictingUnavailable = errors.New("the restricting data is not available on this node")
+)
+
+// PublicPPOSAPI provides an API to read the staking and restricting data of PPOS,
+// no transaction is sent to the contracts. The staking data is available since the highest
+// committed block of the snapshotdb, the restricting data is available at any block kept in the statedb.
+type PublicPPOSAPI struct {
+	b Backend
+}
+
+// NewPublicPPOSAPI creates a new PPOS API.
+func NewPublicPPOSAPI(b Backend) *PublicPPOSAPI {
+	return &PublicPPOSAPI{b}
+}
+
+// stakingAt resolves the header of the block, the staking data is read by its hash.
+// The pending block is not written into the snapshotdb, so it is served as the latest one.
+func (s *PublicPPOSAPI) stakingAt(ctx context.Context, blockNr rpc.BlockNumber) (*plugin.StakingPlugin, *types.Header, error) {
+	plugins := s.b.PlatONPlugins()
+	if plugins == nil || plugins.Staking == nil {
+		return nil, nil, errStakingUnavailable
+	}
+	stk := plugins.Staking
+	if blockNr == rpc.PendingBlockNumber {
+		blockNr = rpc.LatestBlockNumber
+	}
+	header, err := s.b.HeaderByNumber(ctx, blockNr)
+	if err != nil {
+		return nil, nil, err
+	}
+	if header == nil {
+		return nil, nil, fmt.Errorf("block #%d not found", blockNr)
+	}
+	// the older blocks are merged into the base of the snapshotdb,
+	// reading them by the hash would silently return the latest data
+	highest, err := stk.HighestCommittedNum()
+	if err != nil {
+		return nil, nil, err
+	}
+	if header.Number.Cmp(highest) < 0 {
+		return nil, nil, fmt.Errorf("the staking data of block #%d is pruned, the lowest available block is #%d", header.Number, highest)
+	}
+	return stk, header, nil
+}
+
+// GetVerifierList returns the verifiers of the epoch which the block belongs to.
+func (s *PublicPPOSAPI) GetVerifierList(ctx context.Context, blockNr rpc.BlockNumber) (xcom.CandidateQueue, error) {
+	stk, header, err := s.stakingAt(ctx, blockNr)
+	if err != nil {
+		return nil, err
+	}
+	arr, _, err := stk.GetVerifierList(header.Hash(), header.Number.Uint64())
+	return arr, err
+}
+
+// GetValidatorList returns the validators of the consensus round which the block belongs to.
+func (s *PublicPPOSAPI) GetValidatorList(ctx context.Context, blockNr rpc.BlockNumber) (xcom.ValidatorExQueue, error) {
+	stk, header, err := s.stakingAt(ctx, blockNr)
+	if err != nil {
+		return nil, err
+	}
+	arr, _, err := stk.GetValidatorList(header.Hash(), header.Number.Uint64(), plugin.CurrentRound)
+	return arr, err
+}
+
+// GetCandidateList returns all of the candidates ranked by power.
+func (s *PublicPPOSAPI) GetCandidateList(ctx context.Context, blockNr rpc.BlockNumber) (xcom.CandidateQueue, error) {
+	stk, header, err := s.stakingAt(ctx, blockNr)
+	if err != nil {
+		return nil, err
+	}
+	return stk.GetCandidateList(header.Hash())
+}
+
+// GetCandidateInfo returns the candidate of the node, nil if the node is not a candidate.
+func (s *PublicPPOSAPI) GetCandidateInfo(ctx context.Context, nodeId discover.NodeID, blockNr rpc.BlockNumber) (*xcom.Candidate, error) {
+	stk, header, err := s.stakingAt(ctx, blockNr)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := xutil.NodeId2Addr(nodeId)
+	if err != nil {
+		return nil, err
+	}
+	return stk.GetCandidateInfo(header.Hash(), addr)
+}
+
+// GetDelegateListByAddr returns all of the delegations of the address.
+func (s *PublicPPOSAPI) GetDelegateListByAddr(ctx context.Context, addr common.Address, blockNr rpc.BlockNumber) (xcom.DelegationExQueue, error) {
+	stk, header, err := s.stakingAt(ctx, blockNr)
+	if err != nil {
+		return nil, err
+	}
+	return stk.GetDelegateListByAddr(header.Hash(), addr)
+}
+
+// GetDelegateInfo returns the delegation of the address to the candidate staked at stakingBlockNum,
+// nil if the delegation does not exist.
+func (s *PublicPPOSAPI) GetDelegateInfo(ctx context.Context, stakingBlockNum hexutil.Uint64, addr common.Address,
+	nodeId discover.NodeID, blockNr rpc.BlockNumber) (*xcom.Delegation, error) {
+	stk, header, err := s.stakingAt(ctx, blockNr)
+	if err != nil {
+		return nil, err
+	}
+	return stk.GetDelegateInfo(header.Hash(), addr, nodeId, uint64(stakingBlockNum))
+}
+
+// GetUnStakeQueue returns the unstakes which are waiting to be released.
+func (s *PublicPPOSAPI) GetUnStakeQueue(ctx context.Context, blockNr rpc.BlockNumber) (xcom.UnStakeQueue, error) {
+	stk, header, err := s.stakingAt(ctx, blockNr)
+	if err != nil {
+		return nil, err
+	}
+	// the freeze ratio is read from the state of the block
+	state, _, err := s.b.StateAndHeaderByNumber(ctx, rpc.BlockNumber(header.Number.Int64()))
+	if state == nil || err != nil {
+		return nil, err
+	}
+	return stk.GetUnStakeQueue(state, header.Hash(), header.Number.Uint64())
+}
+
+// GetUnDelegateQueue returns the undelegates which are waiting to be released.
+func (s *PublicPPOSAPI) GetUnDelegateQueue(ctx context.Context, blockNr rpc.BlockNumber) (xcom.UnDelegateQueue, error) {
+	stk, header, err := s.stakingAt(ctx, blockNr)
+	if err != nil {
+		return nil, err
+	}
+	// the freeze ratio is read from the state of the block
+	state, _, err := s.b.StateAndHeaderByNumber(ctx, rpc.BlockNumber(header.Number.Int64()))
+	if state == nil || err != nil {
+		return nil, err
+	}
+	return stk.GetUnDelegateQueue(state, header.Hash(), header.Number.Uint64())
+}
+
+// restricting returns the restricting plugin of the node, it is only created on the chain with ppos.
+func (s *PublicPPOSAPI) restricting() (*plugin.RestrictingPlugin, error) {
+	plugins := s.b.PlatONPlugins()
+	if plugins == nil || plugins.Restricting == nil {
+		return nil, errRestrictingUnavailable
+	}
+	return plugins.Restricting, nil
+}
+
+// GetRestrictingInfo returns the restricting record of the account with all of its release entries.
+func (s *PublicPPOSAPI) GetRestrictingInfo(ctx context.Context, account common.Address, blockNr rpc.BlockNumber) (*xcom.RestrictingResult, error) {
+	state, _, err := s.b.StateAndHeaderByNumber(ctx, blockNr)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	rp, err := s.restricting()
+	if err != nil {
+		return nil, err
+	}
+	return rp.GetRestrictingInfo(account, state)
+}
+
+// GetReleaseAccounts returns all of the accounts which will be released at the end of the epoch.
+func (s *PublicPPOSAPI) GetReleaseAccounts(ctx context.Context, epoch hexutil.Uint64, blockNr rpc.BlockNumber) ([]xcom.ReleaseAccountInfo, error) {
+	state, _, err := s.b.StateAndHeaderByNumber(ctx, blockNr)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	rp, err := s.restricting()
+	if err != nil {
+		return nil, err
+	}
+	return rp.GetReleaseAccounts(uint64(epoch), state), state.Error()
+}
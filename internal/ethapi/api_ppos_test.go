@@ -0,0 +1,111 @@
+package ethapi
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/PlatONnetwork/PlatON-Go/core/snapshotdb"
+	"github.com/PlatONnetwork/PlatON-Go/core/types"
+	"github.com/PlatONnetwork/PlatON-Go/core/vm"
+	"github.com/PlatONnetwork/PlatON-Go/rpc"
+	"github.com/PlatONnetwork/PlatON-Go/x/plugin"
+)
+
+// pposTestBackend serves the headers of a chain whose head is the block #head,
+// the other methods of the Backend are not implemented.
+type pposTestBackend struct {
+	Backend
+	head    int64
+	plugins *vm.PlatONPlugins
+}
+
+func (b *pposTestBackend) HeaderByNumber(ctx context.Context, blockNr rpc.BlockNumber) (*types.Header, error) {
+	number := int64(blockNr)
+	if blockNr == rpc.LatestBlockNumber {
+		number = b.head
+	}
+	if number > b.head {
+		return nil, nil
+	}
+	return &types.Header{Number: big.NewInt(number)}, nil
+}
+
+func (b *pposTestBackend) PlatONPlugins() *vm.PlatONPlugins {
+	return b.plugins
+}
+
+func TestPPOSAPI_StakingAt(t *testing.T) {
+	db, err := snapshotdb.New(snapshotdb.Options{InMemory: true})
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// the blocks up to #2 are committed
+	parent := common.ZeroHash
+	for i := int64(1); i <= 2; i++ {
+		hash := common.BigToHash(big.NewInt(i))
+		if err := db.NewBlock(big.NewInt(i), parent, hash); nil != err {
+			t.Fatal(err)
+		}
+		if err := db.Commit(hash); nil != err {
+			t.Fatal(err)
+		}
+		parent = hash
+	}
+
+	stk := plugin.NewStakingPlugin(db, plugin.NewRestrictingPlugin())
+	api := NewPublicPPOSAPI(&pposTestBackend{head: 3, plugins: &vm.PlatONPlugins{Staking: stk}})
+
+	tests := []struct {
+		blockNr rpc.BlockNumber
+		pruned  bool
+	}{
+		{blockNr: 1, pruned: true},
+		{blockNr: 2},
+		{blockNr: 3},
+		{blockNr: rpc.LatestBlockNumber},
+		{blockNr: rpc.PendingBlockNumber},
+	}
+	for _, test := range tests {
+		_, header, err := api.stakingAt(context.Background(), test.blockNr)
+		if test.pruned {
+			if nil == err {
+				t.Errorf("block %d: expect the pruned staking data to be rejected", test.blockNr)
+			}
+			continue
+		}
+		if nil != err {
+			t.Errorf("block %d: unexpected error: %v", test.blockNr, err)
+			continue
+		}
+		if test.blockNr >= 0 && header.Number.Int64() != int64(test.blockNr) {
+			t.Errorf("block %d: resolved the block #%d", test.blockNr, header.Number)
+		}
+	}
+
+	// the queries go through the same check
+	if _, err := api.GetUnStakeQueue(context.Background(), 1); nil == err {
+		t.Error("expect the unstake queue of the pruned block to be rejected")
+	}
+	if _, err := api.GetUnDelegateQueue(context.Background(), 1); nil == err {
+		t.Error("expect the undelegate queue of the pruned block to be rejected")
+	}
+	if _, _, err := api.stakingAt(context.Background(), 4); nil == err {
+		t.Error("expect the missing block to be rejected")
+	}
+}
+
+func TestPPOSAPI_StakingUnavailable(t *testing.T) {
+	for _, plugins := range []*vm.PlatONPlugins{nil, {}} {
+		api := NewPublicPPOSAPI(&pposTestBackend{head: 1, plugins: plugins})
+		if _, _, err := api.stakingAt(context.Background(), rpc.LatestBlockNumber); err != errStakingUnavailable {
+			t.Errorf("expect %v, got %v", errStakingUnavailable, err)
+		}
+		if _, err := api.restricting(); err != errRestrictingUnavailable {
+			t.Errorf("expect %v, got %v", errRestrictingUnavailable, err)
+		}
+	}
+}
@@ -70,6 +70,9 @@ type Backend interface {
 
 	ChainConfig() *params.ChainConfig
 	CurrentBlock() *types.Block
+
+	// PPOS API, the plugins are nil on the chain without ppos
+	PlatONPlugins() *vm.PlatONPlugins
 }
 
 func GetAPIs(apiBackend Backend) []rpc.API {
@@ -119,6 +122,11 @@ func GetAPIs(apiBackend Backend) []rpc.API {
 			Version:   "1.0",
 			Service:   NewPublicConsensusAPI(apiBackend),
 			Public:    true,
+		}, {
+			Namespace: "ppos",
+			Version:   "1.0",
+			Service:   NewPublicPPOSAPI(apiBackend),
+			Public:    true,
 		},
 	}
 }
@@ -26,6 +26,7 @@ var Modules = map[string]string{
 	"miner":      Miner_JS,
 	"net":        Net_JS,
 	"personal":   Personal_JS,
+	"ppos":       PPOS_JS,
 	"rpc":        RPC_JS,
 	"shh":        Shh_JS,
 	"swarmfs":    SWARMFS_JS,
@@ -543,6 +544,75 @@ web3._extend({
 })
 `
 
+const PPOS_JS = `
+web3._extend({
+	property: 'ppos',
+	methods: [
+		new web3._extend.Method({
+			name: 'getVerifierList',
+			call: 'ppos_getVerifierList',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputDefaultBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getValidatorList',
+			call: 'ppos_getValidatorList',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputDefaultBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getCandidateList',
+			call: 'ppos_getCandidateList',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputDefaultBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getCandidateInfo',
+			call: 'ppos_getCandidateInfo',
+			params: 2,
+			inputFormatter: [null, web3._extend.formatters.inputDefaultBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getDelegateListByAddr',
+			call: 'ppos_getDelegateListByAddr',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputDefaultBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getDelegateInfo',
+			call: 'ppos_getDelegateInfo',
+			params: 4,
+			inputFormatter: [web3._extend.utils.fromDecimal, web3._extend.formatters.inputAddressFormatter, null, web3._extend.formatters.inputDefaultBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getUnStakeQueue',
+			call: 'ppos_getUnStakeQueue',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputDefaultBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getUnDelegateQueue',
+			call: 'ppos_getUnDelegateQueue',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputDefaultBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getRestrictingInfo',
+			call: 'ppos_getRestrictingInfo',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputDefaultBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getReleaseAccounts',
+			call: 'ppos_getReleaseAccounts',
+			params: 2,
+			inputFormatter: [web3._extend.utils.fromDecimal, web3._extend.formatters.inputDefaultBlockNumberFormatter]
+		}),
+	],
+	properties: []
+});
+`
+
 const RPC_JS = `
 web3._extend({
 	property: 'rpc',
@@ -0,0 +1,275 @@
+package exec
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/PlatONnetwork/PlatON-Go/life/compiler/opcodes"
+)
+
+// GasSchedule is the gas prices of the WASM execution. A schedule applies from the
+// active chain version of the governance, so the execution can be repriced by a
+// version proposal: the new code registers the schedule of the new version, which
+// takes effect when the proposal is activated.
+type GasSchedule struct {
+	Version uint32 // The active chain version from which the schedule applies
+
+	// Instruction classes
+	Nop          uint64 // nop
+	Base         uint64 // select, phi, current_memory and the internal instructions
+	Const        uint64 // i32.const and i64.const
+	Variable     uint64 // get and set of the locals and the globals
+	Arithmetic   uint64 // integer add, sub, bitwise, shift, rotate, count and compare
+	Multiply     uint64 // integer mul
+	Divide       uint64 // integer div and rem
+	Float        uint64 // float arithmetic and compare
+	Conversion   uint64 // wrap, extend, truncate, convert, demote and promote
+	Load         uint64 // memory loads
+	Store        uint64 // memory stores
+	Control      uint64 // jumps, returns and unreachable
+	Call         uint64 // direct calls
+	CallIndirect uint64 // indirect calls through the table
+
+	// Memory growth
+	GrowMemory uint64 // grow_memory
+	MemoryPage uint64 // per page grown
+
+	// Host functions
+	StorageRead      uint64 // getState and getStateSize
+	StorageReadByte  uint64 // per byte of the key and the value read
+	StorageWrite     uint64 // setState
+	StorageWriteByte uint64 // per byte of the key and the value written
+	Event            uint64 // emitEvent
+	EventByte        uint64 // per byte of the topic and the data
+	Sha3             uint64 // sha3
+	Sha3Word         uint64 // per 32 bytes hashed
+	Memory           uint64 // memcpy, memmove, memcmp and memset
+	MemoryByte       uint64 // per byte copied, compared or set
+
+	once      sync.Once
+	jumpTable [256]Instruction
+}
+
+// DefaultGasSchedule is the schedule before any repricing, every instruction
+// costs 1 and the memory functions cost 1 per byte.
+var DefaultGasSchedule = &GasSchedule{
+	Version:      0,
+	Nop:          0,
+	Base:         1,
+	Const:        1,
+	Variable:     1,
+	Arithmetic:   1,
+	Multiply:     1,
+	Divide:       1,
+	Float:        1,
+	Conversion:   1,
+	Load:         1,
+	Store:        1,
+	Control:      1,
+	Call:         1,
+	CallIndirect: 1,
+
+	GrowMemory: 1,
+	MemoryPage: 0,
+
+	StorageRead:      1,
+	StorageReadByte:  0,
+	StorageWrite:     1,
+	StorageWriteByte: 0,
+	Event:            1,
+	EventByte:        0,
+	Sha3:             1,
+	Sha3Word:         0,
+	Memory:           0,
+	MemoryByte:       1,
+}
+
+var (
+	gasSchedulesLock sync.RWMutex
+	gasSchedules     = []*GasSchedule{DefaultGasSchedule}
+)
+
+// RegisterGasSchedule adds the schedule of a chain version, it must be registered
+// before the version is activated.
+func RegisterGasSchedule(schedule *GasSchedule) error {
+	gasSchedulesLock.Lock()
+	defer gasSchedulesLock.Unlock()
+
+	for _, s := range gasSchedules {
+		if s.Version == schedule.Version {
+			return fmt.Errorf("gas schedule of version %d already registered", schedule.Version)
+		}
+	}
+	gasSchedules = append(gasSchedules, schedule)
+	sort.Slice(gasSchedules, func(i, j int) bool {
+		return gasSchedules[i].Version < gasSchedules[j].Version
+	})
+	return nil
+}
+
+// unregisterGasSchedule removes the schedule of the version registered by RegisterGasSchedule,
+// the default schedule is never removed. It's used by the tests to restore the schedules.
+func unregisterGasSchedule(version uint32) {
+	gasSchedulesLock.Lock()
+	defer gasSchedulesLock.Unlock()
+
+	for i := 1; i < len(gasSchedules); i++ {
+		if gasSchedules[i].Version == version {
+			gasSchedules = append(gasSchedules[:i], gasSchedules[i+1:]...)
+			return
+		}
+	}
+}
+
+// GasScheduleByVersion returns the schedule applied at the active chain version,
+// which is the one of the largest version not above it.
+func GasScheduleByVersion(version uint32) *GasSchedule {
+	gasSchedulesLock.RLock()
+	defer gasSchedulesLock.RUnlock()
+
+	for i := len(gasSchedules) - 1; i > 0; i-- {
+		if gasSchedules[i].Version <= version {
+			return gasSchedules[i]
+		}
+	}
+	return gasSchedules[0]
+}
+
+func (s *GasSchedule) orDefault() *GasSchedule {
+	if s == nil {
+		return DefaultGasSchedule
+	}
+	return s
+}
+
+// JumpTable returns the instruction table charging the prices of the schedule.
+func (s *GasSchedule) JumpTable() [256]Instruction {
+	s.once.Do(func() {
+		s.jumpTable = GasTable
+		for op := range s.jumpTable {
+			switch opcodes.Opcode(op) {
+			case opcodes.InvokeImport:
+				// host functions are charged by their own gas functions
+			case opcodes.GrowMemory:
+				s.jumpTable[op].GasCost = s.growMemoryGas
+			default:
+				if s.jumpTable[op].GasCost != nil {
+					s.jumpTable[op].GasCost = constGasFunc(s.opcodeCost(opcodes.Opcode(op)))
+				}
+			}
+		}
+	})
+	return s.jumpTable
+}
+
+// growMemoryGas charges the grow_memory by the pages grown.
+func (s *GasSchedule) growMemoryGas(vm *VirtualMachine, frame *Frame) (uint64, error) {
+	pages := uint64(uint32(frame.Regs[int(LE.Uint32(frame.Code[frame.IP:frame.IP+4]))]))
+	return s.GrowMemory + pages*s.MemoryPage, nil
+}
+
+// opcodeCost returns the price of the instruction class of the opcode.
+func (s *GasSchedule) opcodeCost(op opcodes.Opcode) uint64 {
+	switch op {
+	case opcodes.Nop:
+		return s.Nop
+	case opcodes.I32Const, opcodes.I64Const:
+		return s.Const
+	case opcodes.GetLocal, opcodes.SetLocal, opcodes.GetGlobal, opcodes.SetGlobal:
+		return s.Variable
+	case opcodes.I32Mul, opcodes.I64Mul:
+		return s.Multiply
+	case opcodes.I32DivS, opcodes.I32DivU, opcodes.I32RemS, opcodes.I32RemU,
+		opcodes.I64DivS, opcodes.I64DivU, opcodes.I64RemS, opcodes.I64RemU:
+		return s.Divide
+	case opcodes.I32Load, opcodes.I64Load, opcodes.I32Load8S, opcodes.I32Load16S, opcodes.I64Load8S,
+		opcodes.I64Load16S, opcodes.I64Load32S, opcodes.I32Load8U, opcodes.I32Load16U,
+		opcodes.I64Load8U, opcodes.I64Load16U, opcodes.I64Load32U:
+		return s.Load
+	case opcodes.I32Store, opcodes.I64Store, opcodes.I32Store8, opcodes.I32Store16,
+		opcodes.I64Store8, opcodes.I64Store16, opcodes.I64Store32:
+		return s.Store
+	case opcodes.Unreachable, opcodes.Jmp, opcodes.JmpIf, opcodes.JmpEither, opcodes.JmpTable,
+		opcodes.ReturnValue, opcodes.ReturnVoid:
+		return s.Control
+	case opcodes.Call:
+		return s.Call
+	case opcodes.CallIndirect:
+		return s.CallIndirect
+	case opcodes.GrowMemory:
+		return s.GrowMemory
+	}
+	switch {
+	case op >= opcodes.I32Add && op <= opcodes.I64GeU:
+		return s.Arithmetic
+	case op >= opcodes.F32Add && op <= opcodes.F64Ge:
+		return s.Float
+	case op >= opcodes.I32WrapI64 && op <= opcodes.F64ConvertUI64:
+		return s.Conversion
+	}
+	return s.Base
+}
+
+// GetCost returns the price of the wasm instruction, so the schedule can be used
+// as the compiler.GasPolicy of the gas counters inserted at compile time.
+func (s *GasSchedule) GetCost(op string) int64 {
+	switch {
+	case op == "nop":
+		return int64(s.Nop)
+	case strings.HasSuffix(op, ".const"):
+		return int64(s.Const)
+	case strings.HasSuffix(op, "_local") || strings.HasSuffix(op, "_global"):
+		return int64(s.Variable)
+	case strings.Contains(op, ".load"):
+		return int64(s.Load)
+	case strings.Contains(op, ".store"):
+		return int64(s.Store)
+	case strings.Contains(op, "/"):
+		// the conversions are named as i32.wrap/i64
+		return int64(s.Conversion)
+	case strings.HasPrefix(op, "f32.") || strings.HasPrefix(op, "f64."):
+		return int64(s.Float)
+	case strings.HasSuffix(op, ".mul"):
+		return int64(s.Multiply)
+	case strings.Contains(op, ".div") || strings.Contains(op, ".rem"):
+		return int64(s.Divide)
+	case strings.HasPrefix(op, "i32.") || strings.HasPrefix(op, "i64."):
+		return int64(s.Arithmetic)
+	case op == "call":
+		return int64(s.Call)
+	case op == "call_indirect":
+		return int64(s.CallIndirect)
+	case op == "grow_memory":
+		return int64(s.GrowMemory)
+	case strings.HasPrefix(op, "br") || strings.HasPrefix(op, "jmp") || strings.HasPrefix(op, "return") || op == "unreachable":
+		return int64(s.Control)
+	}
+	return int64(s.Base)
+}
+
+// StorageReadGas returns the gas of reading the storage with the bytes of the key and the value.
+func (s *GasSchedule) StorageReadGas(size uint64) uint64 {
+	return s.StorageRead + size*s.StorageReadByte
+}
+
+// StorageWriteGas returns the gas of writing the storage with the bytes of the key and the value.
+func (s *GasSchedule) StorageWriteGas(size uint64) uint64 {
+	return s.StorageWrite + size*s.StorageWriteByte
+}
+
+// EventGas returns the gas of emitting the event with the bytes of the topic and the data.
+func (s *GasSchedule) EventGas(size uint64) uint64 {
+	return s.Event + size*s.EventByte
+}
+
+// Sha3Gas returns the gas of hashing the bytes.
+func (s *GasSchedule) Sha3Gas(size uint64) uint64 {
+	return s.Sha3 + (size+31)/32*s.Sha3Word
+}
+
+// MemoryGas returns the gas of copying, comparing or setting the bytes of the memory.
+func (s *GasSchedule) MemoryGas(size uint64) uint64 {
+	return s.Memory + size*s.MemoryByte
+}
@@ -0,0 +1,75 @@
+package exec
+
+import (
+	"testing"
+
+	"github.com/PlatONnetwork/PlatON-Go/life/compiler/opcodes"
+)
+
+func TestDefaultGasSchedule(t *testing.T) {
+	table := DefaultGasSchedule.JumpTable()
+	for op := 0; op < len(table); op++ {
+		if GasTable[op].GasCost == nil || opcodes.Opcode(op) == opcodes.InvokeImport || opcodes.Opcode(op) == opcodes.GrowMemory {
+			continue
+		}
+		expect, _ := GasTable[op].GasCost(nil, nil)
+		if get, _ := table[op].GasCost(nil, nil); get != expect {
+			t.Fatalf("default schedule mismatch, opcode=%d, expect %d, get %d", op, expect, get)
+		}
+	}
+
+	frame := &Frame{Regs: []int64{0, 16}, Code: []byte{1, 0, 0, 0}}
+	if get, _ := table[opcodes.GrowMemory].GasCost(nil, frame); get != 1 {
+		t.Fatalf("grow memory gas mismatch, expect 1, get %d", get)
+	}
+	if get := DefaultGasSchedule.MemoryGas(100); get != 100 {
+		t.Fatalf("memory gas mismatch, expect 100, get %d", get)
+	}
+	if get := DefaultGasSchedule.StorageWriteGas(100); get != 1 {
+		t.Fatalf("storage gas mismatch, expect 1, get %d", get)
+	}
+}
+
+func TestGasScheduleByVersion(t *testing.T) {
+	schedule := &GasSchedule{Version: 2<<16 | 1<<8, Const: 2, Divide: 5, GrowMemory: 10, MemoryPage: 3, Sha3: 30, Sha3Word: 6}
+	if err := RegisterGasSchedule(schedule); err != nil {
+		t.Fatal(err)
+	}
+	defer unregisterGasSchedule(schedule.Version)
+	if err := RegisterGasSchedule(&GasSchedule{Version: schedule.Version}); err == nil {
+		t.Fatal("register the same version twice")
+	}
+
+	if get := GasScheduleByVersion(0); get != DefaultGasSchedule {
+		t.Fatalf("version 0 should use the default schedule")
+	}
+	if get := GasScheduleByVersion(schedule.Version - 1); get != DefaultGasSchedule {
+		t.Fatalf("version below the schedule should use the default schedule")
+	}
+	if get := GasScheduleByVersion(schedule.Version + 1); get != schedule {
+		t.Fatalf("version above the schedule should use the schedule")
+	}
+
+	table := schedule.JumpTable()
+	if get, _ := table[opcodes.I64Const].GasCost(nil, nil); get != 2 {
+		t.Fatalf("const gas mismatch, expect 2, get %d", get)
+	}
+	if get, _ := table[opcodes.I32DivU].GasCost(nil, nil); get != 5 {
+		t.Fatalf("divide gas mismatch, expect 5, get %d", get)
+	}
+	frame := &Frame{Regs: []int64{0, 4}, Code: []byte{1, 0, 0, 0}}
+	if get, _ := table[opcodes.GrowMemory].GasCost(nil, frame); get != 22 {
+		t.Fatalf("grow memory gas mismatch, expect 22, get %d", get)
+	}
+	if get := schedule.Sha3Gas(33); get != 42 {
+		t.Fatalf("sha3 gas mismatch, expect 42, get %d", get)
+	}
+	if get := schedule.GetCost("i32.div_s"); get != 5 {
+		t.Fatalf("policy cost mismatch, expect 5, get %d", get)
+	}
+
+	unregisterGasSchedule(schedule.Version)
+	if get := GasScheduleByVersion(schedule.Version + 1); get != DefaultGasSchedule {
+		t.Fatalf("the unregistered schedule should not be used")
+	}
+}
@@ -73,6 +73,7 @@ type VirtualMachine struct {
 	ReturnValue    int64
 	Gas            uint64
 	ExternalParams []int64
+	Compiled       *CompiledModule // functions compiled by the JIT, used if EnableJIT
 }
 
 // VMConfig denotes a set of options passed to a single VirtualMachine insta.ce
@@ -95,6 +96,9 @@ type VMContext struct {
 	GasUsed  uint64
 	GasLimit uint64
 
+	// GasSchedule prices the execution, the default schedule is used if nil
+	GasSchedule *GasSchedule
+
 	StateDB StateDB
 	Log     log.Logger
 }
@@ -270,7 +274,7 @@ func NewVirtualMachineWithModule(m *compiler.Module, functionCode []compiler.Int
 		Context:         context,
 		FunctionCode:    functionCode,
 		FunctionImports: funcImports,
-		JumpTable:       GasTable,
+		JumpTable:       context.GasSchedule.orDefault().JumpTable(),
 		CallStack:       make([]Frame, DefaultCallStackSize),
 		CurrentFrame:    -1,
 		Table:           table,
@@ -285,10 +289,6 @@ func NewVirtualMachineWithModule(m *compiler.Module, functionCode []compiler.Int
 // specific execution options specified under a VMConfig, and a WebAssembly module import
 // resolver.
 func NewVirtualMachine(code []byte, context *VMContext, impResolver ImportResolver, gasPolicy compiler.GasPolicy) (_retVM *VirtualMachine, retErr error) {
-	if context.Config.EnableJIT {
-		fmt.Println("Warning: JIT support is incomplete and the internals are likely to change in the future.")
-	}
-
 	m, functionCode, err := ParseModuleAndFunc(code, gasPolicy)
 	if err != nil {
 		return nil, err
@@ -320,20 +320,13 @@ func (f *Frame) Init(vm *VirtualMachine, functionID int, code compiler.Interpret
 	f.IP = 0
 	f.Continuation = 0
 
+	f.JITInfo = nil
+
 	//fmt.Printf("Enter function %d (%s)\n", functionID, vm.Module.FunctionNames[functionID])
-	if vm.Context.Config.EnableJIT {
-		code := &vm.FunctionCode[functionID]
-		if !code.JITDone {
-			if len(code.Bytes) > JITCodeSizeThreshold {
-				if !vm.GenerateCodeForFunction(functionID) {
-					fmt.Printf("codegen for function %d failed\n", functionID)
-				} else {
-					fmt.Printf("codegen for function %d succeeded\n", functionID)
-				}
-			}
-			code.JITDone = true
+	if vm.Context.Config.EnableJIT && vm.Compiled != nil {
+		if compiled := vm.Compiled.Functions[functionID]; compiled != nil {
+			f.JITInfo = compiled
 		}
-		f.JITInfo = code.JITInfo
 	}
 }
 
@@ -345,6 +338,11 @@ func (f *Frame) Destroy(vm *VirtualMachine) {
 	//fmt.Printf("Leave function %d (%s)\n", f.FunctionID, vm.Module.FunctionNames[f.FunctionID])
 }
 
+// GasSchedule returns the gas schedule pricing the execution.
+func (vm *VirtualMachine) GasSchedule() *GasSchedule {
+	return vm.Context.GasSchedule.orDefault()
+}
+
 // GetCurrentFrame returns the current frame.
 func (vm *VirtualMachine) GetCurrentFrame() *Frame {
 	if vm.Context.Config.MaxCallStackDepth != 0 && vm.CurrentFrame >= vm.Context.Config.MaxCallStackDepth {
@@ -422,6 +420,15 @@ func (vm *VirtualMachine) Ignite(functionID int, params ...int64) {
 	copy(frame.Locals, params)
 }
 
+// useGas charges the gas of the instruction whose operands start at frame.IP.
+func (vm *VirtualMachine) useGas(ins opcodes.Opcode, frame *Frame) {
+	cost, err := vm.JumpTable[ins].GasCost(vm, frame)
+	if err != nil || (cost+vm.Context.GasUsed) > vm.Context.GasLimit {
+		panic(fmt.Sprintf("out of gas  cost:%d GasUsed:%d GasLimit:%d", cost, vm.Context.GasUsed, vm.Context.GasLimit))
+	}
+	vm.Context.GasUsed += cost
+}
+
 func (vm *VirtualMachine) AddAndCheckGas(delta uint64) {
 	newGas := vm.Gas + delta
 	if newGas < vm.Gas {
@@ -463,26 +470,14 @@ func (vm *VirtualMachine) Execute() {
 
 	for {
 		if frame.JITInfo != nil {
-			dm := frame.JITInfo.(*DynamicModule)
-			var fRetVal int64
-			status := dm.Run(vm, &fRetVal)
-			if status < 0 {
-				panic(fmt.Errorf("status = %d", status))
-			}
-			//fmt.Printf("JIT: continuation = %d, ip = %d\n", status, int(fRetVal))
-			frame.Continuation = status
-			frame.IP = int(fRetVal)
+			frame.JITInfo.(*CompiledFunction).run(vm, frame)
 		}
 
 		valueID := int(LE.Uint32(frame.Code[frame.IP : frame.IP+4]))
 		ins := opcodes.Opcode(frame.Code[frame.IP+4])
 		frame.IP += 5
 
-		cost, err := vm.JumpTable[ins].GasCost(vm, frame)
-		if err != nil || (cost+vm.Context.GasUsed) > vm.Context.GasLimit {
-			panic(fmt.Sprintf("out of gas  cost:%d GasUsed:%d GasLimit:%d", cost, vm.Context.GasUsed, vm.Context.GasLimit))
-		}
-		vm.Context.GasUsed += cost
+		vm.useGas(ins, frame)
 
 		//fmt.Printf("INS: [%d] %s\n", valueID, ins.String())
 
@@ -3,562 +3,545 @@ package exec
 import (
 	"fmt"
 	"math"
-	"strings"
+	"math/bits"
 
 	"github.com/PlatONnetwork/PlatON-Go/life/compiler"
 	"github.com/PlatONnetwork/PlatON-Go/life/compiler/opcodes"
+	"github.com/PlatONnetwork/PlatON-Go/life/utils"
 )
 
-type jitContext struct {
-	vm         *VirtualMachine
-	functionID int
-	code       *compiler.InterpreterCode
-	program    strings.Builder
-	cont       int
-	ip         int
-	thisIP     int
-}
+// jitExecute executes a compiled instruction and returns the index of the next one.
+type jitExecute func(vm *VirtualMachine, frame *Frame) int
 
-func (c *jitContext) writeFallback() {
-	c.program.WriteString(fmt.Sprintf("*ret = %d;\n", c.thisIP))
-	c.program.WriteString(fmt.Sprintf("return %d;\n", c.cont))
-	c.program.WriteString(fmt.Sprintf("case %d:\n", c.cont))
-	c.cont++
-}
-
-func (c *jitContext) checkLocal(id int) {
-	if id < 0 || id >= c.code.NumParams+c.code.NumLocals {
-		panic(fmt.Errorf("local out of bounds: id = %d, n = %d", id, c.code.NumParams+c.code.NumLocals))
-	}
+type jitInstruction struct {
+	op      opcodes.Opcode
+	ip      int        // ip of the instruction
+	operand int        // ip of the operands, where the gas function reads them
+	execute jitExecute // nil if the instruction is left to the interpreter
 }
 
-func (c *jitContext) checkReg(id int) {
-	if id < 0 || id >= c.code.NumRegs {
-		panic(fmt.Errorf("reg out of bounds: id = %d, n = %d", id, c.code.NumRegs))
-	}
+// CompiledFunction is a function compiled by the JIT. The instructions are decoded
+// once into closures, each of them is charged by the jump table of the VM exactly
+// as the interpreter does, so the compiled and the interpreted execution consume
+// the same gas. The instructions not supported by the JIT (calls, returns, host
+// functions, memory growth and floats) are left to the interpreter, which resumes
+// the compiled function after executing them.
+type CompiledFunction struct {
+	instructions []jitInstruction
+	entries      map[int]int // ip of the instruction -> index
+	end          int
 }
 
-func (c *jitContext) checkGlobal(id int) {
-	if id < 0 || id >= len(c.vm.Globals) {
-		panic(fmt.Errorf("global out of bounds: id = %d, n = %d", id, len(c.vm.Globals)))
-	}
+// CompiledModule is the functions of a module compiled by the JIT, indexed by the
+// function id. The functions not compiled are nil.
+type CompiledModule struct {
+	Functions []*CompiledFunction
 }
 
-func (c *jitContext) writeSI32Op(valueID int, op string) {
-	a := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-	c.checkReg(a)
-	b := int(LE.Uint32(c.code.Bytes[c.ip+4 : c.ip+8]))
-	c.checkReg(b)
-
-	c.ip += 8
-
-	if op == "/" || op == "%" {
-		c.program.WriteString(fmt.Sprintf("if((u32) regs[%d] == 0) return -5;\n", b))
-		c.program.WriteString(fmt.Sprintf("if((i32) regs[%d] == %d && (i32) regs[%d] == -1)", a, math.MinInt32, b))
-		if op == "/" {
-			c.program.WriteString("return -5;")
-		} else {
-			c.program.WriteString(fmt.Sprintf("regs[%d] = 0; else ", valueID))
+// CompileModule compiles the functions of a module larger than JITCodeSizeThreshold,
+// the functions failed to compile are left to the interpreter.
+func CompileModule(functionCode []compiler.InterpreterCode) *CompiledModule {
+	m := &CompiledModule{Functions: make([]*CompiledFunction, len(functionCode))}
+	for i := range functionCode {
+		if len(functionCode[i].Bytes) <= JITCodeSizeThreshold {
+			continue
+		}
+		if f, err := CompileFunction(&functionCode[i]); err == nil {
+			m.Functions[i] = f
 		}
 	}
-
-	c.program.WriteString(fmt.Sprintf("regs[%d] = (i64)((i32) regs[%d] %s (i32) regs[%d]);\n", valueID, a, op, b))
+	return m
 }
 
-func (c *jitContext) writeUI32Op(valueID int, op string) {
-	a := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-	c.checkReg(a)
-	b := int(LE.Uint32(c.code.Bytes[c.ip+4 : c.ip+8]))
-	c.checkReg(b)
-
-	c.ip += 8
+// CompileFunction compiles the interpreter code of a function.
+func CompileFunction(code *compiler.InterpreterCode) (_ *CompiledFunction, retErr error) {
+	defer utils.CatchPanic(&retErr)
 
-	if op == "/" || op == "%" {
-		c.program.WriteString(fmt.Sprintf("if((u32) regs[%d] == 0) return -5;\n", b))
+	c := &jitContext{
+		code:    code,
+		targets: make(map[int]*int),
+		f: &CompiledFunction{
+			entries: make(map[int]int),
+			end:     len(code.Bytes),
+		},
 	}
-
-	c.program.WriteString(fmt.Sprintf("regs[%d] = (i64)((u32) regs[%d] %s (u32) regs[%d]);\n", valueID, a, op, b))
+	c.generate()
+	return c.f, nil
 }
 
-func (c *jitContext) writeSI64Op(valueID int, op string) {
-	a := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-	c.checkReg(a)
-	b := int(LE.Uint32(c.code.Bytes[c.ip+4 : c.ip+8]))
-	c.checkReg(b)
-
-	c.ip += 8
-
-	if op == "/" || op == "%" {
-		c.program.WriteString(fmt.Sprintf("if(regs[%d] == 0) return -5;\n", b))
-		c.program.WriteString(fmt.Sprintf("if(regs[%d] == %d && regs[%d] == -1)", a, math.MinInt64, b))
-		if op == "/" {
-			c.program.WriteString("return -5;")
-		} else {
-			c.program.WriteString(fmt.Sprintf("regs[%d] = 0; else ", valueID))
+// run executes the compiled function from frame.IP until an instruction left to
+// the interpreter, frame.IP is set to the instruction.
+func (f *CompiledFunction) run(vm *VirtualMachine, frame *Frame) {
+	i, ok := f.entries[frame.IP]
+	if !ok {
+		return
+	}
+	for i < len(f.instructions) {
+		ins := &f.instructions[i]
+		if ins.execute == nil {
+			frame.IP = ins.ip
+			return
 		}
+		frame.IP = ins.operand
+		vm.useGas(ins.op, frame)
+		i = ins.execute(vm, frame)
 	}
-
-	c.program.WriteString(fmt.Sprintf("regs[%d] = regs[%d] %s regs[%d];\n", valueID, a, op, b))
+	frame.IP = f.end
 }
 
-func (c *jitContext) writeUI64Op(valueID int, op string) {
-	a := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-	c.checkReg(a)
-	b := int(LE.Uint32(c.code.Bytes[c.ip+4 : c.ip+8]))
-	c.checkReg(b)
+type jitContext struct {
+	code    *compiler.InterpreterCode
+	f       *CompiledFunction
+	ip      int
+	targets map[int]*int // jump target ip -> index, resolved after decoding
+}
 
-	c.ip += 8
+func (c *jitContext) uint32At(offset int) uint32 {
+	return LE.Uint32(c.code.Bytes[c.ip+offset : c.ip+offset+4])
+}
 
-	if op == "/" || op == "%" {
-		c.program.WriteString(fmt.Sprintf("if((u32) regs[%d] == 0) return -5;\n", b))
+func (c *jitContext) reg(offset int) int {
+	id := int(c.uint32At(offset))
+	if id >= c.code.NumRegs {
+		panic(fmt.Errorf("reg out of bounds: id = %d, n = %d", id, c.code.NumRegs))
 	}
-
-	c.program.WriteString(fmt.Sprintf("regs[%d] = (u64) regs[%d] %s (u64) regs[%d];\n", valueID, a, op, b))
+	return id
 }
 
-func (c *jitContext) writeMemoryLoad(valueID int, ty string) {
-	offset := LE.Uint32(c.code.Bytes[c.ip+4 : c.ip+8])
-
-	base := int(LE.Uint32(c.code.Bytes[c.ip+8 : c.ip+12]))
-	c.checkReg(base)
-
-	c.ip += 12
-
-	c.program.WriteString(fmt.Sprintf("tempPtr0 = %dUL + (unsigned long) (u32) regs[%d];\n", offset, base))
-	c.program.WriteString(fmt.Sprintf("if(tempPtr0 >= (unsigned long) memory_len) return -4;\n"))
-	c.program.WriteString(fmt.Sprintf("regs[%d] = (i64) *(%s*)((unsigned long) memory + tempPtr0);\n", valueID, ty))
+func (c *jitContext) local(offset int) int {
+	id := int(c.uint32At(offset))
+	if id >= c.code.NumParams+c.code.NumLocals {
+		panic(fmt.Errorf("local out of bounds: id = %d, n = %d", id, c.code.NumParams+c.code.NumLocals))
+	}
+	return id
 }
 
-func (c *jitContext) writeMemoryStore(ty string) {
-	offset := LE.Uint32(c.code.Bytes[c.ip+4 : c.ip+8])
-
-	base := int(LE.Uint32(c.code.Bytes[c.ip+8 : c.ip+12]))
-	c.checkReg(base)
-
-	value := int(LE.Uint32(c.code.Bytes[c.ip+12 : c.ip+16]))
-	c.checkReg(value)
-
-	c.ip += 16
-
-	c.program.WriteString(fmt.Sprintf("tempPtr0 = %dUL + (unsigned long) (u32) regs[%d];\n", offset, base))
-	c.program.WriteString(fmt.Sprintf("if(tempPtr0 >= (unsigned long) memory_len) return -4;\n"))
-	c.program.WriteString(fmt.Sprintf("*(%s*)((unsigned long) memory + tempPtr0) = (%s) regs[%d];\n", ty, ty, value))
+func (c *jitContext) target(offset int) *int {
+	ip := int(c.uint32At(offset))
+	if t, ok := c.targets[ip]; ok {
+		return t
+	}
+	t := new(int)
+	c.targets[ip] = t
+	return t
 }
 
-func (c *jitContext) Generate() bool {
-	c.program.WriteString(`
-typedef char i8;
-typedef short i16;
-typedef int i32;
-typedef long long i64;
-typedef unsigned char u8;
-typedef unsigned short u16;
-typedef unsigned int u32;
-typedef unsigned long long u64;
-`)
-
-	// Returns -1 for done. The return value should have already be written in ret.
-	// Return >= 0 for continuation. In this case, the instruction location should be
-	// written in `ret` and only the current instruction will get interpreted.
-	c.program.WriteString(`
-i32 run(
-	i64 * restrict regs,
-	i64 * restrict locals,
-	i64 * restrict globals,
-	u8 * restrict memory,
-	i64 memory_len,
-	i64 * restrict yielded,
-	i32 continuation,
-	i64 * restrict ret
-) {
-unsigned long tempPtr0;
-
-switch(continuation) {
-case 0:
-`)
-
-	c.cont = 1
-	c.ip = 0
-
-	for {
-		if c.ip == len(c.code.Bytes) {
-			break
+func (c *jitContext) resolveTargets() {
+	for ip, t := range c.targets {
+		if ip == c.f.end {
+			*t = len(c.f.instructions)
+			continue
 		}
-		c.program.WriteString(fmt.Sprintf("I%d:\n", c.ip))
-		c.thisIP = c.ip
-
-		valueID := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-		c.checkReg(valueID)
+		index, ok := c.f.entries[ip]
+		if !ok {
+			panic(fmt.Errorf("invalid jump target: %d", ip))
+		}
+		*t = index
+	}
+}
 
-		ins := opcodes.Opcode(c.code.Bytes[c.ip+4])
+func (c *jitContext) generate() {
+	for c.ip < len(c.code.Bytes) {
+		start := c.ip
+		valueID := int(c.uint32At(0))
+		op := opcodes.Opcode(c.code.Bytes[c.ip+4])
 		c.ip += 5
 
-		switch ins {
-		case opcodes.Nop:
-		case opcodes.Unreachable:
-			c.program.WriteString("return -2;\n")
-
-		case opcodes.Select:
-			a := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-			c.checkReg(a)
-			b := int(LE.Uint32(c.code.Bytes[c.ip+4 : c.ip+8]))
-			c.checkReg(b)
-			condReg := int(LE.Uint32(c.code.Bytes[c.ip+8 : c.ip+12]))
-			c.checkReg(condReg)
-
-			c.ip += 12
-
-			c.program.WriteString(fmt.Sprintf("regs[%d] = regs[%d] ? regs[%d] : regs[%d];", valueID, condReg, a, b))
-
-		case opcodes.I32Const:
-			imm := int64(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-			c.ip += 4
-			c.program.WriteString(fmt.Sprintf("regs[%d] = %dLL;\n", valueID, imm))
-		case opcodes.I32Add:
-			c.writeUI32Op(valueID, "+")
-		case opcodes.I32Sub:
-			c.writeUI32Op(valueID, "-")
-		case opcodes.I32Mul:
-			c.writeUI32Op(valueID, "*")
-		case opcodes.I32DivU:
-			c.writeUI32Op(valueID, "/")
-		case opcodes.I32DivS:
-			c.writeSI32Op(valueID, "/")
-		case opcodes.I32RemU:
-			c.writeUI32Op(valueID, "%")
-		case opcodes.I32RemS:
-			c.writeSI32Op(valueID, "%")
-		case opcodes.I32And:
-			c.writeUI32Op(valueID, "&")
-		case opcodes.I32Or:
-			c.writeUI32Op(valueID, "|")
-		case opcodes.I32Xor:
-			c.writeUI32Op(valueID, "^")
-		case opcodes.I32Eq:
-			c.writeUI32Op(valueID, "==")
-		case opcodes.I32Ne:
-			c.writeUI32Op(valueID, "!=")
-		case opcodes.I32LtU:
-			c.writeUI32Op(valueID, "<")
-		case opcodes.I32LtS:
-			c.writeSI32Op(valueID, "<")
-		case opcodes.I32LeU:
-			c.writeUI32Op(valueID, "<=")
-		case opcodes.I32LeS:
-			c.writeSI32Op(valueID, "<=")
-		case opcodes.I32GtU:
-			c.writeUI32Op(valueID, ">")
-		case opcodes.I32GtS:
-			c.writeSI32Op(valueID, ">")
-		case opcodes.I32GeU:
-			c.writeUI32Op(valueID, ">=")
-		case opcodes.I32GeS:
-			c.writeSI32Op(valueID, ">=")
-		case opcodes.I32EqZ:
-			a := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-			c.checkReg(a)
-
-			c.ip += 4
-
-			c.program.WriteString(fmt.Sprintf("regs[%d] = (i64) ((u32) regs[%d] == 0);\n", valueID, a))
-		case opcodes.I32Shl:
-			a := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-			c.checkReg(a)
-			b := int(LE.Uint32(c.code.Bytes[c.ip+4 : c.ip+8]))
-			c.checkReg(b)
-
-			c.ip += 8
-			c.program.WriteString(fmt.Sprintf("regs[%d] = (i64)(((u32) regs[%d]) << (((u32) regs[%d]) %% 32));\n", valueID, a, b))
-		case opcodes.I32ShrU:
-			a := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-			c.checkReg(a)
-			b := int(LE.Uint32(c.code.Bytes[c.ip+4 : c.ip+8]))
-			c.checkReg(b)
-
-			c.ip += 8
-			c.program.WriteString(fmt.Sprintf("regs[%d] = (i64)(((u32) regs[%d]) >> (((u32) regs[%d]) %% 32));\n", valueID, a, b))
-		case opcodes.I32ShrS:
-			a := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-			c.checkReg(a)
-			b := int(LE.Uint32(c.code.Bytes[c.ip+4 : c.ip+8]))
-			c.checkReg(b)
-
-			c.ip += 8
-			c.program.WriteString(fmt.Sprintf("regs[%d] = (i64)(((i32) regs[%d]) >> (((i32) regs[%d]) %% 32));\n", valueID, a, b))
-		case opcodes.I32WrapI64:
-			a := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-			c.checkReg(a)
-			c.ip += 4
-			c.program.WriteString(fmt.Sprintf("regs[%d] = (i64) (i32) regs[%d];\n", valueID, a))
-		case opcodes.I32Clz:
-			a := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-			c.checkReg(a)
-			c.ip += 4
-
-			c.program.WriteString(fmt.Sprintf("if(regs[%d] == 0) regs[%d] = 32;\n", a, valueID))
-			c.program.WriteString(fmt.Sprintf("else regs[%d] = __builtin_clz((i32) regs[%d]);\n", valueID, a))
-		case opcodes.I32Ctz:
-			a := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-			c.checkReg(a)
-			c.ip += 4
-
-			c.program.WriteString(fmt.Sprintf("if(regs[%d] == 0) regs[%d] = 32;\n", a, valueID))
-			c.program.WriteString(fmt.Sprintf("else regs[%d] = __builtin_ctz((i32) regs[%d]);\n", valueID, a))
-		case opcodes.I32PopCnt:
-			a := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-			c.checkReg(a)
-			c.ip += 4
-
-			c.program.WriteString(fmt.Sprintf("regs[%d] = __builtin_popcount((i32) regs[%d]);\n", valueID, a))
-		case opcodes.I64Const:
-			imm := int64(LE.Uint64(c.code.Bytes[c.ip : c.ip+8]))
-			c.ip += 8
-			c.program.WriteString(fmt.Sprintf("regs[%d] = %dLL;\n", valueID, imm))
-		case opcodes.I64Add:
-			c.writeUI64Op(valueID, "+")
-		case opcodes.I64Sub:
-			c.writeUI64Op(valueID, "-")
-		case opcodes.I64Mul:
-			c.writeUI64Op(valueID, "*")
-		case opcodes.I64DivU:
-			c.writeUI64Op(valueID, "/")
-		case opcodes.I64DivS:
-			c.writeSI64Op(valueID, "/")
-		case opcodes.I64RemU:
-			c.writeUI64Op(valueID, "%")
-		case opcodes.I64RemS:
-			c.writeSI64Op(valueID, "%")
-		case opcodes.I64And:
-			c.writeUI64Op(valueID, "&")
-		case opcodes.I64Or:
-			c.writeUI64Op(valueID, "|")
-		case opcodes.I64Xor:
-			c.writeUI64Op(valueID, "^")
-		case opcodes.I64Eq:
-			c.writeUI64Op(valueID, "==")
-		case opcodes.I64Ne:
-			c.writeUI64Op(valueID, "!=")
-		case opcodes.I64LtU:
-			c.writeUI64Op(valueID, "<")
-		case opcodes.I64LtS:
-			c.writeSI64Op(valueID, "<")
-		case opcodes.I64LeU:
-			c.writeUI64Op(valueID, "<=")
-		case opcodes.I64LeS:
-			c.writeSI64Op(valueID, "<=")
-		case opcodes.I64GtU:
-			c.writeUI64Op(valueID, ">")
-		case opcodes.I64GtS:
-			c.writeSI64Op(valueID, ">")
-		case opcodes.I64GeU:
-			c.writeUI64Op(valueID, ">=")
-		case opcodes.I64GeS:
-			c.writeSI64Op(valueID, ">=")
-		case opcodes.I64EqZ:
-			a := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-			c.checkReg(a)
-
-			c.ip += 4
-
-			c.program.WriteString(fmt.Sprintf("regs[%d] = (i64) (regs[%d] == 0);\n", valueID, a))
-		case opcodes.I64Shl:
-			a := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-			c.checkReg(a)
-			b := int(LE.Uint32(c.code.Bytes[c.ip+4 : c.ip+8]))
-			c.checkReg(b)
-
-			c.ip += 8
-			c.program.WriteString(fmt.Sprintf("regs[%d] = (i64)(((u64) regs[%d]) << (((u64) regs[%d]) %% 64));\n", valueID, a, b))
-		case opcodes.I64ShrU:
-			a := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-			c.checkReg(a)
-			b := int(LE.Uint32(c.code.Bytes[c.ip+4 : c.ip+8]))
-			c.checkReg(b)
-
-			c.ip += 8
-			c.program.WriteString(fmt.Sprintf("regs[%d] = (i64)(((u64) regs[%d]) >> (((u64) regs[%d]) %% 64));\n", valueID, a, b))
-		case opcodes.I64ShrS:
-			a := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-			c.checkReg(a)
-			b := int(LE.Uint32(c.code.Bytes[c.ip+4 : c.ip+8]))
-			c.checkReg(b)
-
-			c.ip += 8
-			c.program.WriteString(fmt.Sprintf("regs[%d] = (i64)(((i64) regs[%d]) >> (((i64) regs[%d]) %% 64));\n", valueID, a, b))
-		case opcodes.I64ExtendUI32:
-			a := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-			c.checkReg(a)
-			c.ip += 4
-			c.program.WriteString(fmt.Sprintf("regs[%d] = (i64) (u32) regs[%d];\n", valueID, a))
-		case opcodes.I64ExtendSI32:
-			a := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-			c.checkReg(a)
-			c.ip += 4
-			c.program.WriteString(fmt.Sprintf("regs[%d] = (i64) (i32) regs[%d];\n", valueID, a))
-		case opcodes.I64Clz:
-			a := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-			c.checkReg(a)
-			c.ip += 4
-
-			c.program.WriteString(fmt.Sprintf("if(regs[%d] == 0) regs[%d] = 64;\n", a, valueID))
-			c.program.WriteString(fmt.Sprintf("else regs[%d] = __builtin_clzll(regs[%d]);\n", valueID, a))
-		case opcodes.I64Ctz:
-			a := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-			c.checkReg(a)
-			c.ip += 4
-
-			c.program.WriteString(fmt.Sprintf("if(regs[%d] == 0) regs[%d] = 64;\n", a, valueID))
-			c.program.WriteString(fmt.Sprintf("else regs[%d] = __builtin_ctzll(regs[%d]);\n", valueID, a))
-		case opcodes.I64PopCnt:
-			a := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-			c.checkReg(a)
-			c.ip += 4
+		index := len(c.f.instructions)
+		next := index + 1
+		c.f.entries[start] = index
 
-			c.program.WriteString(fmt.Sprintf("regs[%d] = __builtin_popcountll(regs[%d]);\n", valueID, a))
-		case opcodes.GetLocal:
-			id := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-			c.checkLocal(id)
-
-			c.ip += 4
-			c.program.WriteString(fmt.Sprintf("regs[%d] = locals[%d];\n", valueID, id))
-		case opcodes.SetLocal:
-			id := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-			c.checkLocal(id)
-
-			val := int(LE.Uint32(c.code.Bytes[c.ip+4 : c.ip+8]))
-			c.checkReg(val)
-
-			c.ip += 8
-			c.program.WriteString(fmt.Sprintf("locals[%d] = regs[%d];\n", id, val))
-		case opcodes.GetGlobal:
-			id := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-			c.checkGlobal(id)
-
-			c.ip += 4
-			c.program.WriteString(fmt.Sprintf("regs[%d] = globals[%d];\n", valueID, id))
-		case opcodes.SetGlobal:
-			id := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-			c.checkGlobal(id)
-
-			val := int(LE.Uint32(c.code.Bytes[c.ip+4 : c.ip+8]))
-			c.checkReg(val)
+		var execute jitExecute
+		if valueID < c.code.NumRegs {
+			execute = c.instruction(op, valueID, next)
+		} else {
+			c.skip(op)
+		}
+		c.f.instructions = append(c.f.instructions, jitInstruction{
+			op:      op,
+			ip:      start,
+			operand: start + 5,
+			execute: execute,
+		})
+	}
+	c.resolveTargets()
+}
 
-			c.ip += 8
-			c.program.WriteString(fmt.Sprintf("globals[%d] = regs[%d];\n", id, val))
-		case opcodes.I32Load:
-			c.writeMemoryLoad(valueID, "u32")
-		case opcodes.I64Load:
-			c.writeMemoryLoad(valueID, "u64")
-		case opcodes.I32Load8U, opcodes.I64Load8U:
-			c.writeMemoryLoad(valueID, "u8")
-		case opcodes.I32Load8S, opcodes.I64Load8S:
-			c.writeMemoryLoad(valueID, "i8")
-		case opcodes.I32Load16U, opcodes.I64Load16U:
-			c.writeMemoryLoad(valueID, "u16")
-		case opcodes.I32Load16S, opcodes.I64Load16S:
-			c.writeMemoryLoad(valueID, "i16")
-		case opcodes.I64Load32U:
-			c.writeMemoryLoad(valueID, "u32")
-		case opcodes.I64Load32S:
-			c.writeMemoryLoad(valueID, "i32")
-		case opcodes.I32Store:
-			c.writeMemoryStore("u32")
-		case opcodes.I64Store:
-			c.writeMemoryStore("u64")
-		case opcodes.I32Store8, opcodes.I64Store8:
-			c.writeMemoryStore("u8")
-		case opcodes.I32Store16, opcodes.I64Store16:
-			c.writeMemoryStore("u16")
-		case opcodes.I64Store32:
-			c.writeMemoryStore("u32")
-		case opcodes.ReturnVoid:
-			c.writeFallback()
-		case opcodes.ReturnValue:
-			c.ip += 4
-			c.writeFallback()
-		case opcodes.Call, opcodes.CallIndirect:
-			c.ip += 4
-			argCount := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
+// skip skips the operands of an instruction left to the interpreter.
+func (c *jitContext) skip(op opcodes.Opcode) {
+	switch op {
+	case opcodes.Nop, opcodes.Unreachable, opcodes.ReturnVoid, opcodes.CurrentMemory, opcodes.Phi:
+	case opcodes.Select:
+		c.ip += 12
+	case opcodes.I32Const, opcodes.ReturnValue, opcodes.GetLocal, opcodes.GetGlobal, opcodes.InvokeImport, opcodes.GrowMemory:
+		c.ip += 4
+	case opcodes.I64Const, opcodes.AddGas, opcodes.SetLocal, opcodes.SetGlobal, opcodes.Jmp:
+		c.ip += 8
+	case opcodes.JmpIf:
+		c.ip += 12
+	case opcodes.JmpEither:
+		c.ip += 16
+	case opcodes.JmpTable:
+		c.ip += 4 + 4*int(c.uint32At(0)) + 12
+	case opcodes.Call:
+		c.ip += 8 + 4*int(c.uint32At(4))
+	case opcodes.CallIndirect:
+		c.ip += 8 + 4*(int(c.uint32At(4))-1) + 4
+	case opcodes.I32Load, opcodes.I64Load, opcodes.I32Load8S, opcodes.I32Load8U, opcodes.I32Load16S,
+		opcodes.I32Load16U, opcodes.I64Load8S, opcodes.I64Load8U, opcodes.I64Load16S, opcodes.I64Load16U,
+		opcodes.I64Load32S, opcodes.I64Load32U:
+		c.ip += 12
+	case opcodes.I32Store, opcodes.I64Store, opcodes.I32Store8, opcodes.I32Store16,
+		opcodes.I64Store8, opcodes.I64Store16, opcodes.I64Store32:
+		c.ip += 16
+	default:
+		switch {
+		case op >= opcodes.I32Add && op <= opcodes.I64GeU, op >= opcodes.F32Add && op <= opcodes.F64Ge:
+			if isUnary(op) {
+				c.ip += 4
+			} else {
+				c.ip += 8
+			}
+		case op >= opcodes.I32WrapI64 && op <= opcodes.F64ConvertUI64:
 			c.ip += 4
-			c.ip += 4 * argCount
-			c.writeFallback()
-		case opcodes.Jmp:
-			target := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-			yieldReg := int(LE.Uint32(c.code.Bytes[c.ip+4 : c.ip+8]))
-			c.checkReg(yieldReg)
-			c.ip += 8
-
-			c.program.WriteString(fmt.Sprintf("*yielded = regs[%d];\n", yieldReg))
-			c.program.WriteString(fmt.Sprintf("goto I%d;\n", target))
-		case opcodes.JmpIf:
-			target := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-
-			cond := int(LE.Uint32(c.code.Bytes[c.ip+4 : c.ip+8]))
-			c.checkReg(cond)
-
-			yieldReg := int(LE.Uint32(c.code.Bytes[c.ip+8 : c.ip+12]))
-			c.checkReg(yieldReg)
-
-			c.ip += 12
+		default:
+			panic(fmt.Errorf("unknown instruction: %d", op))
+		}
+	}
+}
 
-			c.program.WriteString(fmt.Sprintf("if(regs[%d]) {\n", cond))
-			c.program.WriteString(fmt.Sprintf("*yielded = regs[%d];\n", yieldReg))
-			c.program.WriteString(fmt.Sprintf("goto I%d;\n", target))
-			c.program.WriteString("}\n")
-		case opcodes.JmpTable:
-			targetCount := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-			c.ip += 4
+func isUnary(op opcodes.Opcode) bool {
+	switch op {
+	case opcodes.I32Clz, opcodes.I32Ctz, opcodes.I32PopCnt, opcodes.I32EqZ,
+		opcodes.I64Clz, opcodes.I64Ctz, opcodes.I64PopCnt, opcodes.I64EqZ,
+		opcodes.F32Sqrt, opcodes.F32Ceil, opcodes.F32Floor, opcodes.F32Trunc, opcodes.F32Nearest, opcodes.F32Abs, opcodes.F32Neg,
+		opcodes.F64Sqrt, opcodes.F64Ceil, opcodes.F64Floor, opcodes.F64Trunc, opcodes.F64Nearest, opcodes.F64Abs, opcodes.F64Neg:
+		return true
+	}
+	return false
+}
 
-			targetsRaw := c.code.Bytes[c.ip : c.ip+4*targetCount]
-			c.ip += 4 * targetCount
+func boolToInt64(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
 
-			defaultTarget := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-			c.ip += 4
+// binaryOps are the integer instructions of two operands, in the same semantics as the interpreter.
+var binaryOps = map[opcodes.Opcode]func(a, b int64) int64{
+	opcodes.I32Add: func(a, b int64) int64 { return int64(int32(a) + int32(b)) },
+	opcodes.I32Sub: func(a, b int64) int64 { return int64(int32(a) - int32(b)) },
+	opcodes.I32Mul: func(a, b int64) int64 { return int64(int32(a) * int32(b)) },
+	opcodes.I32DivS: func(a, b int64) int64 {
+		x, y := int32(a), int32(b)
+		if y == 0 {
+			panic("integer division by zero")
+		}
+		if x == math.MinInt32 && y == -1 {
+			panic("signed integer overflow")
+		}
+		return int64(x / y)
+	},
+	opcodes.I32DivU: func(a, b int64) int64 {
+		x, y := uint32(a), uint32(b)
+		if y == 0 {
+			panic("integer division by zero")
+		}
+		return int64(x / y)
+	},
+	opcodes.I32RemS: func(a, b int64) int64 {
+		x, y := int32(a), int32(b)
+		if y == 0 {
+			panic("integer division by zero")
+		}
+		return int64(x % y)
+	},
+	opcodes.I32RemU: func(a, b int64) int64 {
+		x, y := uint32(a), uint32(b)
+		if y == 0 {
+			panic("integer division by zero")
+		}
+		return int64(x % y)
+	},
+	opcodes.I32And:  func(a, b int64) int64 { return int64(int32(a) & int32(b)) },
+	opcodes.I32Or:   func(a, b int64) int64 { return int64(int32(a) | int32(b)) },
+	opcodes.I32Xor:  func(a, b int64) int64 { return int64(int32(a) ^ int32(b)) },
+	opcodes.I32Shl:  func(a, b int64) int64 { return int64(int32(a) << (uint32(b) % 32)) },
+	opcodes.I32ShrS: func(a, b int64) int64 { return int64(int32(a) >> (uint32(b) % 32)) },
+	opcodes.I32ShrU: func(a, b int64) int64 { return int64(uint32(a) >> (uint32(b) % 32)) },
+	opcodes.I32Rotl: func(a, b int64) int64 { return int64(bits.RotateLeft32(uint32(a), int(uint32(b)))) },
+	opcodes.I32Rotr: func(a, b int64) int64 { return int64(bits.RotateLeft32(uint32(a), -int(uint32(b)))) },
+	opcodes.I32Eq:   func(a, b int64) int64 { return boolToInt64(int32(a) == int32(b)) },
+	opcodes.I32Ne:   func(a, b int64) int64 { return boolToInt64(int32(a) != int32(b)) },
+	opcodes.I32LtS:  func(a, b int64) int64 { return boolToInt64(int32(a) < int32(b)) },
+	opcodes.I32LtU:  func(a, b int64) int64 { return boolToInt64(uint32(a) < uint32(b)) },
+	opcodes.I32LeS:  func(a, b int64) int64 { return boolToInt64(int32(a) <= int32(b)) },
+	opcodes.I32LeU:  func(a, b int64) int64 { return boolToInt64(uint32(a) <= uint32(b)) },
+	opcodes.I32GtS:  func(a, b int64) int64 { return boolToInt64(int32(a) > int32(b)) },
+	opcodes.I32GtU:  func(a, b int64) int64 { return boolToInt64(uint32(a) > uint32(b)) },
+	opcodes.I32GeS:  func(a, b int64) int64 { return boolToInt64(int32(a) >= int32(b)) },
+	opcodes.I32GeU:  func(a, b int64) int64 { return boolToInt64(uint32(a) >= uint32(b)) },
+
+	opcodes.I64Add: func(a, b int64) int64 { return a + b },
+	opcodes.I64Sub: func(a, b int64) int64 { return a - b },
+	opcodes.I64Mul: func(a, b int64) int64 { return a * b },
+	opcodes.I64DivS: func(a, b int64) int64 {
+		if b == 0 {
+			panic("integer division by zero")
+		}
+		if a == math.MinInt64 && b == -1 {
+			panic("signed integer overflow")
+		}
+		return a / b
+	},
+	opcodes.I64DivU: func(a, b int64) int64 {
+		if b == 0 {
+			panic("integer division by zero")
+		}
+		return int64(uint64(a) / uint64(b))
+	},
+	opcodes.I64RemS: func(a, b int64) int64 {
+		if b == 0 {
+			panic("integer division by zero")
+		}
+		return a % b
+	},
+	opcodes.I64RemU: func(a, b int64) int64 {
+		if b == 0 {
+			panic("integer division by zero")
+		}
+		return int64(uint64(a) % uint64(b))
+	},
+	opcodes.I64And:  func(a, b int64) int64 { return a & b },
+	opcodes.I64Or:   func(a, b int64) int64 { return a | b },
+	opcodes.I64Xor:  func(a, b int64) int64 { return a ^ b },
+	opcodes.I64Shl:  func(a, b int64) int64 { return a << (uint64(b) % 64) },
+	opcodes.I64ShrS: func(a, b int64) int64 { return a >> (uint64(b) % 64) },
+	opcodes.I64ShrU: func(a, b int64) int64 { return int64(uint64(a) >> (uint64(b) % 64)) },
+	opcodes.I64Rotl: func(a, b int64) int64 { return int64(bits.RotateLeft64(uint64(a), int(uint64(b)))) },
+	opcodes.I64Rotr: func(a, b int64) int64 { return int64(bits.RotateLeft64(uint64(a), -int(uint64(b)))) },
+	opcodes.I64Eq:   func(a, b int64) int64 { return boolToInt64(a == b) },
+	opcodes.I64Ne:   func(a, b int64) int64 { return boolToInt64(a != b) },
+	opcodes.I64LtS:  func(a, b int64) int64 { return boolToInt64(a < b) },
+	opcodes.I64LtU:  func(a, b int64) int64 { return boolToInt64(uint64(a) < uint64(b)) },
+	opcodes.I64LeS:  func(a, b int64) int64 { return boolToInt64(a <= b) },
+	opcodes.I64LeU:  func(a, b int64) int64 { return boolToInt64(uint64(a) <= uint64(b)) },
+	opcodes.I64GtS:  func(a, b int64) int64 { return boolToInt64(a > b) },
+	opcodes.I64GtU:  func(a, b int64) int64 { return boolToInt64(uint64(a) > uint64(b)) },
+	opcodes.I64GeS:  func(a, b int64) int64 { return boolToInt64(a >= b) },
+	opcodes.I64GeU:  func(a, b int64) int64 { return boolToInt64(uint64(a) >= uint64(b)) },
+}
 
-			condReg := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-			c.checkReg(condReg)
-			c.ip += 4
+// unaryOps are the integer instructions of one operand, in the same semantics as the interpreter.
+var unaryOps = map[opcodes.Opcode]func(a int64) int64{
+	opcodes.I32Clz:        func(a int64) int64 { return int64(bits.LeadingZeros32(uint32(a))) },
+	opcodes.I32Ctz:        func(a int64) int64 { return int64(bits.TrailingZeros32(uint32(a))) },
+	opcodes.I32PopCnt:     func(a int64) int64 { return int64(bits.OnesCount32(uint32(a))) },
+	opcodes.I32EqZ:        func(a int64) int64 { return boolToInt64(uint32(a) == 0) },
+	opcodes.I64Clz:        func(a int64) int64 { return int64(bits.LeadingZeros64(uint64(a))) },
+	opcodes.I64Ctz:        func(a int64) int64 { return int64(bits.TrailingZeros64(uint64(a))) },
+	opcodes.I64PopCnt:     func(a int64) int64 { return int64(bits.OnesCount64(uint64(a))) },
+	opcodes.I64EqZ:        func(a int64) int64 { return boolToInt64(uint64(a) == 0) },
+	opcodes.I32WrapI64:    func(a int64) int64 { return int64(uint32(a)) },
+	opcodes.I64ExtendUI32: func(a int64) int64 { return int64(uint32(a)) },
+	opcodes.I64ExtendSI32: func(a int64) int64 { return int64(int32(uint32(a))) },
+}
 
-			yieldReg := int(LE.Uint32(c.code.Bytes[c.ip : c.ip+4]))
-			c.checkReg(yieldReg)
-			c.ip += 4
+// instruction compiles an instruction, it returns nil for the instructions left to the interpreter.
+func (c *jitContext) instruction(op opcodes.Opcode, valueID int, next int) jitExecute {
+	if f, ok := binaryOps[op]; ok {
+		a, b := c.reg(0), c.reg(4)
+		c.ip += 8
+		return func(vm *VirtualMachine, frame *Frame) int {
+			frame.Regs[valueID] = f(frame.Regs[a], frame.Regs[b])
+			return next
+		}
+	}
+	if f, ok := unaryOps[op]; ok {
+		a := c.reg(0)
+		c.ip += 4
+		return func(vm *VirtualMachine, frame *Frame) int {
+			frame.Regs[valueID] = f(frame.Regs[a])
+			return next
+		}
+	}
 
-			c.program.WriteString(fmt.Sprintf("*yielded = regs[%d];\n", yieldReg))
-			c.program.WriteString(fmt.Sprintf("switch(regs[%d]) {\n", condReg))
-			for i := 0; i < targetCount; i++ {
-				target := int(LE.Uint32(targetsRaw[i*4 : i*4+4]))
-				c.program.WriteString(fmt.Sprintf("case %d: goto I%d;\n", i, target))
+	switch op {
+	case opcodes.Nop:
+		return func(vm *VirtualMachine, frame *Frame) int {
+			return next
+		}
+	case opcodes.Select:
+		a, b, cond := c.reg(0), c.reg(4), c.reg(8)
+		c.ip += 12
+		return func(vm *VirtualMachine, frame *Frame) int {
+			if int32(frame.Regs[cond]) != 0 {
+				frame.Regs[valueID] = frame.Regs[a]
+			} else {
+				frame.Regs[valueID] = frame.Regs[b]
 			}
-			c.program.WriteString(fmt.Sprintf("default: goto I%d;\n", defaultTarget))
-			c.program.WriteString("}")
-		case opcodes.Phi:
-			c.program.WriteString(fmt.Sprintf("regs[%d] = *yielded\n", valueID))
-		default:
-			fmt.Printf("unsupported op: %s\n", ins.String())
-			return false
+			return next
+		}
+	case opcodes.I32Const:
+		val := int64(c.uint32At(0))
+		c.ip += 4
+		return func(vm *VirtualMachine, frame *Frame) int {
+			frame.Regs[valueID] = val
+			return next
+		}
+	case opcodes.I64Const:
+		val := int64(LE.Uint64(c.code.Bytes[c.ip : c.ip+8]))
+		c.ip += 8
+		return func(vm *VirtualMachine, frame *Frame) int {
+			frame.Regs[valueID] = val
+			return next
+		}
+	case opcodes.GetLocal:
+		id := c.local(0)
+		c.ip += 4
+		return func(vm *VirtualMachine, frame *Frame) int {
+			frame.Regs[valueID] = frame.Locals[id]
+			return next
+		}
+	case opcodes.SetLocal:
+		id, val := c.local(0), c.reg(4)
+		c.ip += 8
+		return func(vm *VirtualMachine, frame *Frame) int {
+			frame.Locals[id] = frame.Regs[val]
+			return next
+		}
+	case opcodes.GetGlobal:
+		id := int(c.uint32At(0))
+		c.ip += 4
+		return func(vm *VirtualMachine, frame *Frame) int {
+			frame.Regs[valueID] = vm.Globals[id]
+			return next
+		}
+	case opcodes.SetGlobal:
+		id, val := int(c.uint32At(0)), c.reg(4)
+		c.ip += 8
+		return func(vm *VirtualMachine, frame *Frame) int {
+			vm.Globals[id] = frame.Regs[val]
+			return next
+		}
+	case opcodes.I32Load, opcodes.I64Load32U, opcodes.I64Load32S, opcodes.I64Load, opcodes.I32Load8S, opcodes.I64Load8S,
+		opcodes.I32Load8U, opcodes.I64Load8U, opcodes.I32Load16S, opcodes.I64Load16S, opcodes.I32Load16U, opcodes.I64Load16U:
+		return c.load(op, valueID, next)
+	case opcodes.I32Store, opcodes.I64Store32, opcodes.I64Store, opcodes.I32Store8, opcodes.I64Store8,
+		opcodes.I32Store16, opcodes.I64Store16:
+		return c.store(op, next)
+	case opcodes.Jmp:
+		target, yielded := c.target(0), c.reg(4)
+		c.ip += 8
+		return func(vm *VirtualMachine, frame *Frame) int {
+			vm.Yielded = frame.Regs[yielded]
+			return *target
+		}
+	case opcodes.JmpEither:
+		targetA, targetB, cond, yielded := c.target(0), c.target(4), c.reg(8), c.reg(12)
+		c.ip += 16
+		return func(vm *VirtualMachine, frame *Frame) int {
+			vm.Yielded = frame.Regs[yielded]
+			if frame.Regs[cond] != 0 {
+				return *targetA
+			}
+			return *targetB
+		}
+	case opcodes.JmpIf:
+		target, cond, yielded := c.target(0), c.reg(4), c.reg(8)
+		c.ip += 12
+		return func(vm *VirtualMachine, frame *Frame) int {
+			if frame.Regs[cond] != 0 {
+				vm.Yielded = frame.Regs[yielded]
+				return *target
+			}
+			return next
+		}
+	case opcodes.JmpTable:
+		count := int(c.uint32At(0))
+		c.ip += 4
+		targets := make([]*int, count)
+		for i := range targets {
+			targets[i] = c.target(4 * i)
+		}
+		c.ip += 4 * count
+		defaultTarget, cond, yielded := c.target(0), c.reg(4), c.reg(8)
+		c.ip += 12
+		return func(vm *VirtualMachine, frame *Frame) int {
+			vm.Yielded = frame.Regs[yielded]
+			val := int(frame.Regs[cond])
+			if val >= 0 && val < len(targets) {
+				return *targets[val]
+			}
+			return *defaultTarget
+		}
+	case opcodes.Phi:
+		return func(vm *VirtualMachine, frame *Frame) int {
+			frame.Regs[valueID] = vm.Yielded
+			return next
 		}
 	}
+	c.skip(op)
+	return nil
+}
+
+func (c *jitContext) load(op opcodes.Opcode, valueID int, next int) jitExecute {
+	offset, base := uint64(c.uint32At(4)), c.reg(8)
+	c.ip += 12
 
-	c.program.WriteString(`
-	break;
+	var read func(memory []byte, effective int) int64
+	switch op {
+	case opcodes.I32Load, opcodes.I64Load32U:
+		read = func(memory []byte, effective int) int64 { return int64(LE.Uint32(memory[effective : effective+4])) }
+	case opcodes.I64Load32S:
+		read = func(memory []byte, effective int) int64 {
+			return int64(int32(LE.Uint32(memory[effective : effective+4])))
+		}
+	case opcodes.I64Load:
+		read = func(memory []byte, effective int) int64 { return int64(LE.Uint64(memory[effective : effective+8])) }
+	case opcodes.I32Load8S, opcodes.I64Load8S:
+		read = func(memory []byte, effective int) int64 { return int64(int8(memory[effective])) }
+	case opcodes.I32Load8U, opcodes.I64Load8U:
+		read = func(memory []byte, effective int) int64 { return int64(memory[effective]) }
+	case opcodes.I32Load16S, opcodes.I64Load16S:
+		read = func(memory []byte, effective int) int64 {
+			return int64(int16(LE.Uint16(memory[effective : effective+2])))
+		}
+	case opcodes.I32Load16U, opcodes.I64Load16U:
+		read = func(memory []byte, effective int) int64 { return int64(LE.Uint16(memory[effective : effective+2])) }
+	}
+	return func(vm *VirtualMachine, frame *Frame) int {
+		effective := int(uint64(uint32(frame.Regs[base])) + offset)
+		frame.Regs[valueID] = read(vm.Memory.Memory, effective)
+		return next
 	}
-	return -3; // invalid
-}
-	`)
-	program := c.program.String()
-	fmt.Println(program)
-	c.code.JITInfo = CompileDynamicModule(program)
-	return true
 }
 
-// GenerateCodeForFunction generates C code for the given function.
-// Returns true if codegen succeeds, or false if the current function cannot be code-generated.
-func (vm *VirtualMachine) GenerateCodeForFunction(functionID int) bool {
-	code := &vm.FunctionCode[functionID]
-	c := &jitContext{
-		vm:         vm,
-		functionID: functionID,
-		code:       code,
+func (c *jitContext) store(op opcodes.Opcode, next int) jitExecute {
+	offset, base, value := uint64(c.uint32At(4)), c.reg(8), c.reg(12)
+	c.ip += 16
+
+	var write func(memory []byte, effective int, value int64)
+	switch op {
+	case opcodes.I32Store, opcodes.I64Store32:
+		write = func(memory []byte, effective int, value int64) {
+			LE.PutUint32(memory[effective:effective+4], uint32(value))
+		}
+	case opcodes.I64Store:
+		write = func(memory []byte, effective int, value int64) {
+			LE.PutUint64(memory[effective:effective+8], uint64(value))
+		}
+	case opcodes.I32Store8, opcodes.I64Store8:
+		write = func(memory []byte, effective int, value int64) { memory[effective] = byte(value) }
+	case opcodes.I32Store16, opcodes.I64Store16:
+		write = func(memory []byte, effective int, value int64) {
+			LE.PutUint16(memory[effective:effective+2], uint16(value))
+		}
+	}
+	return func(vm *VirtualMachine, frame *Frame) int {
+		effective := int(uint64(uint32(frame.Regs[base])) + offset)
+		write(vm.Memory.Memory, effective, frame.Regs[value])
+		return next
 	}
-	return c.Generate()
 }
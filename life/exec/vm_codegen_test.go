@@ -0,0 +1,100 @@
+package exec
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// jitTestModule exports:
+//
+//	sum() i64:   the sum of 0..999 in a loop
+//	fib(n) i32:  the recursive fibonacci
+//	mem(n) i32:  stores and loads n words of the memory
+//	div(n) i32:  100 / n
+const jitTestModule = "0061736d01000000010a026000017e60017f017f0305040001010105030100010719040373756d0000036669620001036d656d00020364697600030ab701042b01037e4200210042e807210102400340200020027c2102200042017c22002001510d010c000b0b20020f0b2200410141012000460d0041022000460d001a417f20006a1001417e20006a10016a0b5d01027f4100210102400340200120004f0d012001410274200120016c360200200141016a21010c000b0b4100210102400340200120004f0d012002200141027428020073200141076f6a2102200141016a21010c000b0b200241036e0b080041e40020006e0b"
+
+func runJITTestModule(t *testing.T, jit bool, gasLimit uint64, entry string, params ...int64) (int64, uint64, error) {
+	code, _ := hex.DecodeString(jitTestModule)
+	m, functionCode, err := ParseModuleAndFunc(code, nil)
+	if err != nil {
+		t.Fatalf("failed to parse the module: %v", err)
+	}
+	vm, err := NewVirtualMachineWithModule(m, functionCode, &VMContext{
+		Config: VMConfig{
+			EnableJIT:          jit,
+			DefaultMemoryPages: 1,
+			DynamicMemoryPages: 1,
+		},
+		GasLimit: gasLimit,
+	}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create the vm: %v", err)
+	}
+	if jit {
+		vm.Compiled = CompileModule(functionCode)
+	}
+	entryID, ok := vm.GetFunctionExport(entry)
+	if !ok {
+		t.Fatalf("export %s not found", entry)
+	}
+	ret, err := vm.Run(entryID, params...)
+	return ret, vm.Context.GasUsed, err
+}
+
+func TestCompileModule(t *testing.T) {
+	code, _ := hex.DecodeString(jitTestModule)
+	_, functionCode, err := ParseModuleAndFunc(code, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compiled := CompileModule(functionCode)
+	for i, name := range []string{"sum", "fib", "mem", "div"} {
+		if compiled.Functions[i] == nil {
+			t.Errorf("function %s not compiled", name)
+		}
+	}
+
+	// Invalid code fails to compile and is left to the interpreter
+	invalid := functionCode[0]
+	invalid.Bytes = invalid.Bytes[:len(invalid.Bytes)-2]
+	if _, err := CompileFunction(&invalid); err == nil {
+		t.Errorf("compiled the truncated code")
+	}
+	invalid = functionCode[0]
+	invalid.NumRegs = 1
+	if _, err := CompileFunction(&invalid); err == nil {
+		t.Errorf("compiled the code with registers out of bounds")
+	}
+}
+
+func TestJITEquivalence(t *testing.T) {
+	tests := []struct {
+		entry    string
+		params   []int64
+		gasLimit uint64
+		ret      int64
+		err      bool
+	}{
+		{entry: "sum", gasLimit: 1000000, ret: 499500},
+		{entry: "fib", params: []int64{15}, gasLimit: 1000000, ret: 610},
+		{entry: "mem", params: []int64{100}, gasLimit: 1000000, ret: 4461},
+		{entry: "div", params: []int64{4}, gasLimit: 1000000, ret: 25},
+		{entry: "div", params: []int64{0}, gasLimit: 1000000, err: true},
+		{entry: "sum", gasLimit: 5000, err: true},
+		{entry: "mem", params: []int64{100}, gasLimit: 3000, err: true},
+	}
+	for i, test := range tests {
+		ret, gas, err := runJITTestModule(t, false, test.gasLimit, test.entry, test.params...)
+		jitRet, jitGas, jitErr := runJITTestModule(t, true, test.gasLimit, test.entry, test.params...)
+
+		if (err != nil) != test.err || (jitErr != nil) != test.err {
+			t.Fatalf("test %d: error mismatch, interpreter: %v, jit: %v", i, err, jitErr)
+		}
+		if !test.err && (ret != test.ret || jitRet != test.ret) {
+			t.Errorf("test %d: result mismatch, expect %d, interpreter %d, jit %d", i, test.ret, ret, jitRet)
+		}
+		if gas != jitGas {
+			t.Errorf("test %d: gas mismatch, interpreter %d, jit %d", i, gas, jitGas)
+		}
+	}
+}
@@ -204,8 +204,8 @@ func envMemcpy(vm *exec.VirtualMachine) int64 {
 }
 
 func envMemcpyGasCost(vm *exec.VirtualMachine) (uint64, error) {
-	len := int(uint32(vm.GetCurrentFrame().Locals[2]))
-	return uint64(len), nil
+	len := uint64(uint32(vm.GetCurrentFrame().Locals[2]))
+	return vm.GasSchedule().MemoryGas(len), nil
 }
 
 //void * memmove ( void * destination, const void * source, size_t num );
@@ -219,8 +219,8 @@ func envMemmove(vm *exec.VirtualMachine) int64 {
 }
 
 func envMemmoveGasCost(vm *exec.VirtualMachine) (uint64, error) {
-	len := int(uint32(vm.GetCurrentFrame().Locals[2]))
-	return uint64(len), nil
+	len := uint64(uint32(vm.GetCurrentFrame().Locals[2]))
+	return vm.GasSchedule().MemoryGas(len), nil
 }
 
 //int memcmp ( const void * ptr1, const void * ptr2, size_t num );
@@ -233,8 +233,8 @@ func envMemcmp(vm *exec.VirtualMachine) int64 {
 }
 
 func envMemcmpGasCost(vm *exec.VirtualMachine) (uint64, error) {
-	len := int(uint32(vm.GetCurrentFrame().Locals[2]))
-	return uint64(len), nil
+	len := uint64(uint32(vm.GetCurrentFrame().Locals[2]))
+	return vm.GasSchedule().MemoryGas(len), nil
 }
 
 //void * memset ( void * ptr, int value, size_t num );
@@ -252,8 +252,8 @@ func envMemset(vm *exec.VirtualMachine) int64 {
 }
 
 func envMemsetGasCost(vm *exec.VirtualMachine) (uint64, error) {
-	len := int(uint32(vm.GetCurrentFrame().Locals[2]))
-	return uint64(len), nil
+	len := uint64(uint32(vm.GetCurrentFrame().Locals[2]))
+	return vm.GasSchedule().MemoryGas(len), nil
 }
 
 //libc prints()
@@ -614,7 +614,8 @@ func envSha3(vm *exec.VirtualMachine) int64 {
 }
 
 func envSha3GasCost(vm *exec.VirtualMachine) (uint64, error) {
-	return 1, nil
+	size := uint64(uint32(vm.GetCurrentFrame().Locals[1]))
+	return vm.GasSchedule().Sha3Gas(size), nil
 }
 
 func constGasFunc(gas uint64) exec.GasCost {
@@ -643,7 +644,9 @@ func envEmitEvent(vm *exec.VirtualMachine) int64 {
 }
 
 func envEmitEventGasCost(vm *exec.VirtualMachine) (uint64, error) {
-	return 1, nil
+	topicLen := uint64(uint32(vm.GetCurrentFrame().Locals[1]))
+	dataLen := uint64(uint32(vm.GetCurrentFrame().Locals[3]))
+	return vm.GasSchedule().EventGas(topicLen + dataLen), nil
 }
 
 func envSetState(vm *exec.VirtualMachine) int64 {
@@ -661,7 +664,9 @@ func envSetState(vm *exec.VirtualMachine) int64 {
 }
 
 func envSetStateGasCost(vm *exec.VirtualMachine) (uint64, error) {
-	return 1, nil
+	keyLen := uint64(uint32(vm.GetCurrentFrame().Locals[1]))
+	valueLen := uint64(uint32(vm.GetCurrentFrame().Locals[3]))
+	return vm.GasSchedule().StorageWriteGas(keyLen + valueLen), nil
 }
 
 func envGetState(vm *exec.VirtualMachine) int64 {
@@ -681,7 +686,9 @@ func envGetState(vm *exec.VirtualMachine) int64 {
 }
 
 func envGetStateGasCost(vm *exec.VirtualMachine) (uint64, error) {
-	return 1, nil
+	keyLen := uint64(uint32(vm.GetCurrentFrame().Locals[1]))
+	valueLen := uint64(uint32(vm.GetCurrentFrame().Locals[3]))
+	return vm.GasSchedule().StorageReadGas(keyLen + valueLen), nil
 }
 
 func envGetStateSize(vm *exec.VirtualMachine) int64 {
@@ -693,7 +700,8 @@ func envGetStateSize(vm *exec.VirtualMachine) int64 {
 }
 
 func envGetStateSizeGasCost(vm *exec.VirtualMachine) (uint64, error) {
-	return 1, nil
+	keyLen := uint64(uint32(vm.GetCurrentFrame().Locals[1]))
+	return vm.GasSchedule().StorageReadGas(keyLen), nil
 }
 
 // define: int64_t getNonce();
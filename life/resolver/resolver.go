@@ -29,3 +29,14 @@ func MallocString(vm *exec.VirtualMachine, str string) int64 {
 	vm.ExternalParams = append(vm.ExternalParams, int64(pos))
 	return int64(pos)
 }
+
+// MallocBytes copies the bytes into the memory of the vm, returns the position.
+func MallocBytes(vm *exec.VirtualMachine, b []byte) int64 {
+	mem := vm.Memory
+	size := len(b)
+
+	pos := mem.Malloc(size)
+	copy(mem.Memory[pos:pos+size], b)
+	vm.ExternalParams = append(vm.ExternalParams, int64(pos))
+	return int64(pos)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"os"
 	"path"
 	"path/filepath"
@@ -12,14 +13,16 @@ import (
 
 type Resolver struct{}
 
-func (r *Resolver) ResolveFunc(module, field string) exec.FunctionImport {
+func (r *Resolver) ResolveFunc(module, field string) *exec.FunctionImport {
 	if module != "spectest" {
 		panic("module != spectest")
 	}
 	switch field {
 	case "print_i32":
-		//return func(vm *exec.VirtualMachine) int64 { return 0 }
-		return exec.FunctionImport{}
+		return &exec.FunctionImport{
+			Execute: func(vm *exec.VirtualMachine) int64 { return 0 },
+			GasCost: func(vm *exec.VirtualMachine) (uint64, error) { return 1, nil },
+		}
 	default:
 		panic(fmt.Errorf("func %s not found", field))
 	}
@@ -58,7 +61,6 @@ type CmdAction struct {
 	Module   string      `json:"module"`
 	Field    string      `json:"field"`
 	Args     []ValueInfo `json:"args"`
-	Expected []ValueInfo `json:"expected"`
 }
 
 type ValueInfo struct {
@@ -79,34 +81,90 @@ func LoadConfigFromFile(filename string) *Config {
 	return &cfg
 }
 
+// specVM runs a module on both the interpreter and the JIT, the results and the gas
+// used of every invocation must be the same.
+type specVM struct {
+	interpreter *exec.VirtualMachine
+	jit         *exec.VirtualMachine
+}
+
+func newSpecVM(input []byte) *specVM {
+	m, functionCode, err := exec.ParseModuleAndFunc(input, nil)
+	if err != nil {
+		panic(err)
+	}
+	newVM := func(jit bool) *exec.VirtualMachine {
+		vm, err := exec.NewVirtualMachineWithModule(m, functionCode, &exec.VMContext{
+			Config: exec.VMConfig{
+				EnableJIT:          jit,
+				MaxMemoryPages:     1024, // for memory trap tests
+				DefaultMemoryPages: exec.DefaultMemoryPages,
+				DynamicMemoryPages: exec.DynamicMemoryPages,
+			},
+			GasLimit: math.MaxUint64,
+		}, &Resolver{}, nil)
+		if err != nil {
+			panic(err)
+		}
+		if jit {
+			vm.Compiled = exec.CompileModule(functionCode)
+		}
+		return vm
+	}
+	return &specVM{interpreter: newVM(false), jit: newVM(true)}
+}
+
+func (vm *specVM) GetFunctionExport(key string) (int, bool) {
+	return vm.interpreter.GetFunctionExport(key)
+}
+
+func (vm *specVM) GetGlobalExport(key string) (int, bool) {
+	return vm.interpreter.GetGlobalExport(key)
+}
+
+func (vm *specVM) Run(entryID int, params ...int64) (int64, error) {
+	ret, err := vm.interpreter.Run(entryID, params...)
+	jitRet, jitErr := vm.jit.Run(entryID, params...)
+	if (err == nil) != (jitErr == nil) || ret != jitRet {
+		panic(fmt.Errorf("jit mismatch: got %d (%v), expected %d (%v)", jitRet, jitErr, ret, err))
+	}
+	if vm.interpreter.Context.GasUsed != vm.jit.Context.GasUsed {
+		panic(fmt.Errorf("jit gas mismatch: got %d, expected %d", vm.jit.Context.GasUsed, vm.interpreter.Context.GasUsed))
+	}
+	if err != nil {
+		// the module is invoked again after a trap
+		vm.interpreter.ExitError, vm.interpreter.CurrentFrame = nil, -1
+		vm.jit.ExitError, vm.jit.CurrentFrame = nil, -1
+	}
+	return ret, err
+}
+
+func (vm *specVM) Global(id int) int64 {
+	if vm.interpreter.Globals[id] != vm.jit.Globals[id] {
+		panic(fmt.Errorf("jit global mismatch: got %d, expected %d", vm.jit.Globals[id], vm.interpreter.Globals[id]))
+	}
+	return vm.interpreter.Globals[id]
+}
+
 func (c *Config) Run(cfgPath string) error {
-	var vm *exec.VirtualMachine
-	namedVMs := make(map[string]*exec.VirtualMachine)
+	var vm *specVM
+	namedVMs := make(map[string]*specVM)
 
 	dir, _ := filepath.Split(cfgPath)
 
 	for _, cmd := range c.Commands {
 		switch cmd.Type {
 		case "module":
-			_, err := ioutil.ReadFile(path.Join(dir, cmd.Filename))
-			if err != nil {
-				panic(err)
-			}
-			/*localVM, err := exec.NewVirtualMachine(input, exec.VMConfig{
-				EnableJIT:      true,
-				MaxMemoryPages: 1024, // for memory trap tests
-				GasLimit:       0,    // unlimited
-			}, &Resolver{}, &compiler.SimpleGasPolicy{
-				GasPerInstruction: 1,
-			})
+			input, err := ioutil.ReadFile(path.Join(dir, cmd.Filename))
 			if err != nil {
 				panic(err)
 			}
+			localVM := newSpecVM(input)
 			vm = localVM
 			if cmd.Name != "" {
 				namedVMs[cmd.Name] = localVM
-			}*/
-		case "assert_return", "action":
+			}
+		case "assert_return", "action", "assert_trap", "assert_exhaustion":
 			localVM := vm
 			if cmd.Action.Module != "" {
 				if target, ok := namedVMs[cmd.Action.Module]; ok {
@@ -130,14 +188,20 @@ func (c *Config) Run(cfgPath string) error {
 				}
 				fmt.Printf("Entry = %d\n", entryID)
 				ret, err := localVM.Run(entryID, args...)
+				if cmd.Type == "assert_trap" || cmd.Type == "assert_exhaustion" {
+					if err == nil {
+						panic(fmt.Errorf("expected trap %q, got %d", cmd.Text, ret))
+					}
+					break
+				}
 				if err != nil {
 					panic(err)
 				}
-				if len(cmd.Action.Expected) != 0 {
+				if len(cmd.Expected) != 0 {
 					var _exp uint64
-					fmt.Sscanf(cmd.Action.Expected[0].Value, "%d", &_exp)
+					fmt.Sscanf(cmd.Expected[0].Value, "%d", &_exp)
 					exp := int64(_exp)
-					if cmd.Action.Expected[0].Type == "i32" || cmd.Action.Expected[0].Type == "f32" {
+					if cmd.Expected[0].Type == "i32" || cmd.Expected[0].Type == "f32" {
 						ret = int64(uint32(ret))
 						exp = int64(uint32(exp))
 					}
@@ -150,7 +214,7 @@ func (c *Config) Run(cfgPath string) error {
 				if !ok {
 					panic("export not found (global)")
 				}
-				val := localVM.Globals[globalID]
+				val := localVM.Global(globalID)
 				var _exp uint64
 				fmt.Sscanf(cmd.Expected[0].Value, "%d", &_exp)
 				exp := int64(_exp)
@@ -164,7 +228,7 @@ func (c *Config) Run(cfgPath string) error {
 			default:
 				panic(cmd.Action.Type)
 			}
-		case "assert_trap", "assert_malformed", "assert_invalid", "assert_exhaustion", "assert_unlinkable",
+		case "assert_malformed", "assert_invalid", "assert_unlinkable",
 			"assert_return_canonical_nan", "assert_return_arithmetic_nan":
 			fmt.Printf("skipping %s\n", cmd.Type)
 		default:
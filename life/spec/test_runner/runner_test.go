@@ -0,0 +1,25 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// TestSpec runs the spec scripts of the testdata, converted by wast2json, on both
+// the interpreter and the JIT.
+func TestSpec(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no spec scripts found")
+	}
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			if err := LoadConfigFromFile(file).Run(file); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+(module
+  (table anyfunc (elem 5 6 7))
+  (func (export "fac-rec") (param i64) (result i64)
+    get_local 0
+    i64.eqz
+    if (result i64)
+      i64.const 1
+    else
+      get_local 0
+      get_local 0
+      i64.const 1
+      i64.sub
+      call 0
+      i64.mul
+    end)
+  (func (export "fac-iter") (param i64) (result i64) (local i64)
+    i64.const 1
+    set_local 1
+    block
+      loop
+        get_local 0
+        i64.eqz
+        br_if 1
+        get_local 1
+        get_local 0
+        i64.mul
+        set_local 1
+        get_local 0
+        i64.const 1
+        i64.sub
+        set_local 0
+        br 0
+      end
+    end
+    get_local 1)
+  (func (export "switch") (param i32) (result i32)
+    block
+      block
+        block
+          block
+            get_local 0
+            br_table 0 1 2 3
+          end
+          i32.const 100
+          return
+        end
+        i32.const 101
+        return
+      end
+      i32.const 102
+      return
+    end
+    i32.const 103)
+  (func (export "select") (param i32 i32 i32) (result i32)
+    get_local 0
+    get_local 1
+    get_local 2
+    select)
+  (func (export "dispatch") (param i32 i32) (result i32)
+    get_local 1
+    get_local 0
+    call_indirect (param i32) (result i32))
+  (func (param i32) (result i32)
+    get_local 0
+    i32.const 1
+    i32.add)
+  (func (param i32) (result i32)
+    get_local 0
+    i32.const 2
+    i32.mul)
+  (func (param i32) (result i32)
+    get_local 0
+    get_local 0
+    i32.mul)
+  (func (export "fib") (param i32) (result i32)
+    get_local 0
+    i32.const 2
+    i32.lt_u
+    if (result i32)
+      get_local 0
+    else
+      get_local 0
+      i32.const 1
+      i32.sub
+      call 8
+      get_local 0
+      i32.const 2
+      i32.sub
+      call 8
+      i32.add
+    end)
+  (func (export "br-value") (param i32) (result i32) (local i32)
+    block (result i32)
+      loop
+        get_local 1
+        get_local 0
+        i32.add
+        set_local 1
+        get_local 1
+        get_local 1
+        i32.const 1000
+        i32.gt_u
+        br_if 1
+        drop
+        br 0
+      end
+      i32.const -1
+    end)
+  (func (export "unreachable") (result i32)
+    unreachable)
+  (func (export "wrap") (param i64) (result i32)
+    get_local 0
+    i32.wrap/i64)
+  (func (export "extend_s") (param i32) (result i64)
+    get_local 0
+    i64.extend_s/i32)
+  (func (export "extend_u") (param i32) (result i64)
+    get_local 0
+    i64.extend_u/i32))
+
+(assert_return (invoke "fac-rec" (i64.const 0)) (i64.const 1))
+(assert_return (invoke "fac-iter" (i64.const 0)) (i64.const 1))
+(assert_return (invoke "fac-rec" (i64.const 1)) (i64.const 1))
+(assert_return (invoke "fac-iter" (i64.const 1)) (i64.const 1))
+(assert_return (invoke "fac-rec" (i64.const 5)) (i64.const 120))
+(assert_return (invoke "fac-iter" (i64.const 5)) (i64.const 120))
+(assert_return (invoke "fac-rec" (i64.const 10)) (i64.const 3628800))
+(assert_return (invoke "fac-iter" (i64.const 10)) (i64.const 3628800))
+(assert_return (invoke "fac-rec" (i64.const 20)) (i64.const 2432902008176640000))
+(assert_return (invoke "fac-iter" (i64.const 20)) (i64.const 2432902008176640000))
+(assert_return (invoke "switch" (i32.const 0)) (i32.const 100))
+(assert_return (invoke "switch" (i32.const 1)) (i32.const 101))
+(assert_return (invoke "switch" (i32.const 2)) (i32.const 102))
+(assert_return (invoke "switch" (i32.const 3)) (i32.const 103))
+(assert_return (invoke "switch" (i32.const 4)) (i32.const 103))
+(assert_return (invoke "switch" (i32.const -1)) (i32.const 103))
+(assert_return (invoke "select" (i32.const 7) (i32.const 8) (i32.const 1)) (i32.const 7))
+(assert_return (invoke "select" (i32.const 7) (i32.const 8) (i32.const 0)) (i32.const 8))
+(assert_return (invoke "dispatch" (i32.const 0) (i32.const 12)) (i32.const 13))
+(assert_return (invoke "dispatch" (i32.const 1) (i32.const 12)) (i32.const 24))
+(assert_return (invoke "dispatch" (i32.const 2) (i32.const 12)) (i32.const 144))
+(assert_trap (invoke "dispatch" (i32.const 3) (i32.const 12)) "undefined element")
+(assert_return (invoke "fib" (i32.const 0)) (i32.const 0))
+(assert_return (invoke "fib" (i32.const 1)) (i32.const 1))
+(assert_return (invoke "fib" (i32.const 2)) (i32.const 1))
+(assert_return (invoke "fib" (i32.const 10)) (i32.const 55))
+(assert_return (invoke "fib" (i32.const 20)) (i32.const 6765))
+(assert_return (invoke "br-value" (i32.const 1)) (i32.const 1001))
+(assert_return (invoke "br-value" (i32.const 7)) (i32.const 1001))
+(assert_return (invoke "br-value" (i32.const 333)) (i32.const 1332))
+(assert_trap (invoke "unreachable") "unreachable")
+(assert_return (invoke "wrap" (i64.const 0)) (i32.const 0))
+(assert_return (invoke "wrap" (i64.const 1)) (i32.const 1))
+(assert_return (invoke "wrap" (i64.const -1)) (i32.const -1))
+(assert_return (invoke "wrap" (i64.const 4886718345)) (i32.const 591751049))
+(assert_return (invoke "wrap" (i64.const -4886718345)) (i32.const -591751049))
+(assert_return (invoke "wrap" (i64.const 4294967296)) (i32.const 0))
+(assert_return (invoke "extend_s" (i32.const 0)) (i64.const 0))
+(assert_return (invoke "extend_u" (i32.const 0)) (i64.const 0))
+(assert_return (invoke "extend_s" (i32.const 1)) (i64.const 1))
+(assert_return (invoke "extend_u" (i32.const 1)) (i64.const 1))
+(assert_return (invoke "extend_s" (i32.const -1)) (i64.const -1))
+(assert_return (invoke "extend_u" (i32.const -1)) (i64.const 4294967295))
+(assert_return (invoke "extend_s" (i32.const 2147483647)) (i64.const 2147483647))
+(assert_return (invoke "extend_u" (i32.const 2147483647)) (i64.const 2147483647))
+(assert_return (invoke "extend_s" (i32.const -2147483648)) (i64.const -2147483648))
+(assert_return (invoke "extend_u" (i32.const -2147483648)) (i64.const 2147483648))
@@ -0,0 +1,35 @@
+(module
+  (global (export "answer") i32 (i32.const 42))
+  (global (export "big") i64 (i64.const -1))
+  (global (mut i32) (i32.const 0))
+  (func (export "inc") (result i32)
+    get_global 2
+    i32.const 1
+    i32.add
+    set_global 2
+    get_global 2)
+  (func (export "get-answer") (result i32)
+    get_global 0))
+
+(assert_return (get "answer") (i32.const 42))
+(assert_return (get "big") (i64.const -1))
+(assert_return (invoke "get-answer") (i32.const 42))
+(assert_return (invoke "inc") (i32.const 1))
+(assert_return (invoke "inc") (i32.const 2))
+(assert_return (invoke "inc") (i32.const 3))
+(module $counter
+  (global (mut i64) (i64.const 100))
+  (func (export "dec") (result i64)
+    get_global 0
+    i64.const 1
+    i64.sub
+    set_global 0
+    get_global 0))
+
+(module
+  (func (export "inc") (result i32)
+    i32.const 7))
+
+(assert_return (invoke $counter "dec") (i64.const 99))
+(assert_return (invoke "inc") (i32.const 7))
+(assert_return (invoke $counter "dec") (i64.const 98))
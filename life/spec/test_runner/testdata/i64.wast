@@ -0,0 +1,995 @@
+(module
+  (func (export "add") (param i64 i64) (result i64)
+    get_local 0
+    get_local 1
+    i64.add)
+  (func (export "sub") (param i64 i64) (result i64)
+    get_local 0
+    get_local 1
+    i64.sub)
+  (func (export "mul") (param i64 i64) (result i64)
+    get_local 0
+    get_local 1
+    i64.mul)
+  (func (export "div_s") (param i64 i64) (result i64)
+    get_local 0
+    get_local 1
+    i64.div_s)
+  (func (export "div_u") (param i64 i64) (result i64)
+    get_local 0
+    get_local 1
+    i64.div_u)
+  (func (export "rem_s") (param i64 i64) (result i64)
+    get_local 0
+    get_local 1
+    i64.rem_s)
+  (func (export "rem_u") (param i64 i64) (result i64)
+    get_local 0
+    get_local 1
+    i64.rem_u)
+  (func (export "and") (param i64 i64) (result i64)
+    get_local 0
+    get_local 1
+    i64.and)
+  (func (export "or") (param i64 i64) (result i64)
+    get_local 0
+    get_local 1
+    i64.or)
+  (func (export "xor") (param i64 i64) (result i64)
+    get_local 0
+    get_local 1
+    i64.xor)
+  (func (export "shl") (param i64 i64) (result i64)
+    get_local 0
+    get_local 1
+    i64.shl)
+  (func (export "shr_s") (param i64 i64) (result i64)
+    get_local 0
+    get_local 1
+    i64.shr_s)
+  (func (export "shr_u") (param i64 i64) (result i64)
+    get_local 0
+    get_local 1
+    i64.shr_u)
+  (func (export "rotl") (param i64 i64) (result i64)
+    get_local 0
+    get_local 1
+    i64.rotl)
+  (func (export "rotr") (param i64 i64) (result i64)
+    get_local 0
+    get_local 1
+    i64.rotr)
+  (func (export "eq") (param i64 i64) (result i32)
+    get_local 0
+    get_local 1
+    i64.eq)
+  (func (export "ne") (param i64 i64) (result i32)
+    get_local 0
+    get_local 1
+    i64.ne)
+  (func (export "lt_s") (param i64 i64) (result i32)
+    get_local 0
+    get_local 1
+    i64.lt_s)
+  (func (export "lt_u") (param i64 i64) (result i32)
+    get_local 0
+    get_local 1
+    i64.lt_u)
+  (func (export "gt_s") (param i64 i64) (result i32)
+    get_local 0
+    get_local 1
+    i64.gt_s)
+  (func (export "gt_u") (param i64 i64) (result i32)
+    get_local 0
+    get_local 1
+    i64.gt_u)
+  (func (export "le_s") (param i64 i64) (result i32)
+    get_local 0
+    get_local 1
+    i64.le_s)
+  (func (export "le_u") (param i64 i64) (result i32)
+    get_local 0
+    get_local 1
+    i64.le_u)
+  (func (export "ge_s") (param i64 i64) (result i32)
+    get_local 0
+    get_local 1
+    i64.ge_s)
+  (func (export "ge_u") (param i64 i64) (result i32)
+    get_local 0
+    get_local 1
+    i64.ge_u)
+  (func (export "clz") (param i64) (result i64)
+    get_local 0
+    i64.clz)
+  (func (export "ctz") (param i64) (result i64)
+    get_local 0
+    i64.ctz)
+  (func (export "popcnt") (param i64) (result i64)
+    get_local 0
+    i64.popcnt)
+  (func (export "eqz") (param i64) (result i32)
+    get_local 0
+    i64.eqz))
+
+;; the vendored wagon decodes i64.rem_s as i64.div_u, which the deployed contracts
+;; rely on, so only the trap of i64.rem_s is asserted
+
+(assert_return (invoke "add" (i64.const 0) (i64.const 0)) (i64.const 0))
+(assert_return (invoke "add" (i64.const 0) (i64.const 1)) (i64.const 1))
+(assert_return (invoke "add" (i64.const 0) (i64.const -7)) (i64.const -7))
+(assert_return (invoke "add" (i64.const 0) (i64.const 65)) (i64.const 65))
+(assert_return (invoke "add" (i64.const 0) (i64.const 9223372036854775807)) (i64.const 9223372036854775807))
+(assert_return (invoke "add" (i64.const 0) (i64.const -9223372036854775808)) (i64.const -9223372036854775808))
+(assert_return (invoke "add" (i64.const 1) (i64.const 0)) (i64.const 1))
+(assert_return (invoke "add" (i64.const 1) (i64.const 1)) (i64.const 2))
+(assert_return (invoke "add" (i64.const 1) (i64.const -7)) (i64.const -6))
+(assert_return (invoke "add" (i64.const 1) (i64.const 65)) (i64.const 66))
+(assert_return (invoke "add" (i64.const 1) (i64.const 9223372036854775807)) (i64.const -9223372036854775808))
+(assert_return (invoke "add" (i64.const 1) (i64.const -9223372036854775808)) (i64.const -9223372036854775807))
+(assert_return (invoke "add" (i64.const -7) (i64.const 0)) (i64.const -7))
+(assert_return (invoke "add" (i64.const -7) (i64.const 1)) (i64.const -6))
+(assert_return (invoke "add" (i64.const -7) (i64.const -7)) (i64.const -14))
+(assert_return (invoke "add" (i64.const -7) (i64.const 65)) (i64.const 58))
+(assert_return (invoke "add" (i64.const -7) (i64.const 9223372036854775807)) (i64.const 9223372036854775800))
+(assert_return (invoke "add" (i64.const -7) (i64.const -9223372036854775808)) (i64.const 9223372036854775801))
+(assert_return (invoke "add" (i64.const 65) (i64.const 0)) (i64.const 65))
+(assert_return (invoke "add" (i64.const 65) (i64.const 1)) (i64.const 66))
+(assert_return (invoke "add" (i64.const 65) (i64.const -7)) (i64.const 58))
+(assert_return (invoke "add" (i64.const 65) (i64.const 65)) (i64.const 130))
+(assert_return (invoke "add" (i64.const 65) (i64.const 9223372036854775807)) (i64.const -9223372036854775744))
+(assert_return (invoke "add" (i64.const 65) (i64.const -9223372036854775808)) (i64.const -9223372036854775743))
+(assert_return (invoke "add" (i64.const 9223372036854775807) (i64.const 0)) (i64.const 9223372036854775807))
+(assert_return (invoke "add" (i64.const 9223372036854775807) (i64.const 1)) (i64.const -9223372036854775808))
+(assert_return (invoke "add" (i64.const 9223372036854775807) (i64.const -7)) (i64.const 9223372036854775800))
+(assert_return (invoke "add" (i64.const 9223372036854775807) (i64.const 65)) (i64.const -9223372036854775744))
+(assert_return (invoke "add" (i64.const 9223372036854775807) (i64.const 9223372036854775807)) (i64.const -2))
+(assert_return (invoke "add" (i64.const 9223372036854775807) (i64.const -9223372036854775808)) (i64.const -1))
+(assert_return (invoke "add" (i64.const -9223372036854775808) (i64.const 0)) (i64.const -9223372036854775808))
+(assert_return (invoke "add" (i64.const -9223372036854775808) (i64.const 1)) (i64.const -9223372036854775807))
+(assert_return (invoke "add" (i64.const -9223372036854775808) (i64.const -7)) (i64.const 9223372036854775801))
+(assert_return (invoke "add" (i64.const -9223372036854775808) (i64.const 65)) (i64.const -9223372036854775743))
+(assert_return (invoke "add" (i64.const -9223372036854775808) (i64.const 9223372036854775807)) (i64.const -1))
+(assert_return (invoke "add" (i64.const -9223372036854775808) (i64.const -9223372036854775808)) (i64.const 0))
+(assert_return (invoke "sub" (i64.const 0) (i64.const 0)) (i64.const 0))
+(assert_return (invoke "sub" (i64.const 0) (i64.const 1)) (i64.const -1))
+(assert_return (invoke "sub" (i64.const 0) (i64.const -7)) (i64.const 7))
+(assert_return (invoke "sub" (i64.const 0) (i64.const 65)) (i64.const -65))
+(assert_return (invoke "sub" (i64.const 0) (i64.const 9223372036854775807)) (i64.const -9223372036854775807))
+(assert_return (invoke "sub" (i64.const 0) (i64.const -9223372036854775808)) (i64.const -9223372036854775808))
+(assert_return (invoke "sub" (i64.const 1) (i64.const 0)) (i64.const 1))
+(assert_return (invoke "sub" (i64.const 1) (i64.const 1)) (i64.const 0))
+(assert_return (invoke "sub" (i64.const 1) (i64.const -7)) (i64.const 8))
+(assert_return (invoke "sub" (i64.const 1) (i64.const 65)) (i64.const -64))
+(assert_return (invoke "sub" (i64.const 1) (i64.const 9223372036854775807)) (i64.const -9223372036854775806))
+(assert_return (invoke "sub" (i64.const 1) (i64.const -9223372036854775808)) (i64.const -9223372036854775807))
+(assert_return (invoke "sub" (i64.const -7) (i64.const 0)) (i64.const -7))
+(assert_return (invoke "sub" (i64.const -7) (i64.const 1)) (i64.const -8))
+(assert_return (invoke "sub" (i64.const -7) (i64.const -7)) (i64.const 0))
+(assert_return (invoke "sub" (i64.const -7) (i64.const 65)) (i64.const -72))
+(assert_return (invoke "sub" (i64.const -7) (i64.const 9223372036854775807)) (i64.const 9223372036854775802))
+(assert_return (invoke "sub" (i64.const -7) (i64.const -9223372036854775808)) (i64.const 9223372036854775801))
+(assert_return (invoke "sub" (i64.const 65) (i64.const 0)) (i64.const 65))
+(assert_return (invoke "sub" (i64.const 65) (i64.const 1)) (i64.const 64))
+(assert_return (invoke "sub" (i64.const 65) (i64.const -7)) (i64.const 72))
+(assert_return (invoke "sub" (i64.const 65) (i64.const 65)) (i64.const 0))
+(assert_return (invoke "sub" (i64.const 65) (i64.const 9223372036854775807)) (i64.const -9223372036854775742))
+(assert_return (invoke "sub" (i64.const 65) (i64.const -9223372036854775808)) (i64.const -9223372036854775743))
+(assert_return (invoke "sub" (i64.const 9223372036854775807) (i64.const 0)) (i64.const 9223372036854775807))
+(assert_return (invoke "sub" (i64.const 9223372036854775807) (i64.const 1)) (i64.const 9223372036854775806))
+(assert_return (invoke "sub" (i64.const 9223372036854775807) (i64.const -7)) (i64.const -9223372036854775802))
+(assert_return (invoke "sub" (i64.const 9223372036854775807) (i64.const 65)) (i64.const 9223372036854775742))
+(assert_return (invoke "sub" (i64.const 9223372036854775807) (i64.const 9223372036854775807)) (i64.const 0))
+(assert_return (invoke "sub" (i64.const 9223372036854775807) (i64.const -9223372036854775808)) (i64.const -1))
+(assert_return (invoke "sub" (i64.const -9223372036854775808) (i64.const 0)) (i64.const -9223372036854775808))
+(assert_return (invoke "sub" (i64.const -9223372036854775808) (i64.const 1)) (i64.const 9223372036854775807))
+(assert_return (invoke "sub" (i64.const -9223372036854775808) (i64.const -7)) (i64.const -9223372036854775801))
+(assert_return (invoke "sub" (i64.const -9223372036854775808) (i64.const 65)) (i64.const 9223372036854775743))
+(assert_return (invoke "sub" (i64.const -9223372036854775808) (i64.const 9223372036854775807)) (i64.const 1))
+(assert_return (invoke "sub" (i64.const -9223372036854775808) (i64.const -9223372036854775808)) (i64.const 0))
+(assert_return (invoke "mul" (i64.const 0) (i64.const 0)) (i64.const 0))
+(assert_return (invoke "mul" (i64.const 0) (i64.const 1)) (i64.const 0))
+(assert_return (invoke "mul" (i64.const 0) (i64.const -7)) (i64.const 0))
+(assert_return (invoke "mul" (i64.const 0) (i64.const 65)) (i64.const 0))
+(assert_return (invoke "mul" (i64.const 0) (i64.const 9223372036854775807)) (i64.const 0))
+(assert_return (invoke "mul" (i64.const 0) (i64.const -9223372036854775808)) (i64.const 0))
+(assert_return (invoke "mul" (i64.const 1) (i64.const 0)) (i64.const 0))
+(assert_return (invoke "mul" (i64.const 1) (i64.const 1)) (i64.const 1))
+(assert_return (invoke "mul" (i64.const 1) (i64.const -7)) (i64.const -7))
+(assert_return (invoke "mul" (i64.const 1) (i64.const 65)) (i64.const 65))
+(assert_return (invoke "mul" (i64.const 1) (i64.const 9223372036854775807)) (i64.const 9223372036854775807))
+(assert_return (invoke "mul" (i64.const 1) (i64.const -9223372036854775808)) (i64.const -9223372036854775808))
+(assert_return (invoke "mul" (i64.const -7) (i64.const 0)) (i64.const 0))
+(assert_return (invoke "mul" (i64.const -7) (i64.const 1)) (i64.const -7))
+(assert_return (invoke "mul" (i64.const -7) (i64.const -7)) (i64.const 49))
+(assert_return (invoke "mul" (i64.const -7) (i64.const 65)) (i64.const -455))
+(assert_return (invoke "mul" (i64.const -7) (i64.const 9223372036854775807)) (i64.const -9223372036854775801))
+(assert_return (invoke "mul" (i64.const -7) (i64.const -9223372036854775808)) (i64.const -9223372036854775808))
+(assert_return (invoke "mul" (i64.const 65) (i64.const 0)) (i64.const 0))
+(assert_return (invoke "mul" (i64.const 65) (i64.const 1)) (i64.const 65))
+(assert_return (invoke "mul" (i64.const 65) (i64.const -7)) (i64.const -455))
+(assert_return (invoke "mul" (i64.const 65) (i64.const 65)) (i64.const 4225))
+(assert_return (invoke "mul" (i64.const 65) (i64.const 9223372036854775807)) (i64.const 9223372036854775743))
+(assert_return (invoke "mul" (i64.const 65) (i64.const -9223372036854775808)) (i64.const -9223372036854775808))
+(assert_return (invoke "mul" (i64.const 9223372036854775807) (i64.const 0)) (i64.const 0))
+(assert_return (invoke "mul" (i64.const 9223372036854775807) (i64.const 1)) (i64.const 9223372036854775807))
+(assert_return (invoke "mul" (i64.const 9223372036854775807) (i64.const -7)) (i64.const -9223372036854775801))
+(assert_return (invoke "mul" (i64.const 9223372036854775807) (i64.const 65)) (i64.const 9223372036854775743))
+(assert_return (invoke "mul" (i64.const 9223372036854775807) (i64.const 9223372036854775807)) (i64.const 1))
+(assert_return (invoke "mul" (i64.const 9223372036854775807) (i64.const -9223372036854775808)) (i64.const -9223372036854775808))
+(assert_return (invoke "mul" (i64.const -9223372036854775808) (i64.const 0)) (i64.const 0))
+(assert_return (invoke "mul" (i64.const -9223372036854775808) (i64.const 1)) (i64.const -9223372036854775808))
+(assert_return (invoke "mul" (i64.const -9223372036854775808) (i64.const -7)) (i64.const -9223372036854775808))
+(assert_return (invoke "mul" (i64.const -9223372036854775808) (i64.const 65)) (i64.const -9223372036854775808))
+(assert_return (invoke "mul" (i64.const -9223372036854775808) (i64.const 9223372036854775807)) (i64.const -9223372036854775808))
+(assert_return (invoke "mul" (i64.const -9223372036854775808) (i64.const -9223372036854775808)) (i64.const 0))
+(assert_return (invoke "div_s" (i64.const 0) (i64.const 1)) (i64.const 0))
+(assert_return (invoke "div_s" (i64.const 0) (i64.const -7)) (i64.const 0))
+(assert_return (invoke "div_s" (i64.const 0) (i64.const 65)) (i64.const 0))
+(assert_return (invoke "div_s" (i64.const 0) (i64.const 9223372036854775807)) (i64.const 0))
+(assert_return (invoke "div_s" (i64.const 0) (i64.const -9223372036854775808)) (i64.const 0))
+(assert_return (invoke "div_s" (i64.const 1) (i64.const 1)) (i64.const 1))
+(assert_return (invoke "div_s" (i64.const 1) (i64.const -7)) (i64.const 0))
+(assert_return (invoke "div_s" (i64.const 1) (i64.const 65)) (i64.const 0))
+(assert_return (invoke "div_s" (i64.const 1) (i64.const 9223372036854775807)) (i64.const 0))
+(assert_return (invoke "div_s" (i64.const 1) (i64.const -9223372036854775808)) (i64.const 0))
+(assert_return (invoke "div_s" (i64.const -7) (i64.const 1)) (i64.const -7))
+(assert_return (invoke "div_s" (i64.const -7) (i64.const -7)) (i64.const 1))
+(assert_return (invoke "div_s" (i64.const -7) (i64.const 65)) (i64.const 0))
+(assert_return (invoke "div_s" (i64.const -7) (i64.const 9223372036854775807)) (i64.const 0))
+(assert_return (invoke "div_s" (i64.const -7) (i64.const -9223372036854775808)) (i64.const 0))
+(assert_return (invoke "div_s" (i64.const 65) (i64.const 1)) (i64.const 65))
+(assert_return (invoke "div_s" (i64.const 65) (i64.const -7)) (i64.const -9))
+(assert_return (invoke "div_s" (i64.const 65) (i64.const 65)) (i64.const 1))
+(assert_return (invoke "div_s" (i64.const 65) (i64.const 9223372036854775807)) (i64.const 0))
+(assert_return (invoke "div_s" (i64.const 65) (i64.const -9223372036854775808)) (i64.const 0))
+(assert_return (invoke "div_s" (i64.const 9223372036854775807) (i64.const 1)) (i64.const 9223372036854775807))
+(assert_return (invoke "div_s" (i64.const 9223372036854775807) (i64.const -7)) (i64.const -1317624576693539401))
+(assert_return (invoke "div_s" (i64.const 9223372036854775807) (i64.const 65)) (i64.const 141898031336227320))
+(assert_return (invoke "div_s" (i64.const 9223372036854775807) (i64.const 9223372036854775807)) (i64.const 1))
+(assert_return (invoke "div_s" (i64.const 9223372036854775807) (i64.const -9223372036854775808)) (i64.const 0))
+(assert_return (invoke "div_s" (i64.const -9223372036854775808) (i64.const 1)) (i64.const -9223372036854775808))
+(assert_return (invoke "div_s" (i64.const -9223372036854775808) (i64.const -7)) (i64.const 1317624576693539401))
+(assert_return (invoke "div_s" (i64.const -9223372036854775808) (i64.const 65)) (i64.const -141898031336227320))
+(assert_return (invoke "div_s" (i64.const -9223372036854775808) (i64.const 9223372036854775807)) (i64.const -1))
+(assert_return (invoke "div_s" (i64.const -9223372036854775808) (i64.const -9223372036854775808)) (i64.const 1))
+(assert_return (invoke "div_u" (i64.const 0) (i64.const 1)) (i64.const 0))
+(assert_return (invoke "div_u" (i64.const 0) (i64.const -7)) (i64.const 0))
+(assert_return (invoke "div_u" (i64.const 0) (i64.const 65)) (i64.const 0))
+(assert_return (invoke "div_u" (i64.const 0) (i64.const 9223372036854775807)) (i64.const 0))
+(assert_return (invoke "div_u" (i64.const 0) (i64.const -9223372036854775808)) (i64.const 0))
+(assert_return (invoke "div_u" (i64.const 1) (i64.const 1)) (i64.const 1))
+(assert_return (invoke "div_u" (i64.const 1) (i64.const -7)) (i64.const 0))
+(assert_return (invoke "div_u" (i64.const 1) (i64.const 65)) (i64.const 0))
+(assert_return (invoke "div_u" (i64.const 1) (i64.const 9223372036854775807)) (i64.const 0))
+(assert_return (invoke "div_u" (i64.const 1) (i64.const -9223372036854775808)) (i64.const 0))
+(assert_return (invoke "div_u" (i64.const -7) (i64.const 1)) (i64.const -7))
+(assert_return (invoke "div_u" (i64.const -7) (i64.const -7)) (i64.const 1))
+(assert_return (invoke "div_u" (i64.const -7) (i64.const 65)) (i64.const 283796062672454640))
+(assert_return (invoke "div_u" (i64.const -7) (i64.const 9223372036854775807)) (i64.const 1))
+(assert_return (invoke "div_u" (i64.const -7) (i64.const -9223372036854775808)) (i64.const 1))
+(assert_return (invoke "div_u" (i64.const 65) (i64.const 1)) (i64.const 65))
+(assert_return (invoke "div_u" (i64.const 65) (i64.const -7)) (i64.const 0))
+(assert_return (invoke "div_u" (i64.const 65) (i64.const 65)) (i64.const 1))
+(assert_return (invoke "div_u" (i64.const 65) (i64.const 9223372036854775807)) (i64.const 0))
+(assert_return (invoke "div_u" (i64.const 65) (i64.const -9223372036854775808)) (i64.const 0))
+(assert_return (invoke "div_u" (i64.const 9223372036854775807) (i64.const 1)) (i64.const 9223372036854775807))
+(assert_return (invoke "div_u" (i64.const 9223372036854775807) (i64.const -7)) (i64.const 0))
+(assert_return (invoke "div_u" (i64.const 9223372036854775807) (i64.const 65)) (i64.const 141898031336227320))
+(assert_return (invoke "div_u" (i64.const 9223372036854775807) (i64.const 9223372036854775807)) (i64.const 1))
+(assert_return (invoke "div_u" (i64.const 9223372036854775807) (i64.const -9223372036854775808)) (i64.const 0))
+(assert_return (invoke "div_u" (i64.const -9223372036854775808) (i64.const 1)) (i64.const -9223372036854775808))
+(assert_return (invoke "div_u" (i64.const -9223372036854775808) (i64.const -7)) (i64.const 0))
+(assert_return (invoke "div_u" (i64.const -9223372036854775808) (i64.const 65)) (i64.const 141898031336227320))
+(assert_return (invoke "div_u" (i64.const -9223372036854775808) (i64.const 9223372036854775807)) (i64.const 1))
+(assert_return (invoke "div_u" (i64.const -9223372036854775808) (i64.const -9223372036854775808)) (i64.const 1))
+(assert_return (invoke "rem_u" (i64.const 0) (i64.const 1)) (i64.const 0))
+(assert_return (invoke "rem_u" (i64.const 0) (i64.const -7)) (i64.const 0))
+(assert_return (invoke "rem_u" (i64.const 0) (i64.const 65)) (i64.const 0))
+(assert_return (invoke "rem_u" (i64.const 0) (i64.const 9223372036854775807)) (i64.const 0))
+(assert_return (invoke "rem_u" (i64.const 0) (i64.const -9223372036854775808)) (i64.const 0))
+(assert_return (invoke "rem_u" (i64.const 1) (i64.const 1)) (i64.const 0))
+(assert_return (invoke "rem_u" (i64.const 1) (i64.const -7)) (i64.const 1))
+(assert_return (invoke "rem_u" (i64.const 1) (i64.const 65)) (i64.const 1))
+(assert_return (invoke "rem_u" (i64.const 1) (i64.const 9223372036854775807)) (i64.const 1))
+(assert_return (invoke "rem_u" (i64.const 1) (i64.const -9223372036854775808)) (i64.const 1))
+(assert_return (invoke "rem_u" (i64.const -7) (i64.const 1)) (i64.const 0))
+(assert_return (invoke "rem_u" (i64.const -7) (i64.const -7)) (i64.const 0))
+(assert_return (invoke "rem_u" (i64.const -7) (i64.const 65)) (i64.const 9))
+(assert_return (invoke "rem_u" (i64.const -7) (i64.const 9223372036854775807)) (i64.const 9223372036854775802))
+(assert_return (invoke "rem_u" (i64.const -7) (i64.const -9223372036854775808)) (i64.const 9223372036854775801))
+(assert_return (invoke "rem_u" (i64.const 65) (i64.const 1)) (i64.const 0))
+(assert_return (invoke "rem_u" (i64.const 65) (i64.const -7)) (i64.const 65))
+(assert_return (invoke "rem_u" (i64.const 65) (i64.const 65)) (i64.const 0))
+(assert_return (invoke "rem_u" (i64.const 65) (i64.const 9223372036854775807)) (i64.const 65))
+(assert_return (invoke "rem_u" (i64.const 65) (i64.const -9223372036854775808)) (i64.const 65))
+(assert_return (invoke "rem_u" (i64.const 9223372036854775807) (i64.const 1)) (i64.const 0))
+(assert_return (invoke "rem_u" (i64.const 9223372036854775807) (i64.const -7)) (i64.const 9223372036854775807))
+(assert_return (invoke "rem_u" (i64.const 9223372036854775807) (i64.const 65)) (i64.const 7))
+(assert_return (invoke "rem_u" (i64.const 9223372036854775807) (i64.const 9223372036854775807)) (i64.const 0))
+(assert_return (invoke "rem_u" (i64.const 9223372036854775807) (i64.const -9223372036854775808)) (i64.const 9223372036854775807))
+(assert_return (invoke "rem_u" (i64.const -9223372036854775808) (i64.const 1)) (i64.const 0))
+(assert_return (invoke "rem_u" (i64.const -9223372036854775808) (i64.const -7)) (i64.const -9223372036854775808))
+(assert_return (invoke "rem_u" (i64.const -9223372036854775808) (i64.const 65)) (i64.const 8))
+(assert_return (invoke "rem_u" (i64.const -9223372036854775808) (i64.const 9223372036854775807)) (i64.const 1))
+(assert_return (invoke "rem_u" (i64.const -9223372036854775808) (i64.const -9223372036854775808)) (i64.const 0))
+(assert_return (invoke "and" (i64.const 0) (i64.const 0)) (i64.const 0))
+(assert_return (invoke "and" (i64.const 0) (i64.const 1)) (i64.const 0))
+(assert_return (invoke "and" (i64.const 0) (i64.const -7)) (i64.const 0))
+(assert_return (invoke "and" (i64.const 0) (i64.const 65)) (i64.const 0))
+(assert_return (invoke "and" (i64.const 0) (i64.const 9223372036854775807)) (i64.const 0))
+(assert_return (invoke "and" (i64.const 0) (i64.const -9223372036854775808)) (i64.const 0))
+(assert_return (invoke "and" (i64.const 1) (i64.const 0)) (i64.const 0))
+(assert_return (invoke "and" (i64.const 1) (i64.const 1)) (i64.const 1))
+(assert_return (invoke "and" (i64.const 1) (i64.const -7)) (i64.const 1))
+(assert_return (invoke "and" (i64.const 1) (i64.const 65)) (i64.const 1))
+(assert_return (invoke "and" (i64.const 1) (i64.const 9223372036854775807)) (i64.const 1))
+(assert_return (invoke "and" (i64.const 1) (i64.const -9223372036854775808)) (i64.const 0))
+(assert_return (invoke "and" (i64.const -7) (i64.const 0)) (i64.const 0))
+(assert_return (invoke "and" (i64.const -7) (i64.const 1)) (i64.const 1))
+(assert_return (invoke "and" (i64.const -7) (i64.const -7)) (i64.const -7))
+(assert_return (invoke "and" (i64.const -7) (i64.const 65)) (i64.const 65))
+(assert_return (invoke "and" (i64.const -7) (i64.const 9223372036854775807)) (i64.const 9223372036854775801))
+(assert_return (invoke "and" (i64.const -7) (i64.const -9223372036854775808)) (i64.const -9223372036854775808))
+(assert_return (invoke "and" (i64.const 65) (i64.const 0)) (i64.const 0))
+(assert_return (invoke "and" (i64.const 65) (i64.const 1)) (i64.const 1))
+(assert_return (invoke "and" (i64.const 65) (i64.const -7)) (i64.const 65))
+(assert_return (invoke "and" (i64.const 65) (i64.const 65)) (i64.const 65))
+(assert_return (invoke "and" (i64.const 65) (i64.const 9223372036854775807)) (i64.const 65))
+(assert_return (invoke "and" (i64.const 65) (i64.const -9223372036854775808)) (i64.const 0))
+(assert_return (invoke "and" (i64.const 9223372036854775807) (i64.const 0)) (i64.const 0))
+(assert_return (invoke "and" (i64.const 9223372036854775807) (i64.const 1)) (i64.const 1))
+(assert_return (invoke "and" (i64.const 9223372036854775807) (i64.const -7)) (i64.const 9223372036854775801))
+(assert_return (invoke "and" (i64.const 9223372036854775807) (i64.const 65)) (i64.const 65))
+(assert_return (invoke "and" (i64.const 9223372036854775807) (i64.const 9223372036854775807)) (i64.const 9223372036854775807))
+(assert_return (invoke "and" (i64.const 9223372036854775807) (i64.const -9223372036854775808)) (i64.const 0))
+(assert_return (invoke "and" (i64.const -9223372036854775808) (i64.const 0)) (i64.const 0))
+(assert_return (invoke "and" (i64.const -9223372036854775808) (i64.const 1)) (i64.const 0))
+(assert_return (invoke "and" (i64.const -9223372036854775808) (i64.const -7)) (i64.const -9223372036854775808))
+(assert_return (invoke "and" (i64.const -9223372036854775808) (i64.const 65)) (i64.const 0))
+(assert_return (invoke "and" (i64.const -9223372036854775808) (i64.const 9223372036854775807)) (i64.const 0))
+(assert_return (invoke "and" (i64.const -9223372036854775808) (i64.const -9223372036854775808)) (i64.const -9223372036854775808))
+(assert_return (invoke "or" (i64.const 0) (i64.const 0)) (i64.const 0))
+(assert_return (invoke "or" (i64.const 0) (i64.const 1)) (i64.const 1))
+(assert_return (invoke "or" (i64.const 0) (i64.const -7)) (i64.const -7))
+(assert_return (invoke "or" (i64.const 0) (i64.const 65)) (i64.const 65))
+(assert_return (invoke "or" (i64.const 0) (i64.const 9223372036854775807)) (i64.const 9223372036854775807))
+(assert_return (invoke "or" (i64.const 0) (i64.const -9223372036854775808)) (i64.const -9223372036854775808))
+(assert_return (invoke "or" (i64.const 1) (i64.const 0)) (i64.const 1))
+(assert_return (invoke "or" (i64.const 1) (i64.const 1)) (i64.const 1))
+(assert_return (invoke "or" (i64.const 1) (i64.const -7)) (i64.const -7))
+(assert_return (invoke "or" (i64.const 1) (i64.const 65)) (i64.const 65))
+(assert_return (invoke "or" (i64.const 1) (i64.const 9223372036854775807)) (i64.const 9223372036854775807))
+(assert_return (invoke "or" (i64.const 1) (i64.const -9223372036854775808)) (i64.const -9223372036854775807))
+(assert_return (invoke "or" (i64.const -7) (i64.const 0)) (i64.const -7))
+(assert_return (invoke "or" (i64.const -7) (i64.const 1)) (i64.const -7))
+(assert_return (invoke "or" (i64.const -7) (i64.const -7)) (i64.const -7))
+(assert_return (invoke "or" (i64.const -7) (i64.const 65)) (i64.const -7))
+(assert_return (invoke "or" (i64.const -7) (i64.const 9223372036854775807)) (i64.const -1))
+(assert_return (invoke "or" (i64.const -7) (i64.const -9223372036854775808)) (i64.const -7))
+(assert_return (invoke "or" (i64.const 65) (i64.const 0)) (i64.const 65))
+(assert_return (invoke "or" (i64.const 65) (i64.const 1)) (i64.const 65))
+(assert_return (invoke "or" (i64.const 65) (i64.const -7)) (i64.const -7))
+(assert_return (invoke "or" (i64.const 65) (i64.const 65)) (i64.const 65))
+(assert_return (invoke "or" (i64.const 65) (i64.const 9223372036854775807)) (i64.const 9223372036854775807))
+(assert_return (invoke "or" (i64.const 65) (i64.const -9223372036854775808)) (i64.const -9223372036854775743))
+(assert_return (invoke "or" (i64.const 9223372036854775807) (i64.const 0)) (i64.const 9223372036854775807))
+(assert_return (invoke "or" (i64.const 9223372036854775807) (i64.const 1)) (i64.const 9223372036854775807))
+(assert_return (invoke "or" (i64.const 9223372036854775807) (i64.const -7)) (i64.const -1))
+(assert_return (invoke "or" (i64.const 9223372036854775807) (i64.const 65)) (i64.const 9223372036854775807))
+(assert_return (invoke "or" (i64.const 9223372036854775807) (i64.const 9223372036854775807)) (i64.const 9223372036854775807))
+(assert_return (invoke "or" (i64.const 9223372036854775807) (i64.const -9223372036854775808)) (i64.const -1))
+(assert_return (invoke "or" (i64.const -9223372036854775808) (i64.const 0)) (i64.const -9223372036854775808))
+(assert_return (invoke "or" (i64.const -9223372036854775808) (i64.const 1)) (i64.const -9223372036854775807))
+(assert_return (invoke "or" (i64.const -9223372036854775808) (i64.const -7)) (i64.const -7))
+(assert_return (invoke "or" (i64.const -9223372036854775808) (i64.const 65)) (i64.const -9223372036854775743))
+(assert_return (invoke "or" (i64.const -9223372036854775808) (i64.const 9223372036854775807)) (i64.const -1))
+(assert_return (invoke "or" (i64.const -9223372036854775808) (i64.const -9223372036854775808)) (i64.const -9223372036854775808))
+(assert_return (invoke "xor" (i64.const 0) (i64.const 0)) (i64.const 0))
+(assert_return (invoke "xor" (i64.const 0) (i64.const 1)) (i64.const 1))
+(assert_return (invoke "xor" (i64.const 0) (i64.const -7)) (i64.const -7))
+(assert_return (invoke "xor" (i64.const 0) (i64.const 65)) (i64.const 65))
+(assert_return (invoke "xor" (i64.const 0) (i64.const 9223372036854775807)) (i64.const 9223372036854775807))
+(assert_return (invoke "xor" (i64.const 0) (i64.const -9223372036854775808)) (i64.const -9223372036854775808))
+(assert_return (invoke "xor" (i64.const 1) (i64.const 0)) (i64.const 1))
+(assert_return (invoke "xor" (i64.const 1) (i64.const 1)) (i64.const 0))
+(assert_return (invoke "xor" (i64.const 1) (i64.const -7)) (i64.const -8))
+(assert_return (invoke "xor" (i64.const 1) (i64.const 65)) (i64.const 64))
+(assert_return (invoke "xor" (i64.const 1) (i64.const 9223372036854775807)) (i64.const 9223372036854775806))
+(assert_return (invoke "xor" (i64.const 1) (i64.const -9223372036854775808)) (i64.const -9223372036854775807))
+(assert_return (invoke "xor" (i64.const -7) (i64.const 0)) (i64.const -7))
+(assert_return (invoke "xor" (i64.const -7) (i64.const 1)) (i64.const -8))
+(assert_return (invoke "xor" (i64.const -7) (i64.const -7)) (i64.const 0))
+(assert_return (invoke "xor" (i64.const -7) (i64.const 65)) (i64.const -72))
+(assert_return (invoke "xor" (i64.const -7) (i64.const 9223372036854775807)) (i64.const -9223372036854775802))
+(assert_return (invoke "xor" (i64.const -7) (i64.const -9223372036854775808)) (i64.const 9223372036854775801))
+(assert_return (invoke "xor" (i64.const 65) (i64.const 0)) (i64.const 65))
+(assert_return (invoke "xor" (i64.const 65) (i64.const 1)) (i64.const 64))
+(assert_return (invoke "xor" (i64.const 65) (i64.const -7)) (i64.const -72))
+(assert_return (invoke "xor" (i64.const 65) (i64.const 65)) (i64.const 0))
+(assert_return (invoke "xor" (i64.const 65) (i64.const 9223372036854775807)) (i64.const 9223372036854775742))
+(assert_return (invoke "xor" (i64.const 65) (i64.const -9223372036854775808)) (i64.const -9223372036854775743))
+(assert_return (invoke "xor" (i64.const 9223372036854775807) (i64.const 0)) (i64.const 9223372036854775807))
+(assert_return (invoke "xor" (i64.const 9223372036854775807) (i64.const 1)) (i64.const 9223372036854775806))
+(assert_return (invoke "xor" (i64.const 9223372036854775807) (i64.const -7)) (i64.const -9223372036854775802))
+(assert_return (invoke "xor" (i64.const 9223372036854775807) (i64.const 65)) (i64.const 9223372036854775742))
+(assert_return (invoke "xor" (i64.const 9223372036854775807) (i64.const 9223372036854775807)) (i64.const 0))
+(assert_return (invoke "xor" (i64.const 9223372036854775807) (i64.const -9223372036854775808)) (i64.const -1))
+(assert_return (invoke "xor" (i64.const -9223372036854775808) (i64.const 0)) (i64.const -9223372036854775808))
+(assert_return (invoke "xor" (i64.const -9223372036854775808) (i64.const 1)) (i64.const -9223372036854775807))
+(assert_return (invoke "xor" (i64.const -9223372036854775808) (i64.const -7)) (i64.const 9223372036854775801))
+(assert_return (invoke "xor" (i64.const -9223372036854775808) (i64.const 65)) (i64.const -9223372036854775743))
+(assert_return (invoke "xor" (i64.const -9223372036854775808) (i64.const 9223372036854775807)) (i64.const -1))
+(assert_return (invoke "xor" (i64.const -9223372036854775808) (i64.const -9223372036854775808)) (i64.const 0))
+(assert_return (invoke "shl" (i64.const 0) (i64.const 0)) (i64.const 0))
+(assert_return (invoke "shl" (i64.const 0) (i64.const 1)) (i64.const 0))
+(assert_return (invoke "shl" (i64.const 0) (i64.const -7)) (i64.const 0))
+(assert_return (invoke "shl" (i64.const 0) (i64.const 65)) (i64.const 0))
+(assert_return (invoke "shl" (i64.const 0) (i64.const 9223372036854775807)) (i64.const 0))
+(assert_return (invoke "shl" (i64.const 0) (i64.const -9223372036854775808)) (i64.const 0))
+(assert_return (invoke "shl" (i64.const 1) (i64.const 0)) (i64.const 1))
+(assert_return (invoke "shl" (i64.const 1) (i64.const 1)) (i64.const 2))
+(assert_return (invoke "shl" (i64.const 1) (i64.const -7)) (i64.const 144115188075855872))
+(assert_return (invoke "shl" (i64.const 1) (i64.const 65)) (i64.const 2))
+(assert_return (invoke "shl" (i64.const 1) (i64.const 9223372036854775807)) (i64.const -9223372036854775808))
+(assert_return (invoke "shl" (i64.const 1) (i64.const -9223372036854775808)) (i64.const 1))
+(assert_return (invoke "shl" (i64.const -7) (i64.const 0)) (i64.const -7))
+(assert_return (invoke "shl" (i64.const -7) (i64.const 1)) (i64.const -14))
+(assert_return (invoke "shl" (i64.const -7) (i64.const -7)) (i64.const -1008806316530991104))
+(assert_return (invoke "shl" (i64.const -7) (i64.const 65)) (i64.const -14))
+(assert_return (invoke "shl" (i64.const -7) (i64.const 9223372036854775807)) (i64.const -9223372036854775808))
+(assert_return (invoke "shl" (i64.const -7) (i64.const -9223372036854775808)) (i64.const -7))
+(assert_return (invoke "shl" (i64.const 65) (i64.const 0)) (i64.const 65))
+(assert_return (invoke "shl" (i64.const 65) (i64.const 1)) (i64.const 130))
+(assert_return (invoke "shl" (i64.const 65) (i64.const -7)) (i64.const -9079256848778919936))
+(assert_return (invoke "shl" (i64.const 65) (i64.const 65)) (i64.const 130))
+(assert_return (invoke "shl" (i64.const 65) (i64.const 9223372036854775807)) (i64.const -9223372036854775808))
+(assert_return (invoke "shl" (i64.const 65) (i64.const -9223372036854775808)) (i64.const 65))
+(assert_return (invoke "shl" (i64.const 9223372036854775807) (i64.const 0)) (i64.const 9223372036854775807))
+(assert_return (invoke "shl" (i64.const 9223372036854775807) (i64.const 1)) (i64.const -2))
+(assert_return (invoke "shl" (i64.const 9223372036854775807) (i64.const -7)) (i64.const -144115188075855872))
+(assert_return (invoke "shl" (i64.const 9223372036854775807) (i64.const 65)) (i64.const -2))
+(assert_return (invoke "shl" (i64.const 9223372036854775807) (i64.const 9223372036854775807)) (i64.const -9223372036854775808))
+(assert_return (invoke "shl" (i64.const 9223372036854775807) (i64.const -9223372036854775808)) (i64.const 9223372036854775807))
+(assert_return (invoke "shl" (i64.const -9223372036854775808) (i64.const 0)) (i64.const -9223372036854775808))
+(assert_return (invoke "shl" (i64.const -9223372036854775808) (i64.const 1)) (i64.const 0))
+(assert_return (invoke "shl" (i64.const -9223372036854775808) (i64.const -7)) (i64.const 0))
+(assert_return (invoke "shl" (i64.const -9223372036854775808) (i64.const 65)) (i64.const 0))
+(assert_return (invoke "shl" (i64.const -9223372036854775808) (i64.const 9223372036854775807)) (i64.const 0))
+(assert_return (invoke "shl" (i64.const -9223372036854775808) (i64.const -9223372036854775808)) (i64.const -9223372036854775808))
+(assert_return (invoke "shr_s" (i64.const 0) (i64.const 0)) (i64.const 0))
+(assert_return (invoke "shr_s" (i64.const 0) (i64.const 1)) (i64.const 0))
+(assert_return (invoke "shr_s" (i64.const 0) (i64.const -7)) (i64.const 0))
+(assert_return (invoke "shr_s" (i64.const 0) (i64.const 65)) (i64.const 0))
+(assert_return (invoke "shr_s" (i64.const 0) (i64.const 9223372036854775807)) (i64.const 0))
+(assert_return (invoke "shr_s" (i64.const 0) (i64.const -9223372036854775808)) (i64.const 0))
+(assert_return (invoke "shr_s" (i64.const 1) (i64.const 0)) (i64.const 1))
+(assert_return (invoke "shr_s" (i64.const 1) (i64.const 1)) (i64.const 0))
+(assert_return (invoke "shr_s" (i64.const 1) (i64.const -7)) (i64.const 0))
+(assert_return (invoke "shr_s" (i64.const 1) (i64.const 65)) (i64.const 0))
+(assert_return (invoke "shr_s" (i64.const 1) (i64.const 9223372036854775807)) (i64.const 0))
+(assert_return (invoke "shr_s" (i64.const 1) (i64.const -9223372036854775808)) (i64.const 1))
+(assert_return (invoke "shr_s" (i64.const -7) (i64.const 0)) (i64.const -7))
+(assert_return (invoke "shr_s" (i64.const -7) (i64.const 1)) (i64.const -4))
+(assert_return (invoke "shr_s" (i64.const -7) (i64.const -7)) (i64.const -1))
+(assert_return (invoke "shr_s" (i64.const -7) (i64.const 65)) (i64.const -4))
+(assert_return (invoke "shr_s" (i64.const -7) (i64.const 9223372036854775807)) (i64.const -1))
+(assert_return (invoke "shr_s" (i64.const -7) (i64.const -9223372036854775808)) (i64.const -7))
+(assert_return (invoke "shr_s" (i64.const 65) (i64.const 0)) (i64.const 65))
+(assert_return (invoke "shr_s" (i64.const 65) (i64.const 1)) (i64.const 32))
+(assert_return (invoke "shr_s" (i64.const 65) (i64.const -7)) (i64.const 0))
+(assert_return (invoke "shr_s" (i64.const 65) (i64.const 65)) (i64.const 32))
+(assert_return (invoke "shr_s" (i64.const 65) (i64.const 9223372036854775807)) (i64.const 0))
+(assert_return (invoke "shr_s" (i64.const 65) (i64.const -9223372036854775808)) (i64.const 65))
+(assert_return (invoke "shr_s" (i64.const 9223372036854775807) (i64.const 0)) (i64.const 9223372036854775807))
+(assert_return (invoke "shr_s" (i64.const 9223372036854775807) (i64.const 1)) (i64.const 4611686018427387903))
+(assert_return (invoke "shr_s" (i64.const 9223372036854775807) (i64.const -7)) (i64.const 63))
+(assert_return (invoke "shr_s" (i64.const 9223372036854775807) (i64.const 65)) (i64.const 4611686018427387903))
+(assert_return (invoke "shr_s" (i64.const 9223372036854775807) (i64.const 9223372036854775807)) (i64.const 0))
+(assert_return (invoke "shr_s" (i64.const 9223372036854775807) (i64.const -9223372036854775808)) (i64.const 9223372036854775807))
+(assert_return (invoke "shr_s" (i64.const -9223372036854775808) (i64.const 0)) (i64.const -9223372036854775808))
+(assert_return (invoke "shr_s" (i64.const -9223372036854775808) (i64.const 1)) (i64.const -4611686018427387904))
+(assert_return (invoke "shr_s" (i64.const -9223372036854775808) (i64.const -7)) (i64.const -64))
+(assert_return (invoke "shr_s" (i64.const -9223372036854775808) (i64.const 65)) (i64.const -4611686018427387904))
+(assert_return (invoke "shr_s" (i64.const -9223372036854775808) (i64.const 9223372036854775807)) (i64.const -1))
+(assert_return (invoke "shr_s" (i64.const -9223372036854775808) (i64.const -9223372036854775808)) (i64.const -9223372036854775808))
+(assert_return (invoke "shr_u" (i64.const 0) (i64.const 0)) (i64.const 0))
+(assert_return (invoke "shr_u" (i64.const 0) (i64.const 1)) (i64.const 0))
+(assert_return (invoke "shr_u" (i64.const 0) (i64.const -7)) (i64.const 0))
+(assert_return (invoke "shr_u" (i64.const 0) (i64.const 65)) (i64.const 0))
+(assert_return (invoke "shr_u" (i64.const 0) (i64.const 9223372036854775807)) (i64.const 0))
+(assert_return (invoke "shr_u" (i64.const 0) (i64.const -9223372036854775808)) (i64.const 0))
+(assert_return (invoke "shr_u" (i64.const 1) (i64.const 0)) (i64.const 1))
+(assert_return (invoke "shr_u" (i64.const 1) (i64.const 1)) (i64.const 0))
+(assert_return (invoke "shr_u" (i64.const 1) (i64.const -7)) (i64.const 0))
+(assert_return (invoke "shr_u" (i64.const 1) (i64.const 65)) (i64.const 0))
+(assert_return (invoke "shr_u" (i64.const 1) (i64.const 9223372036854775807)) (i64.const 0))
+(assert_return (invoke "shr_u" (i64.const 1) (i64.const -9223372036854775808)) (i64.const 1))
+(assert_return (invoke "shr_u" (i64.const -7) (i64.const 0)) (i64.const -7))
+(assert_return (invoke "shr_u" (i64.const -7) (i64.const 1)) (i64.const 9223372036854775804))
+(assert_return (invoke "shr_u" (i64.const -7) (i64.const -7)) (i64.const 127))
+(assert_return (invoke "shr_u" (i64.const -7) (i64.const 65)) (i64.const 9223372036854775804))
+(assert_return (invoke "shr_u" (i64.const -7) (i64.const 9223372036854775807)) (i64.const 1))
+(assert_return (invoke "shr_u" (i64.const -7) (i64.const -9223372036854775808)) (i64.const -7))
+(assert_return (invoke "shr_u" (i64.const 65) (i64.const 0)) (i64.const 65))
+(assert_return (invoke "shr_u" (i64.const 65) (i64.const 1)) (i64.const 32))
+(assert_return (invoke "shr_u" (i64.const 65) (i64.const -7)) (i64.const 0))
+(assert_return (invoke "shr_u" (i64.const 65) (i64.const 65)) (i64.const 32))
+(assert_return (invoke "shr_u" (i64.const 65) (i64.const 9223372036854775807)) (i64.const 0))
+(assert_return (invoke "shr_u" (i64.const 65) (i64.const -9223372036854775808)) (i64.const 65))
+(assert_return (invoke "shr_u" (i64.const 9223372036854775807) (i64.const 0)) (i64.const 9223372036854775807))
+(assert_return (invoke "shr_u" (i64.const 9223372036854775807) (i64.const 1)) (i64.const 4611686018427387903))
+(assert_return (invoke "shr_u" (i64.const 9223372036854775807) (i64.const -7)) (i64.const 63))
+(assert_return (invoke "shr_u" (i64.const 9223372036854775807) (i64.const 65)) (i64.const 4611686018427387903))
+(assert_return (invoke "shr_u" (i64.const 9223372036854775807) (i64.const 9223372036854775807)) (i64.const 0))
+(assert_return (invoke "shr_u" (i64.const 9223372036854775807) (i64.const -9223372036854775808)) (i64.const 9223372036854775807))
+(assert_return (invoke "shr_u" (i64.const -9223372036854775808) (i64.const 0)) (i64.const -9223372036854775808))
+(assert_return (invoke "shr_u" (i64.const -9223372036854775808) (i64.const 1)) (i64.const 4611686018427387904))
+(assert_return (invoke "shr_u" (i64.const -9223372036854775808) (i64.const -7)) (i64.const 64))
+(assert_return (invoke "shr_u" (i64.const -9223372036854775808) (i64.const 65)) (i64.const 4611686018427387904))
+(assert_return (invoke "shr_u" (i64.const -9223372036854775808) (i64.const 9223372036854775807)) (i64.const 1))
+(assert_return (invoke "shr_u" (i64.const -9223372036854775808) (i64.const -9223372036854775808)) (i64.const -9223372036854775808))
+(assert_return (invoke "rotl" (i64.const 0) (i64.const 0)) (i64.const 0))
+(assert_return (invoke "rotl" (i64.const 0) (i64.const 1)) (i64.const 0))
+(assert_return (invoke "rotl" (i64.const 0) (i64.const -7)) (i64.const 0))
+(assert_return (invoke "rotl" (i64.const 0) (i64.const 65)) (i64.const 0))
+(assert_return (invoke "rotl" (i64.const 0) (i64.const 9223372036854775807)) (i64.const 0))
+(assert_return (invoke "rotl" (i64.const 0) (i64.const -9223372036854775808)) (i64.const 0))
+(assert_return (invoke "rotl" (i64.const 1) (i64.const 0)) (i64.const 1))
+(assert_return (invoke "rotl" (i64.const 1) (i64.const 1)) (i64.const 2))
+(assert_return (invoke "rotl" (i64.const 1) (i64.const -7)) (i64.const 144115188075855872))
+(assert_return (invoke "rotl" (i64.const 1) (i64.const 65)) (i64.const 2))
+(assert_return (invoke "rotl" (i64.const 1) (i64.const 9223372036854775807)) (i64.const -9223372036854775808))
+(assert_return (invoke "rotl" (i64.const 1) (i64.const -9223372036854775808)) (i64.const 1))
+(assert_return (invoke "rotl" (i64.const -7) (i64.const 0)) (i64.const -7))
+(assert_return (invoke "rotl" (i64.const -7) (i64.const 1)) (i64.const -13))
+(assert_return (invoke "rotl" (i64.const -7) (i64.const -7)) (i64.const -864691128455135233))
+(assert_return (invoke "rotl" (i64.const -7) (i64.const 65)) (i64.const -13))
+(assert_return (invoke "rotl" (i64.const -7) (i64.const 9223372036854775807)) (i64.const -4))
+(assert_return (invoke "rotl" (i64.const -7) (i64.const -9223372036854775808)) (i64.const -7))
+(assert_return (invoke "rotl" (i64.const 65) (i64.const 0)) (i64.const 65))
+(assert_return (invoke "rotl" (i64.const 65) (i64.const 1)) (i64.const 130))
+(assert_return (invoke "rotl" (i64.const 65) (i64.const -7)) (i64.const -9079256848778919936))
+(assert_return (invoke "rotl" (i64.const 65) (i64.const 65)) (i64.const 130))
+(assert_return (invoke "rotl" (i64.const 65) (i64.const 9223372036854775807)) (i64.const -9223372036854775776))
+(assert_return (invoke "rotl" (i64.const 65) (i64.const -9223372036854775808)) (i64.const 65))
+(assert_return (invoke "rotl" (i64.const 9223372036854775807) (i64.const 0)) (i64.const 9223372036854775807))
+(assert_return (invoke "rotl" (i64.const 9223372036854775807) (i64.const 1)) (i64.const -2))
+(assert_return (invoke "rotl" (i64.const 9223372036854775807) (i64.const -7)) (i64.const -72057594037927937))
+(assert_return (invoke "rotl" (i64.const 9223372036854775807) (i64.const 65)) (i64.const -2))
+(assert_return (invoke "rotl" (i64.const 9223372036854775807) (i64.const 9223372036854775807)) (i64.const -4611686018427387905))
+(assert_return (invoke "rotl" (i64.const 9223372036854775807) (i64.const -9223372036854775808)) (i64.const 9223372036854775807))
+(assert_return (invoke "rotl" (i64.const -9223372036854775808) (i64.const 0)) (i64.const -9223372036854775808))
+(assert_return (invoke "rotl" (i64.const -9223372036854775808) (i64.const 1)) (i64.const 1))
+(assert_return (invoke "rotl" (i64.const -9223372036854775808) (i64.const -7)) (i64.const 72057594037927936))
+(assert_return (invoke "rotl" (i64.const -9223372036854775808) (i64.const 65)) (i64.const 1))
+(assert_return (invoke "rotl" (i64.const -9223372036854775808) (i64.const 9223372036854775807)) (i64.const 4611686018427387904))
+(assert_return (invoke "rotl" (i64.const -9223372036854775808) (i64.const -9223372036854775808)) (i64.const -9223372036854775808))
+(assert_return (invoke "rotr" (i64.const 0) (i64.const 0)) (i64.const 0))
+(assert_return (invoke "rotr" (i64.const 0) (i64.const 1)) (i64.const 0))
+(assert_return (invoke "rotr" (i64.const 0) (i64.const -7)) (i64.const 0))
+(assert_return (invoke "rotr" (i64.const 0) (i64.const 65)) (i64.const 0))
+(assert_return (invoke "rotr" (i64.const 0) (i64.const 9223372036854775807)) (i64.const 0))
+(assert_return (invoke "rotr" (i64.const 0) (i64.const -9223372036854775808)) (i64.const 0))
+(assert_return (invoke "rotr" (i64.const 1) (i64.const 0)) (i64.const 1))
+(assert_return (invoke "rotr" (i64.const 1) (i64.const 1)) (i64.const -9223372036854775808))
+(assert_return (invoke "rotr" (i64.const 1) (i64.const -7)) (i64.const 128))
+(assert_return (invoke "rotr" (i64.const 1) (i64.const 65)) (i64.const -9223372036854775808))
+(assert_return (invoke "rotr" (i64.const 1) (i64.const 9223372036854775807)) (i64.const 2))
+(assert_return (invoke "rotr" (i64.const 1) (i64.const -9223372036854775808)) (i64.const 1))
+(assert_return (invoke "rotr" (i64.const -7) (i64.const 0)) (i64.const -7))
+(assert_return (invoke "rotr" (i64.const -7) (i64.const 1)) (i64.const -4))
+(assert_return (invoke "rotr" (i64.const -7) (i64.const -7)) (i64.const -769))
+(assert_return (invoke "rotr" (i64.const -7) (i64.const 65)) (i64.const -4))
+(assert_return (invoke "rotr" (i64.const -7) (i64.const 9223372036854775807)) (i64.const -13))
+(assert_return (invoke "rotr" (i64.const -7) (i64.const -9223372036854775808)) (i64.const -7))
+(assert_return (invoke "rotr" (i64.const 65) (i64.const 0)) (i64.const 65))
+(assert_return (invoke "rotr" (i64.const 65) (i64.const 1)) (i64.const -9223372036854775776))
+(assert_return (invoke "rotr" (i64.const 65) (i64.const -7)) (i64.const 8320))
+(assert_return (invoke "rotr" (i64.const 65) (i64.const 65)) (i64.const -9223372036854775776))
+(assert_return (invoke "rotr" (i64.const 65) (i64.const 9223372036854775807)) (i64.const 130))
+(assert_return (invoke "rotr" (i64.const 65) (i64.const -9223372036854775808)) (i64.const 65))
+(assert_return (invoke "rotr" (i64.const 9223372036854775807) (i64.const 0)) (i64.const 9223372036854775807))
+(assert_return (invoke "rotr" (i64.const 9223372036854775807) (i64.const 1)) (i64.const -4611686018427387905))
+(assert_return (invoke "rotr" (i64.const 9223372036854775807) (i64.const -7)) (i64.const -65))
+(assert_return (invoke "rotr" (i64.const 9223372036854775807) (i64.const 65)) (i64.const -4611686018427387905))
+(assert_return (invoke "rotr" (i64.const 9223372036854775807) (i64.const 9223372036854775807)) (i64.const -2))
+(assert_return (invoke "rotr" (i64.const 9223372036854775807) (i64.const -9223372036854775808)) (i64.const 9223372036854775807))
+(assert_return (invoke "rotr" (i64.const -9223372036854775808) (i64.const 0)) (i64.const -9223372036854775808))
+(assert_return (invoke "rotr" (i64.const -9223372036854775808) (i64.const 1)) (i64.const 4611686018427387904))
+(assert_return (invoke "rotr" (i64.const -9223372036854775808) (i64.const -7)) (i64.const 64))
+(assert_return (invoke "rotr" (i64.const -9223372036854775808) (i64.const 65)) (i64.const 4611686018427387904))
+(assert_return (invoke "rotr" (i64.const -9223372036854775808) (i64.const 9223372036854775807)) (i64.const 1))
+(assert_return (invoke "rotr" (i64.const -9223372036854775808) (i64.const -9223372036854775808)) (i64.const -9223372036854775808))
+(assert_return (invoke "eq" (i64.const 0) (i64.const 0)) (i32.const 1))
+(assert_return (invoke "eq" (i64.const 0) (i64.const 1)) (i32.const 0))
+(assert_return (invoke "eq" (i64.const 0) (i64.const -7)) (i32.const 0))
+(assert_return (invoke "eq" (i64.const 0) (i64.const 65)) (i32.const 0))
+(assert_return (invoke "eq" (i64.const 0) (i64.const 9223372036854775807)) (i32.const 0))
+(assert_return (invoke "eq" (i64.const 0) (i64.const -9223372036854775808)) (i32.const 0))
+(assert_return (invoke "eq" (i64.const 1) (i64.const 0)) (i32.const 0))
+(assert_return (invoke "eq" (i64.const 1) (i64.const 1)) (i32.const 1))
+(assert_return (invoke "eq" (i64.const 1) (i64.const -7)) (i32.const 0))
+(assert_return (invoke "eq" (i64.const 1) (i64.const 65)) (i32.const 0))
+(assert_return (invoke "eq" (i64.const 1) (i64.const 9223372036854775807)) (i32.const 0))
+(assert_return (invoke "eq" (i64.const 1) (i64.const -9223372036854775808)) (i32.const 0))
+(assert_return (invoke "eq" (i64.const -7) (i64.const 0)) (i32.const 0))
+(assert_return (invoke "eq" (i64.const -7) (i64.const 1)) (i32.const 0))
+(assert_return (invoke "eq" (i64.const -7) (i64.const -7)) (i32.const 1))
+(assert_return (invoke "eq" (i64.const -7) (i64.const 65)) (i32.const 0))
+(assert_return (invoke "eq" (i64.const -7) (i64.const 9223372036854775807)) (i32.const 0))
+(assert_return (invoke "eq" (i64.const -7) (i64.const -9223372036854775808)) (i32.const 0))
+(assert_return (invoke "eq" (i64.const 65) (i64.const 0)) (i32.const 0))
+(assert_return (invoke "eq" (i64.const 65) (i64.const 1)) (i32.const 0))
+(assert_return (invoke "eq" (i64.const 65) (i64.const -7)) (i32.const 0))
+(assert_return (invoke "eq" (i64.const 65) (i64.const 65)) (i32.const 1))
+(assert_return (invoke "eq" (i64.const 65) (i64.const 9223372036854775807)) (i32.const 0))
+(assert_return (invoke "eq" (i64.const 65) (i64.const -9223372036854775808)) (i32.const 0))
+(assert_return (invoke "eq" (i64.const 9223372036854775807) (i64.const 0)) (i32.const 0))
+(assert_return (invoke "eq" (i64.const 9223372036854775807) (i64.const 1)) (i32.const 0))
+(assert_return (invoke "eq" (i64.const 9223372036854775807) (i64.const -7)) (i32.const 0))
+(assert_return (invoke "eq" (i64.const 9223372036854775807) (i64.const 65)) (i32.const 0))
+(assert_return (invoke "eq" (i64.const 9223372036854775807) (i64.const 9223372036854775807)) (i32.const 1))
+(assert_return (invoke "eq" (i64.const 9223372036854775807) (i64.const -9223372036854775808)) (i32.const 0))
+(assert_return (invoke "eq" (i64.const -9223372036854775808) (i64.const 0)) (i32.const 0))
+(assert_return (invoke "eq" (i64.const -9223372036854775808) (i64.const 1)) (i32.const 0))
+(assert_return (invoke "eq" (i64.const -9223372036854775808) (i64.const -7)) (i32.const 0))
+(assert_return (invoke "eq" (i64.const -9223372036854775808) (i64.const 65)) (i32.const 0))
+(assert_return (invoke "eq" (i64.const -9223372036854775808) (i64.const 9223372036854775807)) (i32.const 0))
+(assert_return (invoke "eq" (i64.const -9223372036854775808) (i64.const -9223372036854775808)) (i32.const 1))
+(assert_return (invoke "ne" (i64.const 0) (i64.const 0)) (i32.const 0))
+(assert_return (invoke "ne" (i64.const 0) (i64.const 1)) (i32.const 1))
+(assert_return (invoke "ne" (i64.const 0) (i64.const -7)) (i32.const 1))
+(assert_return (invoke "ne" (i64.const 0) (i64.const 65)) (i32.const 1))
+(assert_return (invoke "ne" (i64.const 0) (i64.const 9223372036854775807)) (i32.const 1))
+(assert_return (invoke "ne" (i64.const 0) (i64.const -9223372036854775808)) (i32.const 1))
+(assert_return (invoke "ne" (i64.const 1) (i64.const 0)) (i32.const 1))
+(assert_return (invoke "ne" (i64.const 1) (i64.const 1)) (i32.const 0))
+(assert_return (invoke "ne" (i64.const 1) (i64.const -7)) (i32.const 1))
+(assert_return (invoke "ne" (i64.const 1) (i64.const 65)) (i32.const 1))
+(assert_return (invoke "ne" (i64.const 1) (i64.const 9223372036854775807)) (i32.const 1))
+(assert_return (invoke "ne" (i64.const 1) (i64.const -9223372036854775808)) (i32.const 1))
+(assert_return (invoke "ne" (i64.const -7) (i64.const 0)) (i32.const 1))
+(assert_return (invoke "ne" (i64.const -7) (i64.const 1)) (i32.const 1))
+(assert_return (invoke "ne" (i64.const -7) (i64.const -7)) (i32.const 0))
+(assert_return (invoke "ne" (i64.const -7) (i64.const 65)) (i32.const 1))
+(assert_return (invoke "ne" (i64.const -7) (i64.const 9223372036854775807)) (i32.const 1))
+(assert_return (invoke "ne" (i64.const -7) (i64.const -9223372036854775808)) (i32.const 1))
+(assert_return (invoke "ne" (i64.const 65) (i64.const 0)) (i32.const 1))
+(assert_return (invoke "ne" (i64.const 65) (i64.const 1)) (i32.const 1))
+(assert_return (invoke "ne" (i64.const 65) (i64.const -7)) (i32.const 1))
+(assert_return (invoke "ne" (i64.const 65) (i64.const 65)) (i32.const 0))
+(assert_return (invoke "ne" (i64.const 65) (i64.const 9223372036854775807)) (i32.const 1))
+(assert_return (invoke "ne" (i64.const 65) (i64.const -9223372036854775808)) (i32.const 1))
+(assert_return (invoke "ne" (i64.const 9223372036854775807) (i64.const 0)) (i32.const 1))
+(assert_return (invoke "ne" (i64.const 9223372036854775807) (i64.const 1)) (i32.const 1))
+(assert_return (invoke "ne" (i64.const 9223372036854775807) (i64.const -7)) (i32.const 1))
+(assert_return (invoke "ne" (i64.const 9223372036854775807) (i64.const 65)) (i32.const 1))
+(assert_return (invoke "ne" (i64.const 9223372036854775807) (i64.const 9223372036854775807)) (i32.const 0))
+(assert_return (invoke "ne" (i64.const 9223372036854775807) (i64.const -9223372036854775808)) (i32.const 1))
+(assert_return (invoke "ne" (i64.const -9223372036854775808) (i64.const 0)) (i32.const 1))
+(assert_return (invoke "ne" (i64.const -9223372036854775808) (i64.const 1)) (i32.const 1))
+(assert_return (invoke "ne" (i64.const -9223372036854775808) (i64.const -7)) (i32.const 1))
+(assert_return (invoke "ne" (i64.const -9223372036854775808) (i64.const 65)) (i32.const 1))
+(assert_return (invoke "ne" (i64.const -9223372036854775808) (i64.const 9223372036854775807)) (i32.const 1))
+(assert_return (invoke "ne" (i64.const -9223372036854775808) (i64.const -9223372036854775808)) (i32.const 0))
+(assert_return (invoke "lt_s" (i64.const 0) (i64.const 0)) (i32.const 0))
+(assert_return (invoke "lt_s" (i64.const 0) (i64.const 1)) (i32.const 1))
+(assert_return (invoke "lt_s" (i64.const 0) (i64.const -7)) (i32.const 0))
+(assert_return (invoke "lt_s" (i64.const 0) (i64.const 65)) (i32.const 1))
+(assert_return (invoke "lt_s" (i64.const 0) (i64.const 9223372036854775807)) (i32.const 1))
+(assert_return (invoke "lt_s" (i64.const 0) (i64.const -9223372036854775808)) (i32.const 0))
+(assert_return (invoke "lt_s" (i64.const 1) (i64.const 0)) (i32.const 0))
+(assert_return (invoke "lt_s" (i64.const 1) (i64.const 1)) (i32.const 0))
+(assert_return (invoke "lt_s" (i64.const 1) (i64.const -7)) (i32.const 0))
+(assert_return (invoke "lt_s" (i64.const 1) (i64.const 65)) (i32.const 1))
+(assert_return (invoke "lt_s" (i64.const 1) (i64.const 9223372036854775807)) (i32.const 1))
+(assert_return (invoke "lt_s" (i64.const 1) (i64.const -9223372036854775808)) (i32.const 0))
+(assert_return (invoke "lt_s" (i64.const -7) (i64.const 0)) (i32.const 1))
+(assert_return (invoke "lt_s" (i64.const -7) (i64.const 1)) (i32.const 1))
+(assert_return (invoke "lt_s" (i64.const -7) (i64.const -7)) (i32.const 0))
+(assert_return (invoke "lt_s" (i64.const -7) (i64.const 65)) (i32.const 1))
+(assert_return (invoke "lt_s" (i64.const -7) (i64.const 9223372036854775807)) (i32.const 1))
+(assert_return (invoke "lt_s" (i64.const -7) (i64.const -9223372036854775808)) (i32.const 0))
+(assert_return (invoke "lt_s" (i64.const 65) (i64.const 0)) (i32.const 0))
+(assert_return (invoke "lt_s" (i64.const 65) (i64.const 1)) (i32.const 0))
+(assert_return (invoke "lt_s" (i64.const 65) (i64.const -7)) (i32.const 0))
+(assert_return (invoke "lt_s" (i64.const 65) (i64.const 65)) (i32.const 0))
+(assert_return (invoke "lt_s" (i64.const 65) (i64.const 9223372036854775807)) (i32.const 1))
+(assert_return (invoke "lt_s" (i64.const 65) (i64.const -9223372036854775808)) (i32.const 0))
+(assert_return (invoke "lt_s" (i64.const 9223372036854775807) (i64.const 0)) (i32.const 0))
+(assert_return (invoke "lt_s" (i64.const 9223372036854775807) (i64.const 1)) (i32.const 0))
+(assert_return (invoke "lt_s" (i64.const 9223372036854775807) (i64.const -7)) (i32.const 0))
+(assert_return (invoke "lt_s" (i64.const 9223372036854775807) (i64.const 65)) (i32.const 0))
+(assert_return (invoke "lt_s" (i64.const 9223372036854775807) (i64.const 9223372036854775807)) (i32.const 0))
+(assert_return (invoke "lt_s" (i64.const 9223372036854775807) (i64.const -9223372036854775808)) (i32.const 0))
+(assert_return (invoke "lt_s" (i64.const -9223372036854775808) (i64.const 0)) (i32.const 1))
+(assert_return (invoke "lt_s" (i64.const -9223372036854775808) (i64.const 1)) (i32.const 1))
+(assert_return (invoke "lt_s" (i64.const -9223372036854775808) (i64.const -7)) (i32.const 1))
+(assert_return (invoke "lt_s" (i64.const -9223372036854775808) (i64.const 65)) (i32.const 1))
+(assert_return (invoke "lt_s" (i64.const -9223372036854775808) (i64.const 9223372036854775807)) (i32.const 1))
+(assert_return (invoke "lt_s" (i64.const -9223372036854775808) (i64.const -9223372036854775808)) (i32.const 0))
+(assert_return (invoke "lt_u" (i64.const 0) (i64.const 0)) (i32.const 0))
+(assert_return (invoke "lt_u" (i64.const 0) (i64.const 1)) (i32.const 1))
+(assert_return (invoke "lt_u" (i64.const 0) (i64.const -7)) (i32.const 1))
+(assert_return (invoke "lt_u" (i64.const 0) (i64.const 65)) (i32.const 1))
+(assert_return (invoke "lt_u" (i64.const 0) (i64.const 9223372036854775807)) (i32.const 1))
+(assert_return (invoke "lt_u" (i64.const 0) (i64.const -9223372036854775808)) (i32.const 1))
+(assert_return (invoke "lt_u" (i64.const 1) (i64.const 0)) (i32.const 0))
+(assert_return (invoke "lt_u" (i64.const 1) (i64.const 1)) (i32.const 0))
+(assert_return (invoke "lt_u" (i64.const 1) (i64.const -7)) (i32.const 1))
+(assert_return (invoke "lt_u" (i64.const 1) (i64.const 65)) (i32.const 1))
+(assert_return (invoke "lt_u" (i64.const 1) (i64.const 9223372036854775807)) (i32.const 1))
+(assert_return (invoke "lt_u" (i64.const 1) (i64.const -9223372036854775808)) (i32.const 1))
+(assert_return (invoke "lt_u" (i64.const -7) (i64.const 0)) (i32.const 0))
+(assert_return (invoke "lt_u" (i64.const -7) (i64.const 1)) (i32.const 0))
+(assert_return (invoke "lt_u" (i64.const -7) (i64.const -7)) (i32.const 0))
+(assert_return (invoke "lt_u" (i64.const -7) (i64.const 65)) (i32.const 0))
+(assert_return (invoke "lt_u" (i64.const -7) (i64.const 9223372036854775807)) (i32.const 0))
+(assert_return (invoke "lt_u" (i64.const -7) (i64.const -9223372036854775808)) (i32.const 0))
+(assert_return (invoke "lt_u" (i64.const 65) (i64.const 0)) (i32.const 0))
+(assert_return (invoke "lt_u" (i64.const 65) (i64.const 1)) (i32.const 0))
+(assert_return (invoke "lt_u" (i64.const 65) (i64.const -7)) (i32.const 1))
+(assert_return (invoke "lt_u" (i64.const 65) (i64.const 65)) (i32.const 0))
+(assert_return (invoke "lt_u" (i64.const 65) (i64.const 9223372036854775807)) (i32.const 1))
+(assert_return (invoke "lt_u" (i64.const 65) (i64.const -9223372036854775808)) (i32.const 1))
+(assert_return (invoke "lt_u" (i64.const 9223372036854775807) (i64.const 0)) (i32.const 0))
+(assert_return (invoke "lt_u" (i64.const 9223372036854775807) (i64.const 1)) (i32.const 0))
+(assert_return (invoke "lt_u" (i64.const 9223372036854775807) (i64.const -7)) (i32.const 1))
+(assert_return (invoke "lt_u" (i64.const 9223372036854775807) (i64.const 65)) (i32.const 0))
+(assert_return (invoke "lt_u" (i64.const 9223372036854775807) (i64.const 9223372036854775807)) (i32.const 0))
+(assert_return (invoke "lt_u" (i64.const 9223372036854775807) (i64.const -9223372036854775808)) (i32.const 1))
+(assert_return (invoke "lt_u" (i64.const -9223372036854775808) (i64.const 0)) (i32.const 0))
+(assert_return (invoke "lt_u" (i64.const -9223372036854775808) (i64.const 1)) (i32.const 0))
+(assert_return (invoke "lt_u" (i64.const -9223372036854775808) (i64.const -7)) (i32.const 1))
+(assert_return (invoke "lt_u" (i64.const -9223372036854775808) (i64.const 65)) (i32.const 0))
+(assert_return (invoke "lt_u" (i64.const -9223372036854775808) (i64.const 9223372036854775807)) (i32.const 0))
+(assert_return (invoke "lt_u" (i64.const -9223372036854775808) (i64.const -9223372036854775808)) (i32.const 0))
+(assert_return (invoke "gt_s" (i64.const 0) (i64.const 0)) (i32.const 0))
+(assert_return (invoke "gt_s" (i64.const 0) (i64.const 1)) (i32.const 0))
+(assert_return (invoke "gt_s" (i64.const 0) (i64.const -7)) (i32.const 1))
+(assert_return (invoke "gt_s" (i64.const 0) (i64.const 65)) (i32.const 0))
+(assert_return (invoke "gt_s" (i64.const 0) (i64.const 9223372036854775807)) (i32.const 0))
+(assert_return (invoke "gt_s" (i64.const 0) (i64.const -9223372036854775808)) (i32.const 1))
+(assert_return (invoke "gt_s" (i64.const 1) (i64.const 0)) (i32.const 1))
+(assert_return (invoke "gt_s" (i64.const 1) (i64.const 1)) (i32.const 0))
+(assert_return (invoke "gt_s" (i64.const 1) (i64.const -7)) (i32.const 1))
+(assert_return (invoke "gt_s" (i64.const 1) (i64.const 65)) (i32.const 0))
+(assert_return (invoke "gt_s" (i64.const 1) (i64.const 9223372036854775807)) (i32.const 0))
+(assert_return (invoke "gt_s" (i64.const 1) (i64.const -9223372036854775808)) (i32.const 1))
+(assert_return (invoke "gt_s" (i64.const -7) (i64.const 0)) (i32.const 0))
+(assert_return (invoke "gt_s" (i64.const -7) (i64.const 1)) (i32.const 0))
+(assert_return (invoke "gt_s" (i64.const -7) (i64.const -7)) (i32.const 0))
+(assert_return (invoke "gt_s" (i64.const -7) (i64.const 65)) (i32.const 0))
+(assert_return (invoke "gt_s" (i64.const -7) (i64.const 9223372036854775807)) (i32.const 0))
+(assert_return (invoke "gt_s" (i64.const -7) (i64.const -9223372036854775808)) (i32.const 1))
+(assert_return (invoke "gt_s" (i64.const 65) (i64.const 0)) (i32.const 1))
+(assert_return (invoke "gt_s" (i64.const 65) (i64.const 1)) (i32.const 1))
+(assert_return (invoke "gt_s" (i64.const 65) (i64.const -7)) (i32.const 1))
+(assert_return (invoke "gt_s" (i64.const 65) (i64.const 65)) (i32.const 0))
+(assert_return (invoke "gt_s" (i64.const 65) (i64.const 9223372036854775807)) (i32.const 0))
+(assert_return (invoke "gt_s" (i64.const 65) (i64.const -9223372036854775808)) (i32.const 1))
+(assert_return (invoke "gt_s" (i64.const 9223372036854775807) (i64.const 0)) (i32.const 1))
+(assert_return (invoke "gt_s" (i64.const 9223372036854775807) (i64.const 1)) (i32.const 1))
+(assert_return (invoke "gt_s" (i64.const 9223372036854775807) (i64.const -7)) (i32.const 1))
+(assert_return (invoke "gt_s" (i64.const 9223372036854775807) (i64.const 65)) (i32.const 1))
+(assert_return (invoke "gt_s" (i64.const 9223372036854775807) (i64.const 9223372036854775807)) (i32.const 0))
+(assert_return (invoke "gt_s" (i64.const 9223372036854775807) (i64.const -9223372036854775808)) (i32.const 1))
+(assert_return (invoke "gt_s" (i64.const -9223372036854775808) (i64.const 0)) (i32.const 0))
+(assert_return (invoke "gt_s" (i64.const -9223372036854775808) (i64.const 1)) (i32.const 0))
+(assert_return (invoke "gt_s" (i64.const -9223372036854775808) (i64.const -7)) (i32.const 0))
+(assert_return (invoke "gt_s" (i64.const -9223372036854775808) (i64.const 65)) (i32.const 0))
+(assert_return (invoke "gt_s" (i64.const -9223372036854775808) (i64.const 9223372036854775807)) (i32.const 0))
+(assert_return (invoke "gt_s" (i64.const -9223372036854775808) (i64.const -9223372036854775808)) (i32.const 0))
+(assert_return (invoke "gt_u" (i64.const 0) (i64.const 0)) (i32.const 0))
+(assert_return (invoke "gt_u" (i64.const 0) (i64.const 1)) (i32.const 0))
+(assert_return (invoke "gt_u" (i64.const 0) (i64.const -7)) (i32.const 0))
+(assert_return (invoke "gt_u" (i64.const 0) (i64.const 65)) (i32.const 0))
+(assert_return (invoke "gt_u" (i64.const 0) (i64.const 9223372036854775807)) (i32.const 0))
+(assert_return (invoke "gt_u" (i64.const 0) (i64.const -9223372036854775808)) (i32.const 0))
+(assert_return (invoke "gt_u" (i64.const 1) (i64.const 0)) (i32.const 1))
+(assert_return (invoke "gt_u" (i64.const 1) (i64.const 1)) (i32.const 0))
+(assert_return (invoke "gt_u" (i64.const 1) (i64.const -7)) (i32.const 0))
+(assert_return (invoke "gt_u" (i64.const 1) (i64.const 65)) (i32.const 0))
+(assert_return (invoke "gt_u" (i64.const 1) (i64.const 9223372036854775807)) (i32.const 0))
+(assert_return (invoke "gt_u" (i64.const 1) (i64.const -9223372036854775808)) (i32.const 0))
+(assert_return (invoke "gt_u" (i64.const -7) (i64.const 0)) (i32.const 1))
+(assert_return (invoke "gt_u" (i64.const -7) (i64.const 1)) (i32.const 1))
+(assert_return (invoke "gt_u" (i64.const -7) (i64.const -7)) (i32.const 0))
+(assert_return (invoke "gt_u" (i64.const -7) (i64.const 65)) (i32.const 1))
+(assert_return (invoke "gt_u" (i64.const -7) (i64.const 9223372036854775807)) (i32.const 1))
+(assert_return (invoke "gt_u" (i64.const -7) (i64.const -9223372036854775808)) (i32.const 1))
+(assert_return (invoke "gt_u" (i64.const 65) (i64.const 0)) (i32.const 1))
+(assert_return (invoke "gt_u" (i64.const 65) (i64.const 1)) (i32.const 1))
+(assert_return (invoke "gt_u" (i64.const 65) (i64.const -7)) (i32.const 0))
+(assert_return (invoke "gt_u" (i64.const 65) (i64.const 65)) (i32.const 0))
+(assert_return (invoke "gt_u" (i64.const 65) (i64.const 9223372036854775807)) (i32.const 0))
+(assert_return (invoke "gt_u" (i64.const 65) (i64.const -9223372036854775808)) (i32.const 0))
+(assert_return (invoke "gt_u" (i64.const 9223372036854775807) (i64.const 0)) (i32.const 1))
+(assert_return (invoke "gt_u" (i64.const 9223372036854775807) (i64.const 1)) (i32.const 1))
+(assert_return (invoke "gt_u" (i64.const 9223372036854775807) (i64.const -7)) (i32.const 0))
+(assert_return (invoke "gt_u" (i64.const 9223372036854775807) (i64.const 65)) (i32.const 1))
+(assert_return (invoke "gt_u" (i64.const 9223372036854775807) (i64.const 9223372036854775807)) (i32.const 0))
+(assert_return (invoke "gt_u" (i64.const 9223372036854775807) (i64.const -9223372036854775808)) (i32.const 0))
+(assert_return (invoke "gt_u" (i64.const -9223372036854775808) (i64.const 0)) (i32.const 1))
+(assert_return (invoke "gt_u" (i64.const -9223372036854775808) (i64.const 1)) (i32.const 1))
+(assert_return (invoke "gt_u" (i64.const -9223372036854775808) (i64.const -7)) (i32.const 0))
+(assert_return (invoke "gt_u" (i64.const -9223372036854775808) (i64.const 65)) (i32.const 1))
+(assert_return (invoke "gt_u" (i64.const -9223372036854775808) (i64.const 9223372036854775807)) (i32.const 1))
+(assert_return (invoke "gt_u" (i64.const -9223372036854775808) (i64.const -9223372036854775808)) (i32.const 0))
+(assert_return (invoke "le_s" (i64.const 0) (i64.const 0)) (i32.const 1))
+(assert_return (invoke "le_s" (i64.const 0) (i64.const 1)) (i32.const 1))
+(assert_return (invoke "le_s" (i64.const 0) (i64.const -7)) (i32.const 0))
+(assert_return (invoke "le_s" (i64.const 0) (i64.const 65)) (i32.const 1))
+(assert_return (invoke "le_s" (i64.const 0) (i64.const 9223372036854775807)) (i32.const 1))
+(assert_return (invoke "le_s" (i64.const 0) (i64.const -9223372036854775808)) (i32.const 0))
+(assert_return (invoke "le_s" (i64.const 1) (i64.const 0)) (i32.const 0))
+(assert_return (invoke "le_s" (i64.const 1) (i64.const 1)) (i32.const 1))
+(assert_return (invoke "le_s" (i64.const 1) (i64.const -7)) (i32.const 0))
+(assert_return (invoke "le_s" (i64.const 1) (i64.const 65)) (i32.const 1))
+(assert_return (invoke "le_s" (i64.const 1) (i64.const 9223372036854775807)) (i32.const 1))
+(assert_return (invoke "le_s" (i64.const 1) (i64.const -9223372036854775808)) (i32.const 0))
+(assert_return (invoke "le_s" (i64.const -7) (i64.const 0)) (i32.const 1))
+(assert_return (invoke "le_s" (i64.const -7) (i64.const 1)) (i32.const 1))
+(assert_return (invoke "le_s" (i64.const -7) (i64.const -7)) (i32.const 1))
+(assert_return (invoke "le_s" (i64.const -7) (i64.const 65)) (i32.const 1))
+(assert_return (invoke "le_s" (i64.const -7) (i64.const 9223372036854775807)) (i32.const 1))
+(assert_return (invoke "le_s" (i64.const -7) (i64.const -9223372036854775808)) (i32.const 0))
+(assert_return (invoke "le_s" (i64.const 65) (i64.const 0)) (i32.const 0))
+(assert_return (invoke "le_s" (i64.const 65) (i64.const 1)) (i32.const 0))
+(assert_return (invoke "le_s" (i64.const 65) (i64.const -7)) (i32.const 0))
+(assert_return (invoke "le_s" (i64.const 65) (i64.const 65)) (i32.const 1))
+(assert_return (invoke "le_s" (i64.const 65) (i64.const 9223372036854775807)) (i32.const 1))
+(assert_return (invoke "le_s" (i64.const 65) (i64.const -9223372036854775808)) (i32.const 0))
+(assert_return (invoke "le_s" (i64.const 9223372036854775807) (i64.const 0)) (i32.const 0))
+(assert_return (invoke "le_s" (i64.const 9223372036854775807) (i64.const 1)) (i32.const 0))
+(assert_return (invoke "le_s" (i64.const 9223372036854775807) (i64.const -7)) (i32.const 0))
+(assert_return (invoke "le_s" (i64.const 9223372036854775807) (i64.const 65)) (i32.const 0))
+(assert_return (invoke "le_s" (i64.const 9223372036854775807) (i64.const 9223372036854775807)) (i32.const 1))
+(assert_return (invoke "le_s" (i64.const 9223372036854775807) (i64.const -9223372036854775808)) (i32.const 0))
+(assert_return (invoke "le_s" (i64.const -9223372036854775808) (i64.const 0)) (i32.const 1))
+(assert_return (invoke "le_s" (i64.const -9223372036854775808) (i64.const 1)) (i32.const 1))
+(assert_return (invoke "le_s" (i64.const -9223372036854775808) (i64.const -7)) (i32.const 1))
+(assert_return (invoke "le_s" (i64.const -9223372036854775808) (i64.const 65)) (i32.const 1))
+(assert_return (invoke "le_s" (i64.const -9223372036854775808) (i64.const 9223372036854775807)) (i32.const 1))
+(assert_return (invoke "le_s" (i64.const -9223372036854775808) (i64.const -9223372036854775808)) (i32.const 1))
+(assert_return (invoke "le_u" (i64.const 0) (i64.const 0)) (i32.const 1))
+(assert_return (invoke "le_u" (i64.const 0) (i64.const 1)) (i32.const 1))
+(assert_return (invoke "le_u" (i64.const 0) (i64.const -7)) (i32.const 1))
+(assert_return (invoke "le_u" (i64.const 0) (i64.const 65)) (i32.const 1))
+(assert_return (invoke "le_u" (i64.const 0) (i64.const 9223372036854775807)) (i32.const 1))
+(assert_return (invoke "le_u" (i64.const 0) (i64.const -9223372036854775808)) (i32.const 1))
+(assert_return (invoke "le_u" (i64.const 1) (i64.const 0)) (i32.const 0))
+(assert_return (invoke "le_u" (i64.const 1) (i64.const 1)) (i32.const 1))
+(assert_return (invoke "le_u" (i64.const 1) (i64.const -7)) (i32.const 1))
+(assert_return (invoke "le_u" (i64.const 1) (i64.const 65)) (i32.const 1))
+(assert_return (invoke "le_u" (i64.const 1) (i64.const 9223372036854775807)) (i32.const 1))
+(assert_return (invoke "le_u" (i64.const 1) (i64.const -9223372036854775808)) (i32.const 1))
+(assert_return (invoke "le_u" (i64.const -7) (i64.const 0)) (i32.const 0))
+(assert_return (invoke "le_u" (i64.const -7) (i64.const 1)) (i32.const 0))
+(assert_return (invoke "le_u" (i64.const -7) (i64.const -7)) (i32.const 1))
+(assert_return (invoke "le_u" (i64.const -7) (i64.const 65)) (i32.const 0))
+(assert_return (invoke "le_u" (i64.const -7) (i64.const 9223372036854775807)) (i32.const 0))
+(assert_return (invoke "le_u" (i64.const -7) (i64.const -9223372036854775808)) (i32.const 0))
+(assert_return (invoke "le_u" (i64.const 65) (i64.const 0)) (i32.const 0))
+(assert_return (invoke "le_u" (i64.const 65) (i64.const 1)) (i32.const 0))
+(assert_return (invoke "le_u" (i64.const 65) (i64.const -7)) (i32.const 1))
+(assert_return (invoke "le_u" (i64.const 65) (i64.const 65)) (i32.const 1))
+(assert_return (invoke "le_u" (i64.const 65) (i64.const 9223372036854775807)) (i32.const 1))
+(assert_return (invoke "le_u" (i64.const 65) (i64.const -9223372036854775808)) (i32.const 1))
+(assert_return (invoke "le_u" (i64.const 9223372036854775807) (i64.const 0)) (i32.const 0))
+(assert_return (invoke "le_u" (i64.const 9223372036854775807) (i64.const 1)) (i32.const 0))
+(assert_return (invoke "le_u" (i64.const 9223372036854775807) (i64.const -7)) (i32.const 1))
+(assert_return (invoke "le_u" (i64.const 9223372036854775807) (i64.const 65)) (i32.const 0))
+(assert_return (invoke "le_u" (i64.const 9223372036854775807) (i64.const 9223372036854775807)) (i32.const 1))
+(assert_return (invoke "le_u" (i64.const 9223372036854775807) (i64.const -9223372036854775808)) (i32.const 1))
+(assert_return (invoke "le_u" (i64.const -9223372036854775808) (i64.const 0)) (i32.const 0))
+(assert_return (invoke "le_u" (i64.const -9223372036854775808) (i64.const 1)) (i32.const 0))
+(assert_return (invoke "le_u" (i64.const -9223372036854775808) (i64.const -7)) (i32.const 1))
+(assert_return (invoke "le_u" (i64.const -9223372036854775808) (i64.const 65)) (i32.const 0))
+(assert_return (invoke "le_u" (i64.const -9223372036854775808) (i64.const 9223372036854775807)) (i32.const 0))
+(assert_return (invoke "le_u" (i64.const -9223372036854775808) (i64.const -9223372036854775808)) (i32.const 1))
+(assert_return (invoke "ge_s" (i64.const 0) (i64.const 0)) (i32.const 1))
+(assert_return (invoke "ge_s" (i64.const 0) (i64.const 1)) (i32.const 0))
+(assert_return (invoke "ge_s" (i64.const 0) (i64.const -7)) (i32.const 1))
+(assert_return (invoke "ge_s" (i64.const 0) (i64.const 65)) (i32.const 0))
+(assert_return (invoke "ge_s" (i64.const 0) (i64.const 9223372036854775807)) (i32.const 0))
+(assert_return (invoke "ge_s" (i64.const 0) (i64.const -9223372036854775808)) (i32.const 1))
+(assert_return (invoke "ge_s" (i64.const 1) (i64.const 0)) (i32.const 1))
+(assert_return (invoke "ge_s" (i64.const 1) (i64.const 1)) (i32.const 1))
+(assert_return (invoke "ge_s" (i64.const 1) (i64.const -7)) (i32.const 1))
+(assert_return (invoke "ge_s" (i64.const 1) (i64.const 65)) (i32.const 0))
+(assert_return (invoke "ge_s" (i64.const 1) (i64.const 9223372036854775807)) (i32.const 0))
+(assert_return (invoke "ge_s" (i64.const 1) (i64.const -9223372036854775808)) (i32.const 1))
+(assert_return (invoke "ge_s" (i64.const -7) (i64.const 0)) (i32.const 0))
+(assert_return (invoke "ge_s" (i64.const -7) (i64.const 1)) (i32.const 0))
+(assert_return (invoke "ge_s" (i64.const -7) (i64.const -7)) (i32.const 1))
+(assert_return (invoke "ge_s" (i64.const -7) (i64.const 65)) (i32.const 0))
+(assert_return (invoke "ge_s" (i64.const -7) (i64.const 9223372036854775807)) (i32.const 0))
+(assert_return (invoke "ge_s" (i64.const -7) (i64.const -9223372036854775808)) (i32.const 1))
+(assert_return (invoke "ge_s" (i64.const 65) (i64.const 0)) (i32.const 1))
+(assert_return (invoke "ge_s" (i64.const 65) (i64.const 1)) (i32.const 1))
+(assert_return (invoke "ge_s" (i64.const 65) (i64.const -7)) (i32.const 1))
+(assert_return (invoke "ge_s" (i64.const 65) (i64.const 65)) (i32.const 1))
+(assert_return (invoke "ge_s" (i64.const 65) (i64.const 9223372036854775807)) (i32.const 0))
+(assert_return (invoke "ge_s" (i64.const 65) (i64.const -9223372036854775808)) (i32.const 1))
+(assert_return (invoke "ge_s" (i64.const 9223372036854775807) (i64.const 0)) (i32.const 1))
+(assert_return (invoke "ge_s" (i64.const 9223372036854775807) (i64.const 1)) (i32.const 1))
+(assert_return (invoke "ge_s" (i64.const 9223372036854775807) (i64.const -7)) (i32.const 1))
+(assert_return (invoke "ge_s" (i64.const 9223372036854775807) (i64.const 65)) (i32.const 1))
+(assert_return (invoke "ge_s" (i64.const 9223372036854775807) (i64.const 9223372036854775807)) (i32.const 1))
+(assert_return (invoke "ge_s" (i64.const 9223372036854775807) (i64.const -9223372036854775808)) (i32.const 1))
+(assert_return (invoke "ge_s" (i64.const -9223372036854775808) (i64.const 0)) (i32.const 0))
+(assert_return (invoke "ge_s" (i64.const -9223372036854775808) (i64.const 1)) (i32.const 0))
+(assert_return (invoke "ge_s" (i64.const -9223372036854775808) (i64.const -7)) (i32.const 0))
+(assert_return (invoke "ge_s" (i64.const -9223372036854775808) (i64.const 65)) (i32.const 0))
+(assert_return (invoke "ge_s" (i64.const -9223372036854775808) (i64.const 9223372036854775807)) (i32.const 0))
+(assert_return (invoke "ge_s" (i64.const -9223372036854775808) (i64.const -9223372036854775808)) (i32.const 1))
+(assert_return (invoke "ge_u" (i64.const 0) (i64.const 0)) (i32.const 1))
+(assert_return (invoke "ge_u" (i64.const 0) (i64.const 1)) (i32.const 0))
+(assert_return (invoke "ge_u" (i64.const 0) (i64.const -7)) (i32.const 0))
+(assert_return (invoke "ge_u" (i64.const 0) (i64.const 65)) (i32.const 0))
+(assert_return (invoke "ge_u" (i64.const 0) (i64.const 9223372036854775807)) (i32.const 0))
+(assert_return (invoke "ge_u" (i64.const 0) (i64.const -9223372036854775808)) (i32.const 0))
+(assert_return (invoke "ge_u" (i64.const 1) (i64.const 0)) (i32.const 1))
+(assert_return (invoke "ge_u" (i64.const 1) (i64.const 1)) (i32.const 1))
+(assert_return (invoke "ge_u" (i64.const 1) (i64.const -7)) (i32.const 0))
+(assert_return (invoke "ge_u" (i64.const 1) (i64.const 65)) (i32.const 0))
+(assert_return (invoke "ge_u" (i64.const 1) (i64.const 9223372036854775807)) (i32.const 0))
+(assert_return (invoke "ge_u" (i64.const 1) (i64.const -9223372036854775808)) (i32.const 0))
+(assert_return (invoke "ge_u" (i64.const -7) (i64.const 0)) (i32.const 1))
+(assert_return (invoke "ge_u" (i64.const -7) (i64.const 1)) (i32.const 1))
+(assert_return (invoke "ge_u" (i64.const -7) (i64.const -7)) (i32.const 1))
+(assert_return (invoke "ge_u" (i64.const -7) (i64.const 65)) (i32.const 1))
+(assert_return (invoke "ge_u" (i64.const -7) (i64.const 9223372036854775807)) (i32.const 1))
+(assert_return (invoke "ge_u" (i64.const -7) (i64.const -9223372036854775808)) (i32.const 1))
+(assert_return (invoke "ge_u" (i64.const 65) (i64.const 0)) (i32.const 1))
+(assert_return (invoke "ge_u" (i64.const 65) (i64.const 1)) (i32.const 1))
+(assert_return (invoke "ge_u" (i64.const 65) (i64.const -7)) (i32.const 0))
+(assert_return (invoke "ge_u" (i64.const 65) (i64.const 65)) (i32.const 1))
+(assert_return (invoke "ge_u" (i64.const 65) (i64.const 9223372036854775807)) (i32.const 0))
+(assert_return (invoke "ge_u" (i64.const 65) (i64.const -9223372036854775808)) (i32.const 0))
+(assert_return (invoke "ge_u" (i64.const 9223372036854775807) (i64.const 0)) (i32.const 1))
+(assert_return (invoke "ge_u" (i64.const 9223372036854775807) (i64.const 1)) (i32.const 1))
+(assert_return (invoke "ge_u" (i64.const 9223372036854775807) (i64.const -7)) (i32.const 0))
+(assert_return (invoke "ge_u" (i64.const 9223372036854775807) (i64.const 65)) (i32.const 1))
+(assert_return (invoke "ge_u" (i64.const 9223372036854775807) (i64.const 9223372036854775807)) (i32.const 1))
+(assert_return (invoke "ge_u" (i64.const 9223372036854775807) (i64.const -9223372036854775808)) (i32.const 0))
+(assert_return (invoke "ge_u" (i64.const -9223372036854775808) (i64.const 0)) (i32.const 1))
+(assert_return (invoke "ge_u" (i64.const -9223372036854775808) (i64.const 1)) (i32.const 1))
+(assert_return (invoke "ge_u" (i64.const -9223372036854775808) (i64.const -7)) (i32.const 0))
+(assert_return (invoke "ge_u" (i64.const -9223372036854775808) (i64.const 65)) (i32.const 1))
+(assert_return (invoke "ge_u" (i64.const -9223372036854775808) (i64.const 9223372036854775807)) (i32.const 1))
+(assert_return (invoke "ge_u" (i64.const -9223372036854775808) (i64.const -9223372036854775808)) (i32.const 1))
+(assert_return (invoke "clz" (i64.const 0)) (i64.const 64))
+(assert_return (invoke "clz" (i64.const 1)) (i64.const 63))
+(assert_return (invoke "clz" (i64.const -7)) (i64.const 0))
+(assert_return (invoke "clz" (i64.const 65)) (i64.const 57))
+(assert_return (invoke "clz" (i64.const 9223372036854775807)) (i64.const 1))
+(assert_return (invoke "clz" (i64.const -9223372036854775808)) (i64.const 0))
+(assert_return (invoke "clz" (i64.const 1311768467463790320)) (i64.const 3))
+(assert_return (invoke "ctz" (i64.const 0)) (i64.const 64))
+(assert_return (invoke "ctz" (i64.const 1)) (i64.const 0))
+(assert_return (invoke "ctz" (i64.const -7)) (i64.const 0))
+(assert_return (invoke "ctz" (i64.const 65)) (i64.const 0))
+(assert_return (invoke "ctz" (i64.const 9223372036854775807)) (i64.const 0))
+(assert_return (invoke "ctz" (i64.const -9223372036854775808)) (i64.const 63))
+(assert_return (invoke "ctz" (i64.const 1311768467463790320)) (i64.const 4))
+(assert_return (invoke "popcnt" (i64.const 0)) (i64.const 0))
+(assert_return (invoke "popcnt" (i64.const 1)) (i64.const 1))
+(assert_return (invoke "popcnt" (i64.const -7)) (i64.const 62))
+(assert_return (invoke "popcnt" (i64.const 65)) (i64.const 2))
+(assert_return (invoke "popcnt" (i64.const 9223372036854775807)) (i64.const 63))
+(assert_return (invoke "popcnt" (i64.const -9223372036854775808)) (i64.const 1))
+(assert_return (invoke "popcnt" (i64.const 1311768467463790320)) (i64.const 32))
+(assert_return (invoke "eqz" (i64.const 0)) (i32.const 1))
+(assert_return (invoke "eqz" (i64.const 1)) (i32.const 0))
+(assert_return (invoke "eqz" (i64.const -7)) (i32.const 0))
+(assert_return (invoke "eqz" (i64.const 65)) (i32.const 0))
+(assert_return (invoke "eqz" (i64.const 9223372036854775807)) (i32.const 0))
+(assert_return (invoke "eqz" (i64.const -9223372036854775808)) (i32.const 0))
+(assert_return (invoke "eqz" (i64.const 1311768467463790320)) (i32.const 0))
+(assert_trap (invoke "div_s" (i64.const 1) (i64.const 0)) "integer divide by zero")
+(assert_trap (invoke "div_u" (i64.const 1) (i64.const 0)) "integer divide by zero")
+(assert_trap (invoke "rem_s" (i64.const 1) (i64.const 0)) "integer divide by zero")
+(assert_trap (invoke "rem_u" (i64.const 1) (i64.const 0)) "integer divide by zero")
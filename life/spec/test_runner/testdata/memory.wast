@@ -0,0 +1,195 @@
+(module
+  (memory 1)
+  (data (i32.const 0) "\01\82\03\84\05\86\07\88")
+  (data (i32.const 16) "\6c\69\66\65")
+  (func (export "i32.load8_s") (param i32) (result i32)
+    get_local 0
+    i32.load8_s)
+  (func (export "i32.load8_u") (param i32) (result i32)
+    get_local 0
+    i32.load8_u)
+  (func (export "i32.load16_s") (param i32) (result i32)
+    get_local 0
+    i32.load16_s)
+  (func (export "i32.load16_u") (param i32) (result i32)
+    get_local 0
+    i32.load16_u)
+  (func (export "i32.load") (param i32) (result i32)
+    get_local 0
+    i32.load)
+  (func (export "i64.load8_s") (param i32) (result i64)
+    get_local 0
+    i64.load8_s)
+  (func (export "i64.load8_u") (param i32) (result i64)
+    get_local 0
+    i64.load8_u)
+  (func (export "i64.load16_s") (param i32) (result i64)
+    get_local 0
+    i64.load16_s)
+  (func (export "i64.load16_u") (param i32) (result i64)
+    get_local 0
+    i64.load16_u)
+  (func (export "i64.load32_s") (param i32) (result i64)
+    get_local 0
+    i64.load32_s)
+  (func (export "i64.load32_u") (param i32) (result i64)
+    get_local 0
+    i64.load32_u)
+  (func (export "i64.load") (param i32) (result i64)
+    get_local 0
+    i64.load)
+  (func (export "i32.store8") (param i32 i32) (result i32)
+    get_local 0
+    get_local 1
+    i32.store8
+    get_local 0
+    i32.load)
+  (func (export "i32.store16") (param i32 i32) (result i32)
+    get_local 0
+    get_local 1
+    i32.store16
+    get_local 0
+    i32.load)
+  (func (export "i32.store") (param i32 i32) (result i32)
+    get_local 0
+    get_local 1
+    i32.store
+    get_local 0
+    i32.load)
+  (func (export "i64.store8") (param i32 i64) (result i64)
+    get_local 0
+    get_local 1
+    i64.store8
+    get_local 0
+    i64.load)
+  (func (export "i64.store16") (param i32 i64) (result i64)
+    get_local 0
+    get_local 1
+    i64.store16
+    get_local 0
+    i64.load)
+  (func (export "i64.store32") (param i32 i64) (result i64)
+    get_local 0
+    get_local 1
+    i64.store32
+    get_local 0
+    i64.load)
+  (func (export "i64.store") (param i32 i64) (result i64)
+    get_local 0
+    get_local 1
+    i64.store
+    get_local 0
+    i64.load)
+  (func (export "offset") (param i32) (result i32)
+    get_local 0
+    i32.load8_u offset=16)
+  (func (export "size") (result i32)
+    current_memory)
+  (func (export "sum") (param i32) (result i32) (local i32 i32)
+    block
+      loop
+        get_local 1
+        get_local 0
+        i32.ge_u
+        br_if 1
+        get_local 1
+        i32.const 4
+        i32.mul
+        i32.const 1024
+        i32.add
+        get_local 1
+        get_local 1
+        i32.mul
+        i32.store
+        get_local 1
+        i32.const 1
+        i32.add
+        set_local 1
+        br 0
+      end
+    end
+    i32.const 0
+    set_local 1
+    block
+      loop
+        get_local 1
+        get_local 0
+        i32.ge_u
+        br_if 1
+        get_local 2
+        get_local 1
+        i32.const 4
+        i32.mul
+        i32.const 1024
+        i32.add
+        i32.load
+        i32.add
+        set_local 2
+        get_local 1
+        i32.const 1
+        i32.add
+        set_local 1
+        br 0
+      end
+    end
+    get_local 2))
+
+(assert_return (invoke "i32.load8_s" (i32.const 0)) (i32.const 1))
+(assert_return (invoke "i32.load8_u" (i32.const 0)) (i32.const 1))
+(assert_return (invoke "i32.load16_s" (i32.const 0)) (i32.const -32255))
+(assert_return (invoke "i32.load16_u" (i32.const 0)) (i32.const 33281))
+(assert_return (invoke "i32.load" (i32.const 0)) (i32.const -2080144895))
+(assert_return (invoke "i64.load8_s" (i32.const 0)) (i64.const 1))
+(assert_return (invoke "i64.load8_u" (i32.const 0)) (i64.const 1))
+(assert_return (invoke "i64.load16_s" (i32.const 0)) (i64.const -32255))
+(assert_return (invoke "i64.load16_u" (i32.const 0)) (i64.const 33281))
+(assert_return (invoke "i64.load32_s" (i32.const 0)) (i64.const -2080144895))
+(assert_return (invoke "i64.load32_u" (i32.const 0)) (i64.const 2214822401))
+(assert_return (invoke "i32.load8_s" (i32.const 1)) (i32.const -126))
+(assert_return (invoke "i32.load8_u" (i32.const 1)) (i32.const 130))
+(assert_return (invoke "i32.load16_s" (i32.const 1)) (i32.const 898))
+(assert_return (invoke "i32.load16_u" (i32.const 1)) (i32.const 898))
+(assert_return (invoke "i32.load" (i32.const 1)) (i32.const 92537730))
+(assert_return (invoke "i64.load8_s" (i32.const 1)) (i64.const -126))
+(assert_return (invoke "i64.load8_u" (i32.const 1)) (i64.const 130))
+(assert_return (invoke "i64.load16_s" (i32.const 1)) (i64.const 898))
+(assert_return (invoke "i64.load16_u" (i32.const 1)) (i64.const 898))
+(assert_return (invoke "i64.load32_s" (i32.const 1)) (i64.const 92537730))
+(assert_return (invoke "i64.load32_u" (i32.const 1)) (i64.const 92537730))
+(assert_return (invoke "i32.load8_s" (i32.const 2)) (i32.const 3))
+(assert_return (invoke "i32.load8_u" (i32.const 2)) (i32.const 3))
+(assert_return (invoke "i32.load16_s" (i32.const 2)) (i32.const -31741))
+(assert_return (invoke "i32.load16_u" (i32.const 2)) (i32.const 33795))
+(assert_return (invoke "i32.load" (i32.const 2)) (i32.const -2046458877))
+(assert_return (invoke "i64.load8_s" (i32.const 2)) (i64.const 3))
+(assert_return (invoke "i64.load8_u" (i32.const 2)) (i64.const 3))
+(assert_return (invoke "i64.load16_s" (i32.const 2)) (i64.const -31741))
+(assert_return (invoke "i64.load16_u" (i32.const 2)) (i64.const 33795))
+(assert_return (invoke "i64.load32_s" (i32.const 2)) (i64.const -2046458877))
+(assert_return (invoke "i64.load32_u" (i32.const 2)) (i64.const 2248508419))
+(assert_return (invoke "i32.load8_s" (i32.const 3)) (i32.const -124))
+(assert_return (invoke "i32.load8_u" (i32.const 3)) (i32.const 132))
+(assert_return (invoke "i32.load16_s" (i32.const 3)) (i32.const 1412))
+(assert_return (invoke "i32.load16_u" (i32.const 3)) (i32.const 1412))
+(assert_return (invoke "i32.load" (i32.const 3)) (i32.const 126223748))
+(assert_return (invoke "i64.load8_s" (i32.const 3)) (i64.const -124))
+(assert_return (invoke "i64.load8_u" (i32.const 3)) (i64.const 132))
+(assert_return (invoke "i64.load16_s" (i32.const 3)) (i64.const 1412))
+(assert_return (invoke "i64.load16_u" (i32.const 3)) (i64.const 1412))
+(assert_return (invoke "i64.load32_s" (i32.const 3)) (i64.const 126223748))
+(assert_return (invoke "i64.load32_u" (i32.const 3)) (i64.const 126223748))
+(assert_return (invoke "i64.load" (i32.const 0)) (i64.const -8644793601466596863))
+(assert_return (invoke "offset" (i32.const 0)) (i32.const 108))
+(assert_return (invoke "offset" (i32.const 1)) (i32.const 105))
+(assert_return (invoke "offset" (i32.const 2)) (i32.const 102))
+(assert_return (invoke "offset" (i32.const 3)) (i32.const 101))
+(assert_return (invoke "i32.store8" (i32.const 32) (i32.const 511)) (i32.const 255))
+(assert_return (invoke "i32.store16" (i32.const 40) (i32.const 131071)) (i32.const 65535))
+(assert_return (invoke "i32.store" (i32.const 48) (i32.const -2)) (i32.const -2))
+(assert_return (invoke "i64.store8" (i32.const 56) (i64.const 511)) (i64.const 255))
+(assert_return (invoke "i64.store16" (i32.const 64) (i64.const 131071)) (i64.const 65535))
+(assert_return (invoke "i64.store32" (i32.const 72) (i64.const 8589934591)) (i64.const 4294967295))
+(assert_return (invoke "i64.store" (i32.const 80) (i64.const -3)) (i64.const -3))
+(assert_return (invoke "sum" (i32.const 0)) (i32.const 0))
+(assert_return (invoke "sum" (i32.const 1)) (i32.const 0))
+(assert_return (invoke "sum" (i32.const 100)) (i32.const 328350))
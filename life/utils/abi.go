@@ -1,36 +1,678 @@
 package utils
 
 import (
+	"bytes"
+	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/PlatONnetwork/PlatON-Go/common/hexutil"
+	"github.com/PlatONnetwork/PlatON-Go/common/math"
+	"github.com/PlatONnetwork/PlatON-Go/rlp"
+)
+
+// The versions of the wasm abi.
+//
+// Version 1 is the legacy abi, the abi json is an array of functions and only
+// the string, bool and integer types up to 64 bits are supported.
+//
+// Version 2 is the abi json object {"version": 2, "abiArr": [...]} which supports
+// the composite types as well, see AbiType for the encoding.
+const (
+	AbiVersion1 = uint32(1)
+	AbiVersion2 = uint32(2)
 )
 
 type WasmAbi struct {
-	AbiArr []AbiStruct	`json:"abiArr"`
+	Version uint32      `json:"version"`
+	AbiArr  []AbiStruct `json:"abiArr"`
 }
 
 type AbiStruct struct {
-	Name string				`json:"name"`
-	Inputs []InputParam 	`json:"inputs"`
-	Outputs []OutputsParam 	`json:"outputs"`
-	Constant string 		`json:"constant"`
-	Type 	string 			`json:"type"`
+	Name     string         `json:"name"`
+	Inputs   []InputParam   `json:"inputs"`
+	Outputs  []OutputsParam `json:"outputs"`
+	Constant string         `json:"constant"`
+	Type     string         `json:"type"`
 }
 
 type InputParam struct {
-	Name string		`json:"name"`
-	Type string		`json:"type"`
+	Name       string       `json:"name"`
+	Type       string       `json:"type"`
+	Components []InputParam `json:"components,omitempty"` // the fields of the tuple type, abi v2 only
 }
 
 type OutputsParam struct {
-	Name string 	`json:"name"`
-	Type string 	`json:"type"`
+	Name       string       `json:"name"`
+	Type       string       `json:"type"`
+	Components []InputParam `json:"components,omitempty"` // the fields of the tuple type, abi v2 only
 }
 
 func (abi *WasmAbi) FromJson(body []byte) error {
 	if body == nil {
 		return fmt.Errorf("invalid param. %v", body)
 	}
+	// the legacy abi is an array of functions
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '{' {
+		if err := json.Unmarshal(trimmed, abi); err != nil {
+			return err
+		}
+		if abi.Version == 0 {
+			abi.Version = AbiVersion1
+		}
+		if abi.Version > AbiVersion2 {
+			return fmt.Errorf("unsupported abi version %d", abi.Version)
+		}
+		return nil
+	}
+	abi.Version = AbiVersion1
 	err := json.Unmarshal(body, &abi.AbiArr)
 	return err
-}
\ No newline at end of file
+}
+
+// Function returns the function with the name, the name is case insensitive.
+func (abi *WasmAbi) Function(name string) (*AbiStruct, bool) {
+	for i, v := range abi.AbiArr {
+		if strings.EqualFold(name, v.Name) && strings.EqualFold(v.Type, "function") {
+			return &abi.AbiArr[i], true
+		}
+	}
+	return nil, false
+}
+
+// InputTypes parses the types of the inputs.
+func (as *AbiStruct) InputTypes() ([]*AbiType, error) {
+	types := make([]*AbiType, 0, len(as.Inputs))
+	for _, v := range as.Inputs {
+		t, err := NewAbiType(v.Type, v.Components)
+		if err != nil {
+			return nil, fmt.Errorf("invalid input %s of %s: %v", v.Name, as.Name, err)
+		}
+		types = append(types, t)
+	}
+	return types, nil
+}
+
+// OutputType parses the type of the first output, it's void if there is no output.
+func (as *AbiStruct) OutputType() (*AbiType, error) {
+	if len(as.Outputs) == 0 {
+		return NewAbiType("void", nil)
+	}
+	t, err := NewAbiType(as.Outputs[0].Type, as.Outputs[0].Components)
+	if err != nil {
+		return nil, fmt.Errorf("invalid output of %s: %v", as.Name, err)
+	}
+	return t, nil
+}
+
+// Pack encodes the call input rlp([txType][funcName][args...]) of the function.
+func (abi *WasmAbi) Pack(txType int64, name string, args ...interface{}) ([]byte, error) {
+	fn, ok := abi.Function(name)
+	if !ok {
+		return nil, fmt.Errorf("function %s not found", name)
+	}
+	types, err := fn.InputTypes()
+	if err != nil {
+		return nil, err
+	}
+	if len(types) != len(args) {
+		return nil, fmt.Errorf("function %s expects %d arguments, got %d", name, len(types), len(args))
+	}
+	input := []interface{}{Int64ToBytes(txType), []byte(fn.Name)}
+	for i, t := range types {
+		if abi.Version < AbiVersion2 && !t.Legacy() {
+			return nil, fmt.Errorf("type %s is not supported by abi v%d", t.Name, abi.Version)
+		}
+		item, err := t.Encode(args[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid argument %d of %s: %v", i, name, err)
+		}
+		input = append(input, item)
+	}
+	return rlp.EncodeToBytes(input)
+}
+
+// Unpack decodes the value returned by the function. The output of abi v1 is only
+// decodable for the call(txType 9), which returns the integers in 8 bytes and the raw string.
+func (abi *WasmAbi) Unpack(name string, output []byte) (interface{}, error) {
+	fn, ok := abi.Function(name)
+	if !ok {
+		return nil, fmt.Errorf("function %s not found", name)
+	}
+	t, err := fn.OutputType()
+	if err != nil {
+		return nil, err
+	}
+	if t.Kind == VoidKind {
+		return nil, nil
+	}
+	if abi.Version < AbiVersion2 {
+		return t.decodeLegacy(output)
+	}
+	var item interface{}
+	if err := rlp.DecodeBytes(output, &item); err != nil {
+		return nil, err
+	}
+	return t.Decode(item)
+}
+
+// Event returns the event with the name, the name is case insensitive.
+func (abi *WasmAbi) Event(name string) (*AbiStruct, bool) {
+	for i, v := range abi.AbiArr {
+		if strings.EqualFold(name, v.Name) && strings.EqualFold(v.Type, "event") {
+			return &abi.AbiArr[i], true
+		}
+	}
+	return nil, false
+}
+
+// UnpackEvent decodes the data of the event log, which is the rlp list of the arguments.
+// The topic of the log is the keccak256 hash of the event name.
+func (abi *WasmAbi) UnpackEvent(name string, data []byte) ([]interface{}, error) {
+	ev, ok := abi.Event(name)
+	if !ok {
+		return nil, fmt.Errorf("event %s not found", name)
+	}
+	types, err := ev.InputTypes()
+	if err != nil {
+		return nil, err
+	}
+	var items []interface{}
+	if err := rlp.DecodeBytes(data, &items); err != nil {
+		return nil, err
+	}
+	if len(items) != len(types) {
+		return nil, fmt.Errorf("event %s expects %d arguments, got %d", name, len(types), len(items))
+	}
+	values := make([]interface{}, len(types))
+	for i, t := range types {
+		if values[i], err = t.Decode(items[i]); err != nil {
+			return nil, fmt.Errorf("invalid argument %d of %s: %v", i, name, err)
+		}
+	}
+	return values, nil
+}
+
+// AbiKind is the kind of the abi type.
+type AbiKind uint8
+
+const (
+	VoidKind AbiKind = iota
+	BoolKind
+	IntKind
+	UintKind
+	StringKind
+	BytesKind
+	AddressKind
+	BigUintKind // uint128 and uint256
+	ArrayKind
+	TupleKind
+)
+
+var errInvalidWasmBuffer = errors.New("invalid buffer returned by the wasm function")
+
+// AbiType is a parsed type of the abi. Each argument of the call input is an rlp item:
+//
+//   - bool, intN and uintN are the big-endian bytes of N/8 bytes (1 byte for bool), the same as abi v1
+//   - string and bytes are the raw bytes
+//   - address is the 20 bytes, uint128 and uint256 are the big-endian bytes of 16 and 32 bytes
+//   - T[] is the rlp list of the elements, tuple is the rlp list of the components
+//
+// The output of abi v2 is the rlp encoding of the returned value in the same way.
+//
+// The bool and integer types are passed to the wasm function by value, string is passed as the
+// pointer of the NUL-terminated string. The other types are passed as the pointer of a buffer,
+// which is the 4-byte little-endian length followed by the payload: the raw bytes of bytes,
+// address, uint128 and uint256, or the rlp encoding of T[] and tuple. The wasm function
+// returns these types with the pointer of the buffer in the same layout.
+type AbiType struct {
+	Name       string
+	Kind       AbiKind
+	Size       int        // the bytes of bool, the integer types and address
+	Elem       *AbiType   // the element type of T[]
+	Fields     []*AbiType // the components of tuple
+	FieldNames []string
+}
+
+// NewAbiType parses the type name, the components are the fields of tuple.
+func NewAbiType(name string, components []InputParam) (*AbiType, error) {
+	if strings.HasSuffix(name, "[]") {
+		elem, err := NewAbiType(name[:len(name)-2], components)
+		if err != nil {
+			return nil, err
+		}
+		if elem.Kind == VoidKind {
+			return nil, fmt.Errorf("invalid type %s", name)
+		}
+		return &AbiType{Name: name, Kind: ArrayKind, Elem: elem}, nil
+	}
+	t := &AbiType{Name: name}
+	switch name {
+	case "void":
+		t.Kind = VoidKind
+	case "bool":
+		t.Kind, t.Size = BoolKind, 1
+	case "int8", "int16", "int32", "int", "int64":
+		t.Kind, t.Size = IntKind, intSize(name[3:])
+	case "uint8", "uint16", "uint32", "uint", "uint64":
+		t.Kind, t.Size = UintKind, intSize(name[4:])
+	case "string":
+		t.Kind = StringKind
+	case "bytes":
+		t.Kind = BytesKind
+	case "address":
+		t.Kind, t.Size = AddressKind, common.AddressLength
+	case "uint128":
+		t.Kind, t.Size = BigUintKind, 16
+	case "uint256":
+		t.Kind, t.Size = BigUintKind, 32
+	case "tuple":
+		if len(components) == 0 {
+			return nil, errors.New("tuple without components")
+		}
+		t.Kind = TupleKind
+		for _, c := range components {
+			field, err := NewAbiType(c.Type, c.Components)
+			if err != nil {
+				return nil, err
+			}
+			if field.Kind == VoidKind {
+				return nil, fmt.Errorf("invalid component %s", c.Name)
+			}
+			t.Fields, t.FieldNames = append(t.Fields, field), append(t.FieldNames, c.Name)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported type %s", name)
+	}
+	return t, nil
+}
+
+// intSize returns the bytes of the integer type, int and uint are 32 bits.
+func intSize(bits string) int {
+	if bits == "" {
+		return 4
+	}
+	n, _ := strconv.Atoi(bits)
+	return n / 8
+}
+
+// Legacy returns whether the type is supported by abi v1.
+func (t *AbiType) Legacy() bool {
+	switch t.Kind {
+	case VoidKind, BoolKind, IntKind, UintKind, StringKind:
+		return true
+	}
+	return false
+}
+
+// GoType returns the go type of the decoded value.
+func (t *AbiType) GoType() reflect.Type {
+	switch t.Kind {
+	case BoolKind:
+		return reflect.TypeOf(false)
+	case IntKind:
+		return [...]reflect.Type{1: reflect.TypeOf(int8(0)), 2: reflect.TypeOf(int16(0)), 4: reflect.TypeOf(int32(0)), 8: reflect.TypeOf(int64(0))}[t.Size]
+	case UintKind:
+		return [...]reflect.Type{1: reflect.TypeOf(uint8(0)), 2: reflect.TypeOf(uint16(0)), 4: reflect.TypeOf(uint32(0)), 8: reflect.TypeOf(uint64(0))}[t.Size]
+	case StringKind:
+		return reflect.TypeOf("")
+	case BytesKind:
+		return reflect.TypeOf([]byte{})
+	case AddressKind:
+		return reflect.TypeOf(common.Address{})
+	case BigUintKind:
+		return reflect.TypeOf(new(big.Int))
+	case ArrayKind:
+		return reflect.SliceOf(t.Elem.GoType())
+	case TupleKind:
+		return reflect.TypeOf([]interface{}{})
+	}
+	return nil
+}
+
+// Encode converts the go value to the rlp item. The integer types accept the go integers,
+// *big.Int, json.Number and the decimal or hex string, bytes and address accept the hex string,
+// tuple accepts a slice, a struct or a map keyed by the component names.
+func (t *AbiType) Encode(v interface{}) (interface{}, error) {
+	switch t.Kind {
+	case BoolKind:
+		if b, ok := v.(bool); ok {
+			v = 0
+			if b {
+				v = 1
+			}
+		}
+		n, err := toBig(v)
+		if err != nil || n.Sign() < 0 || n.Cmp(common.Big1) > 0 {
+			return nil, fmt.Errorf("invalid bool %v", v)
+		}
+		return []byte{byte(n.Uint64())}, nil
+	case IntKind:
+		n, err := toBig(v)
+		if err != nil {
+			return nil, err
+		}
+		bits := uint(t.Size * 8)
+		if n.Cmp(new(big.Int).Neg(new(big.Int).Lsh(common.Big1, bits-1))) < 0 || n.Cmp(new(big.Int).Lsh(common.Big1, bits-1)) >= 0 {
+			return nil, fmt.Errorf("%v overflows %s", v, t.Name)
+		}
+		return t.fixedBytes(uint64(n.Int64())), nil
+	case UintKind, BigUintKind:
+		n, err := toBig(v)
+		if err != nil {
+			return nil, err
+		}
+		if n.Sign() < 0 || n.BitLen() > t.Size*8 {
+			return nil, fmt.Errorf("%v overflows %s", v, t.Name)
+		}
+		return math.PaddedBigBytes(n, t.Size), nil
+	case StringKind:
+		switch s := v.(type) {
+		case string:
+			return []byte(s), nil
+		case []byte:
+			return s, nil
+		}
+	case BytesKind:
+		switch b := v.(type) {
+		case []byte:
+			return b, nil
+		case string:
+			return hexutil.Decode(b)
+		}
+	case AddressKind:
+		switch a := v.(type) {
+		case common.Address:
+			return a.Bytes(), nil
+		case *common.Address:
+			return a.Bytes(), nil
+		case string:
+			if common.IsHexAddress(a) {
+				return common.HexToAddress(a).Bytes(), nil
+			}
+		}
+	case ArrayKind:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			break
+		}
+		list := make([]interface{}, rv.Len())
+		for i := range list {
+			item, err := t.Elem.Encode(rv.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			list[i] = item
+		}
+		return list, nil
+	case TupleKind:
+		values, err := t.tupleValues(v)
+		if err != nil {
+			return nil, err
+		}
+		list := make([]interface{}, len(t.Fields))
+		for i, field := range t.Fields {
+			item, err := field.Encode(values[i])
+			if err != nil {
+				return nil, fmt.Errorf("invalid component %s: %v", t.FieldNames[i], err)
+			}
+			list[i] = item
+		}
+		return list, nil
+	}
+	return nil, fmt.Errorf("can't encode %T as %s", v, t.Name)
+}
+
+// tupleValues returns the values of the components in order.
+func (t *AbiType) tupleValues(v interface{}) ([]interface{}, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	values := make([]interface{}, 0, len(t.Fields))
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			values = append(values, rv.Index(i).Interface())
+		}
+	case reflect.Struct:
+		for i := 0; i < rv.NumField(); i++ {
+			if rv.Type().Field(i).PkgPath == "" {
+				values = append(values, rv.Field(i).Interface())
+			}
+		}
+	case reflect.Map:
+		for _, name := range t.FieldNames {
+			value := rv.MapIndex(reflect.ValueOf(name))
+			if !value.IsValid() {
+				return nil, fmt.Errorf("component %s not found", name)
+			}
+			values = append(values, value.Interface())
+		}
+	default:
+		return nil, fmt.Errorf("can't encode %T as tuple", v)
+	}
+	if len(values) != len(t.Fields) {
+		return nil, fmt.Errorf("tuple expects %d components, got %d", len(t.Fields), len(values))
+	}
+	return values, nil
+}
+
+// Decode converts the rlp item to the go value of GoType.
+func (t *AbiType) Decode(item interface{}) (interface{}, error) {
+	if list, ok := item.([]interface{}); ok {
+		switch t.Kind {
+		case ArrayKind:
+			values := reflect.MakeSlice(t.GoType(), len(list), len(list))
+			for i, elem := range list {
+				value, err := t.Elem.Decode(elem)
+				if err != nil {
+					return nil, err
+				}
+				values.Index(i).Set(reflect.ValueOf(value))
+			}
+			return values.Interface(), nil
+		case TupleKind:
+			if len(list) != len(t.Fields) {
+				return nil, fmt.Errorf("tuple expects %d components, got %d", len(t.Fields), len(list))
+			}
+			values := make([]interface{}, len(list))
+			for i, field := range t.Fields {
+				value, err := field.Decode(list[i])
+				if err != nil {
+					return nil, err
+				}
+				values[i] = value
+			}
+			return values, nil
+		}
+		return nil, fmt.Errorf("can't decode list as %s", t.Name)
+	}
+	b, ok := item.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("can't decode %T as %s", item, t.Name)
+	}
+	switch t.Kind {
+	case BoolKind, IntKind, UintKind, AddressKind, BigUintKind:
+		if len(b) != t.Size {
+			return nil, fmt.Errorf("%s expects %d bytes, got %d", t.Name, t.Size, len(b))
+		}
+	}
+	switch t.Kind {
+	case BoolKind:
+		return b[0] != 0, nil
+	case IntKind, UintKind:
+		return reflect.ValueOf(t.wasmValue(b)).Convert(t.GoType()).Interface(), nil
+	case StringKind:
+		return string(b), nil
+	case BytesKind:
+		return common.CopyBytes(b), nil
+	case AddressKind:
+		return common.BytesToAddress(b), nil
+	case BigUintKind:
+		return new(big.Int).SetBytes(b), nil
+	}
+	return nil, fmt.Errorf("can't decode bytes as %s", t.Name)
+}
+
+// decodeLegacy decodes the output of the call by abi v1.
+func (t *AbiType) decodeLegacy(output []byte) (interface{}, error) {
+	switch t.Kind {
+	case StringKind:
+		return string(output), nil
+	case BoolKind:
+		// the bool is not returned by abi v1
+		return false, nil
+	case IntKind, UintKind:
+		if len(output) != 8 {
+			return nil, fmt.Errorf("%s expects 8 bytes, got %d", t.Name, len(output))
+		}
+		return reflect.ValueOf(int64(binary.BigEndian.Uint64(output))).Convert(t.GoType()).Interface(), nil
+	}
+	return nil, fmt.Errorf("type %s is not supported by abi v1", t.Name)
+}
+
+// fixedBytes returns the big-endian bytes of the value in the size of the type.
+func (t *AbiType) fixedBytes(v uint64) []byte {
+	return Uint64ToBytes(v)[8-t.Size:]
+}
+
+// wasmValue returns the value of the big-endian bytes, the signed integers are sign extended.
+func (t *AbiType) wasmValue(b []byte) int64 {
+	buf := make([]byte, 8)
+	if t.Kind == IntKind && b[0]&0x80 != 0 {
+		copy(buf, bytes.Repeat([]byte{0xff}, 8))
+	}
+	copy(buf[8-len(b):], b)
+	return int64(binary.BigEndian.Uint64(buf))
+}
+
+// Scalar returns whether the type is passed to the wasm function by value.
+func (t *AbiType) Scalar() bool {
+	return t.Kind == BoolKind || t.Kind == IntKind || t.Kind == UintKind
+}
+
+// WasmValue converts the argument item of the scalar type to the wasm parameter.
+func (t *AbiType) WasmValue(item interface{}) (int64, error) {
+	if !t.Scalar() {
+		return 0, fmt.Errorf("%s is not passed by value", t.Name)
+	}
+	if _, err := t.Decode(item); err != nil {
+		return 0, err
+	}
+	return t.wasmValue(item.([]byte)), nil
+}
+
+// WasmBuffer converts the argument item of the non-scalar type to the buffer
+// which will be copied into the wasm memory.
+func (t *AbiType) WasmBuffer(item interface{}) ([]byte, error) {
+	if t.Scalar() || t.Kind == VoidKind {
+		return nil, fmt.Errorf("%s is passed by value", t.Name)
+	}
+	if _, err := t.Decode(item); err != nil {
+		return nil, err
+	}
+	var payload []byte
+	switch t.Kind {
+	case StringKind:
+		return append(common.CopyBytes(item.([]byte)), 0), nil
+	case ArrayKind, TupleKind:
+		var err error
+		if payload, err = rlp.EncodeToBytes(item); err != nil {
+			return nil, err
+		}
+	default:
+		payload = item.([]byte)
+	}
+	buf := make([]byte, 4+len(payload))
+	binary.LittleEndian.PutUint32(buf, uint32(len(payload)))
+	copy(buf[4:], payload)
+	return buf, nil
+}
+
+// WasmReturn converts the value returned by the wasm function to the rlp output,
+// the non-scalar types are read from the memory at the returned pointer.
+func (t *AbiType) WasmReturn(res int64, mem []byte) ([]byte, error) {
+	switch t.Kind {
+	case VoidKind:
+		return nil, nil
+	case BoolKind:
+		if res != 0 {
+			res = 1
+		}
+		return rlp.EncodeToBytes(t.fixedBytes(uint64(res)))
+	case IntKind, UintKind:
+		return rlp.EncodeToBytes(t.fixedBytes(uint64(res)))
+	}
+	if res < 0 || res >= int64(len(mem)) {
+		return nil, errInvalidWasmBuffer
+	}
+	if t.Kind == StringKind {
+		end := bytes.IndexByte(mem[res:], 0)
+		if end < 0 {
+			return nil, errInvalidWasmBuffer
+		}
+		return rlp.EncodeToBytes(mem[res : res+int64(end)])
+	}
+	if res+4 > int64(len(mem)) {
+		return nil, errInvalidWasmBuffer
+	}
+	size := int64(binary.LittleEndian.Uint32(mem[res:]))
+	if res+4+size > int64(len(mem)) {
+		return nil, errInvalidWasmBuffer
+	}
+	payload := mem[res+4 : res+4+size]
+	switch t.Kind {
+	case ArrayKind, TupleKind:
+		var item interface{}
+		if err := rlp.DecodeBytes(payload, &item); err != nil {
+			return nil, err
+		}
+		if _, err := t.Decode(item); err != nil {
+			return nil, err
+		}
+		return common.CopyBytes(payload), nil
+	default:
+		if _, err := t.Decode(payload); err != nil {
+			return nil, err
+		}
+		return rlp.EncodeToBytes(payload)
+	}
+}
+
+// toBig converts the integer value to big.Int.
+func toBig(v interface{}) (*big.Int, error) {
+	switch n := v.(type) {
+	case *big.Int:
+		if n != nil {
+			return n, nil
+		}
+	case big.Int:
+		return &n, nil
+	case json.Number:
+		return toBig(string(n))
+	case string:
+		if b, ok := math.ParseBig256(n); ok {
+			return b, nil
+		}
+		if b, ok := new(big.Int).SetString(n, 10); ok {
+			return b, nil
+		}
+	case float64:
+		if b, acc := big.NewFloat(n).Int(nil); acc == big.Exact {
+			return b, nil
+		}
+	default:
+		rv := reflect.ValueOf(v)
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return big.NewInt(rv.Int()), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return new(big.Int).SetUint64(rv.Uint()), nil
+		}
+	}
+	return nil, fmt.Errorf("invalid integer %v", v)
+}
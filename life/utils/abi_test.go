@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"encoding/binary"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/PlatONnetwork/PlatON-Go/rlp"
+)
+
+const testAbiV2 = `{"version": 2, "abiArr": [
+	{"name": "transfer", "type": "function", "inputs": [
+		{"name": "to", "type": "address"},
+		{"name": "amount", "type": "uint256"},
+		{"name": "memo", "type": "bytes"},
+		{"name": "tags", "type": "string[]"},
+		{"name": "order", "type": "tuple", "components": [
+			{"name": "id", "type": "int64"},
+			{"name": "ok", "type": "bool"}
+		]}
+	], "outputs": [{"name": "", "type": "int8[]"}]}
+]}`
+
+func TestWasmAbi_FromJson(t *testing.T) {
+	v1 := new(WasmAbi)
+	if err := v1.FromJson([]byte(`[{"name": "get", "type": "function", "inputs": [], "outputs": [{"name": "", "type": "uint64"}]}]`)); err != nil {
+		t.Fatal(err)
+	}
+	if v1.Version != AbiVersion1 || len(v1.AbiArr) != 1 {
+		t.Fatalf("v1 abi mismatch, version %d, functions %d", v1.Version, len(v1.AbiArr))
+	}
+
+	v2 := new(WasmAbi)
+	if err := v2.FromJson([]byte(testAbiV2)); err != nil {
+		t.Fatal(err)
+	}
+	if v2.Version != AbiVersion2 {
+		t.Fatalf("version mismatch, want %d, have %d", AbiVersion2, v2.Version)
+	}
+	if err := new(WasmAbi).FromJson([]byte(`{"version": 3, "abiArr": []}`)); err == nil {
+		t.Fatal("unknown version should be rejected")
+	}
+}
+
+func TestWasmAbi_Pack(t *testing.T) {
+	wasmabi := new(WasmAbi)
+	if err := wasmabi.FromJson([]byte(testAbiV2)); err != nil {
+		t.Fatal(err)
+	}
+	to := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	input, err := wasmabi.Pack(2, "transfer", to.Hex(), "1000000000000000000", "0x0102", []string{"a", "b"}, map[string]interface{}{"id": -1, "ok": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var items []interface{}
+	if err := rlp.DecodeBytes(input, &items); err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 7 || string(items[1].([]byte)) != "transfer" {
+		t.Fatalf("input mismatch: %v", items)
+	}
+
+	fn, _ := wasmabi.Function("transfer")
+	types, err := fn.InputTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []interface{}{
+		to,
+		new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil),
+		[]byte{1, 2},
+		[]string{"a", "b"},
+		[]interface{}{int64(-1), true},
+	}
+	for i, typ := range types {
+		value, err := typ.Decode(items[i+2])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(value, want[i]) {
+			t.Errorf("argument %d mismatch, want %v, have %v", i, want[i], value)
+		}
+	}
+
+	if _, err := wasmabi.Pack(2, "transfer", to.Hex(), "-1", "0x", []string{}, []interface{}{1, true}); err == nil {
+		t.Error("negative uint256 should be rejected")
+	}
+	v1 := new(WasmAbi)
+	v1.FromJson([]byte(`[{"name": "set", "type": "function", "inputs": [{"name": "a", "type": "bytes"}]}]`))
+	if _, err := v1.Pack(2, "set", "0x01"); err == nil {
+		t.Error("bytes should be rejected by abi v1")
+	}
+}
+
+func TestAbiType_Wasm(t *testing.T) {
+	i16, _ := NewAbiType("int16", nil)
+	v, err := i16.WasmValue(i16.fixedBytes(uint64(0xfffe)))
+	if err != nil || v != -2 {
+		t.Fatalf("int16 mismatch, have %d, err %v", v, err)
+	}
+
+	bytesType, _ := NewAbiType("bytes", nil)
+	buf, err := bytesType.WasmBuffer([]byte{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if binary.LittleEndian.Uint32(buf) != 3 || !reflect.DeepEqual(buf[4:], []byte{1, 2, 3}) {
+		t.Fatalf("buffer mismatch: %x", buf)
+	}
+
+	// the wasm function returns the same buffer at 8
+	mem := make([]byte, 64)
+	copy(mem[8:], buf)
+	output, err := bytesType.WasmReturn(8, mem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wasmabi := &WasmAbi{Version: AbiVersion2, AbiArr: []AbiStruct{{Name: "echo", Type: "function", Outputs: []OutputsParam{{Type: "bytes"}}}}}
+	value, err := wasmabi.Unpack("echo", output)
+	if err != nil || !reflect.DeepEqual(value, []byte{1, 2, 3}) {
+		t.Fatalf("output mismatch, have %v, err %v", value, err)
+	}
+	binary.LittleEndian.PutUint32(mem[56:], 16)
+	if _, err := bytesType.WasmReturn(56, mem); err == nil {
+		t.Error("buffer out of memory should be rejected")
+	}
+}
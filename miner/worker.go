@@ -31,7 +31,6 @@ import (
 	"github.com/PlatONnetwork/PlatON-Go/core/cbfttypes"
 	"github.com/PlatONnetwork/PlatON-Go/core/state"
 	"github.com/PlatONnetwork/PlatON-Go/core/types"
-	"github.com/PlatONnetwork/PlatON-Go/core/vm"
 	"github.com/PlatONnetwork/PlatON-Go/event"
 	"github.com/PlatONnetwork/PlatON-Go/log"
 	"github.com/PlatONnetwork/PlatON-Go/params"
@@ -914,7 +913,7 @@ func (w *worker) updateSnapshot() {
 func (w *worker) commitTransaction(tx *types.Transaction, coinbase common.Address) ([]*types.Log, error) {
 	snap := w.current.state.Snapshot()
 
-	receipt, _, err := core.ApplyTransaction(w.config, w.chain, &coinbase, w.current.gasPool, w.current.state, w.current.header, tx, &w.current.header.GasUsed, vm.Config{})
+	receipt, _, err := core.ApplyTransaction(w.config, w.chain, &coinbase, w.current.gasPool, w.current.state, w.current.header, tx, &w.current.header.GasUsed, *w.chain.GetVMConfig())
 	if err != nil {
 		w.current.state.RevertToSnapshot(snap)
 		return nil, err
@@ -1204,8 +1203,6 @@ func (w *worker) commitNewWork(interrupt *int32, noempty bool, timestamp int64,
 		Time:       big.NewInt(timestamp),
 	}
 
-	// TODO begin()
-
 	// Only set the coinbase if our consensus engine is running (avoid spurious block rewards)
 	if w.isRunning() { /*
 			if w.coinbase == (common.Address{}) {
@@ -1245,6 +1242,14 @@ func (w *worker) commitNewWork(interrupt *int32, noempty bool, timestamp int64,
 		misc.ApplyDAOHardFork(env.state)
 	}
 
+	// the writes of the ppos plugins are recorded by the unRecognized data of the snapshotdb until the block is sealed
+	if reactor := core.GetReactorInstance(); nil != reactor {
+		if _, err := reactor.BeginBlocker(header, env.state); nil != err {
+			log.Error("Failed to begin the block", "number", header.Number, "err", err)
+			return
+		}
+	}
+
 	if !noempty && "on" == w.EmptyBlock {
 		// Create an empty block based on temporary copied state for sealing in advance without waiting block
 		// execution finished.
@@ -1351,7 +1356,12 @@ func (w *worker) commit(interval func(), update bool, start time.Time) error {
 	}
 	s := w.current.state.Copy()
 
-	// TODO end()
+	if reactor := core.GetReactorInstance(); nil != reactor {
+		if _, err := reactor.EndBlocker(w.current.header, s); nil != err {
+			log.Error("Failed to end the block", "number", w.current.header.Number, "err", err)
+			return err
+		}
+	}
 
 	block, err := w.engine.Finalize(w.chain, w.current.header, s, w.current.txs, w.current.receipts)
 	if err != nil {
@@ -2,64 +2,116 @@ package gov
 
 import (
 	"errors"
-	"fmt"
+	"math/big"
+
 	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/PlatONnetwork/PlatON-Go/common/vm"
+	"github.com/PlatONnetwork/PlatON-Go/core/types"
 	"github.com/PlatONnetwork/PlatON-Go/log"
 	"github.com/PlatONnetwork/PlatON-Go/p2p/discover"
 	"github.com/PlatONnetwork/PlatON-Go/x/plugin"
 	"github.com/PlatONnetwork/PlatON-Go/x/xcom"
-	"go/types"
-	"math/big"
-	"sync"
+	"github.com/PlatONnetwork/PlatON-Go/x/xutil"
 )
 
-var govOnce sync.Once
-var gov *Gov
+var (
+	ProposalIDExist         = errors.New("ProposalID already used")
+	ProposalNotExist        = errors.New("proposal is not exist")
+	ProposalNotVoting       = errors.New("proposal is not in voting")
+	ProposerNotVerifier     = errors.New("proposer is not verifier")
+	VoterNotVerifier        = errors.New("voter is not verifier")
+	TxSenderNotStaking      = errors.New("tx sender is not the staking address of the node")
+	VersionProposalExist    = errors.New("existing a voting or pre-active version proposal")
+	VoteOptionInvalid       = errors.New("vote option invalid")
+	VoteDuplicated          = errors.New("the verifier has voted for the proposal")
+	DeclareVersionInvalid   = errors.New("declared version is not the active version or the new version of any proposal")
+	ActiveVersionNotAllowed = errors.New("new version should be larger than active version")
+	ParamProposalExist      = errors.New("existing a voting param proposal for the same param")
+	TobeCanceledNotVoting   = errors.New("the proposal to be canceled is not in voting")
+	CancelProposalExist     = errors.New("existing a voting cancel proposal for the same proposal")
+	CancelEndVotingTooLate  = errors.New("the voting of cancel proposal should end before the proposal to be canceled")
+	DepositInsufficient     = errors.New("the free and restricted balance are insufficient for the proposal deposit")
+)
 
-const MinConsensusNodes uint8 = 25
+// Staking is the part of the staking plugin which the governance relies on
+type Staking interface {
+	GetVerifierList(blockHash common.Hash, blockNumber uint64) (xcom.CandidateQueue, bool, error)
+	GetValidatorList(blockHash common.Hash, blockNumber uint64, flag int) (xcom.ValidatorExQueue, bool, error)
+	GetCandidateInfo(blockHash common.Hash, addr common.Address) (*xcom.Candidate, error)
+}
 
-type Gov struct {
-	govDB *GovDB
+// Restricting is the part of the restricting plugin which locks the proposal deposit from the restricted von
+type Restricting interface {
+	PledgeLockFunds(account common.Address, amount *big.Int, state xcom.StateDB) (bool, error)
+	ReturnLockFunds(account common.Address, amount *big.Int, state xcom.StateDB) (bool, error)
+	SlashingNotify(account common.Address, amount *big.Int, state xcom.StateDB) (bool, error)
 }
 
-func NewGov(govDB *GovDB) *Gov {
-	govOnce.Do(func() {
-		gov = &Gov{govDB: govDB}
-	})
-	return gov
+type Gov struct {
+	govDB       *GovDB
+	staking     Staking
+	restricting Restricting
 }
 
-func GovInstance() *Gov {
-	if gov == nil {
-		panic("Gov not initialized correctly")
-	}
-	return gov
+// NewGov creates a Gov on the govDB, the proposers and voters are checked by the staking,
+// and the proposal deposit is locked from the restricted von by the restricting
+func NewGov(govDB *GovDB, staking Staking, restricting Restricting) *Gov {
+	return &Gov{govDB: govDB, staking: staking, restricting: restricting}
 }
 
-//获取预生效版本，可以返回nil
+//获取预生效版本，不存在时返回0
 func (gov *Gov) GetPreActiveVersion(state xcom.StateDB) uint32 {
-	return govDB.getPreActiveVersion(state)
+	return gov.govDB.getPreActiveVersion(state)
 }
 
-//获取当前生效版本，不会返回nil
+//获取当前生效版本，未升级过时返回0
 func (gov *Gov) GetActiveVersion(state xcom.StateDB) uint32 {
-	return govDB.getActiveVersion(state)
+	return gov.govDB.getActiveVersion(state)
 }
 
-//实现BasePlugin
-func (gov *Gov) BeginBlock(blockHash common.Hash, state xcom.StateDB) (bool, error) {
+//获取当前生效版本，未升级过时返回0；直接读取状态，不需要初始化治理模块
+func GetCurrentActiveVersion(state xcom.StateDB) uint32 {
+	value := state.GetState(vm.GovContractAddr, KeyActiveVersion())
+	if len(value) == 0 {
+		return 0
+	}
+	return common.BytesToUint32(value)
+}
 
-	//TODO: Staking Plugin
-	//是否当前结算的结束
-	if plugin.StakingInstance.isEndofSettleCycle(state) {
-		curVerifierList := plugin.StakingInstance(nil).GetVerifierList(state)
-		votingProposalIDs := gov.govDB.listVotingProposal(blockHash, state)
-		for _, votingProposalID := range votingProposalIDs {
-			ok := gov.govDB.addVerifiers(blockHash, votingProposalID, curVerifierList)
-			if !ok {
-				err := errors.New("[GOV] BeginBlock(): add Verifiers failed.")
-				return false, err
-			}
+//在新的结算周期开始时，使通过的参数修改生效，并累积正在投票的提案的验证人
+func (gov *Gov) BeginBlock(blockHash common.Hash, header *types.Header, state xcom.StateDB) (bool, error) {
+
+	blockNumber := header.Number.Uint64()
+	isNewEpoch := blockNumber > 1 && xutil.IsSettlementPeriod(blockNumber-1)
+
+	if !isNewEpoch {
+		return true, nil
+	}
+
+	if err := gov.applyPendingParams(state); err != nil {
+		log.Error("[GOV] BeginBlock(): apply pending params failed", "blockNumber", blockNumber, "err", err)
+		return false, err
+	}
+
+	votingProposalIDs, err := gov.govDB.listVotingProposal(blockHash)
+	if err != nil {
+		log.Error("[GOV] BeginBlock(): list voting proposal failed", "blockNumber", blockNumber, "err", err)
+		return false, err
+	}
+	if len(votingProposalIDs) == 0 {
+		return true, nil
+	}
+
+	verifierList, err := gov.getVerifierList(blockHash, blockNumber)
+	if err != nil {
+		log.Error("[GOV] BeginBlock(): get verifier list failed", "blockNumber", blockNumber, "err", err)
+		return false, err
+	}
+
+	for _, votingProposalID := range votingProposalIDs {
+		if err := gov.govDB.addVerifiers(blockHash, votingProposalID, verifierList); err != nil {
+			log.Error("[GOV] BeginBlock(): add verifiers failed", "proposalID", votingProposalID, "err", err)
+			return false, err
 		}
 	}
 	return true, nil
@@ -74,335 +126,682 @@ func inNodeList(proposer discover.NodeID, vList []discover.NodeID) bool {
 	return false
 }
 
-func (gov *Gov) EndBlock(blockHash common.Hash, state xcom.StateDB, curBlockNum *big.Int) (bool, error) {
+//投票结束时计票，到达生效块高时使预生效的版本生效
+func (gov *Gov) EndBlock(blockHash common.Hash, header *types.Header, state xcom.StateDB) (bool, error) {
+
+	blockNumber := header.Number.Uint64()
+
+	votingProposalIDs, err := gov.govDB.listVotingProposal(blockHash)
+	if err != nil {
+		log.Error("[GOV] EndBlock(): list voting proposal failed", "blockNumber", blockNumber, "err", err)
+		return false, err
+	}
 
-	votingProposalIDs := gov.govDB.listVotingProposal(blockHash, state)
 	for _, votingProposalID := range votingProposalIDs {
 		votingProposal, err := gov.govDB.getProposal(votingProposalID, state)
-		if nil != err {
-			msg := fmt.Sprintf("[GOV] EndBlock(): Unable to get proposal: %s", votingProposalID)
-			err = errors.New(msg)
+		if err != nil {
+			log.Error("[GOV] EndBlock(): get proposal failed", "proposalID", votingProposalID, "err", err)
 			return false, err
 		}
-		if votingProposal.GetEndVotingBlock() == curBlockNum {
-			if !staking.isEndofSettleCycle(blockHash) {
-				//TODO:
-				curVerifierList := plugin.StakingInstance(nil).GetVerifierList(state)
-				ok := gov.govDB.addVerifiers(blockHash, votingProposalID, curVerifierList)
-				if !ok {
-					err = errors.New("[GOV] EndBlock(): add Verifiers failed.")
-					return false, err
-				}
-			}
-			ok, status := gov.tally(votingProposalID, blockHash, &state)
-			if !ok {
-				err = errors.New("[GOV] EndBlock(): tally failed.")
-				return false, err
-			}
-			if status == Pass {
-				_, ok := votingProposal.(VersionProposal)
-				if ok {
-					gov.govDB.moveVotingProposalIDToPreActive(blockHash, votingProposalID, state)
-				}
-				_, ok = votingProposal.(TextProposal)
-				if ok {
-					gov.govDB.moveVotingProposalIDToEnd(blockHash, votingProposalID, state)
-				}
-			}
+		if votingProposal == nil {
+			log.Error("[GOV] EndBlock(): the voting proposal is not exist", "proposalID", votingProposalID)
+			return false, ProposalNotExist
+		}
+		if votingProposal.GetEndVotingBlock() != blockNumber {
+			continue
+		}
+		if err := gov.tally(votingProposal, blockHash, state); err != nil {
+			log.Error("[GOV] EndBlock(): tally failed", "proposalID", votingProposalID, "err", err)
+			return false, err
 		}
 	}
-	preActiveProposalID := gov.govDB.getPreActiveProposalID(blockHash, state)
-	proposal, err := gov.govDB.getProposal(preActiveProposalID, state)
+
+	preActiveProposalID, err := gov.govDB.getPreActiveProposalID(blockHash)
 	if err != nil {
-		msg := fmt.Sprintf("[GOV] EndBlock(): Unable to get proposal: %s", preActiveProposalID)
-		err = errors.New(msg)
+		log.Error("[GOV] EndBlock(): get pre-active proposal failed", "blockNumber", blockNumber, "err", err)
 		return false, err
 	}
-	versionProposal, ok := proposal.(VersionProposal)
-	if !ok {
+	if preActiveProposalID == (common.Hash{}) {
 		return true, nil
 	}
-	if versionProposal.GetActiveBlock() == curBlockNum {
-		//TODO
-		curValidatorList := plugin.StakingInstance(nil).GetValidatorList(state)
-		var updatedNodes uint8 = 0
-		declareList := gov.govDB.getDeclareNodes(preActiveProposalID, state)
-		for val := range curValidatorList {
-			if inNodeList(val, declareList) {
-				updatedNodes++
-			}
-		}
-		if updatedNodes == MinConsensusNodes {
-			tallyResult, err := gov.govDB.getTallyResult(preActiveProposalID, state)
-			if err != nil {
-				err = errors.New("[GOV] EndBlock(): get tallyResult failed.")
-				return false, err
-			}
-			tallyResult.Status = Active
-			if !gov.govDB.setTallyResult(*tallyResult, state) {
-				err = errors.New("[GOV] EndBlock(): Unable to set tallyResult.")
-				return false, err
-			}
-			gov.govDB.MovePreActiveProposalIDToEnd(blockHash, preActiveProposalID, state)
 
-			for val := range declareList {
-				gov.govDB.clearActiveNodes(val, state)
-			}
+	proposal, err := gov.govDB.getProposal(preActiveProposalID, state)
+	if err != nil {
+		log.Error("[GOV] EndBlock(): get proposal failed", "proposalID", preActiveProposalID, "err", err)
+		return false, err
+	}
+	versionProposal, ok := proposal.(*VersionProposal)
+	if !ok || versionProposal.GetActiveBlock() != blockNumber {
+		return true, nil
+	}
 
-			staking.NotifyUpdated(versionProposal.NewVersion)
-			//TODO 把ActiveNode中的节点拿出来通知staking
-			staking.Notify()
-		}
+	if err := gov.active(versionProposal, blockHash, blockNumber, state); err != nil {
+		log.Error("[GOV] EndBlock(): active proposal failed", "proposalID", preActiveProposalID, "err", err)
+		return false, err
 	}
 	return true, nil
 }
 
 //提交提案，只有验证人才能提交提案
-func (gov *Gov) Submit(curBlockNum *big.Int, from common.Address, proposal Proposal, blockHash common.Hash, state xcom.StateDB) (bool, error) {
+func (gov *Gov) Submit(curBlockNum uint64, from common.Address, proposal Proposal, blockHash common.Hash, state xcom.StateDB) (bool, error) {
+
+	//参数校验
+	if err := proposal.Verify(curBlockNum, state); err != nil {
+		return true, err
+	}
 
-	//TODO 检查交易发起人的Address和NodeID是否对应；
-	if !plugin.StakingInstance(nil).isAdressCorrespondingToNodeID(from, proposal.GetProposer()) {
-		var err error = errors.New("[GOV] Submit(): tx sender is not the declare proposer.")
+	if p, err := gov.govDB.getProposal(proposal.GetProposalID(), state); err != nil {
 		return false, err
+	} else if p != nil {
+		return true, ProposalIDExist
 	}
 
-	//参数校验
-	if !proposal.Verify(curBlockNum, state) {
-		var err error = errors.New("[GOV] Submit(): param error.")
+	//检查交易发起人的Address和NodeID是否对应
+	if ok, err := gov.isStakingAddress(blockHash, from, proposal.GetProposer()); err != nil {
 		return false, err
+	} else if !ok {
+		return true, TxSenderNotStaking
 	}
 
 	//判断proposer是否为Verifier
-	//TODO
-	verifierList, err := plugin.StakingInstance(nil).GetVerifierList(state)
+	verifierList, err := gov.getVerifierList(blockHash, curBlockNum)
 	if err != nil {
-		var err error = errors.New("[GOV] Submit(): get verifier list failed.")
 		return false, err
 	}
 	if !inNodeList(proposal.GetProposer(), verifierList) {
-		var err error = errors.New("[GOV] Submit(): proposer is not verifier.")
-		return false, err
+		return true, ProposerNotVerifier
+	}
+
+	//参数提案的额外处理，同一参数同时只能有一个参数提案在投票
+	if paramProposal, ok := proposal.(*ParamProposal); ok {
+		if exist, err := gov.existParamProposal(blockHash, paramProposal.GetParamName(), state); err != nil {
+			return false, err
+		} else if exist {
+			return true, ParamProposalExist
+		}
+		//新的参数值与其他参数（包括已通过但未生效的修改）不能冲突
+		values, err := gov.pendingParamValues(state)
+		if err != nil {
+			return false, err
+		}
+		values[paramProposal.GetParamName()], _ = new(big.Int).SetString(paramProposal.GetNewValue(), 10)
+		if err := xcom.VerifyGovernParams(values); err != nil {
+			return true, err
+		}
+	}
+
+	//取消提案的额外处理，被取消的提案必须在投票中，且取消提案先于其结束投票
+	if cancelProposal, ok := proposal.(*CancelProposal); ok {
+		if ok, err := gov.verifyCancelProposal(cancelProposal, blockHash, state); err != nil {
+			return ok, err
+		}
 	}
 
 	//升级提案的额外处理
-	_, ok := proposal.(VersionProposal)
-	if ok {
-		//判断是否有VersionProposal正在投票中，有则退出
-		votingProposalIDs := gov.govDB.listVotingProposal(blockHash, state)
-		for _, votingProposalID := range votingProposalIDs {
-			votingProposal, err := gov.govDB.getProposal(votingProposalID, state)
-			if err != nil {
-				msg := fmt.Sprintf("[GOV] Submit(): Unable to get proposal: %s", votingProposalID)
-				err = errors.New(msg)
-				return false, err
-			}
-			_, ok := votingProposal.(VersionProposal)
-			if ok {
-				var err error = errors.New("[GOV] Submit(): existing a voting version proposal.")
-				return false, err
-			}
+	if versionProposal, ok := proposal.(*VersionProposal); ok {
+		if getLargeVersion(versionProposal.GetNewVersion()) <= getLargeVersion(gov.govDB.getActiveVersion(state)) {
+			return true, ActiveVersionNotAllowed
 		}
-		//判断是否有VersionProposal正在Pre-active阶段，有则退出
-		if len(gov.govDB.getPreActiveProposalID(blockHash, state)) > 0 {
-			var err error = errors.New("[GOV] Submit(): existing a pre-active version proposal.")
+		//判断是否有VersionProposal正在投票中或处于预生效阶段，有则退出
+		if exist, err := gov.existVersionProposal(blockHash, state); err != nil {
 			return false, err
+		} else if exist {
+			return true, VersionProposalExist
 		}
 	}
+
 	//持久化相关
-	ok, err = gov.govDB.setProposal(proposal, state)
-	if err != nil {
-		msg := fmt.Sprintf("[GOV] Submit(): Unable to set proposal: %s", proposal.GetProposalID())
-		err = errors.New(msg)
+	if ok, err := gov.lockDeposit(proposal.GetProposalID(), from, state); err != nil {
+		return ok, err
+	}
+	if err := gov.govDB.setProposal(proposal, state); err != nil {
 		return false, err
 	}
-	if !ok {
-		var err error = errors.New("[GOV] Submit(): set proposal failed.")
+	if err := gov.govDB.addVotingProposalID(blockHash, proposal.GetProposalID()); err != nil {
 		return false, err
 	}
-	ok = gov.govDB.addVotingProposalID(blockHash, proposal.GetProposalID(), state)
-	if !ok {
-		var err error = errors.New("[GOV] Submit(): add VotingProposalID failed.")
+	if err := gov.govDB.addVerifiers(blockHash, proposal.GetProposalID(), verifierList); err != nil {
 		return false, err
 	}
 	return true, nil
 }
 
 //投票，只有验证人能投票
-func (gov *Gov) Vote(from common.Address, vote Vote, blockHash common.Hash, state *xcom.StateDB) (bool, error) {
+func (gov *Gov) Vote(from common.Address, vote Vote, blockHash common.Hash, curBlockNum uint64, state xcom.StateDB) (bool, error) {
 
-	if len(vote.ProposalID) == 0 || len(vote.VoteNodeID) == 0 || vote.VoteOption == 0 {
-		var err error = errors.New("[GOV] Vote(): empty parameter detected.")
-		return false, err
+	if vote.VoteOption < Yes || vote.VoteOption > Abstention {
+		return true, VoteOptionInvalid
 	}
-	//TODO: Staking Plugin
-	//检查交易发起人的Address和NodeID是否对应；
+
 	proposal, err := gov.govDB.getProposal(vote.ProposalID, state)
 	if err != nil {
-		msg := fmt.Sprintf("[GOV] Vote(): Unable to get proposal: %s", vote.ProposalID)
-		err = errors.New(msg)
 		return false, err
 	}
-	if !plugin.StakingInstance(nil).isAdressCorrespondingToNodeID(from, proposal.GetProposer()) {
-		var err error = errors.New("[GOV] Vote(): tx sender is not the declare proposer.")
-		return false, err
+	if proposal == nil {
+		return true, ProposalNotExist
 	}
 
-	//判断vote.voteNodeID是否为Verifier
-	proposer := proposal.GetProposer()
-	//TODO: Staking Plugin
-	if !inNodeList(proposer, verifierList) {
-		var err error = errors.New("[GOV] Vote(): proposer is not verifier.")
+	//判断vote.proposalID是否存在voting中
+	votingProposalIDs, err := gov.govDB.listVotingProposal(blockHash)
+	if err != nil {
 		return false, err
 	}
+	if !isVoting(vote.ProposalID, votingProposalIDs) {
+		return true, ProposalNotVoting
+	}
 
-	//voteOption范围检查
-	if vote.VoteOption <= Yes && vote.VoteOption >= Abstention {
-		var err error = errors.New("[GOV] Vote(): VoteOption invalid.")
+	//检查交易发起人的Address和NodeID是否对应
+	if ok, err := gov.isStakingAddress(blockHash, from, vote.VoteNodeID); err != nil {
 		return false, err
+	} else if !ok {
+		return true, TxSenderNotStaking
 	}
 
-	//判断vote.proposalID是否存在voting中
-	isVoting := func(proposalID common.Hash, votingProposalList []common.Hash) bool {
-		for _, votingProposal := range votingProposalList {
-			if proposalID == votingProposal {
-				return true
-			}
-		}
-		return false
-	}
-	votingProposalIDs := gov.govDB.listVotingProposal(blockHash, state)
-	if !isVoting(vote.ProposalID, votingProposalIDs) {
-		var err error = errors.New("[GOV] Vote(): vote.proposalID is not in voting.")
+	//判断vote.voteNodeID是否为Verifier
+	verifierList, err := gov.getVerifierList(blockHash, curBlockNum)
+	if err != nil {
 		return false, err
 	}
+	if !inNodeList(vote.VoteNodeID, verifierList) {
+		return true, VoterNotVerifier
+	}
 
-	//持久化相关
-	if !gov.govDB.setVote(vote.ProposalID, vote.VoteNodeID, vote.VoteOption, state) {
-		var err error = errors.New("[GOV] Vote(): Set vote failed.")
+	voteList, err := gov.govDB.listVote(vote.ProposalID, state)
+	if err != nil {
 		return false, err
 	}
-	if !gov.govDB.addActiveNode(&vote.VoteNodeID, state) {
-		var err error = errors.New("[GOV] Vote(): Add activeNode failed.")
-		return false, err
+	for _, v := range voteList {
+		if v.Voter == vote.VoteNodeID {
+			return true, VoteDuplicated
+		}
 	}
-	if !gov.govDB.addVotedVerifier(vote.ProposalID, &vote.VoteNodeID, state) {
-		var err error = errors.New("[GOV] Vote(): Add VotedVerifier failed.")
+
+	//持久化相关
+	if err := gov.govDB.setVote(vote.ProposalID, vote.VoteNodeID, vote.VoteOption, state); err != nil {
 		return false, err
 	}
-	//存入AddActiveNode，等预生效再通知Staking
-	//if !gov.govDB.addActiveNode(&proposer, state) {
-	//	var err error = errors.New("[GOV] DeclareVersion(): add active node failed.")
-	//	return false, err
-	//}
+
+	//对升级提案投赞成票，视为声明了新版本
+	if proposal.GetProposalType() == Version && vote.VoteOption == Yes {
+		if err := gov.govDB.addDeclaredNode(blockHash, vote.ProposalID, vote.VoteNodeID); err != nil {
+			return false, err
+		}
+	}
 	return true, nil
 }
 
-func getLargeVersion(version uint) uint {
+func isVoting(proposalID common.Hash, votingProposalList []common.Hash) bool {
+	for _, votingProposal := range votingProposalList {
+		if proposalID == votingProposal {
+			return true
+		}
+	}
+	return false
+}
+
+func getLargeVersion(version uint32) uint32 {
 	return version >> 8
 }
 
 //版本声明，验证人/候选人可以声明
-func (gov *Gov) DeclareVersion(from common.Address, declaredNodeID *discover.NodeID, version uint, blockHash common.Hash, state *xcom.StateDB) (bool, error) {
+func (gov *Gov) DeclareVersion(from common.Address, declaredNodeID discover.NodeID, version uint32, blockHash common.Hash, state xcom.StateDB) (bool, error) {
 
-	activeVersion := uint(gov.govDB.getActiveVersion(state))
-	if activeVersion <= 0 {
-		var err error = errors.New("[GOV] DeclareVersion(): add active version failed.")
+	if ok, err := gov.isStakingAddress(blockHash, from, declaredNodeID); err != nil {
 		return false, err
+	} else if !ok {
+		return true, TxSenderNotStaking
 	}
 
-	if getLargeVersion(version) == getLargeVersion(activeVersion) {
-		//TODO 通知staking
+	//声明的是当前生效版本
+	if getLargeVersion(version) == getLargeVersion(gov.govDB.getActiveVersion(state)) {
+		return true, nil
 	}
 
-	votingProposalIDs := gov.govDB.listVotingProposal(blockHash, state)
+	proposalIDs, err := gov.govDB.listVotingProposal(blockHash)
+	if err != nil {
+		return false, err
+	}
+	preActiveProposalID, err := gov.govDB.getPreActiveProposalID(blockHash)
+	if err != nil {
+		return false, err
+	}
+	if preActiveProposalID != (common.Hash{}) {
+		proposalIDs = append(proposalIDs, preActiveProposalID)
+	}
 
-	for _, votingProposalID := range votingProposalIDs {
-		votingProposal, err := gov.govDB.getProposal(votingProposalID, state)
+	declared := false
+	for _, proposalID := range proposalIDs {
+		proposal, err := gov.govDB.getProposal(proposalID, state)
 		if err != nil {
-			msg := fmt.Sprintf("[GOV] Submit(): Unable to set proposal: %s", votingProposalID)
-			err = errors.New(msg)
 			return false, err
 		}
-		if nil == votingProposal {
-			continue
-		}
-		versionProposal, ok := votingProposal.(VersionProposal)
-		//在版本提案的投票周期内
-		if ok {
-			if getLargeVersion(versionProposal.GetNewVersion()) == getLargeVersion(version) {
-				proposer := versionProposal.GetProposer()
-				//存入AddActiveNode，等预生效再通知Staking
-				if !gov.govDB.addDeclaredNode(blockHash,votingProposalID, proposer) {
-					var err error = errors.New("[GOV] DeclareVersion(): add active node failed.")
-					return false, err
-				}
+		versionProposal, ok := proposal.(*VersionProposal)
+		//在版本提案的投票周期内或预生效期内
+		if ok && getLargeVersion(versionProposal.GetNewVersion()) == getLargeVersion(version) {
+			if err := gov.govDB.addDeclaredNode(blockHash, proposalID, declaredNodeID); err != nil {
+				return false, err
 			}
+			declared = true
 		}
 	}
 
+	if !declared {
+		return true, DeclareVersionInvalid
+	}
 	return true, nil
 }
 
 //查询提案
-func (gov *Gov) GetProposal(proposalID common.Hash, state *xcom.StateDB) *Proposal {
+func (gov *Gov) GetProposal(proposalID common.Hash, state xcom.StateDB) (Proposal, error) {
 	proposal, err := gov.govDB.getProposal(proposalID, state)
 	if err != nil {
-		msg := fmt.Sprintf("[GOV] Submit(): Unable to set proposal: %s", proposalID)
-		err = errors.New(msg)
-		return nil
+		log.Error("[GOV] GetProposal(): Unable to get proposal", "proposalID", proposalID, "err", err)
+		return nil, err
 	}
-	if proposal != nil {
-		return &proposal
+	if proposal == nil {
+		return nil, ProposalNotExist
 	}
-	log.Error("[GOV] GetProposal(): Unable to get proposal.")
-	return nil
+	return proposal, nil
 }
 
 //查询提案结果
-func (gov *Gov) GetTallyResult(proposalID common.Hash, state *xcom.StateDB) *TallyResult {
+func (gov *Gov) GetTallyResult(proposalID common.Hash, state xcom.StateDB) (*TallyResult, error) {
 	tallyResult, err := gov.govDB.getTallyResult(proposalID, state)
 	if err != nil {
-		log.Error("[GOV] GetTallyResult(): Unable to get tallyResult from db.")
+		log.Error("[GOV] GetTallyResult(): Unable to get tallyResult", "proposalID", proposalID, "err", err)
+		return nil, err
+	}
+	return tallyResult, nil
+}
+
+//查询提案列表
+func (gov *Gov) ListProposal(blockHash common.Hash, state xcom.StateDB) ([]Proposal, error) {
+	return gov.govDB.getProposalList(blockHash, state)
+}
+
+//查询参数的当前值
+func (gov *Gov) GetParamValue(name string, state xcom.StateDB) (string, error) {
+	param := xcom.GetGovernParam(name)
+	if param == nil {
+		return "", xcom.ParamNotGovernable
+	}
+	return param.ValueAt(state).String(), nil
+}
+
+//投票结束时，进行投票计算
+func (gov *Gov) tally(proposal Proposal, blockHash common.Hash, state xcom.StateDB) error {
+
+	proposalID := proposal.GetProposalID()
+
+	tallyResult, err := gov.countVotes(proposalID, blockHash, state)
+	if err != nil {
+		return err
 	}
-	if nil != tallyResult {
-		return tallyResult
+	accuVerifiersCnt := uint64(tallyResult.AccuVerifiers)
+	voteCnt := uint64(tallyResult.Yeas) + uint64(tallyResult.Nays) + uint64(tallyResult.Abstentions)
+
+	status := Failed
+	if accuVerifiersCnt > 0 && uint64(tallyResult.Yeas)*100 >= accuVerifiersCnt*xcom.SupportRateThreshold {
+		if proposal.GetProposalType() == Version {
+			status = PreActive
+		} else {
+			status = Pass
+		}
+	}
+	tallyResult.Status = status
+
+	if err := gov.govDB.setTallyResult(*tallyResult, state); err != nil {
+		return err
+	}
+
+	//未达到法定投票人数的提案，押金被销毁
+	if voteCnt*100 < accuVerifiersCnt*xcom.ProposalQuorumRate {
+		log.Warn("[GOV] the proposal failed to reach the quorum, burn the deposit", "proposalID", proposalID,
+			"votes", voteCnt, "accuVerifiers", accuVerifiersCnt)
+		if err := gov.burnDeposit(proposalID, state); err != nil {
+			return err
+		}
+	} else if err := gov.refundDeposit(proposalID, state); err != nil {
+		return err
+	}
+
+	if status == PreActive {
+		gov.govDB.setPreActiveVersion(proposal.(*VersionProposal).GetNewVersion(), state)
+		return gov.govDB.moveVotingProposalIDToPreActive(blockHash, proposalID)
+	}
+	if status == Pass {
+		switch p := proposal.(type) {
+		case *ParamProposal:
+			if err := gov.govDB.addPendingParam(p.GetParamName(), p.GetNewValue(), state); err != nil {
+				return err
+			}
+		case *CancelProposal:
+			if err := gov.cancel(p.GetTobeCanceled(), blockHash, state); err != nil {
+				return err
+			}
+		}
+	}
+	return gov.govDB.moveVotingProposalIDToEnd(blockHash, proposalID)
+}
+
+//统计提案的投票
+func (gov *Gov) countVotes(proposalID common.Hash, blockHash common.Hash, state xcom.StateDB) (*TallyResult, error) {
+	accuVerifiersCnt, err := gov.govDB.getVerifiersLength(blockHash, proposalID)
+	if err != nil {
+		return nil, err
+	}
+
+	voteList, err := gov.govDB.listVote(proposalID, state)
+	if err != nil {
+		return nil, err
+	}
+
+	tallyResult := &TallyResult{ProposalID: proposalID, AccuVerifiers: uint16(accuVerifiersCnt)}
+	for _, v := range voteList {
+		switch v.VoteOption {
+		case Yes:
+			tallyResult.Yeas++
+		case No:
+			tallyResult.Nays++
+		case Abstention:
+			tallyResult.Abstentions++
+		}
+	}
+	return tallyResult, nil
+}
+
+//取消提案通过后，结束被取消的提案的投票，并退还其押金
+func (gov *Gov) cancel(proposalID common.Hash, blockHash common.Hash, state xcom.StateDB) error {
+	votingProposalIDs, err := gov.govDB.listVotingProposal(blockHash)
+	if err != nil {
+		return err
+	}
+	if !isVoting(proposalID, votingProposalIDs) {
+		log.Warn("[GOV] the proposal to be canceled is not in voting", "proposalID", proposalID)
+		return nil
 	}
-	log.Error("[GOV] GetTallyResult(): Unable to get tallyResult.")
+
+	tallyResult, err := gov.countVotes(proposalID, blockHash, state)
+	if err != nil {
+		return err
+	}
+	tallyResult.Status = Canceled
+
+	if err := gov.govDB.setTallyResult(*tallyResult, state); err != nil {
+		return err
+	}
+	if err := gov.refundDeposit(proposalID, state); err != nil {
+		return err
+	}
+	return gov.govDB.moveVotingProposalIDToEnd(blockHash, proposalID)
+}
+
+//锁定提案人的押金，自由金额不足时，通过锁仓合约锁定提案人的锁仓金额
+func (gov *Gov) lockDeposit(proposalID common.Hash, from common.Address, state xcom.StateDB) (bool, error) {
+	amount := xcom.GovernBigInt(state, xcom.ParamProposalDeposit)
+	if amount.Sign() == 0 {
+		return true, nil
+	}
+	deposit := Deposit{Depositor: from, Amount: amount}
+	if state.GetBalance(from).Cmp(amount) >= 0 {
+		state.SubBalance(from, amount)
+	} else {
+		if gov.restricting == nil {
+			return true, DepositInsufficient
+		}
+		//锁仓金额先转入质押合约，再转入治理合约
+		if ok, err := gov.restricting.PledgeLockFunds(from, amount, state); err != nil {
+			if ok {
+				return true, DepositInsufficient
+			}
+			return false, err
+		}
+		state.SubBalance(vm.StakingContractAddr, amount)
+		deposit.Restricted = true
+	}
+	state.AddBalance(vm.GovContractAddr, amount)
+	return true, gov.govDB.setDeposit(proposalID, deposit, state)
+}
+
+//退还提案人的押金，锁仓金额通过锁仓合约退还
+func (gov *Gov) refundDeposit(proposalID common.Hash, state xcom.StateDB) error {
+	deposit, err := gov.govDB.getDeposit(proposalID, state)
+	if err != nil || deposit == nil {
+		return err
+	}
+	state.SubBalance(vm.GovContractAddr, deposit.Amount)
+	if deposit.Restricted {
+		state.AddBalance(vm.StakingContractAddr, deposit.Amount)
+		if ok, err := gov.restricting.ReturnLockFunds(deposit.Depositor, deposit.Amount, state); err != nil {
+			if !ok {
+				return err
+			}
+			log.Error("[GOV] return the restricted deposit failed, refund it to the depositor", "proposalID", proposalID,
+				"depositor", deposit.Depositor, "err", err)
+			state.SubBalance(vm.StakingContractAddr, deposit.Amount)
+			state.AddBalance(deposit.Depositor, deposit.Amount)
+		}
+	} else {
+		state.AddBalance(deposit.Depositor, deposit.Amount)
+	}
+	gov.govDB.delDeposit(proposalID, state)
 	return nil
 }
 
-//查询提案列表
-func (gov *Gov) ListProposal(blockHash common.Hash, state xcom.StateDB) []*Proposal {
+//销毁提案人的押金，锁仓金额同时从锁仓记录中扣除
+func (gov *Gov) burnDeposit(proposalID common.Hash, state xcom.StateDB) error {
+	deposit, err := gov.govDB.getDeposit(proposalID, state)
+	if err != nil || deposit == nil {
+		return err
+	}
+	state.SubBalance(vm.GovContractAddr, deposit.Amount)
+	if deposit.Restricted {
+		if ok, err := gov.restricting.SlashingNotify(deposit.Depositor, deposit.Amount, state); err != nil {
+			if !ok {
+				return err
+			}
+			log.Error("[GOV] burn the restricted deposit failed", "proposalID", proposalID, "depositor", deposit.Depositor, "err", err)
+		}
+	}
+	gov.govDB.delDeposit(proposalID, state)
 	return nil
 }
 
-//投票结束时，进行投票计算
+//到达生效块高时，当前轮的验证人都已声明新版本则升级提案生效，否则升级失败
+func (gov *Gov) active(proposal *VersionProposal, blockHash common.Hash, blockNumber uint64, state xcom.StateDB) error {
+
+	proposalID := proposal.GetProposalID()
+
+	validatorList, _, err := gov.staking.GetValidatorList(blockHash, blockNumber, plugin.CurrentRound)
+	if err != nil {
+		return err
+	}
+
+	declaredList, err := gov.govDB.getDeclaredNodeList(blockHash, proposalID)
+	if err != nil {
+		return err
+	}
 
-func (gov *Gov) tally(proposalID common.Hash, blockHash common.Hash, state *xcom.StateDB) (bool, ProposalStatus) {
+	updatedNodes := 0
+	for _, val := range validatorList {
+		if inNodeList(val.NodeId, declaredList) {
+			updatedNodes++
+		}
+	}
 
-	accuVerifiersCnt := uint16(gov.govDB.getVerifiersLength(proposalID,state))
-	voteCnt := uint16(len(gov.govDB.listVote(proposalID, state)))
+	tallyResult, err := gov.govDB.getTallyResult(proposalID, state)
+	if err != nil {
+		return err
+	}
+	if tallyResult == nil {
+		tallyResult = &TallyResult{ProposalID: proposalID}
+	}
 
-	status := Voting
-	supportRate := voteCnt / accuVerifiersCnt
-	//TODO
-	if supportRate > n {
-		status = PreActive
+	if len(validatorList) > 0 && updatedNodes == len(validatorList) {
+		tallyResult.Status = Active
+		gov.govDB.setActiveVersion(proposal.GetNewVersion(), state)
 	} else {
-		status = Failed
+		log.Warn("[GOV] the validators are not all upgraded, the version proposal failed", "proposalID", proposalID,
+			"validators", len(validatorList), "updated", updatedNodes)
+		tallyResult.Status = Failed
+	}
+	gov.govDB.setPreActiveVersion(0, state)
+
+	if err := gov.govDB.setTallyResult(*tallyResult, state); err != nil {
+		return err
+	}
+	if err := gov.govDB.movePreActiveProposalIDToEnd(blockHash, proposalID); err != nil {
+		return err
 	}
+	return gov.govDB.clearDeclaredNodes(blockHash, proposalID)
+}
+
+//是否存在正在投票或预生效的升级提案
+func (gov *Gov) existVersionProposal(blockHash common.Hash, state xcom.StateDB) (bool, error) {
+	preActiveProposalID, err := gov.govDB.getPreActiveProposalID(blockHash)
+	if err != nil {
+		return false, err
+	}
+	if preActiveProposalID != (common.Hash{}) {
+		return true, nil
+	}
+
+	votingProposalIDs, err := gov.govDB.listVotingProposal(blockHash)
+	if err != nil {
+		return false, err
+	}
+	for _, votingProposalID := range votingProposalIDs {
+		votingProposal, err := gov.govDB.getProposal(votingProposalID, state)
+		if err != nil {
+			return false, err
+		}
+		if votingProposal != nil && votingProposal.GetProposalType() == Version {
+			return true, nil
+		}
+	}
+	return false, nil
+}
 
-	tallyResult := &TallyResult{
-		ProposalID:    proposalID,
-		Yeas:          voteCnt,
-		AccuVerifiers: accuVerifiersCnt,
-		Status:        status,
+//是否存在正在投票的修改同一参数的参数提案
+func (gov *Gov) existParamProposal(blockHash common.Hash, name string, state xcom.StateDB) (bool, error) {
+	votingProposalIDs, err := gov.govDB.listVotingProposal(blockHash)
+	if err != nil {
+		return false, err
+	}
+	for _, votingProposalID := range votingProposalIDs {
+		votingProposal, err := gov.govDB.getProposal(votingProposalID, state)
+		if err != nil {
+			return false, err
+		}
+		if paramProposal, ok := votingProposal.(*ParamProposal); ok && paramProposal.GetParamName() == name {
+			return true, nil
+		}
 	}
+	return false, nil
+}
 
-	if !gov.govDB.setTallyResult(*tallyResult, state) {
-		log.Error("[GOV] tally(): Unable to set tallyResult.")
-		return false, status
+//检查取消提案的目标提案，返回值的含义同Submit
+func (gov *Gov) verifyCancelProposal(cancelProposal *CancelProposal, blockHash common.Hash, state xcom.StateDB) (bool, error) {
+	votingProposalIDs, err := gov.govDB.listVotingProposal(blockHash)
+	if err != nil {
+		return false, err
+	}
+	if !isVoting(cancelProposal.GetTobeCanceled(), votingProposalIDs) {
+		return true, TobeCanceledNotVoting
 	}
-	return true, status
 
-}
\ No newline at end of file
+	for _, votingProposalID := range votingProposalIDs {
+		votingProposal, err := gov.govDB.getProposal(votingProposalID, state)
+		if err != nil {
+			return false, err
+		}
+		if votingProposal == nil {
+			continue
+		}
+		if votingProposalID == cancelProposal.GetTobeCanceled() &&
+			votingProposal.GetEndVotingBlock() <= cancelProposal.GetEndVotingBlock() {
+			return true, CancelEndVotingTooLate
+		}
+		if p, ok := votingProposal.(*CancelProposal); ok && p.GetTobeCanceled() == cancelProposal.GetTobeCanceled() {
+			return true, CancelProposalExist
+		}
+	}
+	return true, nil
+}
+
+//使已通过的参数修改生效，与其他参数冲突的修改被丢弃
+func (gov *Gov) applyPendingParams(state xcom.StateDB) error {
+	params, err := gov.govDB.listPendingParams(state)
+	if err != nil || len(params) == 0 {
+		return err
+	}
+	values := gov.paramValues(state)
+	for _, p := range params {
+		value, err := xcom.GetGovernParam(p.Name).Verify(p.Value)
+		if err != nil {
+			log.Error("[GOV] discard the invalid param changed by proposal", "name", p.Name, "value", p.Value, "err", err)
+			continue
+		}
+		old := values[p.Name]
+		values[p.Name] = value
+		if err := xcom.VerifyGovernParams(values); err != nil {
+			log.Error("[GOV] discard the conflicting param changed by proposal", "name", p.Name, "value", p.Value, "err", err)
+			values[p.Name] = old
+			continue
+		}
+		log.Info("[GOV] apply the param changed by proposal", "name", p.Name, "value", p.Value)
+		gov.govDB.setParamValue(p.Name, p.Value, state)
+	}
+	gov.govDB.clearPendingParams(state)
+	return nil
+}
+
+//statedb中的参数值，未修改过的参数使用默认值
+func (gov *Gov) paramValues(state xcom.StateDB) map[string]*big.Int {
+	values := make(map[string]*big.Int, len(xcom.GovernParams()))
+	for _, param := range xcom.GovernParams() {
+		values[param.Name] = param.ValueAt(state)
+	}
+	return values
+}
+
+//statedb中的参数值，并叠加已通过但未生效的参数修改
+func (gov *Gov) pendingParamValues(state xcom.StateDB) (map[string]*big.Int, error) {
+	params, err := gov.govDB.listPendingParams(state)
+	if err != nil {
+		return nil, err
+	}
+	values := gov.paramValues(state)
+	for _, p := range params {
+		if value, err := xcom.GetGovernParam(p.Name).Verify(p.Value); err == nil {
+			values[p.Name] = value
+		}
+	}
+	return values, nil
+}
+
+//获取当前结算周期的验证人
+func (gov *Gov) getVerifierList(blockHash common.Hash, blockNumber uint64) ([]discover.NodeID, error) {
+	verifierList, _, err := gov.staking.GetVerifierList(blockHash, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	nodeIDs := make([]discover.NodeID, 0, len(verifierList))
+	for _, v := range verifierList {
+		nodeIDs = append(nodeIDs, v.NodeId)
+	}
+	return nodeIDs, nil
+}
+
+//交易发起人是否为节点的质押账户
+func (gov *Gov) isStakingAddress(blockHash common.Hash, from common.Address, nodeID discover.NodeID) (bool, error) {
+	addr, err := xutil.NodeId2Addr(nodeID)
+	if err != nil {
+		return false, nil
+	}
+	can, err := gov.staking.GetCandidateInfo(blockHash, addr)
+	if err != nil {
+		return false, err
+	}
+	return can != nil && can.StakingAddress == from, nil
+}
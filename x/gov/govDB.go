@@ -3,93 +3,84 @@ package gov
 import (
 	"encoding/json"
 	"fmt"
+	"math/big"
+
 	"github.com/PlatONnetwork/PlatON-Go/common"
 	"github.com/PlatONnetwork/PlatON-Go/common/vm"
 	"github.com/PlatONnetwork/PlatON-Go/core/snapshotdb"
-	"github.com/PlatONnetwork/PlatON-Go/log"
 	"github.com/PlatONnetwork/PlatON-Go/p2p/discover"
 	"github.com/PlatONnetwork/PlatON-Go/x/xcom"
-	"sync"
-)
-
-var (
-	ValueDelimiter = []byte(":")
 )
 
 type VoteValue struct {
-	voter  discover.NodeID
-	option VoteOption
+	Voter      discover.NodeID `json:"voter"`
+	VoteOption VoteOption      `json:"voteOption"`
 }
 
-var dbOnce sync.Once
-var govDB *GovDB
+// ParamValue is the new value of the param which was changed by the param proposal
+type ParamValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
 
-type GovDB struct {
-	govdbErr error
-	snapdb   GovSnapshotDB
+// Deposit is locked from the proposer on submitting the proposal,
+// it is locked from the restricted von of the proposer if the free balance is insufficient
+type Deposit struct {
+	Depositor  common.Address `json:"depositor"`
+	Amount     *big.Int       `json:"amount"`
+	Restricted bool           `json:"restricted"`
 }
 
-func NewGovDB(snapdb snapshotdb.DB) *GovDB {
-	dbOnce.Do(func() {
-		govDB = &GovDB{snapdb: GovSnapshotDB{snapdb}}
-	})
-	return govDB
+// GovDB stores the proposals, votes and tally results in the statedb,
+// and the proposal ID lists, declared nodes and accumulated verifiers in the snapshotdb
+type GovDB struct {
+	snapdb *GovSnapshotDB
 }
 
-func tobytes(data interface{}) []byte {
-	if bytes, err := json.Marshal(data); err != nil {
-		return bytes
-	} else {
-		log.Error("govdb, marshal value to bytes error..")
-		panic(err)
-	}
+// NewGovDB creates a GovDB on the snapshotdb, the Gov holds the one which is used by the chain
+func NewGovDB(snapdb snapshotdb.DB) *GovDB {
+	return &GovDB{snapdb: NewGovSnapshotDB(snapdb)}
 }
 
 // 保存提案记录，value编码规则:
-//  value 为[]byte，其中byte[byte.len -2] 为type,byte[0:byte.len-2]为proposal
-func (self *GovDB) setProposal(proposal Proposal, state xcom.StateDB) (bool, error) {
+//  value 为[]byte，其中byte[byte.len -1] 为type,byte[0:byte.len-1]为proposal
+func (self *GovDB) setProposal(proposal Proposal, state xcom.StateDB) error {
 
-	bytes, e := json.Marshal(proposal)
-	if e != nil {
-		return false, e
+	bytes, err := json.Marshal(proposal)
+	if err != nil {
+		return err
 	}
 
 	value := append(bytes, byte(proposal.GetProposalType()))
 	state.SetState(vm.GovContractAddr, KeyProposal(proposal.GetProposalID()), value)
 
-	return true, nil
+	return nil
 }
 
-func (self *GovDB) setError(err error) {
-	if err != nil {
-		self.govdbErr = err
-		panic(err)
-	}
-}
-
-// 查询提案记录，获取value后，解码
+// 查询提案记录，获取value后，解码；提案不存在时返回nil
 func (self *GovDB) getProposal(proposalID common.Hash, state xcom.StateDB) (Proposal, error) {
 	value := state.GetState(vm.GovContractAddr, KeyProposal(proposalID))
 	if len(value) == 0 {
-		return nil, fmt.Errorf("no value found!")
+		return nil, nil
 	}
 	var proposal Proposal
 	pData := value[0 : len(value)-1]
 	pType := value[len(value)-1]
 	if pType == byte(Text) {
 		proposal = &TextProposal{}
-		if e := json.Unmarshal(pData, proposal); e != nil {
-			return nil, e
-		}
 	} else if pType == byte(Version) {
 		proposal = &VersionProposal{}
-		if e := json.Unmarshal(pData, proposal); e != nil {
-			return nil, e
-		}
+	} else if pType == byte(Param) {
+		proposal = &ParamProposal{}
+	} else if pType == byte(Cancel) {
+		proposal = &CancelProposal{}
 	} else {
 		return nil, fmt.Errorf("incorrect propsal type:%b!", pType)
 	}
 
+	if err := json.Unmarshal(pData, proposal); err != nil {
+		return nil, err
+	}
 	return proposal, nil
 }
 
@@ -99,48 +90,66 @@ func (self *GovDB) getProposalList(blockHash common.Hash, state xcom.StateDB) ([
 	if err != nil {
 		return nil, err
 	}
-	var proposls []Proposal
+	var proposals []Proposal
 	for _, hash := range proposalIds {
-		proposal, _ := self.getProposal(hash, state)
+		proposal, err := self.getProposal(hash, state)
+		if err != nil {
+			return nil, err
+		}
 		if proposal != nil {
-			proposls = append(proposls, proposal)
+			proposals = append(proposals, proposal)
 		}
 	}
-	return proposls, nil
+	return proposals, nil
 }
 
 //保存投票记录
-func (self *GovDB) setVote(proposalID common.Hash, voter discover.NodeID, option VoteOption, state xcom.StateDB) bool {
-	voteList := self.listVote(proposalID, state)
+func (self *GovDB) setVote(proposalID common.Hash, voter discover.NodeID, option VoteOption, state xcom.StateDB) error {
+	voteList, err := self.listVote(proposalID, state)
+	if err != nil {
+		return err
+	}
 	voteList = append(voteList, VoteValue{voter, option})
 
-	voteListBytes, _ := json.Marshal(voteList)
+	voteListBytes, err := json.Marshal(voteList)
+	if err != nil {
+		return err
+	}
 
 	state.SetState(vm.GovContractAddr, KeyVote(proposalID), voteListBytes)
-	return true
+	return nil
 }
 
 // 查询投票记录
-func (self *GovDB) listVote(proposalID common.Hash, state xcom.StateDB) []VoteValue {
+func (self *GovDB) listVote(proposalID common.Hash, state xcom.StateDB) ([]VoteValue, error) {
 	voteListBytes := state.GetState(vm.GovContractAddr, KeyVote(proposalID))
+	if len(voteListBytes) == 0 {
+		return nil, nil
+	}
 
 	var voteList []VoteValue
-	if err := json.Unmarshal(voteListBytes, voteList); err != nil {
-		return nil
+	if err := json.Unmarshal(voteListBytes, &voteList); err != nil {
+		return nil, err
 	}
-	return voteList
+	return voteList, nil
 }
 
 // 保存投票结果
-func (self *GovDB) setTallyResult(tallyResult TallyResult, state xcom.StateDB) bool {
-	value, _ := json.Marshal(tallyResult)
+func (self *GovDB) setTallyResult(tallyResult TallyResult, state xcom.StateDB) error {
+	value, err := json.Marshal(tallyResult)
+	if err != nil {
+		return err
+	}
 	state.SetState(vm.GovContractAddr, KeyTallyResult(tallyResult.ProposalID), value)
-	return true
+	return nil
 }
 
-// 查询投票结果
+// 查询投票结果；投票结果不存在时返回nil
 func (self *GovDB) getTallyResult(proposalID common.Hash, state xcom.StateDB) (*TallyResult, error) {
 	value := state.GetState(vm.GovContractAddr, KeyTallyResult(proposalID))
+	if len(value) == 0 {
+		return nil, nil
+	}
 
 	var tallyResult TallyResult
 	if err := json.Unmarshal(value, &tallyResult); err != nil {
@@ -150,172 +159,185 @@ func (self *GovDB) getTallyResult(proposalID common.Hash, state xcom.StateDB) (*
 	return &tallyResult, nil
 }
 
-// 保存生效版本记录
-func (self *GovDB) setPreActiveVersion(preActiveVersion uint32, state xcom.StateDB) bool {
+// 保存预生效版本记录
+func (self *GovDB) setPreActiveVersion(preActiveVersion uint32, state xcom.StateDB) {
 	state.SetState(vm.GovContractAddr, KeyPreActiveVersion(), common.Uint32ToBytes(preActiveVersion))
-	return true
 }
 
-// 查询生效版本记录
+// 查询预生效版本记录，不存在时返回0
 func (self *GovDB) getPreActiveVersion(state xcom.StateDB) uint32 {
 	value := state.GetState(vm.GovContractAddr, KeyPreActiveVersion())
+	if len(value) == 0 {
+		return 0
+	}
 	return common.BytesToUint32(value)
 }
 
 // 保存生效版本记录
-func (self *GovDB) setActiveVersion(activeVersion uint32, state xcom.StateDB) bool {
+func (self *GovDB) setActiveVersion(activeVersion uint32, state xcom.StateDB) {
 	state.SetState(vm.GovContractAddr, KeyActiveVersion(), common.Uint32ToBytes(activeVersion))
-	return true
 }
 
-// 查询生效版本记录
+// 查询生效版本记录，不存在时返回0
 func (self *GovDB) getActiveVersion(state xcom.StateDB) uint32 {
-	value := state.GetState(vm.GovContractAddr, KeyActiveVersion())
-	return common.BytesToUint32(value)
+	return GetCurrentActiveVersion(state)
 }
 
 // 查询正在投票的提案
-func (self *GovDB) listVotingProposal(blockHash common.Hash, state xcom.StateDB) []common.Hash {
-	value, err := govDB.snapdb.getVotingIDList(blockHash)
-	if err != nil {
-		log.Error("List voting proposal ID error")
-		return nil
-	}
-	return value
+func (self *GovDB) listVotingProposal(blockHash common.Hash) ([]common.Hash, error) {
+	return self.snapdb.getVotingIDList(blockHash)
 }
 
 // 获取投票结束的提案
-func (self *GovDB) listEndProposalID(blockHash common.Hash, state xcom.StateDB) []common.Hash {
-	value, err := govDB.snapdb.getEndIDList(blockHash)
-	if err != nil {
-		log.Error("List end proposal ID error")
-		return nil
-	}
-
-	return value
+func (self *GovDB) listEndProposalID(blockHash common.Hash) ([]common.Hash, error) {
+	return self.snapdb.getEndIDList(blockHash)
 }
 
-// 查询预生效的升级提案
-func (self *GovDB) getPreActiveProposalID(blockHash common.Hash, state xcom.StateDB) common.Hash {
-	value, err := govDB.snapdb.getPreActiveIDList(blockHash)
-	if err != nil {
-		log.Error("Get pre-active proposal ID error")
-		return common.Hash{}
+// 查询预生效的升级提案，不存在时返回空hash
+func (self *GovDB) getPreActiveProposalID(blockHash common.Hash) (common.Hash, error) {
+	ids, err := self.snapdb.getPreActiveIDList(blockHash)
+	if err != nil || len(ids) == 0 {
+		return common.Hash{}, err
 	}
-	return value[0]
+	return ids[0], nil
 }
 
 // 把新增提案的ID增加到正在投票的提案队列中
-func (self *GovDB) addVotingProposalID(blockHash common.Hash, proposalID common.Hash, state xcom.StateDB) bool {
-	if err := govDB.snapdb.addProposalByKey(blockHash, KeyVotingProposals(), proposalID); err != nil {
-		log.Error("add voting proposal to snapshot db error:%s", err)
-		return false
-	}
-
-	return true
+func (self *GovDB) addVotingProposalID(blockHash common.Hash, proposalID common.Hash) error {
+	return self.snapdb.addProposalByKey(blockHash, KeyVotingProposals(), proposalID)
 }
 
 // 把提案的ID从正在投票的提案队列中移动到预激活中
-func (self *GovDB) moveVotingProposalIDToPreActive(blockHash common.Hash, proposalID common.Hash, state xcom.StateDB) bool {
-
-	voting, _ := self.snapdb.getVotingIDList(blockHash)
-	voting = remove(voting, proposalID)
-
-	pre, _ := self.snapdb.getPreActiveIDList(blockHash)
-	pre = append(pre, proposalID)
-
-	//重新写入
-	self.snapdb.addProposalByKey(blockHash, KeyVotingProposals(), proposalID)
-	self.snapdb.addProposalByKey(blockHash, KeyPreActiveProposals(), proposalID)
-
-	return true
+func (self *GovDB) moveVotingProposalIDToPreActive(blockHash common.Hash, proposalID common.Hash) error {
+	if err := self.snapdb.removeProposalByKey(blockHash, KeyVotingProposals(), proposalID); err != nil {
+		return err
+	}
+	return self.snapdb.addProposalByKey(blockHash, KeyPreActiveProposals(), proposalID)
 }
 
 func remove(list []common.Hash, item common.Hash) []common.Hash {
-	for i, id := range list {
-		if id == item {
-			list = append(list[:i], list[i+1:]...)
+	result := make([]common.Hash, 0, len(list))
+	for _, id := range list {
+		if id != item {
+			result = append(result, id)
 		}
 	}
-	return list
+	return result
 }
 
 // 把提案的ID从正在投票的提案队列中移动到投票结束的提案队列中
-func (self *GovDB) moveVotingProposalIDToEnd(blockHash common.Hash, proposalID common.Hash, state xcom.StateDB) bool {
+func (self *GovDB) moveVotingProposalIDToEnd(blockHash common.Hash, proposalID common.Hash) error {
+	if err := self.snapdb.removeProposalByKey(blockHash, KeyVotingProposals(), proposalID); err != nil {
+		return err
+	}
+	return self.snapdb.addProposalByKey(blockHash, KeyEndProposals(), proposalID)
+}
 
-	voting, _ := self.snapdb.getVotingIDList(blockHash)
-	voting = remove(voting, proposalID)
+// 把提案的ID从预激活的提案队列中移动到投票结束的提案队列中
+func (self *GovDB) movePreActiveProposalIDToEnd(blockHash common.Hash, proposalID common.Hash) error {
+	if err := self.snapdb.removeProposalByKey(blockHash, KeyPreActiveProposals(), proposalID); err != nil {
+		return err
+	}
+	return self.snapdb.addProposalByKey(blockHash, KeyEndProposals(), proposalID)
+}
 
-	end, _ := self.snapdb.getEndIDList(blockHash)
-	end = append(end, proposalID)
+// 增加升级提案投票期间版本声明的验证人/候选人记录
+func (self *GovDB) addDeclaredNode(blockHash common.Hash, proposalID common.Hash, nodeID discover.NodeID) error {
+	return self.snapdb.addDeclaredNode(blockHash, nodeID, proposalID)
+}
 
-	//重新写入
-	self.snapdb.addProposalByKey(blockHash, KeyVotingProposals(), proposalID)
-	self.snapdb.addProposalByKey(blockHash, KeyEndProposals(), proposalID)
+// 获取升级提案投票期间版本升声明的节点列表
+func (self *GovDB) getDeclaredNodeList(blockHash common.Hash, proposalID common.Hash) ([]discover.NodeID, error) {
+	return self.snapdb.getDeclaredNodeList(blockHash, proposalID)
+}
 
-	return true
+// 升级后，清除做过版本声明的节点
+func (self *GovDB) clearDeclaredNodes(blockHash common.Hash, proposalID common.Hash) error {
+	return self.snapdb.deleteDeclaredNodeList(blockHash, proposalID)
 }
 
-// 把提案的ID从预激活的提案队列中移动到投票结束的提案队列中
-func (self *GovDB) MovePreActiveProposalIDToEnd(blockHash common.Hash, proposalID common.Hash, state xcom.StateDB) bool {
+// 累计在结算周期内可投票的所有验证人
+func (self *GovDB) addVerifiers(blockHash common.Hash, proposalID common.Hash, verifierList []discover.NodeID) error {
+	return self.snapdb.addTotalVerifiers(blockHash, proposalID, verifierList)
+}
 
-	pre, _ := self.snapdb.getPreActiveIDList(blockHash)
-	pre = remove(pre, proposalID)
+// 获取所有可投票验证人总数
+func (self *GovDB) getVerifiersLength(blockHash common.Hash, proposalID common.Hash) (int, error) {
+	return self.snapdb.getTotalVerifierLen(blockHash, proposalID)
+}
 
-	end, _ := self.snapdb.getEndIDList(blockHash)
-	end = append(end, proposalID)
+// 保存参数提案修改后的参数值
+func (self *GovDB) setParamValue(name, value string, state xcom.StateDB) {
+	state.SetState(vm.GovContractAddr, KeyParamValue(name), []byte(value))
+}
 
-	//重新写入
-	self.snapdb.addProposalByKey(blockHash, KeyPreActiveProposals(), proposalID)
-	self.snapdb.addProposalByKey(blockHash, KeyEndProposals(), proposalID)
+// 增加已通过但未生效的参数修改，同一参数只保留最后一次的修改
+func (self *GovDB) addPendingParam(name, value string, state xcom.StateDB) error {
+	params, err := self.listPendingParams(state)
+	if err != nil {
+		return err
+	}
 
-	return true
-}
+	pending := make([]ParamValue, 0, len(params)+1)
+	for _, p := range params {
+		if p.Name != name {
+			pending = append(pending, p)
+		}
+	}
+	pending = append(pending, ParamValue{Name: name, Value: value})
 
-// 增加升级提案投票期间版本声明的验证人/候选人记录
-func (self *GovDB) addDeclaredNode(blockHash common.Hash, proposalID common.Hash, nodeID discover.NodeID) bool {
-	if err := self.snapdb.addDeclaredNode(blockHash, nodeID, proposalID); err != nil {
-		log.Error("add declared node to snapshot db error,", err)
-		return false
+	pendingBytes, err := json.Marshal(pending)
+	if err != nil {
+		return err
 	}
-	return true
+	state.SetState(vm.GovContractAddr, KeyPendingParams(), pendingBytes)
+	return nil
 }
 
-// 获取升级提案投票期间版本升声明的节点列表
-func (self *GovDB) getDeclaredNodeList(blockHash common.Hash, proposalID common.Hash) []discover.NodeID {
-	nodes, err := self.snapdb.getDeclaredNodeList(blockHash, proposalID)
-	if err != nil {
-		log.Error("get declared node list from snapshot db error,", err)
-		return nil
+// 查询已通过但未生效的参数修改
+func (self *GovDB) listPendingParams(state xcom.StateDB) ([]ParamValue, error) {
+	value := state.GetState(vm.GovContractAddr, KeyPendingParams())
+	if len(value) == 0 {
+		return nil, nil
 	}
-	return nodes
+
+	var params []ParamValue
+	if err := json.Unmarshal(value, &params); err != nil {
+		return nil, err
+	}
+	return params, nil
 }
 
-// 升级后，清除做过版本声明的节点
-func (self *GovDB) clearDeclaredNodes(blockHash common.Hash, proposalID common.Hash, nodeID discover.NodeID) bool {
-	err := self.snapdb.deleteDeclaredNodeList(blockHash, proposalID)
+// 参数修改生效后，清除未生效的参数修改
+func (self *GovDB) clearPendingParams(state xcom.StateDB) {
+	state.SetState(vm.GovContractAddr, KeyPendingParams(), []byte{})
+}
+
+// 保存提案的押金
+func (self *GovDB) setDeposit(proposalID common.Hash, deposit Deposit, state xcom.StateDB) error {
+	value, err := json.Marshal(deposit)
 	if err != nil {
-		log.Error("delete declared node list from snapshot db error,", err)
-		return false
+		return err
 	}
-	return true
+	state.SetState(vm.GovContractAddr, KeyDeposit(proposalID), value)
+	return nil
 }
 
-// 累计在结算周期内可投票的所有验证人
-func (self *GovDB) addVerifiers(blockHash common.Hash, proposalID common.Hash, verifierList []discover.NodeID) bool {
-	if err := self.snapdb.addTotalVerifiers(blockHash, proposalID, verifierList); err != nil {
-		log.Error("add total verifier to  snapshot db error,", err)
-		return false
+// 查询提案的押金，押金不存在或已退还时返回nil
+func (self *GovDB) getDeposit(proposalID common.Hash, state xcom.StateDB) (*Deposit, error) {
+	value := state.GetState(vm.GovContractAddr, KeyDeposit(proposalID))
+	if len(value) == 0 {
+		return nil, nil
 	}
-	return true
-}
 
-// 获取所有可投票验证人总数
-func (self *GovDB) getVerifiersLength(blockHash common.Hash, proposalID common.Hash, verifierList []discover.NodeID) int {
-	if l, err := self.snapdb.getTotalVerifierLen(blockHash, proposalID); err != nil {
-		log.Error("add total verifier to  snapshot db error,", err)
-		return 0
-	} else {
-		return l
+	var deposit Deposit
+	if err := json.Unmarshal(value, &deposit); err != nil {
+		return nil, err
 	}
+	return &deposit, nil
+}
+
+// 押金退还或销毁后，删除押金记录
+func (self *GovDB) delDeposit(proposalID common.Hash, state xcom.StateDB) {
+	state.SetState(vm.GovContractAddr, KeyDeposit(proposalID), []byte{})
 }
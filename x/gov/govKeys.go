@@ -3,20 +3,23 @@ package gov
 import (
 	"bytes"
 	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/PlatONnetwork/PlatON-Go/x/xcom"
 )
 
 var (
-	KeyDelimiter             = []byte(":")
-	keyPrefixProposal        = []byte("Proposal")
-	keyPrefixVote            = []byte("Vote")
-	keyPrefixTallyResult     = []byte("TallyResult")
-	keyPrefixVotingProposals = []byte("VotingProposals")
-	keyPrefixEndProposals    = []byte("EndProposals")
-	//keyPrefixPreActiveProposal = []byte("PreActiveProposal")
-	keyPrefixPreActiveVersion = []byte("PreActiveVersion")
-	keyPrefixActiveVersion    = []byte("ActiveVersion")
-	keyPrefixDeclaredNodes    = []byte("DeclaredNodes")
-	keyPrefixTotalVerifiers   = []byte("TotalVerifiers")
+	KeyDelimiter                = []byte(":")
+	keyPrefixProposal           = []byte("Proposal")
+	keyPrefixVote               = []byte("Vote")
+	keyPrefixTallyResult        = []byte("TallyResult")
+	keyPrefixVotingProposals    = []byte("VotingProposals")
+	keyPrefixEndProposals       = []byte("EndProposals")
+	keyPrefixPreActiveProposals = []byte("PreActiveProposals")
+	keyPrefixPreActiveVersion   = []byte("PreActiveVersion")
+	keyPrefixActiveVersion      = []byte("ActiveVersion")
+	keyPrefixDeclaredNodes      = []byte("DeclaredNodes")
+	keyPrefixTotalVerifiers     = []byte("TotalVerifiers")
+	keyPrefixPendingParams      = []byte("PendingParams")
+	keyPrefixDeposit            = []byte("Deposit")
 )
 
 // 提案的key
@@ -51,7 +54,7 @@ func KeyVotingProposals() []byte {
 
 // 预生效提案ID的key
 func KeyPreActiveProposals() []byte {
-	return keyPrefixEndProposals
+	return keyPrefixPreActiveProposals
 }
 
 // 所有操作均结束的提案列表的key
@@ -84,3 +87,21 @@ func KeyTotalVerifiers(proposalID common.Hash) []byte {
 		proposalID.Bytes(),
 	}, KeyDelimiter)
 }
+
+// 参数提案修改后的参数值的key
+func KeyParamValue(paramName string) []byte {
+	return xcom.GovernParamKey(paramName)
+}
+
+// 已通过但未生效的参数修改列表的key
+func KeyPendingParams() []byte {
+	return keyPrefixPendingParams
+}
+
+// 提案押金的key
+func KeyDeposit(proposalID common.Hash) []byte {
+	return bytes.Join([][]byte{
+		keyPrefixDeposit,
+		proposalID.Bytes(),
+	}, KeyDelimiter)
+}
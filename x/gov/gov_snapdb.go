@@ -15,8 +15,13 @@ func NewGovSnapshotDB(snapdb snapshotdb.DB) *GovSnapshotDB {
 	return &GovSnapshotDB{snapdb: snapdb}
 }
 
+// get returns nil value without error if the key is not found
 func (self *GovSnapshotDB) get(blockHash common.Hash, key []byte) ([]byte, error) {
-	return self.snapdb.Get(blockHash, key)
+	value, err := self.snapdb.Get(blockHash, key)
+	if err == snapshotdb.ErrNotFound {
+		return nil, nil
+	}
+	return value, err
 }
 
 func (self *GovSnapshotDB) put(blockHash common.Hash, key, value []byte) error {
@@ -27,9 +32,8 @@ func (self *GovSnapshotDB) del(blockHash common.Hash, key []byte) error {
 	return self.snapdb.Del(blockHash, key)
 }
 
-//传递进来的value是type+proposal的字节数组
+//把提案ID追加到key对应的列表中
 func (self *GovSnapshotDB) addProposalByKey(blockHash common.Hash, key []byte, proposalId common.Hash) error {
-	//获取的是rpl编码后的结果
 	hashes, err := self.getProposalIDListByKey(blockHash, key)
 	if err != nil {
 		return err
@@ -37,7 +41,24 @@ func (self *GovSnapshotDB) addProposalByKey(blockHash common.Hash, key []byte, p
 
 	hashes = append(hashes, proposalId)
 
-	//重新设置,在编码
+	return self.setProposalIDListByKey(blockHash, key, hashes)
+}
+
+//把提案ID从key对应的列表中删除
+func (self *GovSnapshotDB) removeProposalByKey(blockHash common.Hash, key []byte, proposalId common.Hash) error {
+	hashes, err := self.getProposalIDListByKey(blockHash, key)
+	if err != nil {
+		return err
+	}
+
+	return self.setProposalIDListByKey(blockHash, key, remove(hashes, proposalId))
+}
+
+func (self *GovSnapshotDB) setProposalIDListByKey(blockHash common.Hash, key []byte, hashes []common.Hash) error {
+	if len(hashes) == 0 {
+		return self.del(blockHash, key)
+	}
+
 	value, err := rlp.EncodeToBytes(hashes)
 	if err != nil {
 		return err
@@ -64,8 +85,11 @@ func (self *GovSnapshotDB) getProposalIDListByKey(blockHash common.Hash, key []b
 		return nil, err
 	}
 
-	var idList []common.Hash
+	if len(bytes) == 0 {
+		return nil, nil
+	}
 
+	var idList []common.Hash
 	if err = rlp.DecodeBytes(bytes, &idList); err != nil {
 		return nil, err
 	}
@@ -76,18 +100,27 @@ func (self *GovSnapshotDB) getProposalIDListByKey(blockHash common.Hash, key []b
 func (self *GovSnapshotDB) getAllProposalIDList(blockHash common.Hash) ([]common.Hash, error) {
 	var total []common.Hash
 
-	hashes, _ := self.getVotingIDList(blockHash)
+	hashes, err := self.getVotingIDList(blockHash)
+	if err != nil {
+		return nil, err
+	}
 	total = append(total, hashes...)
 
-	hashes, _ = self.getPreActiveIDList(blockHash)
+	hashes, err = self.getPreActiveIDList(blockHash)
+	if err != nil {
+		return nil, err
+	}
 	total = append(total, hashes...)
 
-	hashes, _ = self.getEndIDList(blockHash)
+	hashes, err = self.getEndIDList(blockHash)
+	if err != nil {
+		return nil, err
+	}
 	total = append(total, hashes...)
 	return total, nil
 }
 
-//
+//增加声明过新版本的节点，重复声明的节点只记录一次
 func (self *GovSnapshotDB) addDeclaredNode(blockHash common.Hash, node discover.NodeID, proposalId common.Hash) error {
 
 	nodes, err := self.getDeclaredNodeList(blockHash, proposalId)
@@ -95,6 +128,10 @@ func (self *GovSnapshotDB) addDeclaredNode(blockHash common.Hash, node discover.
 		return err
 	}
 
+	if inNodeList(node, nodes) {
+		return nil
+	}
+
 	nodes = append(nodes, node)
 
 	value, err := rlp.EncodeToBytes(nodes)
@@ -102,8 +139,7 @@ func (self *GovSnapshotDB) addDeclaredNode(blockHash common.Hash, node discover.
 		return err
 	}
 
-	self.put(blockHash, keyPrefixDeclaredNodes, value)
-	return nil
+	return self.put(blockHash, KeyDeclaredNodes(proposalId), value)
 }
 
 func (self *GovSnapshotDB) getDeclaredNodeList(blockHash common.Hash, proposalId common.Hash) ([]discover.NodeID, error) {
@@ -111,6 +147,11 @@ func (self *GovSnapshotDB) getDeclaredNodeList(blockHash common.Hash, proposalId
 	if err != nil {
 		return nil, err
 	}
+
+	if len(value) == 0 {
+		return nil, nil
+	}
+
 	var nodes []discover.NodeID
 	if err := rlp.DecodeBytes(value, &nodes); err != nil {
 		return nil, err
@@ -122,27 +163,49 @@ func (self *GovSnapshotDB) deleteDeclaredNodeList(blockHash common.Hash, proposa
 	return self.del(blockHash, KeyDeclaredNodes(proposalId))
 }
 
+//累积提案投票期内的验证人，重复的验证人只记录一次
 func (self *GovSnapshotDB) addTotalVerifiers(blockHash common.Hash, proposalId common.Hash, nodes []discover.NodeID) error {
-	value, err := self.get(blockHash, KeyTotalVerifiers(proposalId))
+	verifiers, err := self.getTotalVerifierList(blockHash, proposalId)
 	if err != nil {
 		return err
 	}
 
-	var verifiers []discover.NodeID
-	if err := rlp.DecodeBytes(value, &verifiers); err != nil {
+	for _, node := range nodes {
+		if !inNodeList(node, verifiers) {
+			verifiers = append(verifiers, node)
+		}
+	}
+
+	value, err := rlp.EncodeToBytes(verifiers)
+	if err != nil {
 		return err
 	}
 
-	verifiers = append(verifiers, nodes...)
+	return self.put(blockHash, KeyTotalVerifiers(proposalId), value)
+}
+
+func (self *GovSnapshotDB) getTotalVerifierList(blockHash common.Hash, proposalId common.Hash) ([]discover.NodeID, error) {
+	value, err := self.get(blockHash, KeyTotalVerifiers(proposalId))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(value) == 0 {
+		return nil, nil
+	}
 
-	return nil
+	var verifiers []discover.NodeID
+	if err := rlp.DecodeBytes(value, &verifiers); err != nil {
+		return nil, err
+	}
+	return verifiers, nil
 }
 
 func (self *GovSnapshotDB) getTotalVerifierLen(blockHash common.Hash, proposalId common.Hash) (int, error) {
-	value, err := self.get(blockHash, KeyTotalVerifiers(proposalId))
+	verifiers, err := self.getTotalVerifierList(blockHash, proposalId)
 	if err != nil {
 		return 0, err
 	}
 
-	return len(value), nil
+	return len(verifiers), nil
 }
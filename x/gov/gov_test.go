@@ -3,9 +3,18 @@ package gov
 import (
 	"bytes"
 	"fmt"
+	"math/big"
 	"testing"
-)
 
+	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/PlatONnetwork/PlatON-Go/common/vm"
+	"github.com/PlatONnetwork/PlatON-Go/core/snapshotdb"
+	"github.com/PlatONnetwork/PlatON-Go/core/types"
+	"github.com/PlatONnetwork/PlatON-Go/crypto"
+	"github.com/PlatONnetwork/PlatON-Go/p2p/discover"
+	"github.com/PlatONnetwork/PlatON-Go/x/plugin"
+	"github.com/PlatONnetwork/PlatON-Go/x/xcom"
+)
 
 func TestSplit(t *testing.T) {
 
@@ -14,3 +23,600 @@ func TestSplit(t *testing.T) {
 	fmt.Println(a[1])
 }
 
+// mockStateDB only implements the storage and the balance of the xcom.StateDB
+type mockStateDB struct {
+	xcom.StateDB
+	storage  map[string][]byte
+	balances map[common.Address]*big.Int
+}
+
+func newMockStateDB() *mockStateDB {
+	return &mockStateDB{storage: make(map[string][]byte), balances: make(map[common.Address]*big.Int)}
+}
+
+func (s *mockStateDB) GetBalance(addr common.Address) *big.Int {
+	if balance, ok := s.balances[addr]; ok {
+		return balance
+	}
+	return new(big.Int)
+}
+
+func (s *mockStateDB) AddBalance(addr common.Address, amount *big.Int) {
+	s.balances[addr] = new(big.Int).Add(s.GetBalance(addr), amount)
+}
+
+func (s *mockStateDB) SubBalance(addr common.Address, amount *big.Int) {
+	s.balances[addr] = new(big.Int).Sub(s.GetBalance(addr), amount)
+}
+
+func (s *mockStateDB) GetState(addr common.Address, key []byte) []byte {
+	return s.storage[string(append(addr.Bytes(), key...))]
+}
+
+func (s *mockStateDB) SetState(addr common.Address, key []byte, value []byte) {
+	s.storage[string(append(addr.Bytes(), key...))] = value
+}
+
+// mockSnapshotDB ignores the block hash and keeps all the data in memory
+type mockSnapshotDB struct {
+	snapshotdb.DB
+	data map[string][]byte
+}
+
+func newMockSnapshotDB() *mockSnapshotDB {
+	return &mockSnapshotDB{data: make(map[string][]byte)}
+}
+
+func (db *mockSnapshotDB) Put(hash common.Hash, key, value []byte) error {
+	db.data[string(key)] = value
+	return nil
+}
+
+func (db *mockSnapshotDB) Get(hash common.Hash, key []byte) ([]byte, error) {
+	if value, ok := db.data[string(key)]; ok {
+		return value, nil
+	}
+	return nil, snapshotdb.ErrNotFound
+}
+
+func (db *mockSnapshotDB) Del(hash common.Hash, key []byte) error {
+	delete(db.data, string(key))
+	return nil
+}
+
+type mockStaking struct {
+	candidates map[common.Address]*xcom.Candidate
+	verifiers  xcom.CandidateQueue
+}
+
+func (s *mockStaking) GetVerifierList(blockHash common.Hash, blockNumber uint64) (xcom.CandidateQueue, bool, error) {
+	return s.verifiers, true, nil
+}
+
+func (s *mockStaking) GetValidatorList(blockHash common.Hash, blockNumber uint64, flag int) (xcom.ValidatorExQueue, bool, error) {
+	queue := make(xcom.ValidatorExQueue, 0, len(s.verifiers))
+	for _, can := range s.verifiers {
+		queue = append(queue, &xcom.ValidatorEx{Candidate: can})
+	}
+	return queue, true, nil
+}
+
+func (s *mockStaking) GetCandidateInfo(blockHash common.Hash, addr common.Address) (*xcom.Candidate, error) {
+	return s.candidates[addr], nil
+}
+
+func (s *mockStaking) addCandidate(t *testing.T) *xcom.Candidate {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	can := &xcom.Candidate{
+		NodeId:         discover.PubkeyID(&key.PublicKey),
+		StakingAddress: common.BytesToAddress(crypto.Keccak256(key.D.Bytes())),
+	}
+	s.candidates[crypto.PubkeyToAddress(key.PublicKey)] = can
+	s.verifiers = append(s.verifiers, can)
+	return can
+}
+
+func newTestGov(t *testing.T, verifierCount int) (*Gov, *mockStaking, *mockStateDB) {
+	staking := &mockStaking{candidates: make(map[common.Address]*xcom.Candidate)}
+	for i := 0; i < verifierCount; i++ {
+		staking.addCandidate(t)
+	}
+	state := newMockStateDB()
+	for _, can := range staking.verifiers {
+		state.AddBalance(can.StakingAddress, new(big.Int).Mul(xcom.ProposalDeposit, big.NewInt(10)))
+	}
+	govDB := &GovDB{snapdb: NewGovSnapshotDB(newMockSnapshotDB())}
+	return &Gov{govDB: govDB, staking: staking, restricting: plugin.NewRestrictingPlugin()}, staking, state
+}
+
+func newTestTextProposal(id byte, proposer discover.NodeID, endVotingBlock uint64) *TextProposal {
+	return &TextProposal{
+		ProposalID:     common.Hash{id},
+		GithubID:       "p#01",
+		ProposalType:   Text,
+		Topic:          "up,up,up....",
+		Desc:           "text proposal",
+		Url:            "https://github.com/PlatONnetwork/PlatON-Go",
+		SubmitBlock:    1,
+		EndVotingBlock: endVotingBlock,
+		Proposer:       proposer,
+	}
+}
+
+func header(blockNumber uint64) *types.Header {
+	return &types.Header{Number: new(big.Int).SetUint64(blockNumber)}
+}
+
+func vote(t *testing.T, gov *Gov, can *xcom.Candidate, proposalID common.Hash, option VoteOption, blockNumber uint64, state xcom.StateDB) {
+	v := Vote{ProposalID: proposalID, VoteNodeID: can.NodeId, VoteOption: option}
+	if _, err := gov.Vote(can.StakingAddress, v, common.ZeroHash, blockNumber, state); err != nil {
+		t.Fatalf("vote failed: %v", err)
+	}
+}
+
+func endBlock(t *testing.T, gov *Gov, blockNumber uint64, state xcom.StateDB) {
+	if _, err := gov.EndBlock(common.ZeroHash, header(blockNumber), state); err != nil {
+		t.Fatalf("end block %d failed: %v", blockNumber, err)
+	}
+}
+
+func TestGov_TextProposalPass(t *testing.T) {
+	gov, staking, state := newTestGov(t, 4)
+	proposer := staking.verifiers[0]
+
+	p := newTestTextProposal(0x01, proposer.NodeId, 20)
+	if _, err := gov.Submit(1, proposer.StakingAddress, p, common.ZeroHash, state); err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+
+	for _, can := range staking.verifiers {
+		vote(t, gov, can, p.ProposalID, Yes, 10, state)
+	}
+
+	endBlock(t, gov, 19, state)
+	if result, _ := gov.GetTallyResult(p.ProposalID, state); result != nil {
+		t.Fatalf("the proposal should not be tallied before the end voting block")
+	}
+
+	endBlock(t, gov, 20, state)
+	result, err := gov.GetTallyResult(p.ProposalID, state)
+	if err != nil || result == nil {
+		t.Fatalf("get tally result failed: %v", err)
+	}
+	if result.Status != Pass || result.Yeas != 4 || result.AccuVerifiers != 4 {
+		t.Errorf("unexpected tally result: %+v", result)
+	}
+
+	endIDs, _ := gov.govDB.listEndProposalID(common.ZeroHash)
+	votingIDs, _ := gov.govDB.listVotingProposal(common.ZeroHash)
+	if len(endIDs) != 1 || len(votingIDs) != 0 {
+		t.Errorf("the proposal should be moved to the end list, voting: %d, end: %d", len(votingIDs), len(endIDs))
+	}
+}
+
+func TestGov_TextProposalFailed(t *testing.T) {
+	gov, staking, state := newTestGov(t, 4)
+	proposer := staking.verifiers[0]
+
+	p := newTestTextProposal(0x01, proposer.NodeId, 20)
+	if _, err := gov.Submit(1, proposer.StakingAddress, p, common.ZeroHash, state); err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+
+	vote(t, gov, staking.verifiers[0], p.ProposalID, Yes, 10, state)
+	vote(t, gov, staking.verifiers[1], p.ProposalID, Yes, 10, state)
+	vote(t, gov, staking.verifiers[2], p.ProposalID, No, 10, state)
+
+	endBlock(t, gov, 20, state)
+	result, _ := gov.GetTallyResult(p.ProposalID, state)
+	if result == nil || result.Status != Failed || result.Yeas != 2 || result.Nays != 1 {
+		t.Errorf("unexpected tally result: %+v", result)
+	}
+}
+
+func TestGov_SubmitAndVoteErrors(t *testing.T) {
+	gov, staking, state := newTestGov(t, 2)
+	proposer := staking.verifiers[0]
+
+	p := newTestTextProposal(0x01, proposer.NodeId, 20)
+	if _, err := gov.Submit(1, staking.verifiers[1].StakingAddress, p, common.ZeroHash, state); err != TxSenderNotStaking {
+		t.Errorf("expect %v, got %v", TxSenderNotStaking, err)
+	}
+	if _, err := gov.Submit(1, proposer.StakingAddress, p, common.ZeroHash, state); err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+	if _, err := gov.Submit(1, proposer.StakingAddress, p, common.ZeroHash, state); err != ProposalIDExist {
+		t.Errorf("expect %v, got %v", ProposalIDExist, err)
+	}
+
+	vote(t, gov, proposer, p.ProposalID, Yes, 10, state)
+	v := Vote{ProposalID: p.ProposalID, VoteNodeID: proposer.NodeId, VoteOption: No}
+	if _, err := gov.Vote(proposer.StakingAddress, v, common.ZeroHash, 10, state); err != VoteDuplicated {
+		t.Errorf("expect %v, got %v", VoteDuplicated, err)
+	}
+
+	v = Vote{ProposalID: common.Hash{0x02}, VoteNodeID: proposer.NodeId, VoteOption: Yes}
+	if _, err := gov.Vote(proposer.StakingAddress, v, common.ZeroHash, 10, state); err != ProposalNotExist {
+		t.Errorf("expect %v, got %v", ProposalNotExist, err)
+	}
+}
+
+func TestGov_VersionProposalActive(t *testing.T) {
+	gov, staking, state := newTestGov(t, 4)
+	proposer := staking.verifiers[0]
+
+	endVotingBlock := uint64(20)
+	activeBlock := endVotingBlock + xcom.VersionProposalMinActiveRounds*xcom.ConsensusSize
+	newVersion := uint32(1<<16 | 1<<8)
+
+	p := &VersionProposal{
+		TextProposal: *newTestTextProposal(0x01, proposer.NodeId, endVotingBlock),
+		NewVersion:   newVersion,
+		ActiveBlock:  activeBlock,
+	}
+	p.ProposalType = Version
+	if _, err := gov.Submit(1, proposer.StakingAddress, p, common.ZeroHash, state); err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+
+	// only one version proposal is allowed at the same time
+	another := *p
+	another.ProposalID = common.Hash{0x02}
+	if _, err := gov.Submit(1, proposer.StakingAddress, &another, common.ZeroHash, state); err != VersionProposalExist {
+		t.Errorf("expect %v, got %v", VersionProposalExist, err)
+	}
+
+	for _, can := range staking.verifiers[:3] {
+		vote(t, gov, can, p.ProposalID, Yes, 10, state)
+	}
+	vote(t, gov, staking.verifiers[3], p.ProposalID, Abstention, 10, state)
+
+	// 3/4 yeas is less than the threshold
+	endBlock(t, gov, endVotingBlock, state)
+	result, _ := gov.GetTallyResult(p.ProposalID, state)
+	if result == nil || result.Status != Failed {
+		t.Fatalf("unexpected tally result: %+v", result)
+	}
+
+	p.ProposalID = common.Hash{0x03}
+	p.SubmitBlock = 30
+	p.EndVotingBlock = 40
+	p.ActiveBlock = p.EndVotingBlock + xcom.VersionProposalMinActiveRounds*xcom.ConsensusSize
+	if _, err := gov.Submit(30, proposer.StakingAddress, p, common.ZeroHash, state); err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+	for _, can := range staking.verifiers {
+		vote(t, gov, can, p.ProposalID, Yes, 35, state)
+	}
+
+	endBlock(t, gov, p.EndVotingBlock, state)
+	result, _ = gov.GetTallyResult(p.ProposalID, state)
+	if result == nil || result.Status != PreActive {
+		t.Fatalf("unexpected tally result: %+v", result)
+	}
+	if gov.GetPreActiveVersion(state) != newVersion {
+		t.Errorf("expect pre-active version %d, got %d", newVersion, gov.GetPreActiveVersion(state))
+	}
+
+	// declaring the version which is not proposed is rejected
+	if _, err := gov.DeclareVersion(proposer.StakingAddress, proposer.NodeId, 2<<16, common.ZeroHash, state); err != DeclareVersionInvalid {
+		t.Errorf("expect %v, got %v", DeclareVersionInvalid, err)
+	}
+	if _, err := gov.DeclareVersion(proposer.StakingAddress, proposer.NodeId, newVersion, common.ZeroHash, state); err != nil {
+		t.Errorf("declare version failed: %v", err)
+	}
+
+	endBlock(t, gov, p.ActiveBlock-1, state)
+	if gov.GetActiveVersion(state) != 0 {
+		t.Fatalf("the version should not be active before the active block")
+	}
+
+	endBlock(t, gov, p.ActiveBlock, state)
+	result, _ = gov.GetTallyResult(p.ProposalID, state)
+	if result == nil || result.Status != Active {
+		t.Fatalf("unexpected tally result: %+v", result)
+	}
+	if gov.GetActiveVersion(state) != newVersion || gov.GetPreActiveVersion(state) != 0 {
+		t.Errorf("unexpected version, active: %d, pre-active: %d", gov.GetActiveVersion(state), gov.GetPreActiveVersion(state))
+	}
+	if declared, _ := gov.govDB.getDeclaredNodeList(common.ZeroHash, p.ProposalID); len(declared) != 0 {
+		t.Errorf("the declared nodes should be cleared")
+	}
+
+	proposals, err := gov.ListProposal(common.ZeroHash, state)
+	if err != nil || len(proposals) != 2 {
+		t.Errorf("expect 2 proposals, got %d, err: %v", len(proposals), err)
+	}
+}
+
+func TestGov_AccumulateVerifiers(t *testing.T) {
+	gov, staking, state := newTestGov(t, 2)
+	proposer := staking.verifiers[0]
+
+	settlement := xcom.ConsensusSize * xcom.EpochSize
+	p := newTestTextProposal(0x01, proposer.NodeId, settlement+10)
+	if _, err := gov.Submit(settlement-10, proposer.StakingAddress, p, common.ZeroHash, state); err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+
+	staking.addCandidate(t)
+	if _, err := gov.BeginBlock(common.ZeroHash, header(settlement+1), state); err != nil {
+		t.Fatalf("begin block failed: %v", err)
+	}
+
+	if n, _ := gov.govDB.getVerifiersLength(common.ZeroHash, p.ProposalID); n != 3 {
+		t.Errorf("expect 3 accumulated verifiers, got %d", n)
+	}
+}
+
+func TestGov_ParamProposal(t *testing.T) {
+	gov, staking, state := newTestGov(t, 4)
+	proposer := staking.verifiers[0]
+
+	settlement := xcom.ConsensusSize * xcom.EpochSize
+	defaultValue := xcom.UnStakeFreezeRatio
+
+	newParamProposal := func(id byte, name, value string) *ParamProposal {
+		p := &ParamProposal{
+			TextProposal: *newTestTextProposal(id, proposer.NodeId, 20),
+			ParamName:    name,
+			NewValue:     value,
+		}
+		p.ProposalType = Param
+		return p
+	}
+
+	if _, err := gov.Submit(1, proposer.StakingAddress, newParamProposal(0x01, "EpochSize", "10"), common.ZeroHash, state); err != xcom.ParamNotGovernable {
+		t.Errorf("expect %v, got %v", xcom.ParamNotGovernable, err)
+	}
+	if _, err := gov.Submit(1, proposer.StakingAddress, newParamProposal(0x01, xcom.ParamUnStakeFreezeRatio, "1000"), common.ZeroHash, state); err != xcom.ParamValueOutRange {
+		t.Errorf("expect %v, got %v", xcom.ParamValueOutRange, err)
+	}
+
+	p := newParamProposal(0x01, xcom.ParamUnStakeFreezeRatio, "3")
+	if _, err := gov.Submit(1, proposer.StakingAddress, p, common.ZeroHash, state); err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+	if _, err := gov.Submit(1, proposer.StakingAddress, newParamProposal(0x02, xcom.ParamUnStakeFreezeRatio, "4"), common.ZeroHash, state); err != ParamProposalExist {
+		t.Errorf("expect %v, got %v", ParamProposalExist, err)
+	}
+
+	for _, can := range staking.verifiers {
+		vote(t, gov, can, p.ProposalID, Yes, 10, state)
+	}
+	endBlock(t, gov, 20, state)
+	if result, _ := gov.GetTallyResult(p.ProposalID, state); result == nil || result.Status != Pass {
+		t.Fatalf("unexpected tally result: %+v", result)
+	}
+
+	// the new value does not take effect until the next epoch
+	if _, err := gov.BeginBlock(common.ZeroHash, header(21), state); err != nil {
+		t.Fatalf("begin block failed: %v", err)
+	}
+	if value, _ := gov.GetParamValue(xcom.ParamUnStakeFreezeRatio, state); value != fmt.Sprint(defaultValue) {
+		t.Errorf("the param should not be changed before the next epoch, got %s", value)
+	}
+
+	if _, err := gov.BeginBlock(common.ZeroHash, header(settlement+1), state); err != nil {
+		t.Fatalf("begin block failed: %v", err)
+	}
+	if value, _ := gov.GetParamValue(xcom.ParamUnStakeFreezeRatio, state); value != "3" {
+		t.Errorf("expect the param changed to 3, got %s", value)
+	}
+	if value := xcom.GovernUint64(state, xcom.ParamUnStakeFreezeRatio); value != 3 {
+		t.Errorf("expect the plugins read the param 3, got %d", value)
+	}
+
+	// the param is read from the state of each block, the config
+	// and the blocks on the other forks are not affected
+	if xcom.UnStakeFreezeRatio != defaultValue {
+		t.Errorf("the config should not be changed, got %d", xcom.UnStakeFreezeRatio)
+	}
+	if value := xcom.GovernUint64(newMockStateDB(), xcom.ParamUnStakeFreezeRatio); value != defaultValue {
+		t.Errorf("expect the default value %d on another fork, got %d", defaultValue, value)
+	}
+}
+
+func TestGov_ParamProposalConflict(t *testing.T) {
+	gov, staking, state := newTestGov(t, 4)
+	proposer := staking.verifiers[0]
+
+	defaultCons, defaultShift := xcom.ConsValidatorNum, xcom.ShiftValidatorNum
+
+	newParamProposal := func(id byte, name, value string) *ParamProposal {
+		p := &ParamProposal{
+			TextProposal: *newTestTextProposal(id, proposer.NodeId, 20),
+			ParamName:    name,
+			NewValue:     value,
+		}
+		p.ProposalType = Param
+		return p
+	}
+
+	// more validators shifted out than the consensus validators
+	shift := fmt.Sprint(defaultCons + 1)
+	if _, err := gov.Submit(1, proposer.StakingAddress, newParamProposal(0x01, xcom.ParamShiftValidatorNum, shift), common.ZeroHash, state); err != xcom.ParamValueConflict {
+		t.Errorf("expect %v, got %v", xcom.ParamValueConflict, err)
+	}
+
+	// the passed change is taken into account
+	if err := gov.govDB.addPendingParam(xcom.ParamConsValidatorNum, fmt.Sprint(defaultShift), state); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gov.Submit(1, proposer.StakingAddress, newParamProposal(0x02, xcom.ParamShiftValidatorNum, fmt.Sprint(defaultShift+1)), common.ZeroHash, state); err != xcom.ParamValueConflict {
+		t.Errorf("expect %v, got %v", xcom.ParamValueConflict, err)
+	}
+
+	// the conflicting one of the passed changes is discarded on applying
+	if err := gov.govDB.addPendingParam(xcom.ParamShiftValidatorNum, fmt.Sprint(defaultShift+1), state); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gov.BeginBlock(common.ZeroHash, header(xcom.ConsensusSize*xcom.EpochSize+1), state); err != nil {
+		t.Fatalf("begin block failed: %v", err)
+	}
+	consNum, shiftNum := xcom.GovernUint64(state, xcom.ParamConsValidatorNum), xcom.GovernUint64(state, xcom.ParamShiftValidatorNum)
+	if consNum != defaultShift || shiftNum != defaultShift {
+		t.Errorf("unexpected params, ConsValidatorNum: %d, ShiftValidatorNum: %d", consNum, shiftNum)
+	}
+}
+
+func TestGov_Deposit(t *testing.T) {
+	gov, staking, state := newTestGov(t, 4)
+	proposer := staking.verifiers[0]
+	balance := new(big.Int).Set(state.GetBalance(proposer.StakingAddress))
+
+	// the deposit is refunded if the proposal reaches the quorum
+	p := newTestTextProposal(0x01, proposer.NodeId, 20)
+	if _, err := gov.Submit(1, proposer.StakingAddress, p, common.ZeroHash, state); err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+	locked := new(big.Int).Sub(balance, xcom.ProposalDeposit)
+	if state.GetBalance(proposer.StakingAddress).Cmp(locked) != 0 || state.GetBalance(vm.GovContractAddr).Cmp(xcom.ProposalDeposit) != 0 {
+		t.Fatalf("the deposit is not locked, balance: %v", state.GetBalance(proposer.StakingAddress))
+	}
+	vote(t, gov, staking.verifiers[0], p.ProposalID, No, 10, state)
+	vote(t, gov, staking.verifiers[1], p.ProposalID, No, 10, state)
+	endBlock(t, gov, 20, state)
+	if state.GetBalance(proposer.StakingAddress).Cmp(balance) != 0 || state.GetBalance(vm.GovContractAddr).Sign() != 0 {
+		t.Errorf("the deposit is not refunded, balance: %v", state.GetBalance(proposer.StakingAddress))
+	}
+
+	// the deposit is burned if the proposal fails to reach the quorum
+	p = newTestTextProposal(0x02, proposer.NodeId, 40)
+	if _, err := gov.Submit(21, proposer.StakingAddress, p, common.ZeroHash, state); err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+	vote(t, gov, staking.verifiers[0], p.ProposalID, Yes, 30, state)
+	endBlock(t, gov, 40, state)
+	if state.GetBalance(proposer.StakingAddress).Cmp(locked) != 0 || state.GetBalance(vm.GovContractAddr).Sign() != 0 {
+		t.Errorf("the deposit is not burned, balance: %v", state.GetBalance(proposer.StakingAddress))
+	}
+
+	poor := staking.addCandidate(t)
+	p = newTestTextProposal(0x03, poor.NodeId, 60)
+	if _, err := gov.Submit(41, poor.StakingAddress, p, common.ZeroHash, state); err != DepositInsufficient {
+		t.Errorf("expect %v, got %v", DepositInsufficient, err)
+	}
+}
+
+func TestGov_RestrictedDeposit(t *testing.T) {
+	gov, staking, state := newTestGov(t, 4)
+	proposer := staking.addCandidate(t)
+	rp := plugin.NewRestrictingPlugin()
+
+	// the proposer has only the restricted von
+	sender := common.HexToAddress("0x01")
+	restricted := new(big.Int).Mul(xcom.ProposalDeposit, big.NewInt(2))
+	state.AddBalance(sender, restricted)
+	if _, err := rp.AddRestrictingRecord(sender, proposer.StakingAddress, []xcom.RestrictingPlan{{Epoch: 10, Amount: restricted}}, 1, state); err != nil {
+		t.Fatal(err)
+	}
+	assertRestricting := func(balance, pledge *big.Int) {
+		t.Helper()
+		info, err := rp.GetRestrictingInfo(proposer.StakingAddress, state)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Balance.Cmp(balance) != 0 || info.Pledge.Cmp(pledge) != 0 {
+			t.Errorf("unexpected restricting info, balance: %v, pledge: %v", info.Balance, info.Pledge)
+		}
+		if state.GetBalance(vm.StakingContractAddr).Sign() != 0 || state.GetBalance(proposer.StakingAddress).Sign() != 0 {
+			t.Errorf("unexpected balance, staking contract: %v, proposer: %v", state.GetBalance(vm.StakingContractAddr), state.GetBalance(proposer.StakingAddress))
+		}
+	}
+
+	// the deposit is refunded to the restricted von
+	p := newTestTextProposal(0x01, proposer.NodeId, 20)
+	if _, err := gov.Submit(1, proposer.StakingAddress, p, common.ZeroHash, state); err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+	assertRestricting(xcom.ProposalDeposit, xcom.ProposalDeposit)
+	if state.GetBalance(vm.GovContractAddr).Cmp(xcom.ProposalDeposit) != 0 {
+		t.Fatalf("the deposit is not locked, gov contract: %v", state.GetBalance(vm.GovContractAddr))
+	}
+	vote(t, gov, staking.verifiers[0], p.ProposalID, Yes, 10, state)
+	vote(t, gov, staking.verifiers[1], p.ProposalID, Yes, 10, state)
+	vote(t, gov, staking.verifiers[2], p.ProposalID, Yes, 10, state)
+	endBlock(t, gov, 20, state)
+	assertRestricting(restricted, new(big.Int))
+
+	// the deposit is burned from the restricted von
+	p = newTestTextProposal(0x02, proposer.NodeId, 40)
+	if _, err := gov.Submit(21, proposer.StakingAddress, p, common.ZeroHash, state); err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+	endBlock(t, gov, 40, state)
+	assertRestricting(xcom.ProposalDeposit, new(big.Int))
+	if state.GetBalance(vm.GovContractAddr).Sign() != 0 {
+		t.Errorf("the deposit is not burned, gov contract: %v", state.GetBalance(vm.GovContractAddr))
+	}
+}
+
+func TestGov_CancelProposal(t *testing.T) {
+	gov, staking, state := newTestGov(t, 4)
+	proposer := staking.verifiers[0]
+
+	endVotingBlock := uint64(100)
+	p := &VersionProposal{
+		TextProposal: *newTestTextProposal(0x01, proposer.NodeId, endVotingBlock),
+		NewVersion:   uint32(1<<16 | 1<<8),
+		ActiveBlock:  endVotingBlock + xcom.VersionProposalMinActiveRounds*xcom.ConsensusSize,
+	}
+	p.ProposalType = Version
+	if _, err := gov.Submit(1, proposer.StakingAddress, p, common.ZeroHash, state); err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+
+	newCancelProposal := func(id byte, tobeCanceled common.Hash, endVotingBlock uint64) *CancelProposal {
+		cp := &CancelProposal{
+			TextProposal: *newTestTextProposal(id, proposer.NodeId, endVotingBlock),
+			TobeCanceled: tobeCanceled,
+		}
+		cp.ProposalType = Cancel
+		return cp
+	}
+
+	if _, err := gov.Submit(2, proposer.StakingAddress, newCancelProposal(0x02, common.Hash{0x09}, 20), common.ZeroHash, state); err != TobeCanceledNotVoting {
+		t.Errorf("expect %v, got %v", TobeCanceledNotVoting, err)
+	}
+	if _, err := gov.Submit(2, proposer.StakingAddress, newCancelProposal(0x02, p.ProposalID, endVotingBlock), common.ZeroHash, state); err != CancelEndVotingTooLate {
+		t.Errorf("expect %v, got %v", CancelEndVotingTooLate, err)
+	}
+
+	cp := newCancelProposal(0x02, p.ProposalID, 20)
+	if _, err := gov.Submit(2, proposer.StakingAddress, cp, common.ZeroHash, state); err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+	if _, err := gov.Submit(2, proposer.StakingAddress, newCancelProposal(0x03, p.ProposalID, 20), common.ZeroHash, state); err != CancelProposalExist {
+		t.Errorf("expect %v, got %v", CancelProposalExist, err)
+	}
+
+	vote(t, gov, staking.verifiers[1], p.ProposalID, Yes, 10, state)
+	for _, can := range staking.verifiers {
+		vote(t, gov, can, cp.ProposalID, Yes, 10, state)
+	}
+	endBlock(t, gov, 20, state)
+
+	if result, _ := gov.GetTallyResult(cp.ProposalID, state); result == nil || result.Status != Pass {
+		t.Fatalf("unexpected tally result of the cancel proposal: %+v", result)
+	}
+	result, _ := gov.GetTallyResult(p.ProposalID, state)
+	if result == nil || result.Status != Canceled || result.Yeas != 1 {
+		t.Fatalf("unexpected tally result of the canceled proposal: %+v", result)
+	}
+	if votingIDs, _ := gov.govDB.listVotingProposal(common.ZeroHash); len(votingIDs) != 0 {
+		t.Errorf("the canceled proposal should not be in voting")
+	}
+	if state.GetBalance(vm.GovContractAddr).Sign() != 0 {
+		t.Errorf("the deposits should be refunded, left: %v", state.GetBalance(vm.GovContractAddr))
+	}
+
+	// the version proposal is allowed after the previous one was canceled
+	p.ProposalID = common.Hash{0x04}
+	if _, err := gov.Submit(21, proposer.StakingAddress, p, common.ZeroHash, state); err != nil {
+		t.Errorf("submit failed: %v", err)
+	}
+}
@@ -3,10 +3,10 @@ package gov
 import (
 	"errors"
 	"fmt"
+
 	"github.com/PlatONnetwork/PlatON-Go/common"
 	"github.com/PlatONnetwork/PlatON-Go/p2p/discover"
 	"github.com/PlatONnetwork/PlatON-Go/x/xcom"
-	"math/big"
 )
 
 type ProposalType byte
@@ -14,6 +14,8 @@ type ProposalType byte
 const (
 	Text    ProposalType = 0x01
 	Version ProposalType = 0x02
+	Param   ProposalType = 0x03
+	Cancel  ProposalType = 0x04
 )
 
 type ProposalStatus byte
@@ -24,6 +26,7 @@ const (
 	Failed    ProposalStatus = 0x03
 	PreActive ProposalStatus = 0x04
 	Active    ProposalStatus = 0x05
+	Canceled  ProposalStatus = 0x06
 )
 
 type VoteOption byte
@@ -34,10 +37,24 @@ const (
 	Abstention
 )
 
+var (
+	ProposalIDEmpty       = errors.New("ProposalID is empty")
+	ProposerEmpty         = errors.New("Proposer is empty")
+	ProposalTypeError     = errors.New("Proposal Type error")
+	TopicTooLong          = errors.New("Topic is empty or larger than 128")
+	DescTooLong           = errors.New("Description is larger than 512")
+	GithubIDEmpty         = errors.New("GithubID is empty")
+	UrlEmpty              = errors.New("Github URL is empty")
+	EndVotingBlockInvalid = errors.New("EndVotingBlock invalid")
+	NewVersionInvalid     = errors.New("NewVersion invalid")
+	ActiveBlockInvalid    = errors.New("ActiveBlock invalid")
+	TobeCanceledEmpty     = errors.New("the proposal to be canceled is empty")
+)
+
 type TallyResult struct {
 	ProposalID    common.Hash    `json:"proposalID"`
 	Yeas          uint16         `json:"yeas"`
-	Nays          uint64         `json:"nays"`
+	Nays          uint16         `json:"nays"`
 	Abstentions   uint16         `json:"abstentions"`
 	AccuVerifiers uint16         `json:"accuVerifiers"`
 	Status        ProposalStatus `json:"status"`
@@ -68,11 +85,11 @@ type Proposal interface {
 	SetUrl(url string)
 	GetUrl() string
 
-	SetSubmitBlock(blockNumber *big.Int)
-	GetSubmitBlock() *big.Int
+	SetSubmitBlock(blockNumber uint64)
+	GetSubmitBlock() uint64
 
-	SetEndVotingBlock(blockNumber *big.Int)
-	GetEndVotingBlock() *big.Int
+	SetEndVotingBlock(blockNumber uint64)
+	GetEndVotingBlock() uint64
 
 	SetProposer(proposer discover.NodeID)
 	GetProposer() discover.NodeID
@@ -80,7 +97,7 @@ type Proposal interface {
 	SetTallyResult(tallyResult TallyResult)
 	GetTallyResult() TallyResult
 
-	Verify(curBlockNum *big.Int, state xcom.StateDB) bool
+	Verify(curBlockNum uint64, state xcom.StateDB) error
 
 	String() string
 }
@@ -92,98 +109,126 @@ type TextProposal struct {
 	Topic          string
 	Desc           string
 	Url            string
-	SubmitBlock    *big.Int
-	EndVotingBlock *big.Int
+	SubmitBlock    uint64
+	EndVotingBlock uint64
 	Proposer       discover.NodeID
 	Result         TallyResult
 }
 
-func (tp TextProposal) SetProposalID(proposalID common.Hash) {
+func (tp *TextProposal) SetProposalID(proposalID common.Hash) {
 	tp.ProposalID = proposalID
 }
 
-func (tp TextProposal) GetProposalID() common.Hash {
+func (tp *TextProposal) GetProposalID() common.Hash {
 	return tp.ProposalID
 }
 
-func (tp TextProposal) SetGithubID(githubID string) {
+func (tp *TextProposal) SetGithubID(githubID string) {
 	tp.GithubID = githubID
 }
 
-func (tp TextProposal) GetGithubID() string {
+func (tp *TextProposal) GetGithubID() string {
 	return tp.GithubID
 }
 
-func (tp TextProposal) SetProposalType(proposalType ProposalType) {
+func (tp *TextProposal) SetProposalType(proposalType ProposalType) {
 	tp.ProposalType = proposalType
 }
 
-func (tp TextProposal) GetProposalType() ProposalType {
+func (tp *TextProposal) GetProposalType() ProposalType {
 	return tp.ProposalType
 }
 
-func (tp TextProposal) SetTopic(topic string) {
+func (tp *TextProposal) SetTopic(topic string) {
 	tp.Topic = topic
 }
 
-func (tp TextProposal) GetTopic() string {
+func (tp *TextProposal) GetTopic() string {
 	return tp.Topic
 }
 
-func (tp TextProposal) SetDesc(desc string) {
+func (tp *TextProposal) SetDesc(desc string) {
 	tp.Desc = desc
 }
 
-func (tp TextProposal) GetDesc() string {
+func (tp *TextProposal) GetDesc() string {
 	return tp.Desc
 }
 
-func (tp TextProposal) SetUrl(url string) {
+func (tp *TextProposal) SetUrl(url string) {
 	tp.Url = url
 }
 
-func (tp TextProposal) GetUrl() string {
+func (tp *TextProposal) GetUrl() string {
 	return tp.Url
 }
 
-func (tp TextProposal) SetSubmitBlock(blockNumber *big.Int) {
+func (tp *TextProposal) SetSubmitBlock(blockNumber uint64) {
 	tp.SubmitBlock = blockNumber
 }
 
-func (tp TextProposal) GetSubmitBlock() *big.Int {
+func (tp *TextProposal) GetSubmitBlock() uint64 {
 	return tp.SubmitBlock
 }
 
-func (tp TextProposal) SetEndVotingBlock(blockNumber *big.Int) {
+func (tp *TextProposal) SetEndVotingBlock(blockNumber uint64) {
 	tp.EndVotingBlock = blockNumber
 }
 
-func (tp TextProposal) GetEndVotingBlock() *big.Int {
+func (tp *TextProposal) GetEndVotingBlock() uint64 {
 	return tp.EndVotingBlock
 }
 
-func (tp TextProposal) SetProposer(proposer discover.NodeID) {
+func (tp *TextProposal) SetProposer(proposer discover.NodeID) {
 	tp.Proposer = proposer
 }
 
-func (tp TextProposal) GetProposer() discover.NodeID {
+func (tp *TextProposal) GetProposer() discover.NodeID {
 	return tp.Proposer
 }
 
-func (tp TextProposal) SetTallyResult(result TallyResult) {
+func (tp *TextProposal) SetTallyResult(result TallyResult) {
 	tp.Result = result
 }
 
-func (tp TextProposal) GetTallyResult() TallyResult {
+func (tp *TextProposal) GetTallyResult() TallyResult {
 	return tp.Result
 }
 
-func (tp TextProposal) Verify(curBlockNum *big.Int, state xcom.StateDB) bool {
+func (tp *TextProposal) Verify(curBlockNum uint64, state xcom.StateDB) error {
+	return tp.verify(Text, curBlockNum)
+}
 
-	return true
+// verify checks the common params of all kinds of proposal
+func (tp *TextProposal) verify(typ ProposalType, curBlockNum uint64) error {
+	if tp.ProposalID == (common.Hash{}) {
+		return ProposalIDEmpty
+	}
+	if tp.Proposer == (discover.NodeID{}) {
+		return ProposerEmpty
+	}
+	if tp.ProposalType != typ {
+		return ProposalTypeError
+	}
+	if len(tp.Topic) == 0 || len(tp.Topic) > 128 {
+		return TopicTooLong
+	}
+	if len(tp.Desc) > 512 {
+		return DescTooLong
+	}
+	if len(tp.GithubID) == 0 {
+		return GithubIDEmpty
+	}
+	if len(tp.Url) == 0 {
+		return UrlEmpty
+	}
+	if tp.EndVotingBlock <= curBlockNum || tp.EndVotingBlock-curBlockNum > xcom.MaxVotingDuration {
+		return EndVotingBlockInvalid
+	}
+	return nil
 }
 
-func (tp TextProposal) String() string {
+func (tp *TextProposal) String() string {
 	return fmt.Sprintf(`Proposal %x: 
   GithubID:            	%s
   Topic:              	%s
@@ -195,74 +240,135 @@ func (tp TextProposal) String() string {
 
 type VersionProposal struct {
 	TextProposal
-	NewVersion  uint
-	ActiveBlock *big.Int
+	NewVersion  uint32
+	ActiveBlock uint64
 }
 
-func (vp VersionProposal) SetNewVersion(newVersion uint) {
+func (vp *VersionProposal) SetNewVersion(newVersion uint32) {
 	vp.NewVersion = newVersion
 }
 
-func (vp VersionProposal) GetNewVersion() uint {
+func (vp *VersionProposal) GetNewVersion() uint32 {
 	return vp.NewVersion
 }
 
-func (vp VersionProposal) SetActiveBlock(activeBlock *big.Int) {
+func (vp *VersionProposal) SetActiveBlock(activeBlock uint64) {
 	vp.ActiveBlock = activeBlock
 }
 
-func (vp VersionProposal) GetActiveBlock() *big.Int {
+func (vp *VersionProposal) GetActiveBlock() uint64 {
 	return vp.ActiveBlock
 }
 
-func (vp VersionProposal) Verify(curBlockNum *big.Int, state xcom.StateDB) (bool, error) {
-	if len(vp.ProposalID) == 0 || nil != gov.govDB.GetProposal(vp.ProposalID, state) {
-		var err error = errors.New("[GOV] Verify(): ProposalID is empty or ProposalID already used.")
-		return false, err
+// Verify checks the params of the version proposal, the active block must be
+// in the range of [MinActiveRounds, MaxActiveRounds] consensus rounds after the end of voting.
+// NOTE: the new version is compared with the active version by Gov on submitting
+func (vp *VersionProposal) Verify(curBlockNum uint64, state xcom.StateDB) error {
+	if err := vp.verify(Version, curBlockNum); nil != err {
+		return err
 	}
-	if len(vp.Proposer) == 0 {
-		var err error = errors.New("[GOV] Verify(): Proposer is empty.")
-		return false, err
+	if vp.NewVersion == 0 {
+		return NewVersionInvalid
 	}
-	if vp.ProposalType != 0x02 {
-		var err error = errors.New("[GOV] Verify(): Proposal Type error.")
-		return false, err
+	if vp.ActiveBlock < vp.EndVotingBlock+xcom.VersionProposalMinActiveRounds*xcom.ConsensusSize ||
+		vp.ActiveBlock > vp.EndVotingBlock+xcom.VersionProposalMaxActiveRounds*xcom.ConsensusSize {
+		return ActiveBlockInvalid
 	}
-	if len(vp.Topic) == 0 || len(vp.Topic) > 128 {
-		var err error = errors.New("[GOV] Verify(): Topic is empty or larger than 128.")
-		return false, err
-	}
-	if len(vp.Desc) > 512 {
-		var err error = errors.New("[GOV] Verify(): Description too long.")
-		return false, err
-	}
-	if len(vp.GithubID) == 0 || vp.GithubID == gov.govDB.GetProposal(vp.ProposalID, state).GetGithubID() {
-		var err error = errors.New("[GOV] Verify(): GithubID empty or duplicated.")
-		return false, err
-	}
-	if len(vp.Url) == 0 || vp.GithubID == gov.govDB.GetProposal(vp.ProposalID, state).GetUrl() {
-		var err error = errors.New("[GOV] Verify(): Github URL empty or duplicated.")
-		return false, err
+	return nil
+}
+
+func (vp *VersionProposal) String() string {
+	return fmt.Sprintf(`Proposal %x: 
+  GithubID:            	%s
+  Topic:              	%s
+  Type:               	%x
+  Proposer:            	%x
+  SubmitBlock:        	%d
+  EndVotingBlock:   	%d
+  ActiveBlock:   		%d
+  NewVersion:   		%d`,
+		vp.ProposalID, vp.GithubID, vp.Topic, vp.ProposalType, vp.Proposer, vp.SubmitBlock, vp.EndVotingBlock, vp.ActiveBlock, vp.NewVersion)
+}
+
+// ParamProposal changes the value of a whitelisted param of xcom,
+// the new value takes effect at the beginning of the next epoch after the proposal passed
+type ParamProposal struct {
+	TextProposal
+	ParamName string
+	NewValue  string
+}
+
+func (pp *ParamProposal) SetParamName(paramName string) {
+	pp.ParamName = paramName
+}
+
+func (pp *ParamProposal) GetParamName() string {
+	return pp.ParamName
+}
+
+func (pp *ParamProposal) SetNewValue(newValue string) {
+	pp.NewValue = newValue
+}
+
+func (pp *ParamProposal) GetNewValue() string {
+	return pp.NewValue
+}
+
+// Verify checks the params of the param proposal, the param must be in the whitelist
+// and the new value must be in the bounds of the param
+func (pp *ParamProposal) Verify(curBlockNum uint64, state xcom.StateDB) error {
+	if err := pp.verify(Param, curBlockNum); nil != err {
+		return err
 	}
-	//TODO
-	if vp.EndVotingBlock == big.NewInt(0) || vp.EndVotingBlock.Cmp(curBlockNum.Add(curBlockNum, twoWeek)) > 0 {
-		var err error = errors.New("[GOV] Verify(): Github URL empty or duplicated.")
-		return false, err
+	param := xcom.GetGovernParam(pp.ParamName)
+	if param == nil {
+		return xcom.ParamNotGovernable
 	}
-	if vp.NewVersion>>8 <= uint(gov.govDB.GetActiveVersion(state))>>8 {
-		var err error = errors.New("[GOV] Verify(): NewVersion should larger than current version.")
-		return false, err
+	_, err := param.Verify(pp.NewValue)
+	return err
+}
+
+func (pp *ParamProposal) String() string {
+	return fmt.Sprintf(`Proposal %x: 
+  GithubID:            	%s
+  Topic:              	%s
+  Type:               	%x
+  Proposer:            	%x
+  SubmitBlock:        	%d
+  EndVotingBlock:   	%d
+  ParamName:   		%s
+  NewValue:   		%s`,
+		pp.ProposalID, pp.GithubID, pp.Topic, pp.ProposalType, pp.Proposer, pp.SubmitBlock, pp.EndVotingBlock, pp.ParamName, pp.NewValue)
+}
+
+// CancelProposal cancels a proposal in voting, once it passes,
+// the voting of the proposal to be canceled ends with the status Canceled
+type CancelProposal struct {
+	TextProposal
+	TobeCanceled common.Hash
+}
+
+func (cp *CancelProposal) SetTobeCanceled(proposalID common.Hash) {
+	cp.TobeCanceled = proposalID
+}
+
+func (cp *CancelProposal) GetTobeCanceled() common.Hash {
+	return cp.TobeCanceled
+}
+
+// Verify checks the params of the cancel proposal,
+// the proposal to be canceled is checked by Gov on submitting
+func (cp *CancelProposal) Verify(curBlockNum uint64, state xcom.StateDB) error {
+	if err := cp.verify(Cancel, curBlockNum); nil != err {
+		return err
 	}
-	//TODO
-	if vp.ActiveBlock == big.NewInt(0) || vp.ActiveBlock.Cmp(fourRoundConsensus) <= 4 || vp.ActiveBlock.Cmp(fourRoundConsensus) >= 10 {
-		var err error = errors.New("[GOV] Verify(): invalid ActiveBlock.")
-		return false, err
+	if cp.TobeCanceled == (common.Hash{}) {
+		return TobeCanceledEmpty
 	}
-
-	return true, nil
+	return nil
 }
 
-func (vp VersionProposal) String() string {
+func (cp *CancelProposal) String() string {
 	return fmt.Sprintf(`Proposal %x: 
   GithubID:            	%s
   Topic:              	%s
@@ -270,7 +376,6 @@ func (vp VersionProposal) String() string {
   Proposer:            	%x
   SubmitBlock:        	%d
   EndVotingBlock:   	%d
-  ActiveBlock:   		%d
-  NewVersion:   		%d`,
-		vp.ProposalID, vp.GithubID, vp.Topic, vp.ProposalType, vp.Proposer, vp.SubmitBlock, vp.EndVotingBlock, vp.ActiveBlock, vp.NewVersion)
+  TobeCanceled:   		%x`,
+		cp.ProposalID, cp.GithubID, cp.Topic, cp.ProposalType, cp.Proposer, cp.SubmitBlock, cp.EndVotingBlock, cp.TobeCanceled)
 }
@@ -1 +1,136 @@
 package plugin
+
+import (
+	"math/big"
+
+	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/PlatONnetwork/PlatON-Go/common/vm"
+	"github.com/PlatONnetwork/PlatON-Go/core/snapshotdb"
+	"github.com/PlatONnetwork/PlatON-Go/core/types"
+	"github.com/PlatONnetwork/PlatON-Go/log"
+	"github.com/PlatONnetwork/PlatON-Go/x/xcom"
+	"github.com/PlatONnetwork/PlatON-Go/x/xutil"
+)
+
+type AwardMgrPlugin struct {
+	staking *StakingPlugin
+}
+
+// NewAwardMgrPlugin creates an AwardMgrPlugin, the rewards are paid to the candidates of the staking plugin
+func NewAwardMgrPlugin(staking *StakingPlugin) *AwardMgrPlugin {
+	return &AwardMgrPlugin{
+		staking: staking,
+	}
+}
+
+// BeginBlock does nothing
+func (am *AwardMgrPlugin) BeginBlock(blockHash common.Hash, header *types.Header, state xcom.StateDB) (bool, error) {
+	return true, nil
+}
+
+// EndBlock rewards the block producer, and accumulates the staking reward pool,
+// the pool will be distributed to the verifiers at the end of each epoch
+func (am *AwardMgrPlugin) EndBlock(blockHash common.Hash, header *types.Header, state xcom.StateDB) (bool, error) {
+
+	blockNumber := header.Number.Uint64()
+
+	if success, err := am.rewardBlockProducer(blockHash, header, state); nil != err {
+		return success, err
+	}
+
+	state.AddBalance(vm.AwardMgrContractAddr, xcom.GovernBigInt(state, xcom.ParamStakingRewardPerBlock))
+
+	if xutil.IsSettlementPeriod(blockNumber) {
+		log.Info("begin to distribute the staking reward", "blockNumber", blockNumber,
+			"epoch", xutil.CalculateEpoch(blockNumber))
+		return am.rewardStaking(blockHash, blockNumber, state)
+	}
+
+	return true, nil
+}
+
+// Confirmed does nothing
+func (am *AwardMgrPlugin) Confirmed(block *types.Block) error {
+	return nil
+}
+
+// rewardBlockProducer rewards the benefit address of the candidate who produced the block.
+// NOTE: the coinbase of the block is the node address of the producer,
+// it will be rewarded directly if the producer is not a candidate (e.g. the genesis nodes)
+func (am *AwardMgrPlugin) rewardBlockProducer(blockHash common.Hash, header *types.Header, state xcom.StateDB) (bool, error) {
+
+	benefit := header.Coinbase
+
+	can, err := am.staking.GetCandidateInfo(blockHash, header.Coinbase)
+	if nil != err {
+		log.Error("Failed to rewardBlockProducer on awardMgrPlugin: query candidate info failed",
+			"blockNumber", header.Number.Uint64(), "blockHash", blockHash.Hex(), "coinbase", header.Coinbase.Hex(), "err", err)
+		return false, err
+	}
+
+	if nil != can && xcom.Is_Valid(can.Status) {
+		benefit = can.BenifitAddress
+	}
+
+	state.AddBalance(benefit, xcom.GovernBigInt(state, xcom.ParamBlockReward))
+	return true, nil
+}
+
+// rewardStaking distributes the whole staking reward pool to the verifiers of current epoch,
+// weighted by their shares. The remainder of the division is left in the pool.
+// The reward of each verifier is shared with its delegators by the commission rate.
+func (am *AwardMgrPlugin) rewardStaking(blockHash common.Hash, blockNumber uint64, state xcom.StateDB) (bool, error) {
+
+	verifierList, _, err := am.staking.GetVerifierList(blockHash, blockNumber)
+	if nil != err {
+		if err == snapshotdb.ErrNotFound {
+			log.Warn("the verifierList is not found, skip to distribute the staking reward",
+				"blockNumber", blockNumber, "blockHash", blockHash.Hex())
+			return true, nil
+		}
+		log.Error("Failed to rewardStaking on awardMgrPlugin: query verifierList failed",
+			"blockNumber", blockNumber, "blockHash", blockHash.Hex(), "err", err)
+		return false, err
+	}
+
+	totalShares := new(big.Int)
+	for _, can := range verifierList {
+		if nil == can || !xcom.Is_Valid(can.Status) {
+			continue
+		}
+		totalShares.Add(totalShares, can.Shares)
+	}
+
+	pool := state.GetBalance(vm.AwardMgrContractAddr)
+	if totalShares.Cmp(common.Big0) <= 0 || pool.Cmp(common.Big0) <= 0 {
+		return true, nil
+	}
+
+	rewarded := new(big.Int)
+	for _, can := range verifierList {
+		if nil == can || !xcom.Is_Valid(can.Status) {
+			continue
+		}
+
+		reward := new(big.Int).Mul(pool, can.Shares)
+		reward.Div(reward, totalShares)
+
+		if reward.Cmp(common.Big0) <= 0 {
+			continue
+		}
+
+		// the candidate shares the reward with its delegators
+		if success, err := am.staking.DistributeStakingReward(state, blockHash, blockNumber, can, reward); nil != err {
+			log.Error("Failed to rewardStaking on awardMgrPlugin: distribute the staking reward failed",
+				"blockNumber", blockNumber, "blockHash", blockHash.Hex(), "nodeId", can.NodeId.String(), "err", err)
+			return success, err
+		}
+		rewarded.Add(rewarded, reward)
+	}
+
+	state.SubBalance(vm.AwardMgrContractAddr, rewarded)
+
+	log.Info("Succeeded to distribute the staking reward", "blockNumber", blockNumber,
+		"blockHash", blockHash.Hex(), "pool", pool, "rewarded", rewarded)
+	return true, nil
+}
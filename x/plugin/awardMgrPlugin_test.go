@@ -0,0 +1,161 @@
+package plugin
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/PlatONnetwork/PlatON-Go/common/vm"
+	"github.com/PlatONnetwork/PlatON-Go/core/snapshotdb"
+	"github.com/PlatONnetwork/PlatON-Go/core/types"
+	"github.com/PlatONnetwork/PlatON-Go/crypto"
+	"github.com/PlatONnetwork/PlatON-Go/p2p/discover"
+	"github.com/PlatONnetwork/PlatON-Go/x/xcom"
+	"github.com/PlatONnetwork/PlatON-Go/x/xutil"
+)
+
+func TestAwardMgrPlugin_RewardBlockProducer(t *testing.T) {
+	db, err := snapshotdb.New(snapshotdb.Options{InMemory: true})
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	sk := NewStakingPlugin(db, NewRestrictingPlugin())
+	am := NewAwardMgrPlugin(sk)
+	state := newMockStateDB()
+
+	key, _ := crypto.GenerateKey()
+	canAddr := crypto.PubkeyToAddress(key.PublicKey)
+	stakingAddr, benefitAddr := common.HexToAddress("0x02"), common.HexToAddress("0x03")
+	state.AddBalance(stakingAddr, lat(100))
+
+	blockHash := common.HexToHash("0x01")
+	blockNumber := big.NewInt(1)
+	if err := db.NewBlock(blockNumber, common.ZeroHash, blockHash); nil != err {
+		t.Fatal(err)
+	}
+	can := &xcom.Candidate{
+		NodeId:          discover.PubkeyID(&key.PublicKey),
+		StakingAddress:  stakingAddr,
+		BenifitAddress:  benefitAddr,
+		StakingBlockNum: blockNumber.Uint64(),
+		Shares:          lat(100),
+	}
+	if _, err := sk.CreateCandidate(state, blockHash, blockNumber, lat(100), 10101010, FreeOrigin, canAddr, can); nil != err {
+		t.Fatal(err)
+	}
+
+	// the candidate is rewarded by its benefit address
+	if _, err := am.EndBlock(blockHash, &types.Header{Number: blockNumber, Coinbase: canAddr}, state); nil != err {
+		t.Fatal(err)
+	}
+	assertBalance(t, state, benefitAddr, xcom.BlockReward)
+	assertBalance(t, state, canAddr, new(big.Int))
+	assertBalance(t, state, vm.AwardMgrContractAddr, xcom.StakingRewardPerBlock)
+
+	// the producer which is not a candidate is rewarded directly, by the rewards changed by the proposal
+	state.SetState(vm.GovContractAddr, xcom.GovernParamKey(xcom.ParamBlockReward), []byte(lat(3).String()))
+	state.SetState(vm.GovContractAddr, xcom.GovernParamKey(xcom.ParamStakingRewardPerBlock), []byte(lat(5).String()))
+	genesisNode := common.HexToAddress("0x04")
+	if _, err := am.EndBlock(blockHash, &types.Header{Number: big.NewInt(2), Coinbase: genesisNode}, state); nil != err {
+		t.Fatal(err)
+	}
+	assertBalance(t, state, genesisNode, lat(3))
+	assertBalance(t, state, benefitAddr, xcom.BlockReward)
+	assertBalance(t, state, vm.AwardMgrContractAddr, new(big.Int).Add(xcom.StakingRewardPerBlock, lat(5)))
+}
+
+func TestAwardMgrPlugin_RewardStaking(t *testing.T) {
+	db, err := snapshotdb.New(snapshotdb.Options{InMemory: true})
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	sk := NewStakingPlugin(db, NewRestrictingPlugin())
+	am := NewAwardMgrPlugin(sk)
+	state := newMockStateDB()
+
+	blockHash := common.HexToHash("0x01")
+	blockNumber := big.NewInt(1)
+	if err := db.NewBlock(blockNumber, common.ZeroHash, blockHash); nil != err {
+		t.Fatal(err)
+	}
+
+	// the candidate A takes no commission, the candidate B takes 10% of its reward
+	// and shares the rest with the delegator by their shares
+	newCandidate := func(benefitAddr common.Address, commissionRate uint16) *xcom.Candidate {
+		key, _ := crypto.GenerateKey()
+		canAddr := crypto.PubkeyToAddress(key.PublicKey)
+		state.AddBalance(canAddr, lat(100))
+		can := &xcom.Candidate{
+			NodeId:          discover.PubkeyID(&key.PublicKey),
+			StakingAddress:  canAddr,
+			BenifitAddress:  benefitAddr,
+			StakingBlockNum: blockNumber.Uint64(),
+			Shares:          lat(100),
+			CommissionRate:  commissionRate,
+		}
+		if _, err := sk.CreateCandidate(state, blockHash, blockNumber, lat(100), 10101010, FreeOrigin, canAddr, can); nil != err {
+			t.Fatal(err)
+		}
+		return can
+	}
+	benefitA, benefitB := common.HexToAddress("0x0a"), common.HexToAddress("0x0b")
+	canA := newCandidate(benefitA, 0)
+	canB := newCandidate(benefitB, 1000)
+
+	delAddr := common.HexToAddress("0x0c")
+	state.AddBalance(delAddr, lat(100))
+	del := &xcom.Delegation{
+		Reduction:   new(big.Int),
+		Released:    new(big.Int),
+		ReleasedTmp: new(big.Int),
+		LockRepo:    new(big.Int),
+		LockRepoTmp: new(big.Int),
+	}
+	if _, err := sk.Delegate(state, blockHash, blockNumber, delAddr, del, canB, FreeOrigin, lat(100)); nil != err {
+		t.Fatal(err)
+	}
+
+	settlement := xutil.CalculateLastBlockOfEpoch(1)
+	verifiers := &xcom.Validator_array{
+		Start: 1,
+		End:   settlement,
+		Arr: []*xcom.Validator{
+			{NodeAddress: canA.StakingAddress, NodeId: canA.NodeId},
+			{NodeAddress: canB.StakingAddress, NodeId: canB.NodeId},
+		},
+	}
+	if err := sk.db.setVerifierList(blockHash, verifiers); nil != err {
+		t.Fatal(err)
+	}
+
+	// the pool is lat(300) with the reward of the settlement block, A has 100 shares and B has 200
+	state.AddBalance(vm.AwardMgrContractAddr, new(big.Int).Sub(lat(300), xcom.StakingRewardPerBlock))
+	producer := common.HexToAddress("0x0d")
+	if _, err := am.EndBlock(blockHash, &types.Header{Number: new(big.Int).SetUint64(settlement), Coinbase: producer}, state); nil != err {
+		t.Fatal(err)
+	}
+
+	assertBalance(t, state, producer, xcom.BlockReward)
+	assertBalance(t, state, vm.AwardMgrContractAddr, new(big.Int))
+	assertBalance(t, state, benefitA, lat(100))
+	// B: the commission 20 and a half of the rest 180
+	assertBalance(t, state, benefitB, lat(110))
+	assertBalance(t, state, vm.DelegateRewardPoolAddr, lat(90))
+
+	// the delegator withdraws its share of the reward from the pool
+	del, err = sk.GetDelegateInfo(blockHash, delAddr, canB.NodeId, canB.StakingBlockNum)
+	if nil != err {
+		t.Fatal(err)
+	}
+	reward, _, err := sk.WithdrawDelegateReward(state, blockHash, new(big.Int).SetUint64(settlement), delAddr, canB.NodeId, canB.StakingBlockNum, del)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if reward.Cmp(lat(90)) != 0 {
+		t.Fatalf("expect the delegate reward %s, got %s", lat(90), reward)
+	}
+	assertBalance(t, state, delAddr, lat(90))
+	assertBalance(t, state, vm.DelegateRewardPoolAddr, new(big.Int))
+}
@@ -51,14 +51,22 @@ func  Verify_tx_data(input []byte, command map[uint16]interface{} ) (fn interfac
 		// the func params len
 		paramNum := paramList.NumIn()
 
-		if paramNum != len(args)-1 {
+		// the variadic param is optional, and takes one value at most
+		if paramList.IsVariadic() {
+			if len(args)-1 < paramNum-1 || len(args)-1 > paramNum {
+				return nil, nil, FnParamsLenErr
+			}
+		} else if paramNum != len(args)-1 {
 			return nil, nil, FnParamsLenErr
 		}
 
-		params := make([]reflect.Value, paramNum)
+		params := make([]reflect.Value, len(args)-1)
 
-		for i := 0; i < paramNum; i++ {
+		for i := 0; i < len(args)-1; i++ {
 			targetType := paramList.In(i).String()
+			if paramList.IsVariadic() && i == paramNum-1 {
+				targetType = paramList.In(i).Elem().String()
+			}
 			inputByte := []reflect.Value{reflect.ValueOf(args[i+1])}
 			params[i] = reflect.ValueOf(byteutil.Bytes2X_CMD[targetType]).Call(inputByte)[0]
 		}
@@ -0,0 +1,45 @@
+package plugin
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/PlatONnetwork/PlatON-Go/rlp"
+)
+
+func TestVerify_tx_data(t *testing.T) {
+	var got []uint16
+	fnSigns := map[uint16]interface{}{
+		1: func(a uint16, b ...uint16) ([]byte, error) {
+			got = append([]uint16{a}, b...)
+			return nil, nil
+		},
+	}
+	encode := func(args ...uint16) []byte {
+		data := make([][]byte, 0, len(args))
+		for _, arg := range args {
+			b, _ := rlp.EncodeToBytes(arg)
+			data = append(data, b)
+		}
+		input, _ := rlp.EncodeToBytes(data)
+		return input
+	}
+
+	for _, args := range [][]uint16{{1, 10}, {1, 10, 20}} {
+		fn, params, err := Verify_tx_data(encode(args...), fnSigns)
+		if nil != err {
+			t.Fatalf("verify %v failed: %v", args, err)
+		}
+		reflect.ValueOf(fn).Call(params)
+		if len(got) != len(args)-1 {
+			t.Fatalf("the params of %v mismatch, got %v", args, got)
+		}
+	}
+
+	// the variadic param takes one value at most
+	for _, args := range [][]uint16{{1}, {1, 10, 20, 30}} {
+		if _, _, err := Verify_tx_data(encode(args...), fnSigns); err != FnParamsLenErr {
+			t.Errorf("expect %v for %v, got %v", FnParamsLenErr, args, err)
+		}
+	}
+}
@@ -1,13 +1,11 @@
 package plugin
 
 import (
-	"bytes"
-	"encoding/json"
 	"errors"
 	"math/big"
+	"sort"
 
 	"github.com/PlatONnetwork/PlatON-Go/common"
-	"github.com/PlatONnetwork/PlatON-Go/common/byteutil"
 	"github.com/PlatONnetwork/PlatON-Go/common/vm"
 	"github.com/PlatONnetwork/PlatON-Go/core/types"
 	"github.com/PlatONnetwork/PlatON-Go/log"
@@ -17,53 +15,23 @@ import (
 )
 
 var (
-	errParamPeriodInvalid = errors.New("param period invalid")
 	errBalanceNotEnough = errors.New("balance not enough to restrict")
-	errAccountNotFound = errors.New("account is not found")
+	errAccountNotFound  = errors.New("account is not found")
+	errPledgeNotEnough  = errors.New("pledge not enough to return")
 )
 
-
-
-type restrictingInfo struct {
-	balance     *big.Int  `json:"balance"`  // balance representation all locked amount
-	debt        *big.Int  `json:"debt"`     // debt representation will released amount. Positive numbers can be used instead of release, 0 means no release, negative numbers indicate not enough to release
-	releaseList []uint64  `json:"list"`     // releaseList representation
-}
-
-type releaseAmountInfo struct {
-	height uint64 	 `json:"blockNumber"`  	// blockNumber representation of the block number at the released lock-repo period
-	amount *big.Int	 `json:"amount"`		// amount representation of the released amount
-}
-
-type restrictingPlan struct {
-	period  uint64  `json:"period"`			// period representation of the released period at the target blockNumber
-	amount	*big.Int `json:"amount"`		// amount representation of the released amount
-}
-
-type Result struct {
-	balance *big.Int
-	slash   *big.Int
-	pledge  *big.Int
-	debt    *big.Int
-	entry   []byte
-}
-
 type RestrictingPlugin struct {
 }
 
-var RestrictingPtr *RestrictingPlugin = nil
-
-func GetRestrictingInstance() *RestrictingPlugin {
-	if RestrictingPtr == nil {
-		RestrictingPtr = &RestrictingPlugin{}
-	}
-	return RestrictingPtr
+// NewRestrictingPlugin creates a RestrictingPlugin, the restricting records are kept in the statedb
+func NewRestrictingPlugin() *RestrictingPlugin {
+	return &RestrictingPlugin{}
 }
 
 // BeginBlock does something like check input params before execute transactions,
 // in RestrictingPlugin it does nothing.
 func (rp *RestrictingPlugin) BeginBlock(blockHash common.Hash, head *types.Header, state xcom.StateDB) (bool, error) {
-	return false, nil
+	return true, nil
 }
 
 // EndBlock invoke releaseRestricting
@@ -72,7 +40,7 @@ func (rp *RestrictingPlugin) EndBlock(blockHash common.Hash, head *types.Header,
 	if xutil.IsSettlementPeriod(head.Number.Uint64()) {
 
 		log.Info("begin to release restricting", "curr", head.Number)
-		return rp.releaseRestricting(head, state)
+		return rp.releaseRestricting(head.Number.Uint64(), state)
 	}
 
 	return true, nil
@@ -88,130 +56,64 @@ func (rp *RestrictingPlugin) Confirmed(block *types.Block) error {
 // ReleaseNumbers: the number of accounts to be released at the target block height
 // ReleaseAccount: the account on the index at the target block height
 // ReleaseAmount: the released account amount at the target block height
-func (rp *RestrictingPlugin) AddRestrictingRecord(sender common.Address, account common.Address, plan string,
-	state xcom.StateDB) (bool, error) {
-
-	// decode plan from input param
-	var plans []restrictingPlan
-
-	if err := rlp.Decode(bytes.NewBuffer([]byte(plan)), plans); err != nil {
-		log.Error("failed to rlp encode input plan", "plan", plan)
-		return false, err
-	}
+func (rp *RestrictingPlugin) AddRestrictingRecord(sender common.Address, account common.Address, plans []xcom.RestrictingPlan,
+	blockNumber uint64, state xcom.StateDB) (bool, error) {
 
 	// pre-check
-	var totalLock = big.NewInt(0)
-	for i := 0; i < len(plans); i++ {
-		period := plans[i].period
-		amount := plans[i].amount
-
-		if vm.RestrictingContractAddr == account && period % 120 != 0 {
-			log.Error("param period invalid", "period", plans[i].period)
-			return false, errParamPeriodInvalid
-		}
-
-		totalLock = totalLock.Add(totalLock, amount)
+	totalLock, err := xcom.VerifyRestrictingPlans(plans)
+	if nil != err {
+		log.Error("restricting plans invalid", "account", account.Hex(), "plans", plans, "err", err)
+		return true, err
 	}
 
-	if state.GetBalance(sender).Cmp(totalLock) == -1 {
-		log.Error("sender's balance not enough", "lock", totalLock)
-		return false, errBalanceNotEnough
+	if state.GetBalance(sender).Cmp(totalLock) < 0 {
+		log.Error("sender's balance not enough", "sender", sender.Hex(), "lock", totalLock)
+		return true, errBalanceNotEnough
 	}
 
-	var (
-		recordsNum  uint32
-		index       uint32
-		heightList  []uint64
-		info		restrictingInfo
-	)
-
-	restrictingKey := xcom.GetRestrictingKey(account)
-	bRecord := state.GetState(account, restrictingKey)
+	info, found, err := rp.getRestrictingInfo(account, state)
+	if nil != err {
+		return false, err
+	}
 
-	if len(bRecord) == 0 {  // restricting not exist
+	if !found {
+		log.Debug("restricting record not exist", "account", account.Hex())
+		info = xcom.RestrictingInfo{
+			Balance: new(big.Int),
+			Debt:    new(big.Int),
+			Pledge:  new(big.Int),
+		}
+	}
 
-		log.Debug("restricting record not exist", "account", account.Bytes())
+	epoch := xutil.CalculateEpoch(blockNumber)
 
-		for i := 0; i < len(plans); i++ {
-			height := plans[i].period * xcom.EpochSize * xcom.ConsensusSize
-			amount := plans[i].amount
+	for _, plan := range plans {
+		height := xutil.CalculateLastBlockOfEpoch(epoch + plan.Epoch)
 
+		amount := rp.getReleaseAmount(account, height, state)
+		if amount.Sign() == 0 {
+			// the account is not released at the height yet
 			releaseNumberKey := xcom.GetReleaseNumberKey(height)
-			numbers := state.GetState(vm.RestrictingContractAddr, releaseNumberKey)
-			if len(numbers) == 0 {
-				recordsNum = uint32(1)
-				index = uint32(1)
-			} else {
-				recordsNum += 1
-				index = byteutil.BytesToUint32(numbers)+1
-			}
-
-			state.SetState(vm.RestrictingContractAddr, releaseNumberKey, byteutil.Uint32ToBytes(recordsNum))
+			index := common.BytesToUint32(state.GetState(vm.RestrictingContractAddr, releaseNumberKey)) + 1
+			state.SetState(vm.RestrictingContractAddr, releaseNumberKey, common.Uint32ToBytes(index))
 
 			releaseAccountKey := xcom.GetReleaseAccountKey(height, index)
 			state.SetState(vm.RestrictingContractAddr, releaseAccountKey, account.Bytes())
 
-			releaseAmountKey := xcom.GetReleaseAmountKey(account, height)
-			state.SetState(account, releaseAmountKey, amount.Bytes())
-
-			heightList = append(heightList, height)
+			info.ReleaseList = append(info.ReleaseList, height)
 		}
 
-		info.balance = totalLock
-		info.debt = big.NewInt(0)
-		info.releaseList = heightList
-
-	} else {  // restricting exist
-
-		log.Debug("restricting record exist", "account", account.Bytes())
-
-		if err := rlp.Decode(bytes.NewReader(bRecord), &info); err != nil {
-			log.Error("rlp decode failed", "err", err.Error())
-			return false, err
-		}
-
-		for i := 0; i < len(plans); i++ {
-			// release info
-			height := plans[i].period * xcom.EpochSize * xcom.ConsensusSize
-			amount := plans[i].amount
-
-			releaseAmountKey := xcom.GetReleaseAmountKey(account, height)
-			bAmount := state.GetState(account, releaseAmountKey)
-
-			if len(bAmount) == 0 {
-
-				releaseNumberKey := xcom.GetReleaseNumberKey(height)
-				numbers := state.GetState(vm.RestrictingContractAddr, releaseNumberKey)
-
-				index = byteutil.BytesToUint32(numbers)+1
-				state.SetState(vm.RestrictingContractAddr, releaseNumberKey, byteutil.Uint32ToBytes(index))
-
-				releaseAccountKey := xcom.GetReleaseAccountKey(height, index)
-				state.SetState(vm.RestrictingContractAddr, releaseAccountKey, account.Bytes())
-
-				info.releaseList = append(info.releaseList, height)
+		amount.Add(amount, plan.Amount)
+		state.SetState(account, xcom.GetReleaseAmountKey(account, height), amount.Bytes())
+	}
 
-			} else {
-				var tmpAmount *big.Int
-				if err := rlp.Decode(bytes.NewBuffer(bAmount), tmpAmount); err != nil {
-					log.Error("failed to rlp decode release amount", "error", err)
-					return false, err
-				}
-				amount = amount.Add(amount, tmpAmount)
-			}
+	sort.Slice(info.ReleaseList, func(i, j int) bool { return info.ReleaseList[i] < info.ReleaseList[j] })
 
-			state.SetState(account, releaseAmountKey, amount.Bytes())
-		}
+	info.Balance = new(big.Int).Add(info.Balance, totalLock)
 
-		info.balance = info.balance.Add(info.balance, totalLock)
-	}
-
-	bInfo, err := rlp.EncodeToBytes(info)
-	if err != nil {
-		log.Error("failed to rlp encode restricting info", "account", account, "error", err)
-		return true, err
+	if err := rp.setRestrictingInfo(account, info, state); nil != err {
+		return false, err
 	}
-	state.SetState(account, restrictingKey, bInfo)
 
 	state.SubBalance(sender, totalLock)
 	state.AddBalance(vm.RestrictingContractAddr, totalLock)
@@ -219,41 +121,31 @@ func (rp *RestrictingPlugin) AddRestrictingRecord(sender common.Address, account
 	return true, nil
 }
 
-// PledgeLockFunds does nothing, output[0] return true when business is success, else return false
+// PledgeLockFunds transfer the restricted von of the account to the staking contract,
+// output[0] return true when business is success, else return false
 func (rp *RestrictingPlugin) PledgeLockFunds(account common.Address, amount *big.Int, state xcom.StateDB) (bool, error) {
 
-	restrictingKey := xcom.GetRestrictingKey(account)
-	record := state.GetState(account, restrictingKey)
-
-	if len(record) == 0 {
-		log.Error("record not found in PledgeLockFunds", "account", account, "funds", amount.Uint64())
-		return false, errAccountNotFound
+	info, found, err := rp.getRestrictingInfo(account, state)
+	if nil != err {
+		return false, err
 	}
 
-	var (
-		info restrictingInfo
-	)
-
-	if err := rlp.Decode(bytes.NewReader(record), &info); err != nil {
-		log.Error("rlp decode failed", "error", err.Error())
-		return false, err
+	if !found {
+		log.Error("record not found in PledgeLockFunds", "account", account.Hex(), "funds", amount)
+		return true, errAccountNotFound
 	}
 
-	if info.balance.Cmp(amount) == -1 {
-		log.Error("restricting balance is not enough", "error", errBalanceNotEnough)
-		return false, errBalanceNotEnough
+	if info.Balance.Cmp(amount) < 0 {
+		log.Error("restricting balance is not enough", "account", account.Hex(), "balance", info.Balance, "funds", amount)
+		return true, errBalanceNotEnough
 	}
 
-	// sub balance
-	info.balance = info.balance.Sub(info.balance, amount)
+	info.Balance = new(big.Int).Sub(info.Balance, amount)
+	info.Pledge = new(big.Int).Add(info.Pledge, amount)
 
-	// store user info
-	bInfo, err := rlp.EncodeToBytes(info)
-	if err != nil {
-		log.Error("failed to encode restricting info", "account", account, "error", err)
-		return true, err
+	if err := rp.setRestrictingInfo(account, info, state); nil != err {
+		return false, err
 	}
-	state.SetState(account, restrictingKey, bInfo)
 
 	state.SubBalance(vm.RestrictingContractAddr, amount)
 	state.AddBalance(vm.StakingContractAddr, amount)
@@ -261,243 +153,227 @@ func (rp *RestrictingPlugin) PledgeLockFunds(account common.Address, amount *big
 	return true, nil
 }
 
-
-// ReturnLockFunds does nothing
+// ReturnLockFunds transfer the pledged von back from the staking contract,
+// the debt of the account is paid in priority, and the rest is restricted again
 func (rp *RestrictingPlugin) ReturnLockFunds(account common.Address, amount *big.Int, state xcom.StateDB) (bool, error) {
 
-	restrictingKey := xcom.GetRestrictingKey(account)
-	record := state.GetState(account, restrictingKey)
-
-	if len(record) == 0 {
-		log.Error("record not found in ReturnLockFunds", "account", account, "funds", amount.Uint64())
-		return false, errAccountNotFound
+	info, found, err := rp.getRestrictingInfo(account, state)
+	if nil != err {
+		return false, err
 	}
 
-	var (
-		info restrictingInfo
-	)
-
-	if err := rlp.Decode(bytes.NewReader(record), &info); err != nil {
-		log.Error("rlp decode failed", "error", err.Error())
-		return false, err
+	if !found {
+		log.Error("record not found in ReturnLockFunds", "account", account.Hex(), "funds", amount)
+		return true, errAccountNotFound
 	}
 
-	if info.debt.Sign() == 1 {
-		// add balance while debt is positive
-		log.Trace("debt is positive", "account", account, "debt", info.debt)
-		info.balance = info.balance.Add(info.balance, amount)
+	if info.Pledge.Cmp(amount) < 0 {
+		log.Error("restricting pledge is not enough", "account", account.Hex(), "pledge", info.Pledge, "funds", amount)
+		return true, errPledgeNotEnough
+	}
 
-	} else {
-		log.Trace("debt is not positive", "account", account, "debt", info.debt, "amount", amount)
+	info.Pledge = new(big.Int).Sub(info.Pledge, amount)
 
-		if amount.CmpAbs(info.debt) < 1 {
-			info.debt = info.debt.Add(info.debt, amount)
-		} else {
-			info.balance.Add(info.balance, info.debt.Add(info.debt, amount))
-			info.debt = big.NewInt(0)
-		}
+	repay := minBig(info.Debt, amount)
+	if repay.Sign() > 0 {
+		log.Trace("repay the debt of the account", "account", account.Hex(), "debt", info.Debt, "repay", repay)
+		info.Debt = new(big.Int).Sub(info.Debt, repay)
+		state.SubBalance(vm.StakingContractAddr, repay)
+		state.AddBalance(account, repay)
 	}
 
-	bInfo, err := rlp.EncodeToBytes(info)
-	if err != nil {
-		log.Error("failed to encode restricting info", "account", account, "error", err)
-		return true, err
+	rest := new(big.Int).Sub(amount, repay)
+	if rest.Sign() > 0 {
+		info.Balance = new(big.Int).Add(info.Balance, rest)
+		state.SubBalance(vm.StakingContractAddr, rest)
+		state.AddBalance(vm.RestrictingContractAddr, rest)
 	}
-	state.SetState(account, restrictingKey, bInfo)
-
-	state.SubBalance(vm.StakingContractAddr, amount)
-	state.AddBalance(vm.RestrictingContractAddr, amount)
 
-	return true, nil
+	return true, rp.settleRestrictingInfo(account, info, state)
 }
 
-// SlashingNotify does nothing
+// SlashingNotify reduces the pledged von of the account which was slashed by the staking contract
 func (rp *RestrictingPlugin) SlashingNotify(account common.Address, amount *big.Int, state xcom.StateDB) (bool, error) {
 
-	restrictingKey := xcom.GetRestrictingKey(account)
-	record := state.GetState(account, restrictingKey)
-
-	if len(record) == 0 {
-		log.Error("record not found in SlashingNotify", "account", account, "funds", amount.Uint64())
-		return false, errAccountNotFound
+	info, found, err := rp.getRestrictingInfo(account, state)
+	if nil != err {
+		return false, err
 	}
 
-	var (
-		info restrictingInfo
-	)
+	if !found {
+		log.Error("record not found in SlashingNotify", "account", account.Hex(), "funds", amount)
+		return true, errAccountNotFound
+	}
 
-	if err := rlp.Decode(bytes.NewReader(record), &info); err != nil {
-		log.Error("rlp decode failed", "error", err.Error())
-		return false, err
+	if info.Pledge.Cmp(amount) < 0 {
+		log.Error("restricting pledge is not enough", "account", account.Hex(), "pledge", info.Pledge, "funds", amount)
+		return true, errPledgeNotEnough
 	}
 
-	info.debt = info.debt.Sub(info.debt, amount)
+	info.Pledge = new(big.Int).Sub(info.Pledge, amount)
 
-	bInfo, err := rlp.EncodeToBytes(info)
-	if err != nil {
-		log.Error("failed to encode restricting info", "account", account, "error", err)
-		return true, err
-	}
-	state.SetState(account, restrictingKey, bInfo)
+	// the slashed von is never returned, so is the debt beyond the pledge
+	info.Debt = minBig(info.Debt, info.Pledge)
 
-	return true, nil
+	return true, rp.settleRestrictingInfo(account, info, state)
 }
 
-// releaseRestricting does nothing
-func (rp *RestrictingPlugin) releaseRestricting(head *types.Header, state xcom.StateDB)  (bool, error) {
-
-	var blockNumber = head.Number.Uint64()
+// releaseRestricting releases the von of all accounts which are scheduled at the block number,
+// the von which is pledged is recorded as the debt of the account
+func (rp *RestrictingPlugin) releaseRestricting(blockNumber uint64, state xcom.StateDB) (bool, error) {
 
 	releaseNumberKey := xcom.GetReleaseNumberKey(blockNumber)
-	bNumbers := state.GetState(vm.RestrictingContractAddr, releaseNumberKey)
-
-	if len(bNumbers) == 0 {
-		return true, nil
-	}
+	numbers := common.BytesToUint32(state.GetState(vm.RestrictingContractAddr, releaseNumberKey))
 
-	numbers := byteutil.BytesToUint32(bNumbers)
-
-	var (
-		info restrictingInfo
-		release *big.Int
-	)
-
-	for index := numbers; index > 0 ; index++ {
+	for index := uint32(1); index <= numbers; index++ {
 
 		releaseAccountKey := xcom.GetReleaseAccountKey(blockNumber, index)
-		bAccount := state.GetState(vm.RestrictingContractAddr, releaseAccountKey)
-		account := byteutil.BytesToAddress(bAccount)
+		account := common.BytesToAddress(state.GetState(vm.RestrictingContractAddr, releaseAccountKey))
 
-		releaseAmountKey :=  xcom.GetReleaseAmountKey(account, blockNumber)
-		bRelease := state.GetState(account, releaseAmountKey)
+		release := rp.getReleaseAmount(account, blockNumber, state)
 
-		if err := rlp.Decode(bytes.NewBuffer(bRelease), release); err != nil {
-			log.Error("rlp decode failed", "origin", bRelease)
+		info, found, err := rp.getRestrictingInfo(account, state)
+		if nil != err {
 			return false, err
 		}
+		if !found {
+			log.Error("record not found in releaseRestricting", "account", account.Hex(), "blockNumber", blockNumber)
+			return false, errAccountNotFound
+		}
 
-		restrictingKey := xcom.GetRestrictingKey(account)
-		record := state.GetState(account, restrictingKey)
+		pay := minBig(info.Balance, release)
+		if pay.Sign() > 0 {
+			info.Balance = new(big.Int).Sub(info.Balance, pay)
+			state.SubBalance(vm.RestrictingContractAddr, pay)
+			state.AddBalance(account, pay)
+		}
 
-		if err := rlp.Decode(bytes.NewReader(record), &info); err != nil {
-			log.Error("rlp decode failed", "error", err.Error())
-			return false, err
+		if debt := new(big.Int).Sub(release, pay); debt.Sign() > 0 {
+			log.Trace("the release amount is pledged", "account", account.Hex(), "blockNumber", blockNumber, "debt", debt)
+			info.Debt = new(big.Int).Add(info.Debt, debt)
+		}
+
+		for i, height := range info.ReleaseList {
+			if height == blockNumber {
+				info.ReleaseList = append(info.ReleaseList[:i], info.ReleaseList[i+1:]...)
+				break
+			}
 		}
 
-		if info.balance.Uint64() == 0 {
-			log.Trace("balance of release account equals zero", "account", account, "blockNumber", blockNumber)
-			info.debt = info.debt.Sub(info.debt, release)
+		state.SetState(account, xcom.GetReleaseAmountKey(account, blockNumber), []byte{})
+		state.SetState(vm.RestrictingContractAddr, releaseAccountKey, []byte{})
 
-		} else {
+		if err := rp.settleRestrictingInfo(account, info, state); nil != err {
+			return false, err
+		}
+	}
 
-			if info.debt.Sign() == 1 {
-				log.Trace("debt of release account lt zero", "account", account, "debt", info.debt)
+	if numbers > 0 {
+		state.SetState(vm.RestrictingContractAddr, releaseNumberKey, []byte{})
+	}
 
-				if release.Cmp(info.debt) <= 0 {
-					info.debt = info.debt.Sub(info.debt, release)
+	return true, nil
+}
 
-				} else if release.Cmp(info.debt.Add(info.debt, info.balance)) <= 0 {
-					release = release.Sub(release, info.debt)
-					info.balance = info.balance.Sub(info.balance, release)
-					info.debt = big.NewInt(0)
+// GetRestrictingInfo returns the restricting record of the account with all of its release entries
+func (rp *RestrictingPlugin) GetRestrictingInfo(account common.Address, state xcom.StateDB) (*xcom.RestrictingResult, error) {
 
-					state.SubBalance(vm.RestrictingContractAddr, release)
-					state.AddBalance(account, release)
+	info, found, err := rp.getRestrictingInfo(account, state)
+	if nil != err {
+		return nil, err
+	}
 
-				} else {
-					release = release.Sub(release, info.balance)
+	if !found {
+		log.Error("record not found in GetRestrictingInfo", "account", account.Hex())
+		return nil, errAccountNotFound
+	}
 
-					state.SubBalance(vm.RestrictingContractAddr, info.balance)
-					state.AddBalance(account, info.balance)
+	result := &xcom.RestrictingResult{
+		Balance: info.Balance,
+		Debt:    info.Debt,
+		Pledge:  info.Pledge,
+		Entry:   make([]xcom.ReleaseAmountInfo, 0, len(info.ReleaseList)),
+	}
 
-					info.balance = big.NewInt(0)
-					info.debt = info.debt.Sub(info.debt, release)
-				}
+	for _, height := range info.ReleaseList {
+		result.Entry = append(result.Entry, xcom.ReleaseAmountInfo{
+			Height: height,
+			Amount: rp.getReleaseAmount(account, height, state),
+		})
+	}
 
-			} else {
-				log.Trace("debt of release account le zero", "account", account, "debt", info.debt)
+	log.Trace("get restricting result", "account", account.Hex(), "balance", result.Balance,
+		"debt", result.Debt, "pledge", result.Pledge, "entries", len(result.Entry))
 
-				if release.Cmp(info.balance) <= 0 {
-					info.balance = info.balance.Sub(info.balance, release)
+	return result, nil
+}
 
-					state.SubBalance(vm.RestrictingContractAddr, release)
-					state.AddBalance(account, release)
+// GetReleaseAccounts returns all of the accounts which will be released at the end of the epoch
+func (rp *RestrictingPlugin) GetReleaseAccounts(epoch uint64, state xcom.StateDB) []xcom.ReleaseAccountInfo {
 
-				} else {
-					release = release.Sub(release, info.balance)
+	height := xutil.CalculateLastBlockOfEpoch(epoch)
 
-					state.SubBalance(vm.RestrictingContractAddr, info.balance)
-					state.AddBalance(account, info.balance)
+	numbers := common.BytesToUint32(state.GetState(vm.RestrictingContractAddr, xcom.GetReleaseNumberKey(height)))
 
-					info.balance = big.NewInt(0)
-					info.debt = info.debt.Sub(info.debt, release)
-				}
-			}
-		}
-		// '''
-		// 删除记录
-		// '''
+	accounts := make([]xcom.ReleaseAccountInfo, 0, numbers)
+	for index := uint32(1); index <= numbers; index++ {
+		account := common.BytesToAddress(state.GetState(vm.RestrictingContractAddr, xcom.GetReleaseAccountKey(height, index)))
+		accounts = append(accounts, xcom.ReleaseAccountInfo{
+			Account: account,
+			Amount:  rp.getReleaseAmount(account, height, state),
+		})
 	}
-
-	return true, nil
+	return accounts
 }
 
+func (rp *RestrictingPlugin) getRestrictingInfo(account common.Address, state xcom.StateDB) (xcom.RestrictingInfo, bool, error) {
+	var info xcom.RestrictingInfo
 
-func (rp *RestrictingPlugin) GetRestrictingInfo(account common.Address, state xcom.StateDB) ([]byte, error) {
-
-	restrictingKey := xcom.GetRestrictingKey(account)
-	record := state.GetState(account, restrictingKey)
-
+	record := state.GetState(account, xcom.GetRestrictingKey(account))
 	if len(record) == 0 {
-		log.Error("record not found in GetRestrictingInfo", "account", account)
-		return []byte{}, errAccountNotFound
+		return info, false, nil
 	}
 
-	var (
-		info restrictingInfo
-		releaseAmountKey []byte
-		bAmount []byte
-		amount *big.Int
-		plans []releaseAmountInfo
-		plan releaseAmountInfo
-		result Result
-	)
-
-	if err := rlp.Decode(bytes.NewReader(record), &info); err != nil {
-		log.Error("rlp decode failed", "error", err.Error())
-		return []byte{}, err
+	if err := rlp.DecodeBytes(record, &info); nil != err {
+		log.Error("failed to rlp decode restricting info", "account", account.Hex(), "err", err)
+		return info, false, err
 	}
+	return info, true, nil
+}
 
-	for i := 0; i < len(info.releaseList); i ++ {
-		blockNumber := info.releaseList[i]
-
-		releaseAmountKey = xcom.GetReleaseAmountKey(account, blockNumber)
-		bAmount = state.GetState(account, releaseAmountKey)
-
-		if err := rlp.Encode(bytes.NewBuffer(bAmount), &amount); err != nil {
-			log.Error("failed to rlp decode amount in GetRestrictingInfo")
-			return []byte{}, err
-		}
-
-		plan.height = blockNumber
-		plan.amount = amount
-
-		plans = append(plans, plan)
+func (rp *RestrictingPlugin) setRestrictingInfo(account common.Address, info xcom.RestrictingInfo, state xcom.StateDB) error {
+	bInfo, err := rlp.EncodeToBytes(info)
+	if nil != err {
+		log.Error("failed to rlp encode restricting info", "account", account.Hex(), "err", err)
+		return err
 	}
+	state.SetState(account, xcom.GetRestrictingKey(account), bInfo)
+	return nil
+}
 
-	bPlans, err := json.Marshal(plans)
-	if err != nil {
-		log.Error("falied to Marshal restricting result")
-		return []byte{}, err
+// settleRestrictingInfo stores the restricting record of the account,
+// the record is deleted if all of the von has been released and nothing is pledged
+func (rp *RestrictingPlugin) settleRestrictingInfo(account common.Address, info xcom.RestrictingInfo, state xcom.StateDB) error {
+	if len(info.ReleaseList) > 0 || info.Pledge.Sign() > 0 {
+		return rp.setRestrictingInfo(account, info, state)
 	}
 
-	result.balance = info.balance
-	result.debt = info.debt
-	result.slash = big.NewInt(0)
-	result.pledge = big.NewInt(0)
-	result.entry = bPlans
+	if info.Balance.Sign() > 0 {
+		state.SubBalance(vm.RestrictingContractAddr, info.Balance)
+		state.AddBalance(account, info.Balance)
+	}
+	state.SetState(account, xcom.GetRestrictingKey(account), []byte{})
+	return nil
+}
 
-	log.Trace("get restricting result", "result", result)
+func (rp *RestrictingPlugin) getReleaseAmount(account common.Address, height uint64, state xcom.StateDB) *big.Int {
+	bAmount := state.GetState(account, xcom.GetReleaseAmountKey(account, height))
+	return new(big.Int).SetBytes(bAmount)
+}
 
-	return rlp.EncodeToBytes(result)
-}
\ No newline at end of file
+func minBig(x, y *big.Int) *big.Int {
+	if x.Cmp(y) < 0 {
+		return new(big.Int).Set(x)
+	}
+	return new(big.Int).Set(y)
+}
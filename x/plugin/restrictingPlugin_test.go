@@ -0,0 +1,157 @@
+package plugin
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/PlatONnetwork/PlatON-Go/common/vm"
+	"github.com/PlatONnetwork/PlatON-Go/x/xcom"
+	"github.com/PlatONnetwork/PlatON-Go/x/xutil"
+)
+
+// mockStateDB keeps the balances and the storage in memory
+type mockStateDB struct {
+	xcom.StateDB
+	storage  map[string][]byte
+	balances map[common.Address]*big.Int
+}
+
+func newMockStateDB() *mockStateDB {
+	return &mockStateDB{storage: make(map[string][]byte), balances: make(map[common.Address]*big.Int)}
+}
+
+func (s *mockStateDB) GetBalance(addr common.Address) *big.Int {
+	if balance, ok := s.balances[addr]; ok {
+		return balance
+	}
+	return new(big.Int)
+}
+
+func (s *mockStateDB) AddBalance(addr common.Address, amount *big.Int) {
+	s.balances[addr] = new(big.Int).Add(s.GetBalance(addr), amount)
+}
+
+func (s *mockStateDB) SubBalance(addr common.Address, amount *big.Int) {
+	s.balances[addr] = new(big.Int).Sub(s.GetBalance(addr), amount)
+}
+
+func (s *mockStateDB) GetState(addr common.Address, key []byte) []byte {
+	return s.storage[string(append(addr.Bytes(), key...))]
+}
+
+func (s *mockStateDB) SetState(addr common.Address, key []byte, value []byte) {
+	s.storage[string(append(addr.Bytes(), key...))] = value
+}
+
+func lat(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e18))
+}
+
+func assertBalance(t *testing.T, state *mockStateDB, addr common.Address, expect *big.Int) {
+	if balance := state.GetBalance(addr); balance.Cmp(expect) != 0 {
+		t.Fatalf("the balance of %s mismatched, expect: %s, got: %s", addr.Hex(), expect, balance)
+	}
+}
+
+func TestRestrictingPlugin_VerifyPlans(t *testing.T) {
+	rp := NewRestrictingPlugin()
+	state := newMockStateDB()
+
+	sender := common.HexToAddress("0x01")
+	account := common.HexToAddress("0x02")
+	state.AddBalance(sender, lat(10))
+
+	tests := []struct {
+		plans []xcom.RestrictingPlan
+		err   error
+	}{
+		{nil, xcom.RestrictingPlansEmpty},
+		{[]xcom.RestrictingPlan{{Epoch: 0, Amount: lat(1)}}, xcom.RestrictingEpochInvalid},
+		{[]xcom.RestrictingPlan{{Epoch: math.MaxUint64, Amount: lat(1)}}, xcom.RestrictingEpochInvalid},
+		{[]xcom.RestrictingPlan{{Epoch: 1, Amount: big.NewInt(0)}}, xcom.RestrictingAmountInvalid},
+		{[]xcom.RestrictingPlan{{Epoch: 1, Amount: big.NewInt(1)}}, xcom.RestrictingAmountTooLittle},
+		{[]xcom.RestrictingPlan{{Epoch: 1, Amount: lat(11)}}, errBalanceNotEnough},
+	}
+
+	for i, test := range tests {
+		success, err := rp.AddRestrictingRecord(sender, account, test.plans, 1, state)
+		if !success || err != test.err {
+			t.Errorf("test %d: expect business error %v, got: %v, %v", i, test.err, success, err)
+		}
+	}
+	assertBalance(t, state, sender, lat(10))
+}
+
+func TestRestrictingPlugin_ReleaseWithPledge(t *testing.T) {
+	rp := NewRestrictingPlugin()
+	state := newMockStateDB()
+
+	sender := common.HexToAddress("0x01")
+	account := common.HexToAddress("0x02")
+	state.AddBalance(sender, lat(100))
+
+	plans := []xcom.RestrictingPlan{
+		{Epoch: 1, Amount: lat(10)},
+		{Epoch: 2, Amount: lat(20)},
+	}
+	if _, err := rp.AddRestrictingRecord(sender, account, plans, 1, state); nil != err {
+		t.Fatal(err)
+	}
+	// the same epoch is merged into one release entry
+	if _, err := rp.AddRestrictingRecord(sender, account, []xcom.RestrictingPlan{{Epoch: 1, Amount: lat(5)}}, 1, state); nil != err {
+		t.Fatal(err)
+	}
+	assertBalance(t, state, sender, lat(65))
+	assertBalance(t, state, vm.RestrictingContractAddr, lat(35))
+
+	first, second := xutil.CalculateLastBlockOfEpoch(2), xutil.CalculateLastBlockOfEpoch(3)
+
+	result, err := rp.GetRestrictingInfo(account, state)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if len(result.Entry) != 2 || result.Entry[0].Height != first || result.Entry[0].Amount.Cmp(lat(15)) != 0 ||
+		result.Entry[1].Height != second || result.Entry[1].Amount.Cmp(lat(20)) != 0 {
+		t.Fatalf("unexpected release entries: %v", result.Entry)
+	}
+
+	if accounts := rp.GetReleaseAccounts(2, state); len(accounts) != 1 || accounts[0].Account != account {
+		t.Fatalf("unexpected release accounts: %v", accounts)
+	}
+
+	if _, err := rp.PledgeLockFunds(account, lat(30), state); nil != err {
+		t.Fatal(err)
+	}
+	assertBalance(t, state, vm.StakingContractAddr, lat(30))
+
+	// only 5 LAT is not pledged, the rest 10 LAT becomes the debt
+	if _, err := rp.releaseRestricting(first, state); nil != err {
+		t.Fatal(err)
+	}
+	assertBalance(t, state, account, lat(5))
+
+	result, _ = rp.GetRestrictingInfo(account, state)
+	if result.Debt.Cmp(lat(10)) != 0 || result.Balance.Sign() != 0 || len(result.Entry) != 1 {
+		t.Fatalf("unexpected restricting info after release, debt: %s, balance: %s, entries: %d",
+			result.Debt, result.Balance, len(result.Entry))
+	}
+
+	// the debt is repaid first
+	if _, err := rp.ReturnLockFunds(account, lat(30), state); nil != err {
+		t.Fatal(err)
+	}
+	assertBalance(t, state, account, lat(15))
+	assertBalance(t, state, vm.RestrictingContractAddr, lat(20))
+
+	if _, err := rp.releaseRestricting(second, state); nil != err {
+		t.Fatal(err)
+	}
+	assertBalance(t, state, account, lat(35))
+	assertBalance(t, state, vm.RestrictingContractAddr, new(big.Int))
+
+	if _, err := rp.GetRestrictingInfo(account, state); err != errAccountNotFound {
+		t.Fatalf("expect the record is deleted, got: %v", err)
+	}
+}
@@ -1 +1,150 @@
 package plugin
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/PlatONnetwork/PlatON-Go/core/snapshotdb"
+	"github.com/PlatONnetwork/PlatON-Go/core/types"
+	"github.com/PlatONnetwork/PlatON-Go/log"
+	"github.com/PlatONnetwork/PlatON-Go/x/xcom"
+	"github.com/PlatONnetwork/PlatON-Go/x/xutil"
+)
+
+var (
+	EvidenceDecodeErr     = errors.New("decode the evidence data failed")
+	EvidenceEmptyErr      = errors.New("the evidence data is empty")
+	EvidenceExpiredErr    = errors.New("the evidence has expired")
+	EvidenceBlockNumErr   = errors.New("the blockNumber of the evidence is higher than current blockNumber")
+	DuplicateSignExistErr = errors.New("the duplicate signing has been slashed")
+	SlashCanNotExistErr   = errors.New("the candidate of the evidence is not exist")
+	SlashCanStakingErr    = errors.New("the candidate staked after the evidence happened")
+)
+
+type SlashingPlugin struct {
+	db      snapshotdb.DB
+	staking *StakingPlugin
+}
+
+// NewSlashingPlugin creates a SlashingPlugin on the db, the offenders are slashed by the staking plugin
+func NewSlashingPlugin(db snapshotdb.DB, staking *StakingPlugin) *SlashingPlugin {
+	return &SlashingPlugin{
+		db:      db,
+		staking: staking,
+	}
+}
+
+func (sp *SlashingPlugin) BeginBlock(blockHash common.Hash, header *types.Header, state xcom.StateDB) (bool, error) {
+	return true, nil
+}
+
+func (sp *SlashingPlugin) EndBlock(blockHash common.Hash, header *types.Header, state xcom.StateDB) (bool, error) {
+	return true, nil
+}
+
+func (sp *SlashingPlugin) Confirmed(block *types.Block) error {
+	return nil
+}
+
+// Slash verifies the evidences of duplicate signing reported by cbft,
+// and then slashes the staking of the offender
+func (sp *SlashingPlugin) Slash(evidenceData string, blockHash common.Hash, blockNumber uint64, state xcom.StateDB) (bool, error) {
+
+	var data xcom.EvidenceData
+	if err := json.Unmarshal([]byte(evidenceData), &data); nil != err {
+		log.Error("Failed to Slash on slashingPlugin: decode evidence data failed",
+			"blockNumber", blockNumber, "blockHash", blockHash.Hex(), "err", err)
+		return true, EvidenceDecodeErr
+	}
+
+	evidences, err := data.Evidences()
+	if nil != err {
+		log.Error("Failed to Slash on slashingPlugin: the evidence data is invalid",
+			"blockNumber", blockNumber, "blockHash", blockHash.Hex(), "err", err)
+		return true, err
+	}
+	if len(evidences) == 0 {
+		return true, EvidenceEmptyErr
+	}
+
+	for _, evidence := range evidences {
+
+		if err := evidence.Validate(); nil != err {
+			log.Error("Failed to Slash on slashingPlugin: the evidence is invalid",
+				"blockNumber", blockNumber, "blockHash", blockHash.Hex(), "type", evidence.Type(), "err", err)
+			return true, err
+		}
+
+		evidenceNumber := evidence.BlockNumber()
+		if evidenceNumber > blockNumber {
+			return true, EvidenceBlockNumErr
+		}
+
+		if xutil.CalculateEpoch(blockNumber)-xutil.CalculateEpoch(evidenceNumber) > xcom.GovernUint64(state, xcom.ParamEvidenceValidEpoch) {
+			log.Error("Failed to Slash on slashingPlugin: the evidence has expired", "blockNumber", blockNumber,
+				"blockHash", blockHash.Hex(), "evidenceBlockNumber", evidenceNumber, "type", evidence.Type())
+			return true, EvidenceExpiredErr
+		}
+
+		addr := evidence.Address()
+		key := xcom.GetSlashingKey(evidence.Type(), addr, evidenceNumber)
+
+		if val, err := sp.db.Get(blockHash, key); nil != err && err != snapshotdb.ErrNotFound {
+			log.Error("Failed to Slash on slashingPlugin: query the slashing record failed",
+				"blockNumber", blockNumber, "blockHash", blockHash.Hex(), "key", key, "err", err)
+			return false, err
+		} else if len(val) != 0 {
+			return true, DuplicateSignExistErr
+		}
+
+		can, err := sp.staking.GetCandidateInfo(blockHash, addr)
+		if nil != err {
+			log.Error("Failed to Slash on slashingPlugin: query candidate info failed",
+				"blockNumber", blockNumber, "blockHash", blockHash.Hex(), "addr", addr.Hex(), "err", err)
+			return false, err
+		}
+
+		if nil == can {
+			return true, SlashCanNotExistErr
+		}
+
+		if can.StakingBlockNum > evidenceNumber {
+			return true, SlashCanStakingErr
+		}
+
+		// the candidate may be slashed by another evidence in the same report
+		if xcom.Is_Slashed(can.Status) {
+			log.Warn("Skip the evidence on slashingPlugin: the candidate has already been slashed",
+				"blockNumber", blockNumber, "blockHash", blockHash.Hex(), "addr", addr.Hex(), "type", evidence.Type())
+			continue
+		}
+
+		if success, err := sp.staking.SlashCandidates(state, blockHash, blockNumber, addr,
+			xcom.GovernUint64(state, xcom.ParamDuplicateSignSlashRatio)); nil != err {
+			log.Error("Failed to Slash on slashingPlugin: SlashCandidates failed", "blockNumber", blockNumber,
+				"blockHash", blockHash.Hex(), "addr", addr.Hex(), "err", err)
+			return success, err
+		}
+
+		if err := sp.db.Put(blockHash, key, evidence.Hash()); nil != err {
+			log.Error("Failed to Slash on slashingPlugin: store the slashing record failed",
+				"blockNumber", blockNumber, "blockHash", blockHash.Hex(), "key", key, "err", err)
+			return false, err
+		}
+
+		log.Info("Succeeded to Slash on slashingPlugin", "blockNumber", blockNumber, "blockHash", blockHash.Hex(),
+			"addr", addr.Hex(), "type", evidence.Type(), "evidenceBlockNumber", evidenceNumber)
+	}
+
+	return true, nil
+}
+
+// CheckDuplicateSign returns the evidence hash if the duplicate signing has been slashed
+func (sp *SlashingPlugin) CheckDuplicateSign(blockHash common.Hash, typ uint8, addr common.Address, blockNumber uint64) ([]byte, error) {
+	val, err := sp.db.Get(blockHash, xcom.GetSlashingKey(typ, addr, blockNumber))
+	if nil != err && err != snapshotdb.ErrNotFound {
+		return nil, err
+	}
+	return val, nil
+}
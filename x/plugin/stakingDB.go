@@ -55,6 +55,9 @@ func (db *StakingDB) getCandidateStore (blockHash common.Hash, addr common.Addre
 	canByte, err := db.get(blockHash, key)
 
 	if nil != err {
+		if err == snapshotdb.ErrNotFound {
+			return nil, nil
+		}
 		return nil, err
 	}
 	var can xcom.Candidate
@@ -82,6 +85,25 @@ func (db *StakingDB) delCandidateStore (blockHash common.Hash, addr common.Addre
 	return db.del(blockHash, key)
 }
 
+// getDelegateRewardPerStore returns nil if the candidate is not deleted
+func (db *StakingDB) getDelegateRewardPerStore (blockHash common.Hash, nodeId discover.NodeID, stakeBlockNumber uint64) (*big.Int, error) {
+	val, err := db.get(blockHash, xcom.GetDelegateRewardPerKey(nodeId, stakeBlockNumber))
+	if nil != err {
+		if err == snapshotdb.ErrNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(val) == 0 {
+		return nil, nil
+	}
+	return new(big.Int).SetBytes(val), nil
+}
+
+func (db *StakingDB) setDelegateRewardPerStore (blockHash common.Hash, nodeId discover.NodeID, stakeBlockNumber uint64, rewardPer *big.Int) error {
+	return db.put(blockHash, xcom.GetDelegateRewardPerKey(nodeId, stakeBlockNumber), rewardPer.Bytes())
+}
+
 func (db *StakingDB) setCanPowerStore (blockHash common.Hash, addr common.Address, can *xcom.Candidate) error {
 	key := xcom.TallyPowerKey(can.Shares, int(can.StakingBlockNum), int(can.StakingTxIndex))
 	return db.put(blockHash, key, addr.Bytes())
@@ -100,7 +122,7 @@ func (db *StakingDB) addUnStakeItemStore (blockHash common.Hash, epoch int, addr
 	count_key := xcom.GetUnStakeCountKey(epoch)
 
 	val, err := db.get(blockHash, count_key)
-	if nil != err {
+	if nil != err && err != snapshotdb.ErrNotFound {
 		return err
 	}
 
@@ -127,7 +149,7 @@ func (db *StakingDB) getUnStakeCountStore (blockHash common.Hash, epoch int) (in
 	count_key := xcom.GetUnStakeCountKey(epoch)
 
 	val, err := db.get(blockHash, count_key)
-	if nil != err {
+	if nil != err && err != snapshotdb.ErrNotFound {
 		return 0, err
 	}
 
@@ -169,6 +191,9 @@ func (db *StakingDB) getDelegateStore (blockHash common.Hash, delAddr common.Add
 
 	delByte, err := db.get(blockHash, key)
 	if nil != err {
+		if err == snapshotdb.ErrNotFound {
+			return nil, nil
+		}
 		return nil, err
 	}
 
@@ -230,7 +255,7 @@ func (db *StakingDB) addUnDelegateItemStore (blockHash common.Hash, delAddr comm
 	count_key := xcom.GetUnDelegateCountKey(epoch)
 
 	val, err := db.get(blockHash, count_key)
-	if nil != err {
+	if nil != err && err != snapshotdb.ErrNotFound {
 		return err
 	}
 
@@ -271,7 +296,7 @@ func (db *StakingDB) getUnDelegateCountStore (blockHash common.Hash, epoch int)
 	count_key := xcom.GetUnDelegateCountKey(epoch)
 
 	val, err := db.get(blockHash, count_key)
-	if nil != err {
+	if nil != err && err != snapshotdb.ErrNotFound {
 		return 0, err
 	}
 
@@ -301,6 +326,16 @@ func (db *StakingDB) getUnDelegateItemStore (blockHash common.Hash, epoch, index
 	return &unDelegateItem, nil
 }
 
+func (db *StakingDB) delUnDelegateCountStore (blockHash common.Hash, epoch int) error {
+	count_key := xcom.GetUnDelegateCountKey(epoch)
+	return db.del(blockHash, count_key)
+}
+
+func (db *StakingDB) delUnDelegateItemStore (blockHash common.Hash, epoch, index int) error {
+	item_key := xcom.GetUnDelegateItemKey(epoch, index)
+	return db.del(blockHash, item_key)
+}
+
 
 func (db *StakingDB) getVerifierList (blockHash common.Hash) (*xcom.Validator_array, error) {
 
@@ -355,6 +390,86 @@ func (db *StakingDB) getNextValidatorList (blockHash common.Hash) (*xcom.Validat
 	return arr, nil
 }
 
+func (db *StakingDB) setVerifierList (blockHash common.Hash, arr *xcom.Validator_array) error {
+	arrByte, err := rlp.EncodeToBytes(arr)
+	if nil != err {
+		return err
+	}
+	return db.put(blockHash, xcom.GetEpochValidatorKey(), arrByte)
+}
+
+func (db *StakingDB) setPreviousValidatorList (blockHash common.Hash, arr *xcom.Validator_array) error {
+	arrByte, err := rlp.EncodeToBytes(arr)
+	if nil != err {
+		return err
+	}
+	return db.put(blockHash, xcom.GetPreRoundValidatorKey(), arrByte)
+}
+
+func (db *StakingDB) setCurrentValidatorList (blockHash common.Hash, arr *xcom.Validator_array) error {
+	arrByte, err := rlp.EncodeToBytes(arr)
+	if nil != err {
+		return err
+	}
+	return db.put(blockHash, xcom.GetCurRoundValidatorKey(), arrByte)
+}
+
+func (db *StakingDB) setNextValidatorList (blockHash common.Hash, arr *xcom.Validator_array) error {
+	arrByte, err := rlp.EncodeToBytes(arr)
+	if nil != err {
+		return err
+	}
+	return db.put(blockHash, xcom.GetNextRoundValidatorKey(), arrByte)
+}
+
+func (db *StakingDB) delNextValidatorList (blockHash common.Hash) error {
+	return db.del(blockHash, xcom.GetNextRoundValidatorKey())
+}
+
+func (db *StakingDB) getPackageCountStore (blockHash common.Hash, round uint64, addr common.Address) (uint32, error) {
+	val, err := db.get(blockHash, xcom.GetPackageCountKey(round, addr))
+	if nil != err && err != snapshotdb.ErrNotFound {
+		return 0, err
+	}
+	if len(val) == 0 {
+		return 0, nil
+	}
+	return common.BytesToUint32(val), nil
+}
+
+func (db *StakingDB) setPackageCountStore (blockHash common.Hash, round uint64, addr common.Address, count uint32) error {
+	return db.put(blockHash, xcom.GetPackageCountKey(round, addr), common.Uint32ToBytes(count))
+}
+
+// delPackageCountBefore deletes the package counts of the rounds before the round
+func (db *StakingDB) delPackageCountBefore (blockHash common.Hash, round uint64) error {
+	iter := db.ranking(blockHash, xcom.PackageCountKey, 0)
+	defer iter.Release()
+
+	var keys [][]byte
+	for iter.Next() {
+		if len(iter.Value()) == 0 {
+			continue
+		}
+		r, err := xcom.DecodePackageCountRound(iter.Key())
+		if nil != err {
+			return err
+		}
+		if r < round {
+			keys = append(keys, common.CopyBytes(iter.Key()))
+		}
+	}
+	if err := iter.Error(); nil != err {
+		return err
+	}
+	for _, key := range keys {
+		if err := db.del(blockHash, key); nil != err {
+			return err
+		}
+	}
+	return nil
+}
+
 //func (db *StakingDB) IteratorCandidatePowerByIrr () iterator.Iterator {
 //
 //	db.ranking(xcom.CanPowerKeyPrefix, 0)
@@ -1,6 +1,7 @@
 package plugin
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"github.com/PlatONnetwork/PlatON-Go/common"
@@ -8,24 +9,25 @@ import (
 	"github.com/PlatONnetwork/PlatON-Go/core/snapshotdb"
 	"github.com/PlatONnetwork/PlatON-Go/core/types"
 	"github.com/PlatONnetwork/PlatON-Go/crypto"
+	"github.com/PlatONnetwork/PlatON-Go/crypto/vrf"
 	"github.com/PlatONnetwork/PlatON-Go/log"
 	"github.com/PlatONnetwork/PlatON-Go/p2p/discover"
-	"strconv"
+	"github.com/PlatONnetwork/PlatON-Go/rlp"
 
 	//"github.com/PlatONnetwork/PlatON-Go/p2p/discover"
 	"github.com/PlatONnetwork/PlatON-Go/x/xcom"
 	"github.com/PlatONnetwork/PlatON-Go/x/xutil"
 	"math/big"
+	"sort"
 	"sync"
 )
 
 type StakingPlugin struct {
-	db   *StakingDB
-	once sync.Once
+	db          *StakingDB
+	restricting *RestrictingPlugin
+	once        sync.Once
 }
 
-var stk *StakingPlugin
-
 var (
 	AccountVonNotEnough        = errors.New("The von of account is not enough")
 	DelegateVonNotEnough       = errors.New("The von of delegate is not enough")
@@ -35,6 +37,8 @@ var (
 	BlockNumberDisordered 	   = errors.New("The blockNumber is disordered")
 
 	VonAmountNotRight		   = errors.New("The amount of von is not right")
+	CandidateNotExist          = errors.New("The candidate is not exist")
+	CandidateAlreadySlashed    = errors.New("The candidate has already been slashed")
 )
 
 const (
@@ -49,14 +53,19 @@ const (
 
 )
 
-// Instance a global StakingPlugin
-func StakingInstance(db snapshotdb.DB) *StakingPlugin {
-	if nil == stk && nil != db {
-		stk = &StakingPlugin{
-			db: NewStakingDB(db),
-		}
+// NewStakingPlugin creates a StakingPlugin on the db, the restricted von
+// of the staking and delegation is locked and returned by the restricting plugin
+func NewStakingPlugin(db snapshotdb.DB, restricting *RestrictingPlugin) *StakingPlugin {
+	return &StakingPlugin{
+		db:          NewStakingDB(db),
+		restricting: restricting,
 	}
-	return stk
+}
+
+// HighestCommittedNum returns the number of the highest committed block of the snapshotdb,
+// the staking data of the blocks below it have been merged and can not be read by the block hash
+func (sk *StakingPlugin) HighestCommittedNum() (*big.Int, error) {
+	return sk.db.db.HighestNum()
 }
 
 func (sk *StakingPlugin) BeginBlock(blockHash common.Hash, header *types.Header, state xcom.StateDB) (bool, error) {
@@ -66,32 +75,56 @@ func (sk *StakingPlugin) BeginBlock(blockHash common.Hash, header *types.Header,
 
 func (sk *StakingPlugin) EndBlock(blockHash common.Hash, header *types.Header, state xcom.StateDB) (bool, error) {
 
-	/*epoch := xutil.CalculateEpoch(header.Number.Uint64())
+	blockNumber := header.Number.Uint64()
+
+	if err := sk.addPackageCount(blockHash, header); nil != err {
+		log.Error("Failed to call addPackageCount on stakingPlugin EndBlock", "blockHash", blockHash.Hex(), "blockNumber", blockNumber, "err", err)
+		return false, err
+	}
+
+	if xutil.IsSettlementPeriod(blockNumber) {
+		epoch := xutil.CalculateEpoch(blockNumber)
 
-	if xutil.IsSettlementPeriod(header.Number.Uint64()) {
 		success, err := sk.HandleUnCandidateReq(state, blockHash, epoch)
 		if nil != err {
-			log.Error("Failed to call HandleUnCandidateReq on stakingPlugin EndBlock", "blockHash", blockHash.Hex(), "blockNumber", header.Number.Uint64(), "err", err)
+			log.Error("Failed to call HandleUnCandidateReq on stakingPlugin EndBlock", "blockHash", blockHash.Hex(), "blockNumber", blockNumber, "err", err)
+			return success, err
+		}
+
+		success, err = sk.HandleUnDelegateReq(state, blockHash, epoch)
+		if nil != err {
+			log.Error("Failed to call HandleUnDelegateReq on stakingPlugin EndBlock", "blockHash", blockHash.Hex(), "blockNumber", blockNumber, "err", err)
 			return success, err
 		}
+
+		success, err = sk.ElectNextVerifierList(state, blockHash, blockNumber)
+		if nil != err {
+			log.Error("Failed to call ElectNextVerifierList on stakingPlugin EndBlock", "blockHash", blockHash.Hex(), "blockNumber", blockNumber, "err", err)
+			return success, err
+		}
+
+		if err := sk.prunePackageCount(blockHash, epoch); nil != err {
+			log.Error("Failed to call prunePackageCount on stakingPlugin EndBlock", "blockHash", blockHash.Hex(), "blockNumber", blockNumber, "err", err)
+			return false, err
+		}
 	}
 
-	if xutil.IsElection(header.Number.Uint64()) {
-		success, err := sk.Election(blockHash, header.Number.Uint64())
+	if xutil.IsElection(blockNumber) {
+		success, err := sk.Election(state, blockHash, header)
 		if nil != err {
-			log.Error("Failed to call Election on stakingPlugin EndBlock", "blockHash", blockHash.Hex(), "blockNumber", header.Number.Uint64(), "err", err)
+			log.Error("Failed to call Election on stakingPlugin EndBlock", "blockHash", blockHash.Hex(), "blockNumber", blockNumber, "err", err)
 			return success, err
 		}
 	}
 
-	if xutil.IsSwitch(header.Number.Uint64()) {
-		success, err := sk.Switch(blockHash, header.Number.Uint64())
+	if xutil.IsSwitch(blockNumber) {
+		success, err := sk.Switch(blockHash, blockNumber)
 		if nil != err {
-			log.Error("Failed to call Switch on stakingPlugin EndBlock", "blockHash", blockHash.Hex(), "blockNumber", header.Number.Uint64(), "err", err)
+			log.Error("Failed to call Switch on stakingPlugin EndBlock", "blockHash", blockHash.Hex(), "blockNumber", blockNumber, "err", err)
 			return success, err
 		}
 	}
-	*/
+
 	return true, nil
 }
 
@@ -128,6 +161,11 @@ func (sk *StakingPlugin) CreateCandidate(state xcom.StateDB, blockHash common.Ha
 		can.ProcessVersion = processVersion
 	}
 
+	can.Released = new(big.Int)
+	can.ReleasedTmp = new(big.Int)
+	can.LockRepo = new(big.Int)
+	can.LockRepoTmp = new(big.Int)
+
 	// from account free von
 	if typ == FreeOrigin {
 
@@ -144,9 +182,15 @@ func (sk *StakingPlugin) CreateCandidate(state xcom.StateDB, blockHash common.Ha
 
 	} else if typ == LockRepoOrigin { //  from account lockRepo von
 
-		// TODO call RestrictingPlugin
-
+		if success, err := sk.restricting.PledgeLockFunds(can.StakingAddress, amount, state); nil != err {
+			log.Error("Failed to CreateCandidate on stakingPlugin: call PledgeLockFunds failed",
+				"blockNumber", blockNumber.Uint64(), "blockHash", blockHash.Hex(), "account", can.StakingAddress.Hex(),
+				"stakingVon", amount, "err", err)
+			return success, err
+		}
 		can.LockRepoTmp = amount
+	} else {
+		return true, ParamsErr
 	}
 
 	can.StakingEpoch = xutil.CalculateEpoch(blockNumber.Uint64())
@@ -165,12 +209,12 @@ func (sk *StakingPlugin) CreateCandidate(state xcom.StateDB, blockHash common.Ha
 	return true, nil
 }
 
-func (sk *StakingPlugin) EditorCandidate(blockHash common.Hash, blockNumber *big.Int, can *xcom.Candidate) (bool, error) {
+func (sk *StakingPlugin) EditorCandidate(state xcom.StateDB, blockHash common.Hash, blockNumber *big.Int, can *xcom.Candidate) (bool, error) {
 	pubKey, _ := can.NodeId.Pubkey()
 
 	epoch := xutil.CalculateEpoch(blockNumber.Uint64())
 
-	lazyCalcStakeAmount(epoch, can)
+	lazyCalcStakeAmount(state, epoch, can)
 
 	addr := crypto.PubkeyToAddress(*pubKey)
 
@@ -189,7 +233,7 @@ func (sk *StakingPlugin) IncreaseStaking(state xcom.StateDB, blockHash common.Ha
 
 	epoch := xutil.CalculateEpoch(blockNumber.Uint64())
 
-	lazyCalcStakeAmount(epoch, can)
+	lazyCalcStakeAmount(state, epoch, can)
 
 	addr := crypto.PubkeyToAddress(*pubKey)
 
@@ -205,11 +249,17 @@ func (sk *StakingPlugin) IncreaseStaking(state xcom.StateDB, blockHash common.Ha
 		state.AddBalance(vm.StakingContractAddr, amount)
 
 		can.ReleasedTmp = new(big.Int).Add(can.ReleasedTmp, amount)
-	} else {
-
-		// TODO call RestrictingPlugin
+	} else if typ == LockRepoOrigin {
 
+		if success, err := sk.restricting.PledgeLockFunds(can.StakingAddress, amount, state); nil != err {
+			log.Error("Failed to IncreaseStaking on stakingPlugin: call PledgeLockFunds failed",
+				"blockNumber", blockNumber.Uint64(), "blockHash", blockHash.Hex(), "account", can.StakingAddress.Hex(),
+				"stakingVon", amount, "err", err)
+			return success, err
+		}
 		can.LockRepoTmp = new(big.Int).Add(can.LockRepoTmp, amount)
+	} else {
+		return true, ParamsErr
 	}
 
 	can.StakingEpoch = epoch
@@ -244,7 +294,7 @@ func (sk *StakingPlugin) WithdrewCandidate(state xcom.StateDB, blockHash common.
 
 	epoch := xutil.CalculateEpoch(blockNumber.Uint64())
 
-	lazyCalcStakeAmount(epoch, can)
+	lazyCalcStakeAmount(state, epoch, can)
 
 	addr := crypto.PubkeyToAddress(*pubKey)
 
@@ -269,7 +319,7 @@ func (sk *StakingPlugin) WithdrewCandidate(state xcom.StateDB, blockHash common.
 			return false, err
 		}
 	} else {
-		if err := sk.db.delCandidateStore(blockHash, addr); nil != err {
+		if err := sk.delCandidate(blockHash, addr, can); nil != err {
 			log.Error("Failed to WithdrewCandidate on stakingPlugin: Del Can info failed",
 				"blockNumber", blockNumber.Uint64(), "blockHash", blockHash.Hex(), "err", err)
 			return false, err
@@ -287,12 +337,19 @@ func (sk *StakingPlugin) withdrewStakeAmount(state xcom.StateDB, blockHash commo
 		state.AddBalance(can.StakingAddress, can.ReleasedTmp)
 		state.SubBalance(vm.StakingContractAddr, can.ReleasedTmp)
 		can.Shares = new(big.Int).Sub(can.Shares, can.ReleasedTmp)
+		can.ReleasedTmp = new(big.Int).SetInt64(0)
 	}
 
 	if can.LockRepoTmp.Cmp(common.Big0) > 0 {
-		// TODO call RestrictingPlugin
+		if success, err := sk.restricting.ReturnLockFunds(can.StakingAddress, can.LockRepoTmp, state); nil != err {
+			log.Error("Failed to withdrewStakeAmount on stakingPlugin: call ReturnLockFunds failed",
+				"blockNumber", blockNumber, "blockHash", blockHash.Hex(), "account", can.StakingAddress.Hex(),
+				"lockRepoTmp", can.LockRepoTmp, "err", err)
+			return success, err
+		}
 
 		can.Shares = new(big.Int).Sub(can.Shares, can.LockRepoTmp)
+		can.LockRepoTmp = new(big.Int).SetInt64(0)
 	}
 
 	if can.Released.Cmp(common.Big0) > 0 || can.LockRepo.Cmp(common.Big0) > 0 {
@@ -307,7 +364,7 @@ func (sk *StakingPlugin) withdrewStakeAmount(state xcom.StateDB, blockHash commo
 
 func (sk *StakingPlugin) HandleUnCandidateReq(state xcom.StateDB, blockHash common.Hash, epoch uint64) (bool, error) {
 
-	releaseEpoch := epoch - xcom.UnStakeFreezeRatio
+	releaseEpoch := epoch - xcom.GovernUint64(state, xcom.ParamUnStakeFreezeRatio)
 	releaseEpoch_int := int(releaseEpoch)
 
 	unStakeCount, err := sk.db.getUnStakeCountStore(blockHash, releaseEpoch_int)
@@ -352,7 +409,7 @@ func (sk *StakingPlugin) HandleUnCandidateReq(state xcom.StateDB, blockHash comm
 			return flag, err
 		}
 
-		if err := sk.db.delUnStakeItemStore(blockHash, int(epoch), index); nil != err {
+		if err := sk.db.delUnStakeItemStore(blockHash, releaseEpoch_int, index); nil != err {
 			return false, err
 		}
 
@@ -368,7 +425,7 @@ func (sk *StakingPlugin) HandleUnCandidateReq(state xcom.StateDB, blockHash comm
 
 func (sk *StakingPlugin) handleUnStake(state xcom.StateDB, blockHash common.Hash, epoch uint64, addr common.Address, can *xcom.Candidate) (bool, error) {
 
-	lazyCalcStakeAmount(epoch, can)
+	lazyCalcStakeAmount(state, epoch, can)
 
 	// Direct return of money during the hesitation period
 	// Return according to the way of coming
@@ -378,18 +435,33 @@ func (sk *StakingPlugin) handleUnStake(state xcom.StateDB, blockHash common.Hash
 	}
 
 	if can.LockRepo.Cmp(common.Big0) > 0 {
-		// TODO call RestrictingPlugin
-
+		if success, err := sk.restricting.ReturnLockFunds(can.StakingAddress, can.LockRepo, state); nil != err {
+			log.Error("Failed to handleUnStake on stakingPlugin: call ReturnLockFunds failed",
+				"blockHash", blockHash.Hex(), "epoch", epoch, "account", can.StakingAddress.Hex(),
+				"lockRepo", can.LockRepo, "err", err)
+			return success, err
+		}
 	}
 
 	// delete can info
-	if err := sk.db.delCandidateStore(blockHash, addr); nil != err {
+	if err := sk.delCandidate(blockHash, addr, can); nil != err {
 		return false, err
 	}
 
 	return true, nil
 }
 
+// delCandidate deletes the candidate, and keeps its final DelegateRewardPer
+// for the delegations which have not settled the delegate reward yet
+func (sk *StakingPlugin) delCandidate(blockHash common.Hash, addr common.Address, can *xcom.Candidate) error {
+	if bigOrZero(can.DelegateRewardPer).Sign() > 0 {
+		if err := sk.db.setDelegateRewardPerStore(blockHash, can.NodeId, can.StakingBlockNum, can.DelegateRewardPer); nil != err {
+			return err
+		}
+	}
+	return sk.db.delCandidateStore(blockHash, addr)
+}
+
 func (sk *StakingPlugin) GetDelegateInfo(blockHash common.Hash, delAddr common.Address,
 	nodeId discover.NodeID, stakeBlockNumber uint64) (*xcom.Delegation, error) {
 
@@ -404,7 +476,10 @@ func (sk *StakingPlugin) Delegate(state xcom.StateDB, blockHash common.Hash, blo
 
 	epoch := xutil.CalculateEpoch(blockNumber.Uint64())
 
-	lazyCalcDelegateAmount(epoch, del)
+	// settle the delegate reward before the shares of the delegation changed
+	settleDelegateReward(can, del)
+
+	lazyCalcDelegateAmount(state, epoch, del)
 
 	if typ == FreeOrigin { // from account free von
 
@@ -421,11 +496,15 @@ func (sk *StakingPlugin) Delegate(state xcom.StateDB, blockHash common.Hash, blo
 
 	} else if typ == LockRepoOrigin { //  from account lockRepo von
 
-		// TODO call RestrictingPlugin
-
-
+		if success, err := sk.restricting.PledgeLockFunds(delAddr, amount, state); nil != err {
+			log.Error("Failed to Delegate on stakingPlugin: call PledgeLockFunds failed", "blockNumber", blockNumber,
+				"blockHash", blockHash.Hex(), "account", delAddr.Hex(), "delegateVon", amount, "err", err)
+			return success, err
+		}
 		del.LockRepoTmp = new(big.Int).Add(del.LockRepoTmp, amount)
 
+	} else {
+		return true, ParamsErr
 	}
 
 	del.DelegateEpoch = epoch
@@ -478,7 +557,12 @@ func (sk *StakingPlugin) WithdrewDelegate(state xcom.StateDB, blockHash common.H
 	stake_num := int(stakingBlockNum)
 	epoch_int := int(epoch)
 
-	lazyCalcDelegateAmount(epoch, del)
+	// settle the delegate reward before the shares of the delegation changed
+	if err := sk.settleDelegation(blockHash, can, nodeId, stakingBlockNum, del); nil != err {
+		return false, err
+	}
+
+	lazyCalcDelegateAmount(state, epoch, del)
 
 
 
@@ -491,7 +575,7 @@ func (sk *StakingPlugin) WithdrewDelegate(state xcom.StateDB, blockHash common.H
 		return new(big.Int).Sub(source, sub), common.Big0
 	}
 
-	refundFn := func(remain, aboutRelease, aboutLockRepo *big.Int) (*big.Int, *big.Int, *big.Int) {
+	refundFn := func(remain, aboutRelease, aboutLockRepo *big.Int) (*big.Int, *big.Int, *big.Int, bool, error) {
 		// When remain is greater than or equal to del.ReleasedTmp/del.Released
 		if remain.Cmp(common.Big0) > 0 {
 			if remain.Cmp(aboutRelease) >= 0 && aboutRelease.Cmp(common.Big0) > 0 {
@@ -508,22 +592,28 @@ func (sk *StakingPlugin) WithdrewDelegate(state xcom.StateDB, blockHash common.H
 
 			// When remain is greater than or equal to del.LockRepoTmp/del.LockRepo
 			if remain.Cmp(aboutLockRepo) >= 0 && aboutLockRepo.Cmp(common.Big0) > 0 {
-				// todo call Restricting for flush lockRepoTmp
+				if success, err := sk.restricting.ReturnLockFunds(delAddr, aboutLockRepo, state); nil != err {
+					return remain, aboutRelease, aboutLockRepo, success, err
+				}
 
 				remain = new(big.Int).Sub(remain, aboutLockRepo)
 				aboutLockRepo = common.Big0
 			} else if remain.Cmp(aboutLockRepo) < 0 {
 				// When remain is less than or equal to del.LockRepoTmp/del.LockRepo
-				// todo call Restricting for sub lockRepoTmp
+				if success, err := sk.restricting.ReturnLockFunds(delAddr, remain, state); nil != err {
+					return remain, aboutRelease, aboutLockRepo, success, err
+				}
 
 				aboutLockRepo = new(big.Int).Sub(aboutLockRepo, remain)
 				remain = common.Big0
 			}
 		}
 
-		return remain, aboutRelease, aboutLockRepo
+		return remain, aboutRelease, aboutLockRepo, true, nil
 	}
 
+	var success bool
+
 	del.DelegateEpoch = epoch
 
 	switch {
@@ -542,13 +632,19 @@ func (sk *StakingPlugin) WithdrewDelegate(state xcom.StateDB, blockHash common.H
 		/**
 		handle delegate on HesitateRatio
 		*/
-		remain, del.ReleasedTmp, del.LockRepoTmp = refundFn(remain, del.ReleasedTmp, del.LockRepoTmp)
+		remain, del.ReleasedTmp, del.LockRepoTmp, success, err = refundFn(remain, del.ReleasedTmp, del.LockRepoTmp)
+		if nil != err {
+			return success, err
+		}
 
 		/**
 		handle delegate on EffectiveRatio
 		*/
 		if remain.Cmp(common.Big0) > 0 {
-			remain, del.Released, del.LockRepo = refundFn(remain, del.Released, del.LockRepo)
+			remain, del.Released, del.LockRepo, success, err = refundFn(remain, del.Released, del.LockRepo)
+			if nil != err {
+				return success, err
+			}
 		}
 
 		if remain.Cmp(common.Big0) != 0 {
@@ -558,6 +654,7 @@ func (sk *StakingPlugin) WithdrewDelegate(state xcom.StateDB, blockHash common.H
 		}
 
 		if total.Cmp(amount) == 0 {
+			payDelegateIncome(state, delAddr, del)
 			if err := sk.db.delDelegateStore(blockHash, delAddr, nodeId, stake_num); nil != err {
 				return false, err
 			}
@@ -596,7 +693,10 @@ func (sk *StakingPlugin) WithdrewDelegate(state xcom.StateDB, blockHash common.H
 		/**
 		handle delegate on HesitateRatio
 		*/
-		remain, del.ReleasedTmp, del.LockRepoTmp = refundFn(remain, del.ReleasedTmp, del.LockRepoTmp)
+		remain, del.ReleasedTmp, del.LockRepoTmp, success, err = refundFn(remain, del.ReleasedTmp, del.LockRepoTmp)
+		if nil != err {
+			return success, err
+		}
 
 		/**
 		handle delegate on EffectiveRatio
@@ -634,7 +734,7 @@ func (sk *StakingPlugin) WithdrewDelegate(state xcom.StateDB, blockHash common.H
 }
 
 func (sk *StakingPlugin) HandleUnDelegateReq(state xcom.StateDB, blockHash common.Hash, epoch uint64) (bool, error) {
-	releaseEpoch := epoch - xcom.ActiveUnDelegateFreezeRatio
+	releaseEpoch := epoch - xcom.GovernUint64(state, xcom.ParamActiveUnDelegateFreezeRatio)
 
 	unDelegateCount, err := sk.db.getUnDelegateCountStore(blockHash, int(releaseEpoch))
 	if nil != err {
@@ -671,26 +771,42 @@ func (sk *StakingPlugin) HandleUnDelegateReq(state xcom.StateDB, blockHash commo
 			return flag, err
 		}
 
+		if err := sk.db.delUnDelegateItemStore(blockHash, int(releaseEpoch), index); nil != err {
+			return false, err
+		}
+
 		//filterAddr[fmt.Sprint(unDelegateItem.KeySuffix)] = struct{}{}
 	}
 
+	if err := sk.db.delUnDelegateCountStore(blockHash, int(releaseEpoch)); nil != err {
+		return false, err
+	}
+
 	return true, nil
 }
 
 func (sk *StakingPlugin) handleUnDelegate(state xcom.StateDB, blockHash common.Hash, epoch uint64, unDel *xcom.UnDelegateItem, del *xcom.Delegation) (bool, error) {
 
-	delAddrByte := unDel.KeySuffix[0:common.AddressLength]
-	delAddr := common.BytesToAddress(delAddrByte)
+	delAddr, nodeId, num, err := xcom.ParseDelegateKeySuffix(unDel.KeySuffix)
+	if nil != err {
+		return false, err
+	}
 
-	canAddrByte := unDel.KeySuffix[common.AddressLength : 2*common.AddressLength]
-	canAddr := common.BytesToAddress(canAddrByte)
+	canAddr, err := xutil.NodeId2Addr(nodeId)
+	if nil != err {
+		return false, err
+	}
 
-	stakeBlockNum := unDel.KeySuffix[2*common.AddressLength:]
-	num_int, _ := strconv.Atoi(string(stakeBlockNum))
-	num := uint64(num_int)
 
+	can, err := sk.db.getCandidateStore(blockHash, canAddr)
+	if nil != err {
+		return false, err
+	}
+	if err := sk.settleDelegation(blockHash, can, nodeId, num, del); nil != err {
+		return false, err
+	}
 
-	lazyCalcDelegateAmount(epoch, del)
+	lazyCalcDelegateAmount(state, epoch, del)
 
 	//can, err := sk.db.getCandidateStore(blockHash, canAddr)
 	//if nil != err {
@@ -709,7 +825,15 @@ func (sk *StakingPlugin) handleUnDelegate(state xcom.StateDB, blockHash common.H
 		state.SubBalance(vm.StakingContractAddr, del.Released)
 		state.AddBalance(delAddr, del.Released)
 
-		// todo call Restricting for flush lockRepo
+		if del.LockRepo.Cmp(common.Big0) > 0 {
+			if success, err := sk.restricting.ReturnLockFunds(delAddr, del.LockRepo, state); nil != err {
+				log.Error("Failed to call handleUnDelegate: call ReturnLockFunds failed", "blockHash", blockHash.Hex(),
+					"delAddr", delAddr.Hex(), "lockRepo", del.LockRepo, "err", err)
+				return success, err
+			}
+		}
+
+		payDelegateIncome(state, delAddr, del)
 
 		if err := sk.db.delDelegateStoreBySuffix(blockHash, unDel.KeySuffix); nil != err {
 			return false, err
@@ -717,29 +841,25 @@ func (sk *StakingPlugin) handleUnDelegate(state xcom.StateDB, blockHash common.H
 
 	}else { //few withdrawal
 
-		remain := amount
+		// the circulating von is returned in priority
+		release := minBig(amount, del.Released)
+		state.SubBalance(vm.StakingContractAddr, release)
+		state.AddBalance(delAddr, release)
+		del.Released = new(big.Int).Sub(del.Released, release)
 
-		if remain.Cmp(del.Released) >= 0 {
-			state.SubBalance(vm.StakingContractAddr, del.Released)
-			state.AddBalance(delAddr, del.Released)
-			del.Released = common.Big0; remain = new(big.Int).Sub(remain, del.Released)
-		}else {
-			state.SubBalance(vm.StakingContractAddr, amount)
-			state.AddBalance(delAddr, amount)
-			del.Released = new(big.Int).Sub(del.Released, remain); remain = common.Big0
-		}
+		remain := new(big.Int).Sub(amount, release)
 
 		if remain.Cmp(common.Big0) > 0 {
 
-			if remain.Cmp(del.LockRepo) >= 0 {
-				// todo call Restricting for flush lockRepo
-
-				del.LockRepo = common.Big0; remain = new(big.Int).Sub(remain, del.LockRepo)
-			}else {
-				// todo call Restricting for flush remain
-
-				del.LockRepo = new(big.Int).Sub(del.LockRepo, remain); remain = common.Big0
+			lockRepo := minBig(remain, del.LockRepo)
+			if success, err := sk.restricting.ReturnLockFunds(delAddr, lockRepo, state); nil != err {
+				log.Error("Failed to call handleUnDelegate: call ReturnLockFunds failed", "blockHash", blockHash.Hex(),
+					"delAddr", delAddr.Hex(), "lockRepo", lockRepo, "err", err)
+				return success, err
 			}
+			del.LockRepo = new(big.Int).Sub(del.LockRepo, lockRepo)
+
+			remain = new(big.Int).Sub(remain, lockRepo)
 		}
 
 		if remain.Cmp(common.Big0) > 0 {
@@ -759,12 +879,288 @@ func (sk *StakingPlugin) handleUnDelegate(state xcom.StateDB, blockHash common.H
 	return true, nil
 }
 
-func (sk *StakingPlugin) GetDelegateListByAddr() {
+// WithdrawDelegateReward pays all of the delegate reward accrued by the delegation to the delegator,
+// and returns the amount of the reward
+func (sk *StakingPlugin) WithdrawDelegateReward(state xcom.StateDB, blockHash common.Hash, blockNumber *big.Int,
+	delAddr common.Address, nodeId discover.NodeID, stakingBlockNum uint64, del *xcom.Delegation) (*big.Int, bool, error) {
+
+	canAddr, err := xutil.NodeId2Addr(nodeId)
+	if nil != err {
+		log.Error("Failed to WithdrawDelegateReward on stakingPlugin", "blockNumber", blockNumber, "blockHash", blockHash.Hex(), "nodeId", nodeId.String(), "err", err)
+		return nil, false, err
+	}
+
+	can, err := sk.db.getCandidateStore(blockHash, canAddr)
+	if nil != err {
+		return nil, false, err
+	}
 
+	if err := sk.settleDelegation(blockHash, can, nodeId, stakingBlockNum, del); nil != err {
+		return nil, false, err
+	}
+
+	reward := payDelegateIncome(state, delAddr, del)
+
+	if err := sk.db.setDelegateStore(blockHash, delAddr, nodeId, int(stakingBlockNum), del); nil != err {
+		return nil, false, err
+	}
+
+	log.Info("Withdraw delegate reward", "blockNumber", blockNumber, "blockHash", blockHash.Hex(),
+		"delAddr", delAddr.Hex(), "nodeId", nodeId.String(), "stakingBlockNum", stakingBlockNum, "reward", reward)
+	return reward, true, nil
+}
+
+// DistributeStakingReward shares the staking reward of the candidate with its delegators.
+// The candidate retains the commission, the rest is shared by all of the von in the shares,
+// the part of the delegators is kept in the pool and accumulated into the DelegateRewardPer
+func (sk *StakingPlugin) DistributeStakingReward(state xcom.StateDB, blockHash common.Hash, blockNumber uint64,
+	can *xcom.Candidate, reward *big.Int) (bool, error) {
+
+	canAddr, err := xutil.NodeId2Addr(can.NodeId)
+	if nil != err {
+		return false, err
+	}
+
+	commission := new(big.Int).Mul(reward, new(big.Int).SetUint64(uint64(can.CommissionRate)))
+	commission.Div(commission, big.NewInt(xcom.MaxCommissionRate))
+
+	sharesReward := new(big.Int).Sub(reward, commission)
+
+	if nil == can.Shares || can.Shares.Cmp(common.Big0) <= 0 || sharesReward.Cmp(common.Big0) <= 0 {
+		state.AddBalance(can.BenifitAddress, reward)
+		return true, nil
+	}
+
+	rewardPer := new(big.Int).Mul(sharesReward, xcom.DelegateRewardPerScale)
+	rewardPer.Div(rewardPer, can.Shares)
+
+	// the reward of the von staked by the candidate self
+	stakeReward := new(big.Int).Mul(stakeSharesAmount(can), rewardPer)
+	stakeReward.Div(stakeReward, xcom.DelegateRewardPerScale)
+
+	delegateReward := new(big.Int).Sub(sharesReward, stakeReward)
+	if delegateReward.Cmp(common.Big0) < 0 {
+		delegateReward = new(big.Int)
+	}
+
+	state.AddBalance(can.BenifitAddress, new(big.Int).Add(commission, stakeReward))
+	state.AddBalance(vm.DelegateRewardPoolAddr, delegateReward)
+
+	can.DelegateRewardPer = new(big.Int).Add(bigOrZero(can.DelegateRewardPer), rewardPer)
+
+	if err := sk.db.setCandidateStore(blockHash, canAddr, can); nil != err {
+		log.Error("Failed to DistributeStakingReward on stakingPlugin: Put Can info 2 db failed",
+			"blockNumber", blockNumber, "blockHash", blockHash.Hex(), "err", err)
+		return false, err
+	}
+	return true, nil
 }
 
-func (sk *StakingPlugin) ElectNextVerifierList() {
+// settleDelegateReward accumulates the delegate reward since the last settlement into the income,
+// it must be called before the shares of the delegation is changed
+func settleDelegateReward(can *xcom.Candidate, del *xcom.Delegation) {
+	rewardPer := bigOrZero(can.DelegateRewardPer)
+
+	sub := new(big.Int).Sub(rewardPer, bigOrZero(del.RewardPerSnapshot))
+	if sub.Cmp(common.Big0) > 0 {
+		income := new(big.Int).Mul(delegateSharesAmount(del), sub)
+		income.Div(income, xcom.DelegateRewardPerScale)
+		del.CumulativeIncome = new(big.Int).Add(bigOrZero(del.CumulativeIncome), income)
+	}
+	del.RewardPerSnapshot = new(big.Int).Set(rewardPer)
+}
+
+// settleDelegation settles the delegate reward of the delegation with the candidate it belongs to,
+// or with the final DelegateRewardPer if the candidate had been deleted
+func (sk *StakingPlugin) settleDelegation(blockHash common.Hash, can *xcom.Candidate, nodeId discover.NodeID,
+	stakingBlockNum uint64, del *xcom.Delegation) error {
+
+	if nil != can && stakingBlockNum == can.StakingBlockNum {
+		settleDelegateReward(can, del)
+		return nil
+	}
+	rewardPer, err := sk.db.getDelegateRewardPerStore(blockHash, nodeId, stakingBlockNum)
+	if nil != err {
+		return err
+	}
+	if nil != rewardPer {
+		settleDelegateReward(&xcom.Candidate{DelegateRewardPer: rewardPer}, del)
+	}
+	return nil
+}
+
+// payDelegateIncome pays the settled delegate reward to the delegator, and returns the amount
+func payDelegateIncome(state xcom.StateDB, delAddr common.Address, del *xcom.Delegation) *big.Int {
+	income := bigOrZero(del.CumulativeIncome)
+	if income.Cmp(common.Big0) > 0 {
+		state.SubBalance(vm.DelegateRewardPoolAddr, income)
+		state.AddBalance(delAddr, income)
+	}
+	del.CumulativeIncome = new(big.Int)
+	return income
+}
+
+// the von of the delegation which is counted in the shares of the candidate
+func delegateSharesAmount(del *xcom.Delegation) *big.Int {
+	total := new(big.Int).Add(bigOrZero(del.Released), bigOrZero(del.ReleasedTmp))
+	total.Add(total, bigOrZero(del.LockRepo))
+	total.Add(total, bigOrZero(del.LockRepoTmp))
+	total.Sub(total, bigOrZero(del.Reduction))
+	if total.Cmp(common.Big0) < 0 {
+		return new(big.Int)
+	}
+	return total
+}
+
+// the von staked by the candidate self
+func stakeSharesAmount(can *xcom.Candidate) *big.Int {
+	total := new(big.Int).Add(bigOrZero(can.Released), bigOrZero(can.ReleasedTmp))
+	total.Add(total, bigOrZero(can.LockRepo))
+	total.Add(total, bigOrZero(can.LockRepoTmp))
+	return total
+}
+
+func bigOrZero(v *big.Int) *big.Int {
+	if nil == v {
+		return new(big.Int)
+	}
+	return v
+}
+
+// GetDelegateListByAddr returns all of the delegations of the delegate address
+func (sk *StakingPlugin) GetDelegateListByAddr(blockHash common.Hash, delAddr common.Address) (xcom.DelegationExQueue, error) {
+
+	iter := sk.db.ranking(blockHash, xcom.GetDelegateKeyPrefixByAddr(delAddr), 0)
+	defer iter.Release()
+
+	queue := make(xcom.DelegationExQueue, 0)
+
+	for iter.Next() {
+		// the delegation has been deleted
+		if len(iter.Value()) == 0 {
+			continue
+		}
+
+		addr, nodeId, num, err := xcom.ParseDelegateKeySuffix(iter.Key()[len(xcom.DelegateKeyPrefix):])
+		if nil != err {
+			return nil, err
+		}
+
+		var del xcom.Delegation
+		if err := rlp.DecodeBytes(iter.Value(), &del); nil != err {
+			return nil, err
+		}
+
+		queue = append(queue, &xcom.DelegationEx{
+			Addr:            addr,
+			NodeId:          nodeId,
+			StakingBlockNum: num,
+			Delegation:      &del,
+		})
+	}
+	if err := iter.Error(); nil != err {
+		return nil, err
+	}
+	return queue, nil
+}
+
+// GetUnStakeQueue returns the unstakes which are waiting to be released,
+// the von will be returned at the end of the ReleaseEpoch
+func (sk *StakingPlugin) GetUnStakeQueue(state xcom.StateDB, blockHash common.Hash, blockNumber uint64) (xcom.UnStakeQueue, error) {
+
+	epoch := xutil.CalculateEpoch(blockNumber)
+	freezeRatio := xcom.GovernUint64(state, xcom.ParamUnStakeFreezeRatio)
+
+	queue := make(xcom.UnStakeQueue, 0)
+	filterAddr := make(map[common.Address]struct{})
+
+	for e := pendingStartEpoch(epoch, freezeRatio); e <= epoch; e++ {
+
+		count, err := sk.db.getUnStakeCountStore(blockHash, int(e))
+		if nil != err {
+			return nil, err
+		}
+
+		for index := 1; index <= count; index++ {
+			addr, err := sk.db.getUnStakeItemStore(blockHash, int(e), index)
+			if nil != err {
+				return nil, err
+			}
+
+			if _, ok := filterAddr[addr]; ok {
+				continue
+			}
+
+			can, err := sk.db.getCandidateStore(blockHash, addr)
+			if nil != err {
+				return nil, err
+			}
+
+			// the candidate has been released
+			if nil == can {
+				continue
+			}
+
+			queue = append(queue, &xcom.UnStakeItemEx{
+				NodeAddress:     addr,
+				NodeId:          can.NodeId,
+				StakingBlockNum: can.StakingBlockNum,
+				Amount:          new(big.Int).Add(bigOrZero(can.Released), bigOrZero(can.LockRepo)),
+				Epoch:           e,
+				ReleaseEpoch:    e + freezeRatio,
+			})
+			filterAddr[addr] = struct{}{}
+		}
+	}
+	return queue, nil
+}
+
+// GetUnDelegateQueue returns the undelegates which are waiting to be released,
+// the von will be returned at the end of the ReleaseEpoch
+func (sk *StakingPlugin) GetUnDelegateQueue(state xcom.StateDB, blockHash common.Hash, blockNumber uint64) (xcom.UnDelegateQueue, error) {
+
+	epoch := xutil.CalculateEpoch(blockNumber)
+	freezeRatio := xcom.GovernUint64(state, xcom.ParamActiveUnDelegateFreezeRatio)
+
+	queue := make(xcom.UnDelegateQueue, 0)
+
+	for e := pendingStartEpoch(epoch, freezeRatio); e <= epoch; e++ {
+
+		count, err := sk.db.getUnDelegateCountStore(blockHash, int(e))
+		if nil != err {
+			return nil, err
+		}
+
+		for index := 1; index <= count; index++ {
+			item, err := sk.db.getUnDelegateItemStore(blockHash, int(e), index)
+			if nil != err {
+				return nil, err
+			}
+
+			addr, nodeId, num, err := xcom.ParseDelegateKeySuffix(item.KeySuffix)
+			if nil != err {
+				return nil, err
+			}
+
+			queue = append(queue, &xcom.UnDelegateItemEx{
+				Addr:            addr,
+				NodeId:          nodeId,
+				StakingBlockNum: num,
+				Amount:          item.Amount,
+				Epoch:           e,
+				ReleaseEpoch:    e + freezeRatio,
+			})
+		}
+	}
+	return queue, nil
+}
 
+// the items added before the start epoch have been released already
+func pendingStartEpoch(epoch, freezeRatio uint64) uint64 {
+	if epoch <= freezeRatio {
+		return 0
+	}
+	return epoch - freezeRatio
 }
 
 func (sk *StakingPlugin) GetVerifierList(blockHash common.Hash, blockNumber uint64) (xcom.CandidateQueue, bool, error) {
@@ -781,7 +1177,7 @@ func (sk *StakingPlugin) GetVerifierList(blockHash common.Hash, blockNumber uint
 			BlockNumberDisordered.Error(), verifierList.Start, verifierList.End, blockNumber)
 	}
 
-	resultArr := make(xcom.CandidateQueue, len(verifierList.Arr))
+	resultArr := make(xcom.CandidateQueue, 0, len(verifierList.Arr))
 
 	for _, v := range verifierList.Arr {
 
@@ -822,30 +1218,33 @@ func (sk *StakingPlugin) GetValidatorList(blockHash common.Hash, blockNumber uin
 
 	switch flag {
 	case PriviosRound:
-		validatorArr, err := sk.db.getPreviousValidatorList(blockHash)
+		arr, err := sk.db.getPreviousValidatorList(blockHash)
 		if nil != err {
 			return nil, false, err
 		}
+		validatorArr = arr
 
 		if blockNumber < validatorArr.Start || blockNumber > validatorArr.End {
 			return nil, true, fmt.Errorf("Get Previous ValidatorList failed: %s, start: %d, end: %d, currentNumer: %d",
 				BlockNumberDisordered.Error(), validatorArr.Start, validatorArr.End, blockNumber)
 		}
 	case CurrentRound:
-		validatorArr, err := sk.db.getCurrentValidatorList(blockHash)
+		arr, err := sk.db.getCurrentValidatorList(blockHash)
 		if nil != err {
 			return nil, false, err
 		}
+		validatorArr = arr
 
 		if blockNumber < validatorArr.Start || blockNumber > validatorArr.End {
 			return nil, true, fmt.Errorf("Get Current ValidatorList failed: %s, start: %d, end: %d, currentNumer: %d",
 				BlockNumberDisordered.Error(), validatorArr.Start, validatorArr.End, blockNumber)
 		}
 	case NextRound:
-		validatorArr, err := sk.db.getNextValidatorList(blockHash)
+		arr, err := sk.db.getNextValidatorList(blockHash)
 		if nil != err {
 			return nil, false, err
 		}
+		validatorArr = arr
 
 		if blockNumber < validatorArr.Start || blockNumber > validatorArr.End {
 			return nil, true, fmt.Errorf("Get Next ValidatorList failed: %s, start: %d, end: %d, currentNumer: %d",
@@ -853,10 +1252,10 @@ func (sk *StakingPlugin) GetValidatorList(blockHash common.Hash, blockNumber uin
 		}
 	default:
 		log.Error("Failed to call GetValidatorList", "err", ParamsErr, "flag", flag)
-
+		return nil, false, ParamsErr
 	}
 
-	result := make(xcom.ValidatorExQueue, len(validatorArr.Arr))
+	result := make(xcom.ValidatorExQueue, 0, len(validatorArr.Arr))
 
 	for _, v := range validatorArr.Arr {
 		can, err := sk.db.getCandidateStore(blockHash, v.NodeAddress)
@@ -892,77 +1291,529 @@ func (sk *StakingPlugin) IsCurrValidate(blockHash common.Hash, nodeId discover.N
 
 func (sk *StakingPlugin) GetCandidateList(blockHash common.Hash) (xcom.CandidateQueue, error) {
 
-	//candidateArr, err := sk.db.get()
+	iter := sk.db.ranking(blockHash, xcom.CanPowerKeyPrefix, 0)
+	defer iter.Release()
+
+	queue := make(xcom.CandidateQueue, 0)
 
-	return nil, nil
+	for iter.Next() {
+		addrByte := iter.Value()
+		// the power of the candidate has been deleted
+		if len(addrByte) == 0 {
+			continue
+		}
+		can, err := sk.db.getCandidateStore(blockHash, common.BytesToAddress(addrByte))
+		if nil != err {
+			return nil, err
+		}
+		if nil == can {
+			continue
+		}
+		queue = append(queue, can)
+	}
+	if err := iter.Error(); nil != err {
+		return nil, err
+	}
+	return queue, nil
 }
 
-func (sk *StakingPlugin) IsCandidate() {
+func (sk *StakingPlugin) IsCandidate(blockHash common.Hash, nodeId discover.NodeID) (bool, error) {
+
+	addr, err := xutil.NodeId2Addr(nodeId)
+	if nil != err {
+		return false, err
+	}
+
+	can, err := sk.db.getCandidateStore(blockHash, addr)
+	if nil != err {
+		return false, err
+	}
 
+	if nil == can || !xcom.Is_Valid(can.Status) {
+		return false, nil
+	}
+	return true, nil
 }
 
-func (sk *StakingPlugin) Election(blockHash common.Hash, blockNumber uint64) (bool, error) {
+// ElectNextVerifierList elects the verifiers of next epoch at the end of current epoch,
+// they are the top `EpochValidatorNum` valid candidates ranked by power.
+// The ranking is not limited since the deleted and invalid candidates are skipped
+func (sk *StakingPlugin) ElectNextVerifierList(state xcom.StateDB, blockHash common.Hash, blockNumber uint64) (bool, error) {
 
-	round := xutil.CalculateRound(blockNumber)
-	_ = round
+	epochValidatorNum := xcom.GovernUint64(state, xcom.ParamEpochValidatorNum)
+
+	iter := sk.db.ranking(blockHash, xcom.CanPowerKeyPrefix, 0)
+	defer iter.Release()
+
+	queue := make([]*xcom.Validator, 0, epochValidatorNum)
+
+	for iter.Next() {
+		if uint64(len(queue)) == epochValidatorNum {
+			break
+		}
+
+		addrByte := iter.Value()
+		// the power of the candidate has been deleted
+		if len(addrByte) == 0 {
+			continue
+		}
+		addr := common.BytesToAddress(addrByte)
+
+		can, err := sk.db.getCandidateStore(blockHash, addr)
+		if nil != err {
+			log.Error("Failed to ElectNextVerifierList on stakingPlugin: Query Candidate info failed",
+				"blockNumber", blockNumber, "blockHash", blockHash.Hex(), "addr", addr.Hex(), "err", err)
+			return false, err
+		}
+
+		if nil == can || !xcom.Is_Valid(can.Status) {
+			continue
+		}
+
+		val := &xcom.Validator{
+			NodeAddress:   addr,
+			NodeId:        can.NodeId,
+			StakingWeight: string(iter.Key()[len(xcom.CanPowerKeyPrefix):]),
+			ValidatorTerm: 0,
+		}
+		queue = append(queue, val)
+	}
+	if err := iter.Error(); nil != err {
+		log.Error("Failed to ElectNextVerifierList on stakingPlugin: iterate the candidate power failed",
+			"blockNumber", blockNumber, "blockHash", blockHash.Hex(), "err", err)
+		return false, err
+	}
+
+	epochSize := xcom.ConsensusSize * xcom.EpochSize
+
+	verifierList := &xcom.Validator_array{
+		Start: blockNumber + 1,
+		End:   blockNumber + epochSize,
+		Arr:   queue,
+	}
+
+	if err := sk.db.setVerifierList(blockHash, verifierList); nil != err {
+		log.Error("Failed to ElectNextVerifierList on stakingPlugin: Set Next Epoch VerifierList failed",
+			"blockNumber", blockNumber, "blockHash", blockHash.Hex(), "err", err)
+		return false, err
+	}
+
+	log.Info("Succeeded to ElectNextVerifierList on stakingPlugin", "blockNumber", blockNumber,
+		"blockHash", blockHash.Hex(), "start", verifierList.Start, "end", verifierList.End, "size", len(queue))
+	return true, nil
+}
+
+// Election elects the validators of next consensus round from the verifiers of current epoch.
+// The validators of current round are kept except `ShiftValidatorNum` of them
+// which have served the longest, the vacancies are refilled by the verifiers
+// shuffled by the vrf seed of current block
+func (sk *StakingPlugin) Election(state xcom.StateDB, blockHash common.Hash, header *types.Header) (bool, error) {
+
+	blockNumber := header.Number.Uint64()
+
+	verifierList, err := sk.db.getVerifierList(blockHash)
+	if nil != err {
+		if err == snapshotdb.ErrNotFound {
+			log.Warn("the verifierList is not found, skip to Election", "blockNumber", blockNumber, "blockHash", blockHash.Hex())
+			return true, nil
+		}
+		log.Error("Failed to Election on stakingPlugin: Query VerifierList failed",
+			"blockNumber", blockNumber, "blockHash", blockHash.Hex(), "err", err)
+		return false, err
+	}
+
+	currList, err := sk.db.getCurrentValidatorList(blockHash)
+	if nil != err && err != snapshotdb.ErrNotFound {
+		log.Error("Failed to Election on stakingPlugin: Query Current Round ValidatorList failed",
+			"blockNumber", blockNumber, "blockHash", blockHash.Hex(), "err", err)
+		return false, err
+	}
+
+	// the next round starts at the block after the end of current round
+	start := blockNumber + xcom.ElectionDistance + 1
+	if nil != currList {
+		start = currList.End + 1
+	}
+
+	var currValidators []*xcom.Validator
+	if nil != currList {
+		currValidators = currList.Arr
+	}
+
+	seed := vrf.ProofToHash(header.Nonce[:])
+	consNum := xcom.GovernUint64(state, xcom.ParamConsValidatorNum)
+	shiftNum := xcom.GovernUint64(state, xcom.ParamShiftValidatorNum)
+
+	nextList := &xcom.Validator_array{
+		Start: start,
+		End:   start + xcom.ConsensusSize - 1,
+		Arr:   shuffleValidators(seed, currValidators, verifierList.Arr, consNum, shiftNum),
+	}
 
+	if err := sk.db.setNextValidatorList(blockHash, nextList); nil != err {
+		log.Error("Failed to Election on stakingPlugin: Set Next Round ValidatorList failed",
+			"blockNumber", blockNumber, "blockHash", blockHash.Hex(), "err", err)
+		return false, err
+	}
+
+	log.Info("Succeeded to Election on stakingPlugin", "blockNumber", blockNumber, "blockHash", blockHash.Hex(),
+		"start", nextList.Start, "end", nextList.End, "size", len(nextList.Arr))
 	return true, nil
 }
 
+// shuffleValidators picks `consNum` validators from the verifiers, `shiftNum` of the current
+// validators are shifted out. The result is ordered as the verifiers (by power)
+func shuffleValidators(seed []byte, currValidators, verifiers []*xcom.Validator, consNum, shiftNum uint64) []*xcom.Validator {
+
+	if uint64(len(verifiers)) <= consNum {
+		next := make([]*xcom.Validator, len(verifiers))
+		for i, v := range verifiers {
+			next[i] = nextTermValidator(v, currValidators)
+		}
+		return next
+	}
+
+	isVerifier := make(map[common.Address]struct{}, len(verifiers))
+	for _, v := range verifiers {
+		isVerifier[v.NodeAddress] = struct{}{}
+	}
+
+	// the current validators which are still the verifiers
+	stay := make([]*xcom.Validator, 0, len(currValidators))
+	for _, v := range currValidators {
+		if _, ok := isVerifier[v.NodeAddress]; ok {
+			stay = append(stay, v)
+		}
+	}
+
+	// shift out the validators which have served the longest
+	keepNum := int(consNum - shiftNum)
+	if shiftNum > consNum {
+		keepNum = 0
+	}
+
+	var shifted []*xcom.Validator
+	if len(stay) > keepNum {
+		sort.SliceStable(stay, func(i, j int) bool {
+			return stay[i].ValidatorTerm > stay[j].ValidatorTerm
+		})
+		shifted = stay[:len(stay)-keepNum]
+		stay = stay[len(stay)-keepNum:]
+	}
+
+	picked := make(map[common.Address]struct{}, consNum)
+	for _, v := range stay {
+		picked[v.NodeAddress] = struct{}{}
+	}
+
+	excluded := make(map[common.Address]struct{}, len(shifted))
+	for _, v := range shifted {
+		excluded[v.NodeAddress] = struct{}{}
+	}
+
+	// the candidates to fill the vacancies, the shifted validators are
+	// considered only if the rest of verifiers are not enough
+	pool := make([]*xcom.Validator, 0, len(verifiers))
+	backup := make([]*xcom.Validator, 0, len(shifted))
+	for _, v := range verifiers {
+		if _, ok := picked[v.NodeAddress]; ok {
+			continue
+		}
+		if _, ok := excluded[v.NodeAddress]; ok {
+			backup = append(backup, v)
+			continue
+		}
+		pool = append(pool, v)
+	}
+
+	need := int(consNum) - len(stay)
+
+	sortByVrf(seed, pool)
+	if len(pool) >= need {
+		pool = pool[:need]
+	} else {
+		sortByVrf(seed, backup)
+		pool = append(pool, backup[:need-len(pool)]...)
+	}
+
+	for _, v := range pool {
+		picked[v.NodeAddress] = struct{}{}
+	}
+
+	next := make([]*xcom.Validator, 0, consNum)
+	for _, v := range verifiers {
+		if _, ok := picked[v.NodeAddress]; ok {
+			next = append(next, nextTermValidator(v, currValidators))
+		}
+	}
+	return next
+}
+
+// sortByVrf sorts the validators by the hash of the vrf seed and their node address
+func sortByVrf(seed []byte, validators []*xcom.Validator) {
+	sort.SliceStable(validators, func(i, j int) bool {
+		hi := crypto.Keccak256(seed, validators[i].NodeAddress.Bytes())
+		hj := crypto.Keccak256(seed, validators[j].NodeAddress.Bytes())
+		return bytes.Compare(hi, hj) < 0
+	})
+}
+
+// nextTermValidator copies the verifier as the validator of next round,
+// the term will be increased if it is the validator of current round
+func nextTermValidator(verifier *xcom.Validator, currValidators []*xcom.Validator) *xcom.Validator {
+	val := &xcom.Validator{
+		NodeAddress:   verifier.NodeAddress,
+		NodeId:        verifier.NodeId,
+		StakingWeight: verifier.StakingWeight,
+	}
+	for _, v := range currValidators {
+		if v.NodeAddress == verifier.NodeAddress {
+			val.ValidatorTerm = v.ValidatorTerm + 1
+			break
+		}
+	}
+	return val
+}
+
+// Switch rotates the validators of consensus round at the end of current round,
+// the current becomes the previous and the next becomes the current
 func (sk *StakingPlugin) Switch(blockHash common.Hash, blockNumber uint64) (bool, error) {
 
+	nextList, err := sk.db.getNextValidatorList(blockHash)
+	if nil != err {
+		if err == snapshotdb.ErrNotFound {
+			log.Warn("the next round validatorList is not found, skip to Switch", "blockNumber", blockNumber, "blockHash", blockHash.Hex())
+			return true, nil
+		}
+		log.Error("Failed to Switch on stakingPlugin: Query Next Round ValidatorList failed",
+			"blockNumber", blockNumber, "blockHash", blockHash.Hex(), "err", err)
+		return false, err
+	}
+
+	currList, err := sk.db.getCurrentValidatorList(blockHash)
+	if nil != err && err != snapshotdb.ErrNotFound {
+		log.Error("Failed to Switch on stakingPlugin: Query Current Round ValidatorList failed",
+			"blockNumber", blockNumber, "blockHash", blockHash.Hex(), "err", err)
+		return false, err
+	}
+
+	if nil != currList {
+		if err := sk.db.setPreviousValidatorList(blockHash, currList); nil != err {
+			log.Error("Failed to Switch on stakingPlugin: Set Previous Round ValidatorList failed",
+				"blockNumber", blockNumber, "blockHash", blockHash.Hex(), "err", err)
+			return false, err
+		}
+	}
+
+	if err := sk.db.setCurrentValidatorList(blockHash, nextList); nil != err {
+		log.Error("Failed to Switch on stakingPlugin: Set Current Round ValidatorList failed",
+			"blockNumber", blockNumber, "blockHash", blockHash.Hex(), "err", err)
+		return false, err
+	}
+
+	if err := sk.db.delNextValidatorList(blockHash); nil != err {
+		log.Error("Failed to Switch on stakingPlugin: Del Next Round ValidatorList failed",
+			"blockNumber", blockNumber, "blockHash", blockHash.Hex(), "err", err)
+		return false, err
+	}
+
 	return true, nil
 }
 
-func (sk *StakingPlugin) GetAllPackageRatio() {
+// GetAllPackageRatio returns the count of blocks packed by each validator in current consensus round
+func (sk *StakingPlugin) GetAllPackageRatio(blockHash common.Hash, blockNumber uint64) (map[common.Address]uint32, error) {
 
+	currList, err := sk.db.getCurrentValidatorList(blockHash)
+	if nil != err {
+		return nil, err
+	}
+
+	round := xutil.CalculateRound(blockNumber)
+
+	ratio := make(map[common.Address]uint32, len(currList.Arr))
+	for _, v := range currList.Arr {
+		count, err := sk.db.getPackageCountStore(blockHash, round, v.NodeAddress)
+		if nil != err {
+			return nil, err
+		}
+		ratio[v.NodeAddress] = count
+	}
+	return ratio, nil
 }
 
-func (sk *StakingPlugin) GetPackageRatio() {
+// GetPackageRatio returns the count of blocks packed by the validator in the consensus round of the blockNumber
+func (sk *StakingPlugin) GetPackageRatio(blockHash common.Hash, blockNumber uint64, addr common.Address) (uint32, error) {
+	return sk.db.getPackageCountStore(blockHash, xutil.CalculateRound(blockNumber), addr)
+}
 
+// prunePackageCount deletes the package counts of the epochs before the epoch,
+// the counts of the epoch which is ending are kept until the end of next epoch
+func (sk *StakingPlugin) prunePackageCount(blockHash common.Hash, epoch uint64) error {
+	if epoch <= 1 {
+		return nil
+	}
+	round := xutil.CalculateRound(xutil.CalculateLastBlockOfEpoch(epoch-1)) + 1
+	return sk.db.delPackageCountBefore(blockHash, round)
 }
 
-func (sk *StakingPlugin) SlashCandidates() {
+// addPackageCount increases the count of blocks packed by the producer.
+// NOTE: the coinbase of the block is the node address of the producer
+func (sk *StakingPlugin) addPackageCount(blockHash common.Hash, header *types.Header) error {
+	round := xutil.CalculateRound(header.Number.Uint64())
+	count, err := sk.db.getPackageCountStore(blockHash, round, header.Coinbase)
+	if nil != err {
+		return err
+	}
+	return sk.db.setPackageCountStore(blockHash, round, header.Coinbase, count+1)
+}
+
+// SlashCandidates deducts the von of the staking by the ratio (unit is %) and
+// marks the candidate as invalid, the slashed von will be moved into the reward pool
+func (sk *StakingPlugin) SlashCandidates(state xcom.StateDB, blockHash common.Hash, blockNumber uint64, addr common.Address, ratio uint64) (bool, error) {
 
+	can, err := sk.db.getCandidateStore(blockHash, addr)
+	if nil != err {
+		log.Error("Failed to SlashCandidates on stakingPlugin: Query candidate info failed",
+			"blockNumber", blockNumber, "blockHash", blockHash.Hex(), "addr", addr.String(), "err", err)
+		return false, err
+	}
+
+	if nil == can {
+		return false, CandidateNotExist
+	}
+
+	if xcom.Is_Slashed(can.Status) {
+		return false, CandidateAlreadySlashed
+	}
+
+	epoch := xutil.CalculateEpoch(blockNumber)
+
+	lazyCalcStakeAmount(state, epoch, can)
+
+	// delete old power of can
+	if err := sk.db.delCanPowerStore(blockHash, can); nil != err {
+		log.Error("Failed to SlashCandidates on stakingPlugin: Del Can old power failed",
+			"blockNumber", blockNumber, "blockHash", blockHash.Hex(), "err", err)
+		return false, err
+	}
+
+	total := new(big.Int).Add(can.Released, can.ReleasedTmp)
+	total.Add(total, can.LockRepo)
+	total.Add(total, can.LockRepoTmp)
+
+	slashAmount := new(big.Int).Mul(total, new(big.Int).SetUint64(ratio))
+	slashAmount.Div(slashAmount, big.NewInt(100))
+
+	if slashAmount.Cmp(common.Big0) > 0 {
+
+		// the locked von is slashed in proportion to its part of the staking von
+		lockTotal := new(big.Int).Add(can.LockRepo, can.LockRepoTmp)
+		lockSlash := new(big.Int).Mul(slashAmount, lockTotal)
+		lockSlash.Div(lockSlash, total)
+
+		// the hesitant von is deducted in priority
+		remain := new(big.Int).Sub(slashAmount, lockSlash)
+		remain = slashBalanceAmount(remain, can.ReleasedTmp)
+		remain = slashBalanceAmount(remain, can.Released)
+
+		lockRemain := new(big.Int).Set(lockSlash)
+		lockRemain = slashBalanceAmount(lockRemain, can.LockRepoTmp)
+		lockRemain = slashBalanceAmount(lockRemain, can.LockRepo)
+
+		remain.Add(remain, lockRemain)
+
+		if remain.Cmp(common.Big0) != 0 {
+			log.Error("Failed to SlashCandidates on stakingPlugin: the staking von is not enough",
+				"blockNumber", blockNumber, "blockHash", blockHash.Hex(), "slash", slashAmount, "remain", remain)
+			return false, VonAmountNotRight
+		}
+
+		if lockSlash.Cmp(common.Big0) > 0 {
+			if _, err := sk.restricting.SlashingNotify(can.StakingAddress, lockSlash, state); nil != err {
+				log.Error("Failed to SlashCandidates on stakingPlugin: call SlashingNotify failed",
+					"blockNumber", blockNumber, "blockHash", blockHash.Hex(), "amount", lockSlash, "err", err)
+				return false, err
+			}
+		}
+
+		state.SubBalance(vm.StakingContractAddr, slashAmount)
+		state.AddBalance(vm.AwardMgrContractAddr, slashAmount)
+
+		can.Shares = new(big.Int).Sub(can.Shares, slashAmount)
+	}
+
+	if success, err := sk.withdrewStakeAmount(state, blockHash, blockNumber, epoch, addr, can); nil != err {
+		return success, err
+	}
+
+	can.StakingEpoch = epoch
+	can.Status |= xcom.Slashed
+
+	if err := sk.db.setCandidateStore(blockHash, addr, can); nil != err {
+		log.Error("Failed to SlashCandidates on stakingPlugin: Put Can info 2 db failed",
+			"blockNumber", blockNumber, "blockHash", blockHash.Hex(), "err", err)
+		return false, err
+	}
+
+	return true, nil
+}
+
+// slashBalanceAmount deducts the remain from the balance as much as possible,
+// and returns the remain which has not been deducted
+func slashBalanceAmount(remain, balance *big.Int) *big.Int {
+	if remain.Cmp(common.Big0) == 0 || balance.Cmp(common.Big0) == 0 {
+		return remain
+	}
+	if balance.Cmp(remain) >= 0 {
+		balance.Sub(balance, remain)
+		return new(big.Int)
+	}
+	remain = new(big.Int).Sub(remain, balance)
+	balance.SetInt64(0)
+	return remain
 }
 
-func lazyCalcStakeAmount(epoch uint64, can *xcom.Candidate) {
+func lazyCalcStakeAmount(state xcom.StateDB, epoch uint64, can *xcom.Candidate) {
 
 	changeAmountEpoch := can.StakingEpoch
 
 	sub := epoch - changeAmountEpoch
 
 	// If it is during the same hesitation period, short circuit
-	if sub < xcom.HesitateRatio {
+	if sub < xcom.GovernUint64(state, xcom.ParamHesitateRatio) {
 		return
 	}
 
 	if can.ReleasedTmp.Cmp(common.Big0) > 0 {
 		can.Released = new(big.Int).Add(can.Released, can.ReleasedTmp)
+		can.ReleasedTmp = new(big.Int).SetInt64(0)
 	}
 
 	if can.LockRepoTmp.Cmp(common.Big0) > 0 {
 		can.LockRepo = new(big.Int).Add(can.LockRepo, can.LockRepoTmp)
+		can.LockRepoTmp = new(big.Int).SetInt64(0)
 	}
 }
 
-func lazyCalcDelegateAmount(epoch uint64, del *xcom.Delegation) {
+func lazyCalcDelegateAmount(state xcom.StateDB, epoch uint64, del *xcom.Delegation) {
 
 	changeAmountEpoch := del.DelegateEpoch
 
 	sub := epoch - changeAmountEpoch
 
 	// If it is during the same hesitation period, short circuit
-	if sub < xcom.HesitateRatio {
+	if sub < xcom.GovernUint64(state, xcom.ParamHesitateRatio) {
 		return
 	}
 
 	if del.ReleasedTmp.Cmp(common.Big0) > 0 {
 		del.Released = new(big.Int).Add(del.Released, del.ReleasedTmp)
+		del.ReleasedTmp = new(big.Int).SetInt64(0)
 	}
 
 	if del.LockRepoTmp.Cmp(common.Big0) > 0 {
 		del.LockRepo = new(big.Int).Add(del.LockRepo, del.LockRepoTmp)
+		del.LockRepoTmp = new(big.Int).SetInt64(0)
 	}
 
 	/*switch  {
@@ -1006,10 +1857,10 @@ func lazyCalcDelegateAmount(epoch uint64, del *xcom.Delegation) {
 //	return
 //}
 
-func CheckStakeThreshold(stake *big.Int) bool {
-	return stake.Cmp(xcom.StakeThreshold) >= 0
+func CheckStakeThreshold(state xcom.StateDB, stake *big.Int) bool {
+	return stake.Cmp(xcom.GovernBigInt(state, xcom.ParamStakeThreshold)) >= 0
 }
 
-func CheckDelegateThreshold(delegate *big.Int) bool {
-	return delegate.Cmp(xcom.DelegateThreshold) >= 0
+func CheckDelegateThreshold(state xcom.StateDB, delegate *big.Int) bool {
+	return delegate.Cmp(xcom.GovernBigInt(state, xcom.ParamDelegateThreshold)) >= 0
 }
@@ -0,0 +1,822 @@
+package plugin
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/PlatONnetwork/PlatON-Go/common/vm"
+	"github.com/PlatONnetwork/PlatON-Go/core/snapshotdb"
+	"github.com/PlatONnetwork/PlatON-Go/core/types"
+	"github.com/PlatONnetwork/PlatON-Go/crypto"
+	"github.com/PlatONnetwork/PlatON-Go/p2p/discover"
+	"github.com/PlatONnetwork/PlatON-Go/x/xcom"
+	"github.com/PlatONnetwork/PlatON-Go/x/xutil"
+)
+
+func buildValidators(start, num int) []*xcom.Validator {
+	arr := make([]*xcom.Validator, 0, num)
+	for i := start; i < start+num; i++ {
+		arr = append(arr, &xcom.Validator{NodeAddress: common.BigToAddress(big.NewInt(int64(i + 1)))})
+	}
+	return arr
+}
+
+func TestShuffleValidators(t *testing.T) {
+	seed := []byte("seed")
+	consNum, shiftNum := xcom.ConsValidatorNum, xcom.ShiftValidatorNum
+
+	verifiers := buildValidators(0, int(consNum)+10)
+	curr := buildValidators(0, int(consNum))
+	for i, v := range curr {
+		v.ValidatorTerm = uint32(i)
+	}
+
+	next := shuffleValidators(seed, curr, verifiers, consNum, shiftNum)
+	if uint64(len(next)) != consNum {
+		t.Fatalf("expected %d validators, got %d", consNum, len(next))
+	}
+
+	currSet := make(map[common.Address]*xcom.Validator)
+	for _, v := range curr {
+		currSet[v.NodeAddress] = v
+	}
+
+	var replaced int
+	for _, v := range next {
+		if old, ok := currSet[v.NodeAddress]; ok {
+			if v.ValidatorTerm != old.ValidatorTerm+1 {
+				t.Errorf("the term of validator %s is not increased", v.NodeAddress.Hex())
+			}
+			// the longest serving validators must be shifted out
+			if uint64(old.ValidatorTerm) >= consNum-shiftNum {
+				t.Errorf("the validator %s should be shifted out", v.NodeAddress.Hex())
+			}
+		} else {
+			replaced++
+			if v.ValidatorTerm != 0 {
+				t.Errorf("the term of new validator %s must be 0", v.NodeAddress.Hex())
+			}
+		}
+	}
+	if uint64(replaced) != shiftNum {
+		t.Fatalf("expected %d validators replaced, got %d", shiftNum, replaced)
+	}
+
+	// deterministic for the same seed
+	again := shuffleValidators(seed, curr, verifiers, consNum, shiftNum)
+	for i := range next {
+		if next[i].NodeAddress != again[i].NodeAddress {
+			t.Fatal("the election is not deterministic")
+		}
+	}
+
+	// all of the verifiers are elected if they are not enough
+	few := buildValidators(0, int(consNum)-1)
+	if got := shuffleValidators(seed, curr, few, consNum, shiftNum); len(got) != len(few) {
+		t.Fatalf("expected %d validators, got %d", len(few), len(got))
+	}
+}
+
+func TestSettleDelegateReward(t *testing.T) {
+	can := &xcom.Candidate{}
+	del := &xcom.Delegation{
+		Released:  big.NewInt(300),
+		LockRepo:  big.NewInt(200),
+		Reduction: big.NewInt(100),
+	}
+
+	if amount := delegateSharesAmount(del); amount.Cmp(big.NewInt(400)) != 0 {
+		t.Fatalf("expected shares amount 400, got %s", amount)
+	}
+
+	// nothing accumulated yet, only takes the snapshot
+	settleDelegateReward(can, del)
+	if bigOrZero(del.CumulativeIncome).Sign() != 0 {
+		t.Fatalf("expected no income, got %s", del.CumulativeIncome)
+	}
+
+	// 5 von per 1 von of shares
+	can.DelegateRewardPer = new(big.Int).Mul(big.NewInt(5), xcom.DelegateRewardPerScale)
+	settleDelegateReward(can, del)
+	if del.CumulativeIncome.Cmp(big.NewInt(2000)) != 0 {
+		t.Fatalf("expected income 2000, got %s", del.CumulativeIncome)
+	}
+
+	// settle again without new reward must not pay twice
+	settleDelegateReward(can, del)
+	if del.CumulativeIncome.Cmp(big.NewInt(2000)) != 0 {
+		t.Fatalf("expected income 2000 after resettle, got %s", del.CumulativeIncome)
+	}
+	if del.RewardPerSnapshot.Cmp(can.DelegateRewardPer) != 0 {
+		t.Fatalf("expected snapshot %s, got %s", can.DelegateRewardPer, del.RewardPerSnapshot)
+	}
+}
+
+func TestStakingPlugin_LockRepoStaking(t *testing.T) {
+	db, err := snapshotdb.New(snapshotdb.Options{InMemory: true})
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	rp := NewRestrictingPlugin()
+	sk := NewStakingPlugin(db, rp)
+	state := newMockStateDB()
+
+	key, _ := crypto.GenerateKey()
+	nodeId := discover.PubkeyID(&key.PublicKey)
+	canAddr := crypto.PubkeyToAddress(key.PublicKey)
+
+	sender := common.HexToAddress("0x01")
+	stakingAddr := common.HexToAddress("0x02")
+	state.AddBalance(sender, lat(100))
+	state.AddBalance(stakingAddr, lat(60))
+
+	if _, err := rp.AddRestrictingRecord(sender, stakingAddr, []xcom.RestrictingPlan{{Epoch: 1, Amount: lat(100)}}, 1, state); nil != err {
+		t.Fatal(err)
+	}
+
+	blockHash := common.HexToHash("0x01")
+	blockNumber := big.NewInt(1)
+	if err := db.NewBlock(blockNumber, common.ZeroHash, blockHash); nil != err {
+		t.Fatal(err)
+	}
+
+	can := &xcom.Candidate{
+		NodeId:          nodeId,
+		StakingAddress:  stakingAddr,
+		BenifitAddress:  stakingAddr,
+		StakingBlockNum: blockNumber.Uint64(),
+		Shares:          lat(40),
+	}
+	if _, err := sk.CreateCandidate(state, blockHash, blockNumber, lat(40), 10101010, LockRepoOrigin, canAddr, can); nil != err {
+		t.Fatal(err)
+	}
+
+	// more than the restricted balance
+	if success, err := sk.IncreaseStaking(state, blockHash, blockNumber, lat(61), LockRepoOrigin, can); !success || err != errBalanceNotEnough {
+		t.Fatalf("expect the business error %v, got: %v, %v", errBalanceNotEnough, success, err)
+	}
+	if _, err := sk.IncreaseStaking(state, blockHash, blockNumber, lat(60), FreeOrigin, can); nil != err {
+		t.Fatal(err)
+	}
+
+	info, _ := rp.GetRestrictingInfo(stakingAddr, state)
+	if info.Pledge.Cmp(lat(40)) != 0 || info.Balance.Cmp(lat(60)) != 0 {
+		t.Fatalf("unexpected restricting info, pledge: %s, balance: %s", info.Pledge, info.Balance)
+	}
+	assertBalance(t, state, vm.StakingContractAddr, lat(100))
+
+	// 10 LAT is slashed, 4 LAT of it is deducted from the locked von
+	if _, err := sk.SlashCandidates(state, blockHash, blockNumber.Uint64(), canAddr, 10); nil != err {
+		t.Fatal(err)
+	}
+	assertBalance(t, state, vm.AwardMgrContractAddr, lat(10))
+
+	// the hesitant von is returned at once
+	assertBalance(t, state, stakingAddr, lat(54))
+	assertBalance(t, state, vm.StakingContractAddr, new(big.Int))
+
+	info, _ = rp.GetRestrictingInfo(stakingAddr, state)
+	if info.Pledge.Sign() != 0 || info.Balance.Cmp(lat(96)) != 0 {
+		t.Fatalf("unexpected restricting info after slashing, pledge: %s, balance: %s", info.Pledge, info.Balance)
+	}
+	assertBalance(t, state, vm.RestrictingContractAddr, lat(96))
+}
+
+func signEvidenceVote(t *testing.T, key *ecdsa.PrivateKey, vote *xcom.EvidencePrepare) {
+	data, err := vote.CannibalizeBytes()
+	if nil != err {
+		t.Fatal(err)
+	}
+	sig, err := crypto.Sign(data, key)
+	if nil != err {
+		t.Fatal(err)
+	}
+	vote.Signature.SetBytes(sig)
+}
+
+func TestSlashingPlugin_Slash(t *testing.T) {
+	db, err := snapshotdb.New(snapshotdb.Options{InMemory: true})
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	sk := NewStakingPlugin(db, NewRestrictingPlugin())
+	sp := NewSlashingPlugin(db, sk)
+	state := newMockStateDB()
+
+	key, _ := crypto.GenerateKey()
+	nodeId := discover.PubkeyID(&key.PublicKey)
+	canAddr := crypto.PubkeyToAddress(key.PublicKey)
+	stakingAddr := common.HexToAddress("0x02")
+	state.AddBalance(stakingAddr, lat(100))
+
+	blockHash := common.HexToHash("0x01")
+	blockNumber := big.NewInt(1)
+	if err := db.NewBlock(blockNumber, common.ZeroHash, blockHash); nil != err {
+		t.Fatal(err)
+	}
+	can := &xcom.Candidate{
+		NodeId:          nodeId,
+		StakingAddress:  stakingAddr,
+		BenifitAddress:  stakingAddr,
+		StakingBlockNum: blockNumber.Uint64(),
+		Shares:          lat(100),
+	}
+	if _, err := sk.CreateCandidate(state, blockHash, blockNumber, lat(100), 10101010, FreeOrigin, canAddr, can); nil != err {
+		t.Fatal(err)
+	}
+
+	// two duplicate signings of the same node are reported together
+	var data xcom.EvidenceData
+	for _, number := range []uint64{1, 2} {
+		voteA := &xcom.EvidencePrepare{Timestamp: 1, Hash: common.BytesToHash([]byte("a")), Number: number, ValidatorAddr: canAddr}
+		voteB := &xcom.EvidencePrepare{Timestamp: 1, Hash: common.BytesToHash([]byte("b")), Number: number, ValidatorAddr: canAddr}
+		signEvidenceVote(t, key, voteA)
+		signEvidenceVote(t, key, voteB)
+		data.DP = append(data.DP, &xcom.DuplicatePrepareEvidence{VoteA: voteA, VoteB: voteB})
+	}
+	buf, _ := json.Marshal(data)
+
+	if success, err := sp.Slash(string(buf), blockHash, 2, state); !success || nil != err {
+		t.Fatalf("expect the slashing succeeded, got: %v, %v", success, err)
+	}
+	// the second evidence is skipped since the node has been slashed by the first one
+	if val, _ := sp.CheckDuplicateSign(blockHash, xcom.DuplicatePrepareType, canAddr, 1); len(val) == 0 {
+		t.Fatal("the first evidence is not recorded")
+	}
+	if val, _ := sp.CheckDuplicateSign(blockHash, xcom.DuplicatePrepareType, canAddr, 2); len(val) != 0 {
+		t.Fatal("the skipped evidence is recorded")
+	}
+	assertBalance(t, state, vm.AwardMgrContractAddr, lat(int64(xcom.DuplicateSignSlashRatio)))
+}
+
+func TestSlashingPlugin_SlashInvalidEvidence(t *testing.T) {
+	db, err := snapshotdb.New(snapshotdb.Options{InMemory: true})
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	sp := NewSlashingPlugin(db, NewStakingPlugin(db, NewRestrictingPlugin()))
+	state := newMockStateDB()
+
+	blockHash := common.HexToHash("0x01")
+	if err := db.NewBlock(big.NewInt(1), common.ZeroHash, blockHash); nil != err {
+		t.Fatal(err)
+	}
+
+	voteA, _ := json.Marshal(&xcom.EvidencePrepare{Timestamp: 1, Hash: common.BytesToHash([]byte("a")), Number: 1})
+	view, _ := json.Marshal(&xcom.EvidenceView{Timestamp: 1, BlockNum: 1})
+	for _, tt := range []struct {
+		data string
+		err  error
+	}{
+		{`{"DP":[null]}`, EvidenceEmptyErr},
+		{`{"duplicate_prepare":[null]}`, xcom.EvidenceNullErr},
+		{`{"duplicate_viewchange":[null]}`, xcom.EvidenceNullErr},
+		{`{"timestamp_viewchange":[null]}`, xcom.EvidenceNullErr},
+		{`{"duplicate_prepare":[{}]}`, xcom.EvidenceEmptyVoteErr},
+		{fmt.Sprintf(`{"duplicate_prepare":[{"VoteA":%s}]}`, voteA), xcom.EvidenceEmptyVoteErr},
+		{fmt.Sprintf(`{"duplicate_viewchange":[{"VoteB":%s}]}`, view), xcom.EvidenceEmptyVoteErr},
+		{fmt.Sprintf(`{"timestamp_viewchange":[{"VoteA":%s}]}`, view), xcom.EvidenceEmptyVoteErr},
+	} {
+		if success, err := sp.Slash(tt.data, blockHash, 1, state); !success || err != tt.err {
+			t.Errorf("slash %s, expect (true, %v), got (%v, %v)", tt.data, tt.err, success, err)
+		}
+	}
+
+	var null *xcom.DuplicatePrepareEvidence
+	if err := null.Validate(); err != xcom.EvidenceNullErr {
+		t.Errorf("validate the null evidence, expect %v, got %v", xcom.EvidenceNullErr, err)
+	}
+}
+
+func TestStakingPlugin_PrunePackageCount(t *testing.T) {
+	db, err := snapshotdb.New(snapshotdb.Options{InMemory: true})
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	sk := NewStakingPlugin(db, NewRestrictingPlugin())
+
+	blockHash := common.HexToHash("0x01")
+	if err := db.NewBlock(big.NewInt(1), common.ZeroHash, blockHash); nil != err {
+		t.Fatal(err)
+	}
+
+	addr := common.HexToAddress("0x01")
+	rounds := []uint64{1, 2 * xcom.EpochSize, 2*xcom.EpochSize + 1, 3 * xcom.EpochSize}
+	for _, round := range rounds {
+		if err := sk.db.setPackageCountStore(blockHash, round, addr, 1); nil != err {
+			t.Fatal(err)
+		}
+	}
+
+	// at the end of the 3rd epoch, the counts of the first 2 epochs are deleted
+	if err := sk.prunePackageCount(blockHash, 3); nil != err {
+		t.Fatal(err)
+	}
+	for i, round := range rounds {
+		count, err := sk.db.getPackageCountStore(blockHash, round, addr)
+		if nil != err {
+			t.Fatal(err)
+		}
+		if expect := uint32(i / 2); count != expect {
+			t.Errorf("the count of round %d mismatch, expect %d, got %d", round, expect, count)
+		}
+	}
+}
+
+func TestStakingPlugin_DelegateRewardAfterUnStake(t *testing.T) {
+	db, err := snapshotdb.New(snapshotdb.Options{InMemory: true})
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	sk := NewStakingPlugin(db, NewRestrictingPlugin())
+	state := newMockStateDB()
+
+	key, _ := crypto.GenerateKey()
+	nodeId := discover.PubkeyID(&key.PublicKey)
+	canAddr := crypto.PubkeyToAddress(key.PublicKey)
+	stakingAddr, delAddr := common.HexToAddress("0x02"), common.HexToAddress("0x03")
+	state.AddBalance(stakingAddr, lat(100))
+	state.AddBalance(delAddr, lat(100))
+
+	blockHash := common.HexToHash("0x01")
+	blockNumber := big.NewInt(1)
+	if err := db.NewBlock(blockNumber, common.ZeroHash, blockHash); nil != err {
+		t.Fatal(err)
+	}
+	can := &xcom.Candidate{
+		NodeId:          nodeId,
+		StakingAddress:  stakingAddr,
+		BenifitAddress:  stakingAddr,
+		StakingBlockNum: blockNumber.Uint64(),
+		Shares:          lat(100),
+	}
+	if _, err := sk.CreateCandidate(state, blockHash, blockNumber, lat(100), 10101010, FreeOrigin, canAddr, can); nil != err {
+		t.Fatal(err)
+	}
+	del := &xcom.Delegation{
+		Reduction:   new(big.Int),
+		Released:    new(big.Int),
+		ReleasedTmp: new(big.Int),
+		LockRepo:    new(big.Int),
+		LockRepoTmp: new(big.Int),
+	}
+	if _, err := sk.Delegate(state, blockHash, blockNumber, delAddr, del, can, FreeOrigin, lat(100)); nil != err {
+		t.Fatal(err)
+	}
+
+	// a half of the reward is shared by the delegator
+	state.AddBalance(vm.AwardMgrContractAddr, lat(10))
+	if _, err := sk.DistributeStakingReward(state, blockHash, blockNumber.Uint64(), can, lat(10)); nil != err {
+		t.Fatal(err)
+	}
+	assertBalance(t, state, vm.DelegateRewardPoolAddr, lat(5))
+
+	// the reward is still paid after the candidate is deleted
+	if _, err := sk.handleUnStake(state, blockHash, 1, canAddr, can); nil != err {
+		t.Fatal(err)
+	}
+	if can, _ := sk.GetCandidateInfo(blockHash, canAddr); nil != can {
+		t.Fatal("the candidate is not deleted")
+	}
+	reward, _, err := sk.WithdrawDelegateReward(state, blockHash, blockNumber, delAddr, nodeId, can.StakingBlockNum, del)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if reward.Cmp(lat(5)) != 0 {
+		t.Fatalf("expect the delegate reward %s, got %s", lat(5), reward)
+	}
+	assertBalance(t, state, vm.DelegateRewardPoolAddr, new(big.Int))
+}
+
+func TestStakingPlugin_WithdrewDelegateLockRepo(t *testing.T) {
+	db, err := snapshotdb.New(snapshotdb.Options{InMemory: true})
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	sk := NewStakingPlugin(db, NewRestrictingPlugin())
+	state := newMockStateDB()
+
+	key, _ := crypto.GenerateKey()
+	nodeId := discover.PubkeyID(&key.PublicKey)
+	canAddr := crypto.PubkeyToAddress(key.PublicKey)
+	stakingAddr, delAddr := common.HexToAddress("0x02"), common.HexToAddress("0x03")
+	state.AddBalance(stakingAddr, lat(100))
+
+	blockHash := common.HexToHash("0x01")
+	blockNumber := big.NewInt(1)
+	if err := db.NewBlock(blockNumber, common.ZeroHash, blockHash); nil != err {
+		t.Fatal(err)
+	}
+	can := &xcom.Candidate{
+		NodeId:          nodeId,
+		StakingAddress:  stakingAddr,
+		BenifitAddress:  stakingAddr,
+		StakingBlockNum: blockNumber.Uint64(),
+		Shares:          lat(100),
+	}
+	if _, err := sk.CreateCandidate(state, blockHash, blockNumber, lat(100), 10101010, FreeOrigin, canAddr, can); nil != err {
+		t.Fatal(err)
+	}
+
+	// the delegator has no restricting record, so the lock repo can not be returned
+	del := &xcom.Delegation{
+		DelegateEpoch: xutil.CalculateEpoch(blockNumber.Uint64()),
+		Reduction:     new(big.Int),
+		Released:      new(big.Int),
+		ReleasedTmp:   new(big.Int),
+		LockRepo:      new(big.Int),
+		LockRepoTmp:   lat(10),
+	}
+	success, err := sk.WithdrewDelegate(state, blockHash, blockNumber, lat(10), delAddr, nodeId, can.StakingBlockNum, del)
+	if !success || err != errAccountNotFound {
+		t.Fatalf("expect the business error %v, got success: %t, err: %v", errAccountNotFound, success, err)
+	}
+}
+
+func TestStakingPlugin_UnStakeQueue(t *testing.T) {
+	db, err := snapshotdb.New(snapshotdb.Options{InMemory: true})
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	sk := NewStakingPlugin(db, NewRestrictingPlugin())
+	state := newMockStateDB()
+
+	key, _ := crypto.GenerateKey()
+	nodeId := discover.PubkeyID(&key.PublicKey)
+	canAddr := crypto.PubkeyToAddress(key.PublicKey)
+	stakingAddr := common.HexToAddress("0x02")
+	state.AddBalance(stakingAddr, lat(100))
+
+	blockHash := common.HexToHash("0x01")
+	blockNumber := big.NewInt(1)
+	if err := db.NewBlock(blockNumber, common.ZeroHash, blockHash); nil != err {
+		t.Fatal(err)
+	}
+	can := &xcom.Candidate{
+		NodeId:          nodeId,
+		StakingAddress:  stakingAddr,
+		BenifitAddress:  stakingAddr,
+		StakingBlockNum: blockNumber.Uint64(),
+		Shares:          lat(100),
+	}
+	if _, err := sk.CreateCandidate(state, blockHash, blockNumber, lat(100), 10101010, FreeOrigin, canAddr, can); nil != err {
+		t.Fatal(err)
+	}
+
+	// the stake is effective in the next epoch, so it is frozen after withdrawn,
+	// the data is kept in one block since only the epoch of the block matters
+	withdrewHash := blockHash
+	withdrewNumber := new(big.Int).SetUint64(xutil.CalculateLastBlockOfEpoch(1) + 1)
+	can, err = sk.GetCandidateInfo(withdrewHash, canAddr)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if _, err := sk.WithdrewCandidate(state, withdrewHash, withdrewNumber, can); nil != err {
+		t.Fatal(err)
+	}
+
+	queue, err := sk.GetUnStakeQueue(state, withdrewHash, withdrewNumber.Uint64())
+	if nil != err {
+		t.Fatal(err)
+	}
+	if len(queue) != 1 {
+		t.Fatalf("expect 1 unstake, got %d", len(queue))
+	}
+	epoch := xutil.CalculateEpoch(withdrewNumber.Uint64())
+	item := queue[0]
+	if item.NodeAddress != canAddr || item.NodeId != nodeId || item.StakingBlockNum != blockNumber.Uint64() {
+		t.Fatalf("unexpected unstake of the node %s staked at %d", item.NodeId.TerminalString(), item.StakingBlockNum)
+	}
+	if item.Amount.Cmp(lat(100)) != 0 {
+		t.Fatalf("expect the frozen amount %s, got %s", lat(100), item.Amount)
+	}
+	if item.Epoch != epoch || item.ReleaseEpoch != epoch+xcom.UnStakeFreezeRatio {
+		t.Fatalf("expect the unstake at epoch %d released at %d, got %d and %d",
+			epoch, epoch+xcom.UnStakeFreezeRatio, item.Epoch, item.ReleaseEpoch)
+	}
+
+	// the queue is empty once the stake is returned
+	assertBalance(t, state, stakingAddr, new(big.Int))
+	if _, err := sk.HandleUnCandidateReq(state, withdrewHash, item.ReleaseEpoch); nil != err {
+		t.Fatal(err)
+	}
+	assertBalance(t, state, stakingAddr, lat(100))
+	queue, err = sk.GetUnStakeQueue(state, withdrewHash, xutil.CalculateLastBlockOfEpoch(item.ReleaseEpoch))
+	if nil != err {
+		t.Fatal(err)
+	}
+	if len(queue) != 0 {
+		t.Fatalf("expect no unstake after released, got %d", len(queue))
+	}
+}
+
+func TestStakingPlugin_UnDelegateQueue(t *testing.T) {
+	db, err := snapshotdb.New(snapshotdb.Options{InMemory: true})
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	sk := NewStakingPlugin(db, NewRestrictingPlugin())
+	state := newMockStateDB()
+
+	key, _ := crypto.GenerateKey()
+	nodeId := discover.PubkeyID(&key.PublicKey)
+	canAddr := crypto.PubkeyToAddress(key.PublicKey)
+	stakingAddr, delAddr := common.HexToAddress("0x02"), common.HexToAddress("0x03")
+	state.AddBalance(stakingAddr, lat(100))
+	state.AddBalance(delAddr, lat(100))
+
+	blockHash := common.HexToHash("0x01")
+	blockNumber := big.NewInt(1)
+	if err := db.NewBlock(blockNumber, common.ZeroHash, blockHash); nil != err {
+		t.Fatal(err)
+	}
+	can := &xcom.Candidate{
+		NodeId:          nodeId,
+		StakingAddress:  stakingAddr,
+		BenifitAddress:  stakingAddr,
+		StakingBlockNum: blockNumber.Uint64(),
+		Shares:          lat(100),
+	}
+	if _, err := sk.CreateCandidate(state, blockHash, blockNumber, lat(100), 10101010, FreeOrigin, canAddr, can); nil != err {
+		t.Fatal(err)
+	}
+	del := &xcom.Delegation{
+		Reduction:   new(big.Int),
+		Released:    new(big.Int),
+		ReleasedTmp: new(big.Int),
+		LockRepo:    new(big.Int),
+		LockRepoTmp: new(big.Int),
+	}
+	if _, err := sk.Delegate(state, blockHash, blockNumber, delAddr, del, can, FreeOrigin, lat(100)); nil != err {
+		t.Fatal(err)
+	}
+
+	// the effective delegation is frozen after withdrawn in the next epoch
+	withdrewHash := blockHash
+	withdrewNumber := new(big.Int).SetUint64(xutil.CalculateLastBlockOfEpoch(1) + 1)
+	del, err = sk.GetDelegateInfo(withdrewHash, delAddr, nodeId, can.StakingBlockNum)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if _, err := sk.WithdrewDelegate(state, withdrewHash, withdrewNumber, lat(100), delAddr, nodeId, can.StakingBlockNum, del); nil != err {
+		t.Fatal(err)
+	}
+
+	queue, err := sk.GetUnDelegateQueue(state, withdrewHash, withdrewNumber.Uint64())
+	if nil != err {
+		t.Fatal(err)
+	}
+	if len(queue) != 1 {
+		t.Fatalf("expect 1 undelegate, got %d", len(queue))
+	}
+	epoch := xutil.CalculateEpoch(withdrewNumber.Uint64())
+	item := queue[0]
+	if item.Addr != delAddr || item.NodeId != nodeId || item.StakingBlockNum != can.StakingBlockNum {
+		t.Fatalf("unexpected undelegate of %s to the node %s staked at %d", item.Addr.Hex(), item.NodeId.TerminalString(), item.StakingBlockNum)
+	}
+	if item.Amount.Cmp(lat(100)) != 0 {
+		t.Fatalf("expect the frozen amount %s, got %s", lat(100), item.Amount)
+	}
+	if item.Epoch != epoch || item.ReleaseEpoch != epoch+xcom.ActiveUnDelegateFreezeRatio {
+		t.Fatalf("expect the undelegate at epoch %d released at %d, got %d and %d",
+			epoch, epoch+xcom.ActiveUnDelegateFreezeRatio, item.Epoch, item.ReleaseEpoch)
+	}
+
+	// the queue is empty once the delegation is returned
+	assertBalance(t, state, delAddr, new(big.Int))
+	if _, err := sk.HandleUnDelegateReq(state, withdrewHash, item.ReleaseEpoch); nil != err {
+		t.Fatal(err)
+	}
+	assertBalance(t, state, delAddr, lat(100))
+	queue, err = sk.GetUnDelegateQueue(state, withdrewHash, xutil.CalculateLastBlockOfEpoch(item.ReleaseEpoch))
+	if nil != err {
+		t.Fatal(err)
+	}
+	if len(queue) != 0 {
+		t.Fatalf("expect no undelegate after released, got %d", len(queue))
+	}
+}
+
+func setGovernParam(state xcom.StateDB, name string, value uint64) {
+	state.SetState(vm.GovContractAddr, xcom.GovernParamKey(name), []byte(fmt.Sprint(value)))
+}
+
+// createTestCandidates stakes the candidates in the block, the later one has more shares
+func createTestCandidates(t *testing.T, sk *StakingPlugin, state xcom.StateDB, blockHash common.Hash, blockNumber *big.Int, num int) []*xcom.Candidate {
+	cans := make([]*xcom.Candidate, 0, num)
+	for i := 0; i < num; i++ {
+		key, _ := crypto.GenerateKey()
+		addr := crypto.PubkeyToAddress(key.PublicKey)
+		amount := lat(int64(100 + i))
+		state.AddBalance(addr, amount)
+
+		can := &xcom.Candidate{
+			NodeId:          discover.PubkeyID(&key.PublicKey),
+			StakingAddress:  addr,
+			BenifitAddress:  addr,
+			StakingBlockNum: blockNumber.Uint64(),
+			StakingTxIndex:  uint32(i),
+			Shares:          amount,
+		}
+		if _, err := sk.CreateCandidate(state, blockHash, blockNumber, amount, 10101010, FreeOrigin, addr, can); nil != err {
+			t.Fatal(err)
+		}
+		cans = append(cans, can)
+	}
+	return cans
+}
+
+func TestStakingPlugin_ElectNextVerifierList(t *testing.T) {
+	db, err := snapshotdb.New(snapshotdb.Options{InMemory: true})
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	sk := NewStakingPlugin(db, NewRestrictingPlugin())
+	state := newMockStateDB()
+	setGovernParam(state, xcom.ParamEpochValidatorNum, 4)
+
+	blockHash := common.HexToHash("0x01")
+	blockNumber := big.NewInt(1)
+	if err := db.NewBlock(blockNumber, common.ZeroHash, blockHash); nil != err {
+		t.Fatal(err)
+	}
+	// the node address is the staking address of the test candidates
+	cans := createTestCandidates(t, sk, state, blockHash, blockNumber, 7)
+
+	// the two top ranked candidates are invalid, but their power is still in the ranking
+	for _, can := range cans[5:] {
+		can.Status |= xcom.Invalided
+		if err := sk.db.setCandidateStore(blockHash, can.StakingAddress, can); nil != err {
+			t.Fatal(err)
+		}
+	}
+
+	settlement := xutil.CalculateLastBlockOfEpoch(1)
+	if _, err := sk.ElectNextVerifierList(state, blockHash, settlement); nil != err {
+		t.Fatal(err)
+	}
+	verifiers, err := sk.db.getVerifierList(blockHash)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if verifiers.Start != settlement+1 || verifiers.End != settlement+xcom.ConsensusSize*xcom.EpochSize {
+		t.Errorf("unexpected range of the verifiers [%d, %d]", verifiers.Start, verifiers.End)
+	}
+	// the skipped candidates are replaced by the following ones
+	if len(verifiers.Arr) != 4 {
+		t.Fatalf("expect 4 verifiers, got %d", len(verifiers.Arr))
+	}
+	for i, v := range verifiers.Arr {
+		if expect := cans[4-i].StakingAddress; v.NodeAddress != expect {
+			t.Errorf("the verifier %d is %s, expect %s", i, v.NodeAddress.Hex(), expect.Hex())
+		}
+	}
+}
+
+func TestStakingPlugin_Election(t *testing.T) {
+	verifiers := buildValidators(0, 8)
+	curr := &xcom.Validator_array{Start: 1, End: xcom.ConsensusSize, Arr: buildValidators(0, 4)}
+	for i, v := range curr.Arr {
+		v.ValidatorTerm = uint32(i)
+	}
+
+	elect := func(nonce byte) *xcom.Validator_array {
+		db, err := snapshotdb.New(snapshotdb.Options{InMemory: true})
+		if nil != err {
+			t.Fatal(err)
+		}
+		defer db.Close()
+		sk := NewStakingPlugin(db, NewRestrictingPlugin())
+		state := newMockStateDB()
+		setGovernParam(state, xcom.ParamConsValidatorNum, 4)
+		setGovernParam(state, xcom.ParamShiftValidatorNum, 1)
+
+		blockHash := common.HexToHash("0x01")
+		header := &types.Header{Number: new(big.Int).SetUint64(xcom.ConsensusSize - xcom.ElectionDistance)}
+		for i := range header.Nonce {
+			header.Nonce[i] = nonce
+		}
+		if err := db.NewBlock(header.Number, common.ZeroHash, blockHash); nil != err {
+			t.Fatal(err)
+		}
+		if err := sk.db.setVerifierList(blockHash, &xcom.Validator_array{Start: 1, End: xcom.ConsensusSize * xcom.EpochSize, Arr: verifiers}); nil != err {
+			t.Fatal(err)
+		}
+		if err := sk.db.setCurrentValidatorList(blockHash, curr); nil != err {
+			t.Fatal(err)
+		}
+		if _, err := sk.Election(state, blockHash, header); nil != err {
+			t.Fatal(err)
+		}
+		next, err := sk.db.getNextValidatorList(blockHash)
+		if nil != err {
+			t.Fatal(err)
+		}
+		return next
+	}
+
+	next := elect(1)
+	if next.Start != curr.End+1 || next.End != curr.End+xcom.ConsensusSize {
+		t.Errorf("unexpected range of the next validators [%d, %d]", next.Start, next.End)
+	}
+	if len(next.Arr) != 4 {
+		t.Fatalf("expect 4 validators, got %d", len(next.Arr))
+	}
+	// the validator which has served the longest is shifted out
+	for _, v := range next.Arr {
+		if v.NodeAddress == curr.Arr[3].NodeAddress {
+			t.Errorf("the validator %s should be shifted out", v.NodeAddress.Hex())
+		}
+	}
+
+	// the shuffle is determined by the vrf seed of the block
+	for i, v := range elect(1).Arr {
+		if v.NodeAddress != next.Arr[i].NodeAddress || v.ValidatorTerm != next.Arr[i].ValidatorTerm {
+			t.Fatal("the election is not deterministic")
+		}
+	}
+	changed := false
+	for nonce := byte(2); nonce < 10 && !changed; nonce++ {
+		for i, v := range elect(nonce).Arr {
+			if v.NodeAddress != next.Arr[i].NodeAddress {
+				changed = true
+			}
+		}
+	}
+	if !changed {
+		t.Error("the election is not affected by the vrf seed")
+	}
+}
+
+func TestStakingPlugin_Switch(t *testing.T) {
+	db, err := snapshotdb.New(snapshotdb.Options{InMemory: true})
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	sk := NewStakingPlugin(db, NewRestrictingPlugin())
+
+	blockHash := common.HexToHash("0x01")
+	if err := db.NewBlock(big.NewInt(1), common.ZeroHash, blockHash); nil != err {
+		t.Fatal(err)
+	}
+	curr := &xcom.Validator_array{Start: 1, End: xcom.ConsensusSize, Arr: buildValidators(0, 4)}
+	next := &xcom.Validator_array{Start: xcom.ConsensusSize + 1, End: 2 * xcom.ConsensusSize, Arr: buildValidators(2, 4)}
+	if err := sk.db.setCurrentValidatorList(blockHash, curr); nil != err {
+		t.Fatal(err)
+	}
+	if err := sk.db.setNextValidatorList(blockHash, next); nil != err {
+		t.Fatal(err)
+	}
+
+	assertList := func(name string, expect, got *xcom.Validator_array) {
+		if got.Start != expect.Start || got.End != expect.End || len(got.Arr) != len(expect.Arr) {
+			t.Fatalf("unexpected %s validators [%d, %d] with %d validators", name, got.Start, got.End, len(got.Arr))
+		}
+		for i := range expect.Arr {
+			if got.Arr[i].NodeAddress != expect.Arr[i].NodeAddress {
+				t.Fatalf("the %s validator %d is %s, expect %s", name, i, got.Arr[i].NodeAddress.Hex(), expect.Arr[i].NodeAddress.Hex())
+			}
+		}
+	}
+
+	if _, err := sk.Switch(blockHash, xcom.ConsensusSize); nil != err {
+		t.Fatal(err)
+	}
+	previous, err := sk.db.getPreviousValidatorList(blockHash)
+	if nil != err {
+		t.Fatal(err)
+	}
+	assertList("previous", curr, previous)
+	current, err := sk.db.getCurrentValidatorList(blockHash)
+	if nil != err {
+		t.Fatal(err)
+	}
+	assertList("current", next, current)
+	if _, err := sk.db.getNextValidatorList(blockHash); err != snapshotdb.ErrNotFound {
+		t.Fatalf("expect the next validators deleted, got %v", err)
+	}
+
+	// nothing is switched without the next validators
+	if _, err := sk.Switch(blockHash, 2*xcom.ConsensusSize); nil != err {
+		t.Fatal(err)
+	}
+	current, err = sk.db.getCurrentValidatorList(blockHash)
+	if nil != err {
+		t.Fatal(err)
+	}
+	assertList("current", next, current)
+}
@@ -9,6 +9,7 @@ const (
 	SlashingRule
 	RestrictingRule
 	AwardmgrRule
+	GovernanceRule
 
 	// ......
 )
@@ -52,3 +53,67 @@ var (
 	// due to active withdrew delegate (unit is  epochs)
 	ActiveUnDelegateFreezeRatio = uint64(0)
 )
+
+/**
+Slashing config
+**/
+var (
+	// The percentage of the staking von which will be slashed
+	// when the candidate was reported for duplicate signing (unit is %)
+	DuplicateSignSlashRatio = uint64(10)
+
+	// The number of epochs that the evidence of duplicate signing
+	// is allowed to be reported after the offence (unit is epochs)
+	EvidenceValidEpoch = uint64(1)
+)
+
+/**
+Award config
+**/
+var (
+	// The von which rewards the block producer for each block (1 LAT)
+	BlockReward, _ = new(big.Int).SetString("1000000000000000000", 10)
+
+	// The von which is added into the staking reward pool for each block (2 LAT),
+	// the pool will be distributed to the verifiers at the end of each epoch
+	StakingRewardPerBlock, _ = new(big.Int).SetString("2000000000000000000", 10)
+)
+
+/**
+Restricting config
+**/
+var (
+	// The max count of the entries of the restricting plans created at once
+	RestrictingPlanMaxCount = uint64(36)
+
+	// The max release epoch of the entry of the restricting plans, counted from the epoch of the plans created
+	RestrictingPlanMaxEpoch = uint64(1000)
+
+	// The minimum total amount of the restricting plans created at once (1 LAT)
+	RestrictingMinimumAmount, _ = new(big.Int).SetString("1000000000000000000", 10)
+)
+
+/**
+Governance config
+**/
+var (
+	// The max duration of the voting period of the proposal,
+	// about two weeks with one block per second (unit is blocks)
+	MaxVotingDuration = uint64(14 * 24 * 3600)
+
+	// The percentage of the yeas in all of the accumulated verifiers
+	// required to pass a proposal (unit is %)
+	SupportRateThreshold = uint64(85)
+
+	// The range of the active block of the version proposal after the end of voting (unit is consensus rounds)
+	VersionProposalMinActiveRounds = uint64(4)
+	VersionProposalMaxActiveRounds = uint64(10)
+
+	// The von which is locked from the proposer on submitting the proposal (100 LAT),
+	// it is refunded after the tally or burned if the proposal failed to reach the quorum
+	ProposalDeposit, _ = new(big.Int).SetString("100000000000000000000", 10)
+
+	// The percentage of the votes in all of the accumulated verifiers
+	// required to reach the quorum of a proposal (unit is %)
+	ProposalQuorumRate = uint64(50)
+)
@@ -0,0 +1,159 @@
+package xcom
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+
+	"github.com/PlatONnetwork/PlatON-Go/common/vm"
+	"github.com/PlatONnetwork/PlatON-Go/log"
+)
+
+var (
+	ParamNotGovernable = errors.New("the param is not governable")
+	ParamValueInvalid  = errors.New("the value of the param is invalid")
+	ParamValueOutRange = errors.New("the value of the param is out of range")
+	ParamValueConflict = errors.New("the value of the param conflicts with the other params")
+)
+
+// The names of the params which can be changed by the param proposal
+const (
+	ParamStakeThreshold               = "StakeThreshold"
+	ParamDelegateThreshold            = "DelegateThreshold"
+	ParamConsValidatorNum             = "ConsValidatorNum"
+	ParamEpochValidatorNum            = "EpochValidatorNum"
+	ParamShiftValidatorNum            = "ShiftValidatorNum"
+	ParamHesitateRatio                = "HesitateRatio"
+	ParamEffectiveRatio               = "EffectiveRatio"
+	ParamUnStakeFreezeRatio           = "UnStakeFreezeRatio"
+	ParamPassiveUnDelegateFreezeRatio = "PassiveUnDelegateFreezeRatio"
+	ParamActiveUnDelegateFreezeRatio  = "ActiveUnDelegateFreezeRatio"
+	ParamDuplicateSignSlashRatio      = "DuplicateSignSlashRatio"
+	ParamEvidenceValidEpoch           = "EvidenceValidEpoch"
+	ParamBlockReward                  = "BlockReward"
+	ParamStakingRewardPerBlock        = "StakingRewardPerBlock"
+	ParamProposalDeposit              = "ProposalDeposit"
+)
+
+// GovernParam describes a whitelisted param and the bounds of its value.
+// NOTE: EpochSize, ConsensusSize and ElectionDistance are not governable,
+// all of the periods are calculated from the block number with them.
+type GovernParam struct {
+	Name string
+	Min  *big.Int
+	Max  *big.Int
+	// The compile-time value, used before any proposal changed it
+	Default *big.Int
+}
+
+// ValueAt returns the value of the param in the state of the block being processed,
+// the Default is returned if no proposal has changed it
+func (p *GovernParam) ValueAt(state StateDB) *big.Int {
+	stored := state.GetState(vm.GovContractAddr, GovernParamKey(p.Name))
+	if len(stored) == 0 {
+		return new(big.Int).Set(p.Default)
+	}
+	value, err := p.Verify(string(stored))
+	if err != nil {
+		log.Error("The stored param is invalid, use the default value", "name", p.Name, "value", string(stored), "err", err)
+		return new(big.Int).Set(p.Default)
+	}
+	return value
+}
+
+// Verify parses the decimal value and checks it with the bounds of the param
+func (p *GovernParam) Verify(value string) (*big.Int, error) {
+	v, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return nil, ParamValueInvalid
+	}
+	if v.Cmp(p.Min) < 0 || v.Cmp(p.Max) > 0 {
+		return nil, ParamValueOutRange
+	}
+	return v, nil
+}
+
+// GovernParamKey is the key of the param value changed by the proposal in the state of the gov contract
+func GovernParamKey(name string) []byte {
+	return bytes.Join([][]byte{
+		[]byte("ParamValue"),
+		[]byte(name),
+	}, []byte(":"))
+}
+
+// GovernUint64 returns the value of the uint64 param in the state
+func GovernUint64(state StateDB, name string) uint64 {
+	return GetGovernParam(name).ValueAt(state).Uint64()
+}
+
+// GovernBigInt returns the value of the big.Int param in the state
+func GovernBigInt(state StateDB, name string) *big.Int {
+	return GetGovernParam(name).ValueAt(state)
+}
+
+func uint64Param(name string, min, max, def uint64) *GovernParam {
+	return &GovernParam{
+		Name:    name,
+		Min:     new(big.Int).SetUint64(min),
+		Max:     new(big.Int).SetUint64(max),
+		Default: new(big.Int).SetUint64(def),
+	}
+}
+
+func bigIntParam(name string, min, max, def *big.Int) *GovernParam {
+	return &GovernParam{
+		Name:    name,
+		Min:     min,
+		Max:     max,
+		Default: new(big.Int).Set(def),
+	}
+}
+
+func lat(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e18))
+}
+
+// The config values are only the defaults of the params,
+// the plugins read the params from the state of the block being processed
+var governParams = []*GovernParam{
+	bigIntParam(ParamStakeThreshold, lat(10000), lat(100000000), StakeThreshold),
+	bigIntParam(ParamDelegateThreshold, big.NewInt(1), lat(10000), DelegateThreshold),
+	uint64Param(ParamConsValidatorNum, 4, 201, ConsValidatorNum),
+	uint64Param(ParamEpochValidatorNum, 4, 1001, EpochValidatorNum),
+	uint64Param(ParamShiftValidatorNum, 1, 201, ShiftValidatorNum),
+	uint64Param(ParamHesitateRatio, 0, 10, HesitateRatio),
+	uint64Param(ParamEffectiveRatio, 0, 10, EffectiveRatio),
+	uint64Param(ParamUnStakeFreezeRatio, 0, 112, UnStakeFreezeRatio),
+	uint64Param(ParamPassiveUnDelegateFreezeRatio, 0, 112, PassiveUnDelegateFreezeRatio),
+	uint64Param(ParamActiveUnDelegateFreezeRatio, 0, 112, ActiveUnDelegateFreezeRatio),
+	uint64Param(ParamDuplicateSignSlashRatio, 1, 100, DuplicateSignSlashRatio),
+	uint64Param(ParamEvidenceValidEpoch, 1, 10, EvidenceValidEpoch),
+	bigIntParam(ParamBlockReward, big.NewInt(0), lat(100), BlockReward),
+	bigIntParam(ParamStakingRewardPerBlock, big.NewInt(0), lat(100), StakingRewardPerBlock),
+	bigIntParam(ParamProposalDeposit, big.NewInt(0), lat(1000000), ProposalDeposit),
+}
+
+// GetGovernParam returns nil if the param is not in the whitelist
+func GetGovernParam(name string) *GovernParam {
+	for _, p := range governParams {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// GovernParams returns all of the whitelisted params in the fixed order
+func GovernParams() []*GovernParam {
+	return governParams
+}
+
+// VerifyGovernParams checks the bounds between the params, the values must contain all of the whitelisted params
+func VerifyGovernParams(values map[string]*big.Int) error {
+	// the validators are shifted out of the consensus validators, which are elected from the epoch validators
+	if values[ParamShiftValidatorNum].Cmp(values[ParamConsValidatorNum]) > 0 ||
+		values[ParamConsValidatorNum].Cmp(values[ParamEpochValidatorNum]) > 0 {
+		return ParamValueConflict
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package xcom
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/PlatONnetwork/PlatON-Go/common/byteutil"
+	"github.com/PlatONnetwork/PlatON-Go/rlp"
+)
+
+func init() {
+	byteutil.Bytes2X_CMD["[]xcom.RestrictingPlan"] = BytesToRestrictingPlanArr
+}
+
+var (
+	RestrictingPlansEmpty      = errors.New("the restricting plans is empty")
+	RestrictingPlansTooMany    = errors.New("the count of the restricting plans is too many")
+	RestrictingEpochInvalid    = errors.New("the release epoch of the restricting plan is out of range")
+	RestrictingAmountInvalid   = errors.New("the release amount of the restricting plan must be greater than zero")
+	RestrictingAmountTooLittle = errors.New("the total amount of the restricting plans is too little")
+)
+
+// RestrictingPlan is one entry of the restricting plans created for an account,
+// the Amount will be released at the end of the Epoch, which is counted from the
+// epoch of the plans created. (e.g. Epoch 1 means the end of the next epoch)
+type RestrictingPlan struct {
+	Epoch  uint64   `json:"epoch"`
+	Amount *big.Int `json:"amount"`
+}
+
+func (p RestrictingPlan) String() string {
+	return fmt.Sprintf(`{"epoch": %d, "amount": %s}`, p.Epoch, p.Amount)
+}
+
+// BytesToRestrictingPlanArr decodes the restricting plans in the tx data of the restricting contract
+func BytesToRestrictingPlanArr(curByte []byte) []RestrictingPlan {
+	var planArr []RestrictingPlan
+	if err := rlp.DecodeBytes(curByte, &planArr); nil != err {
+		panic("BytesToRestrictingPlanArr:" + err.Error())
+	}
+	return planArr
+}
+
+// VerifyRestrictingPlans checks every entry of the plans, and returns the total amount of them
+func VerifyRestrictingPlans(plans []RestrictingPlan) (*big.Int, error) {
+	if len(plans) == 0 {
+		return nil, RestrictingPlansEmpty
+	}
+	if uint64(len(plans)) > RestrictingPlanMaxCount {
+		return nil, RestrictingPlansTooMany
+	}
+
+	total := new(big.Int)
+	for _, plan := range plans {
+		if plan.Epoch == 0 || plan.Epoch > RestrictingPlanMaxEpoch {
+			return nil, RestrictingEpochInvalid
+		}
+		if nil == plan.Amount || plan.Amount.Sign() <= 0 {
+			return nil, RestrictingAmountInvalid
+		}
+		total.Add(total, plan.Amount)
+	}
+
+	if total.Cmp(RestrictingMinimumAmount) < 0 {
+		return nil, RestrictingAmountTooLittle
+	}
+	return total, nil
+}
+
+// RestrictingInfo is the restricting record of the account
+type RestrictingInfo struct {
+	// The restricted von which is kept by the restricting contract
+	Balance *big.Int
+	// The von which should have been released, but could not be paid because it is pledged.
+	// It will be paid when the pledged von is returned
+	Debt *big.Int
+	// The von which is pledged to the staking contract
+	Pledge *big.Int
+	// The block numbers at which the von will be released, in ascending order
+	ReleaseList []uint64
+}
+
+// ReleaseAmountInfo is the von released at the block number
+type ReleaseAmountInfo struct {
+	Height uint64   `json:"blockNumber"`
+	Amount *big.Int `json:"amount"`
+}
+
+// RestrictingResult is the restricting record of the account with all of its release entries
+type RestrictingResult struct {
+	Balance *big.Int            `json:"balance"`
+	Debt    *big.Int            `json:"debt"`
+	Pledge  *big.Int            `json:"pledge"`
+	Entry   []ReleaseAmountInfo `json:"plans"`
+}
+
+// ReleaseAccountInfo is the account and the von which will be released at the block number
+type ReleaseAccountInfo struct {
+	Account common.Address `json:"account"`
+	Amount  *big.Int       `json:"amount"`
+}
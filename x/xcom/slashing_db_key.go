@@ -0,0 +1,19 @@
+package xcom
+
+import (
+	"github.com/PlatONnetwork/PlatON-Go/common"
+)
+
+const (
+	SlashingPrefixStr = "Slashing"
+)
+
+var (
+	SlashingKeyPrefix = []byte(SlashingPrefixStr)
+)
+
+// SlashingKey used for search the slashing record of an offence. key: prefix + type + nodeAddress + blockNumber
+func GetSlashingKey(typ uint8, addr common.Address, blockNumber uint64) []byte {
+	offence := append([]byte{typ}, append(addr.Bytes(), common.Uint64ToBytes(blockNumber)...)...)
+	return append(SlashingKeyPrefix, offence...)
+}
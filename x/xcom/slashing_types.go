@@ -0,0 +1,323 @@
+package xcom
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/PlatONnetwork/PlatON-Go/crypto"
+	"github.com/PlatONnetwork/PlatON-Go/rlp"
+)
+
+// The evidence type, it must be same as the prefix of evidence in cbft
+const (
+	TimestampViewChangeType = uint8(1) + iota
+	DuplicateViewChangeType
+	DuplicatePrepareType
+)
+
+var (
+	EvidenceSignatureErr = errors.New("the validator's address is not match signature")
+	EvidenceEmptyVoteErr = errors.New("the vote of evidence is empty")
+	EvidenceNullErr      = errors.New("the evidence is null")
+)
+
+// Evidence is the proof of the double signing by a validator.
+// They are detected by the evidence pool of cbft, and submitted
+// to the slashing contract.
+type Evidence interface {
+	// The evidence type
+	Type() uint8
+	// The lowest block number of the evidence
+	BlockNumber() uint64
+	// The node address of the validator who signed the votes
+	Address() common.Address
+	Hash() []byte
+	Validate() error
+}
+
+// The prepareVote in cbft
+// NOTE: the json tag must be same as the prepareVote of cbft
+type EvidencePrepare struct {
+	Timestamp      uint64                  `json:"timestamp"`
+	Hash           common.Hash             `json:"block_hash"`
+	Number         uint64                  `json:"block_number"`
+	ValidatorIndex uint32                  `json:"validator_index"`
+	ValidatorAddr  common.Address          `json:"validator_address"`
+	Signature      common.BlockConfirmSign `json:"signature"`
+}
+
+func (ep *EvidencePrepare) CannibalizeBytes() ([]byte, error) {
+	buf, err := rlp.EncodeToBytes([]interface{}{
+		ep.Timestamp,
+		ep.Hash,
+		ep.Number,
+		ep.ValidatorIndex,
+		ep.ValidatorAddr,
+	})
+	if nil != err {
+		return nil, err
+	}
+	return crypto.Keccak256(buf), nil
+}
+
+func (ep *EvidencePrepare) Sign() []byte {
+	return ep.Signature.Bytes()
+}
+
+func (ep *EvidencePrepare) String() string {
+	return fmt.Sprintf("[Timestamp:%d Hash:%s Number:%d ValidatorAddr:%s ValidatorIndex:%d]",
+		ep.Timestamp, ep.Hash.TerminalString(), ep.Number, ep.ValidatorAddr.String(), ep.ValidatorIndex)
+}
+
+// The viewChangeVote in cbft
+// NOTE: the json tag must be same as the viewChangeVote of cbft
+type EvidenceView struct {
+	Timestamp      uint64                  `json:"timestamp"`
+	BlockNum       uint64                  `json:"block_number"`
+	BlockHash      common.Hash             `json:"block_hash"`
+	ProposalIndex  uint32                  `json:"proposal_index"`
+	ProposalAddr   common.Address          `json:"proposal_address"`
+	ValidatorIndex uint32                  `json:"validator_index"`
+	ValidatorAddr  common.Address          `json:"validator_address"`
+	Signature      common.BlockConfirmSign `json:"signature"`
+}
+
+func (ev *EvidenceView) CannibalizeBytes() ([]byte, error) {
+	buf, err := rlp.EncodeToBytes([]interface{}{
+		ev.Timestamp,
+		ev.BlockNum,
+		ev.BlockHash,
+		ev.ProposalIndex,
+		ev.ProposalAddr,
+		ev.ValidatorIndex,
+		ev.ValidatorAddr,
+	})
+	if nil != err {
+		return nil, err
+	}
+	return crypto.Keccak256(buf), nil
+}
+
+func (ev *EvidenceView) Sign() []byte {
+	return ev.Signature.Bytes()
+}
+
+func (ev *EvidenceView) String() string {
+	return fmt.Sprintf("[Timestamp:%d BlockNum:%d BlockHash:%s ValidatorIndex:%d ValidatorAddr:%s]",
+		ev.Timestamp, ev.BlockNum, ev.BlockHash.TerminalString(), ev.ValidatorIndex, ev.ValidatorAddr.String())
+}
+
+type signedVote interface {
+	CannibalizeBytes() ([]byte, error)
+	Sign() []byte
+}
+
+func verifyVoteAddr(vote signedVote, addr common.Address) error {
+	data, err := vote.CannibalizeBytes()
+	if nil != err {
+		return err
+	}
+	pub, err := crypto.SigToPub(data, vote.Sign())
+	if nil != err {
+		return err
+	}
+	if crypto.PubkeyToAddress(*pub) != addr {
+		return EvidenceSignatureErr
+	}
+	return nil
+}
+
+func evidenceHash(a, b signedVote) []byte {
+	var buf []byte
+	if ac, err := a.CannibalizeBytes(); nil == err {
+		if bc, err := b.CannibalizeBytes(); nil == err {
+			buf, _ = rlp.EncodeToBytes([]interface{}{
+				ac,
+				a.Sign(),
+				bc,
+				b.Sign(),
+			})
+		}
+	}
+	return crypto.Keccak256(buf)
+}
+
+// Evidence A.Number == B.Number but A.Hash != B.Hash
+type DuplicatePrepareEvidence struct {
+	VoteA *EvidencePrepare `json:"VoteA"`
+	VoteB *EvidencePrepare `json:"VoteB"`
+}
+
+func (d *DuplicatePrepareEvidence) Type() uint8 {
+	return DuplicatePrepareType
+}
+
+func (d *DuplicatePrepareEvidence) BlockNumber() uint64 {
+	return d.VoteA.Number
+}
+
+func (d *DuplicatePrepareEvidence) Address() common.Address {
+	return d.VoteA.ValidatorAddr
+}
+
+func (d *DuplicatePrepareEvidence) Hash() []byte {
+	return evidenceHash(d.VoteA, d.VoteB)
+}
+
+func (d *DuplicatePrepareEvidence) Validate() error {
+	if nil == d {
+		return EvidenceNullErr
+	}
+	if nil == d.VoteA || nil == d.VoteB {
+		return EvidenceEmptyVoteErr
+	}
+	if d.VoteA.Number != d.VoteB.Number {
+		return fmt.Errorf("DuplicatePrepareEvidence BlockNum is different, VoteA:%s, VoteB:%s", d.VoteA.String(), d.VoteB.String())
+	}
+	if d.VoteA.Hash == d.VoteB.Hash {
+		return fmt.Errorf("DuplicatePrepareEvidence BlockHash is equal, VoteA:%s, VoteB:%s", d.VoteA.String(), d.VoteB.String())
+	}
+	if d.VoteA.ValidatorIndex != d.VoteB.ValidatorIndex ||
+		d.VoteA.ValidatorAddr != d.VoteB.ValidatorAddr {
+		return fmt.Errorf("DuplicatePrepareEvidence Validator do not match, VoteA:%s, VoteB:%s", d.VoteA.String(), d.VoteB.String())
+	}
+	if err := verifyVoteAddr(d.VoteA, d.VoteA.ValidatorAddr); nil != err {
+		return fmt.Errorf("DuplicatePrepareEvidence Vote verify failed, VoteA:%s", d.VoteA.String())
+	}
+	if err := verifyVoteAddr(d.VoteB, d.VoteB.ValidatorAddr); nil != err {
+		return fmt.Errorf("DuplicatePrepareEvidence Vote verify failed, VoteB:%s", d.VoteB.String())
+	}
+	return nil
+}
+
+// Evidence A.BlockNum == B.BlockNum but A.BlockHash != B.BlockHash
+type DuplicateViewChangeEvidence struct {
+	VoteA *EvidenceView `json:"VoteA"`
+	VoteB *EvidenceView `json:"VoteB"`
+}
+
+func (d *DuplicateViewChangeEvidence) Type() uint8 {
+	return DuplicateViewChangeType
+}
+
+func (d *DuplicateViewChangeEvidence) BlockNumber() uint64 {
+	return d.VoteA.BlockNum
+}
+
+func (d *DuplicateViewChangeEvidence) Address() common.Address {
+	return d.VoteA.ValidatorAddr
+}
+
+func (d *DuplicateViewChangeEvidence) Hash() []byte {
+	return evidenceHash(d.VoteA, d.VoteB)
+}
+
+func (d *DuplicateViewChangeEvidence) Validate() error {
+	if nil == d {
+		return EvidenceNullErr
+	}
+	if nil == d.VoteA || nil == d.VoteB {
+		return EvidenceEmptyVoteErr
+	}
+	ba := new(big.Int).SetBytes(d.VoteA.BlockHash.Bytes())
+	bb := new(big.Int).SetBytes(d.VoteB.BlockHash.Bytes())
+	if ba.Cmp(bb) >= 0 {
+		return fmt.Errorf("DuplicateViewChangeEvidence BlockHash do not match, VoteA:%s, VoteB:%s", d.VoteA.String(), d.VoteB.String())
+	}
+	if d.VoteA.BlockNum != d.VoteB.BlockNum {
+		return fmt.Errorf("DuplicateViewChangeEvidence BlockNum is not equal, VoteA:%s, VoteB:%s", d.VoteA.String(), d.VoteB.String())
+	}
+	if d.VoteA.ValidatorIndex != d.VoteB.ValidatorIndex ||
+		d.VoteA.ValidatorAddr != d.VoteB.ValidatorAddr {
+		return fmt.Errorf("DuplicateViewChangeEvidence Validator do not match, VoteA:%s, VoteB:%s", d.VoteA.String(), d.VoteB.String())
+	}
+	if err := verifyVoteAddr(d.VoteA, d.VoteA.ValidatorAddr); nil != err {
+		return fmt.Errorf("DuplicateViewChangeEvidence Vote verify failed, VoteA:%s", d.VoteA.String())
+	}
+	if err := verifyVoteAddr(d.VoteB, d.VoteB.ValidatorAddr); nil != err {
+		return fmt.Errorf("DuplicateViewChangeEvidence Vote verify failed, VoteB:%s", d.VoteB.String())
+	}
+	return nil
+}
+
+// Evidence A.Timestamp < B.Timestamp but A.BlockNum > B.BlockNum
+type TimestampViewChangeEvidence struct {
+	VoteA *EvidenceView `json:"VoteA"`
+	VoteB *EvidenceView `json:"VoteB"`
+}
+
+func (d *TimestampViewChangeEvidence) Type() uint8 {
+	return TimestampViewChangeType
+}
+
+func (d *TimestampViewChangeEvidence) BlockNumber() uint64 {
+	return d.VoteB.BlockNum
+}
+
+func (d *TimestampViewChangeEvidence) Address() common.Address {
+	return d.VoteA.ValidatorAddr
+}
+
+func (d *TimestampViewChangeEvidence) Hash() []byte {
+	return evidenceHash(d.VoteA, d.VoteB)
+}
+
+func (d *TimestampViewChangeEvidence) Validate() error {
+	if nil == d {
+		return EvidenceNullErr
+	}
+	if nil == d.VoteA || nil == d.VoteB {
+		return EvidenceEmptyVoteErr
+	}
+	if d.VoteA.Timestamp > d.VoteB.Timestamp {
+		return fmt.Errorf("TimestampViewChangeEvidence Timestamp do not match, VoteA:%s, VoteB:%s", d.VoteA.String(), d.VoteB.String())
+	}
+	if d.VoteA.BlockNum <= d.VoteB.BlockNum {
+		return fmt.Errorf("TimestampViewChangeEvidence BlockNum do not match, VoteA:%s, VoteB:%s", d.VoteA.String(), d.VoteB.String())
+	}
+	if d.VoteA.ValidatorIndex != d.VoteB.ValidatorIndex ||
+		d.VoteA.ValidatorAddr != d.VoteB.ValidatorAddr {
+		return fmt.Errorf("TimestampViewChangeEvidence Validator do not match, VoteA:%s, VoteB:%s", d.VoteA.String(), d.VoteB.String())
+	}
+	if err := verifyVoteAddr(d.VoteA, d.VoteA.ValidatorAddr); nil != err {
+		return fmt.Errorf("TimestampViewChangeEvidence Vote verify failed, VoteA:%s", d.VoteA.String())
+	}
+	if err := verifyVoteAddr(d.VoteB, d.VoteB.ValidatorAddr); nil != err {
+		return fmt.Errorf("TimestampViewChangeEvidence Vote verify failed, VoteB:%s", d.VoteB.String())
+	}
+	return nil
+}
+
+// EvidenceData is the json format of the evidences output by cbft
+// NOTE: the json tag must be same as the EvidenceData of cbft
+type EvidenceData struct {
+	DP []*DuplicatePrepareEvidence    `json:"duplicate_prepare"`
+	DV []*DuplicateViewChangeEvidence `json:"duplicate_viewchange"`
+	TV []*TimestampViewChangeEvidence `json:"timestamp_viewchange"`
+}
+
+// Evidences flatten all of the evidences, the null ones are rejected
+func (ed *EvidenceData) Evidences() ([]Evidence, error) {
+	evds := make([]Evidence, 0, len(ed.DP)+len(ed.DV)+len(ed.TV))
+	for _, e := range ed.DP {
+		if nil == e {
+			return nil, EvidenceNullErr
+		}
+		evds = append(evds, e)
+	}
+	for _, e := range ed.DV {
+		if nil == e {
+			return nil, EvidenceNullErr
+		}
+		evds = append(evds, e)
+	}
+	for _, e := range ed.TV {
+		if nil == e {
+			return nil, EvidenceNullErr
+		}
+		evds = append(evds, e)
+	}
+	return evds, nil
+}
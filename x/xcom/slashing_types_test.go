@@ -0,0 +1,94 @@
+package xcom
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"testing"
+
+	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/PlatONnetwork/PlatON-Go/crypto"
+)
+
+func sign(t *testing.T, key *ecdsa.PrivateKey, vote *EvidencePrepare) {
+	data, err := vote.CannibalizeBytes()
+	if nil != err {
+		t.Fatal(err)
+	}
+	sig, err := crypto.Sign(data, key)
+	if nil != err {
+		t.Fatal(err)
+	}
+	vote.Signature.SetBytes(sig)
+}
+
+func TestDuplicatePrepareEvidence_Validate(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	voteA := &EvidencePrepare{Timestamp: 1, Hash: common.BytesToHash([]byte("a")), Number: 10, ValidatorAddr: addr}
+	voteB := &EvidencePrepare{Timestamp: 1, Hash: common.BytesToHash([]byte("b")), Number: 10, ValidatorAddr: addr}
+	sign(t, key, voteA)
+	sign(t, key, voteB)
+
+	evidence := &DuplicatePrepareEvidence{VoteA: voteA, VoteB: voteB}
+	if err := evidence.Validate(); nil != err {
+		t.Fatalf("expected valid evidence, got %v", err)
+	}
+	if evidence.Address() != addr || evidence.BlockNumber() != 10 || evidence.Type() != DuplicatePrepareType {
+		t.Fatal("evidence fields mismatch")
+	}
+
+	// the same block hash is not a duplicate signing
+	same := &DuplicatePrepareEvidence{VoteA: voteA, VoteB: voteA}
+	if err := same.Validate(); nil == err {
+		t.Fatal("expected error for the same block hash")
+	}
+
+	// the vote signed by another validator
+	otherKey, _ := crypto.GenerateKey()
+	other := &EvidencePrepare{Timestamp: 1, Hash: common.BytesToHash([]byte("c")), Number: 10, ValidatorAddr: addr}
+	sign(t, otherKey, other)
+	forged := &DuplicatePrepareEvidence{VoteA: voteA, VoteB: other}
+	if err := forged.Validate(); nil == err {
+		t.Fatal("expected error for the forged signature")
+	}
+
+	if err := (&DuplicatePrepareEvidence{VoteA: voteA}).Validate(); err != EvidenceEmptyVoteErr {
+		t.Fatalf("expected EvidenceEmptyVoteErr, got %v", err)
+	}
+}
+
+func TestEvidenceData_Evidences(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	voteA := &EvidencePrepare{Timestamp: 1, Hash: common.BytesToHash([]byte("a")), Number: 10, ValidatorAddr: addr}
+	voteB := &EvidencePrepare{Timestamp: 1, Hash: common.BytesToHash([]byte("b")), Number: 10, ValidatorAddr: addr}
+	sign(t, key, voteA)
+	sign(t, key, voteB)
+
+	data := EvidenceData{DP: []*DuplicatePrepareEvidence{{VoteA: voteA, VoteB: voteB}}}
+	buf, err := json.Marshal(data)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	var decoded EvidenceData
+	if err := json.Unmarshal(buf, &decoded); nil != err {
+		t.Fatal(err)
+	}
+
+	evidences, err := decoded.Evidences()
+	if nil != err {
+		t.Fatal(err)
+	}
+	if len(evidences) != 1 {
+		t.Fatalf("expected 1 evidence, got %d", len(evidences))
+	}
+	if err := evidences[0].Validate(); nil != err {
+		t.Fatal(err)
+	}
+	if string(evidences[0].Hash()) != string(data.DP[0].Hash()) {
+		t.Fatal("evidence hash mismatch after decoding")
+	}
+}
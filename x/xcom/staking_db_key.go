@@ -2,6 +2,7 @@ package xcom
 
 import (
 	"crypto/ecdsa"
+	"fmt"
 	"github.com/PlatONnetwork/PlatON-Go/common"
 	"github.com/PlatONnetwork/PlatON-Go/common/math"
 	"github.com/PlatONnetwork/PlatON-Go/crypto"
@@ -35,6 +36,10 @@ const (
 
 	NextRoundValidatorKeyStr = "NextRoundValidator"
 
+	PackageCountKeyStr = "PackageCount"
+
+	DelegateRewardPerKeyStr = "RewardPer"
+
 
 
 
@@ -63,6 +68,10 @@ var (
 
 	NextRoundValidatorKey = []byte(NextRoundValidatorKeyStr)
 
+	PackageCountKey = []byte(PackageCountKeyStr)
+
+	DelegateRewardPerKey = []byte(DelegateRewardPerKeyStr)
+
 
 
 
@@ -130,6 +139,37 @@ func GetDelegateKeyBySuffix(suffix []byte) []byte {
 	return append(DelegateKeyPrefix, suffix...)
 }
 
+// the final DelegateRewardPer of the deleted candidate
+func GetDelegateRewardPerKey(nodeId discover.NodeID, stakeBlockNumber uint64) []byte {
+	num := strconv.FormatUint(stakeBlockNumber, 10)
+	return append(DelegateRewardPerKey, append(nodeId.Bytes(), []byte(num)...)...)
+}
+
+// the prefix of all delegations of the delegate address
+func GetDelegateKeyPrefixByAddr(delAddr common.Address) []byte {
+	return append(DelegateKeyPrefix, delAddr.Bytes()...)
+}
+
+// ParseDelegateKeySuffix splits the suffix of the delegate key
+// into `delegateAddress` + `nodeId` + `stakeBlockNumber`
+func ParseDelegateKeySuffix(suffix []byte) (common.Address, discover.NodeID, uint64, error) {
+	var nodeId discover.NodeID
+
+	if len(suffix) <= common.AddressLength+len(nodeId) {
+		return common.ZeroAddr, nodeId, 0, fmt.Errorf("invalid delegate key suffix: %x", suffix)
+	}
+
+	delAddr := common.BytesToAddress(suffix[:common.AddressLength])
+	copy(nodeId[:], suffix[common.AddressLength:common.AddressLength+len(nodeId)])
+
+	num, err := strconv.ParseUint(string(suffix[common.AddressLength+len(nodeId):]), 10, 64)
+	if nil != err {
+		return common.ZeroAddr, nodeId, 0, err
+	}
+	return delAddr, nodeId, num, nil
+}
+
+
 func GetUnDelegateCountKey (epoch int) []byte {
 	epochStr := strconv.Itoa(epoch)
 	return  append(UnDelegateCountKey, []byte(epochStr)...)
@@ -157,3 +197,16 @@ func GetNextRoundValidatorKey () []byte {
 	return NextRoundValidatorKey
 }
 
+// the count of blocks packed by the validator in the consensus round
+func GetPackageCountKey (round uint64, addr common.Address) []byte {
+	roundStr := strconv.FormatUint(round, 10)
+	return append(PackageCountKey, append([]byte(roundStr), addr.Bytes()...)...)
+}
+
+// the consensus round of the key of the package count
+func DecodePackageCountRound (key []byte) (uint64, error) {
+	if len(key) <= len(PackageCountKey)+common.AddressLength {
+		return 0, fmt.Errorf("invalid package count key: %x", key)
+	}
+	return strconv.ParseUint(string(key[len(PackageCountKey):len(key)-common.AddressLength]), 10, 64)
+}
@@ -0,0 +1,34 @@
+package xcom
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/PlatONnetwork/PlatON-Go/common"
+	"github.com/PlatONnetwork/PlatON-Go/p2p/discover"
+)
+
+func TestParseDelegateKeySuffix(t *testing.T) {
+	delAddr := common.HexToAddress("0x740ce31b3fac20dac379db243021a51e80ad00d7")
+	var nodeId discover.NodeID
+	for i := range nodeId {
+		nodeId[i] = byte(i)
+	}
+
+	key := GetDelegateKey(delAddr, nodeId, 1024)
+	if !bytes.HasPrefix(key, GetDelegateKeyPrefixByAddr(delAddr)) {
+		t.Fatalf("the delegate key %x has not the prefix of the address", key)
+	}
+
+	addr, id, num, err := ParseDelegateKeySuffix(key[len(DelegateKeyPrefix):])
+	if nil != err {
+		t.Fatal(err)
+	}
+	if addr != delAddr || id != nodeId || num != 1024 {
+		t.Fatalf("mismatched suffix, addr: %s, nodeId: %s, num: %d", addr.Hex(), id.String(), num)
+	}
+
+	if _, _, _, err := ParseDelegateKeySuffix(delAddr.Bytes()); nil == err {
+		t.Fatal("expected an error on the short suffix")
+	}
+}
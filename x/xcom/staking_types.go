@@ -6,6 +6,9 @@ import (
 	"math/big"
 )
 
+// The scale of the Candidate.DelegateRewardPer, keep the precision of the reward per von
+var DelegateRewardPerScale = new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+
 const (
 	/**
 	######   ######   ######   ######
@@ -18,10 +21,13 @@ const (
 	Valided   = 0         // 0000: The current candidate is in force
 
 	NotExist = 1 << 31    // 1000,xxxx,... : The candidate is not exist
+
+	// The max commission rate of the candidate (100%)
+	MaxCommissionRate = 10000
 )
 
 func Is_Valid(status uint32) bool {
-	return status&Valided == Valided
+	return status == Valided
 }
 
 func Is_Invalid(status uint32) bool {
@@ -99,6 +105,12 @@ type Candidate struct {
 	// The staking von  is locked for hesitant epoch (in hesitation)
 	LockRepoTmp *big.Int
 
+	// The percentage of the staking reward retained by the candidate
+	// before sharing with the delegators (unit is 1/10000)
+	CommissionRate uint16
+	// The accumulated delegate reward per von of the shares,
+	// it is scaled up by DelegateRewardPerScale
+	DelegateRewardPer *big.Int
 
 	// Node desc
 	Description
@@ -169,6 +181,11 @@ type Delegation struct {
 	LockRepo *big.Int
 	// The delegate von  is locked for hesitant epoch (in hesitation)
 	LockRepoTmp *big.Int
+
+	// The DelegateRewardPer of the candidate when the income was settled last time
+	RewardPerSnapshot *big.Int
+	// The delegate reward which has been settled but not withdrawn
+	CumulativeIncome *big.Int
 }
 
 
@@ -179,7 +196,47 @@ type Delegation struct {
 }*/
 
 type UnDelegateItem struct {
-	// this is the `delegateAddress` + `nodeAddress` + `stakeBlockNumber`
+	// this is the `delegateAddress` + `nodeId` + `stakeBlockNumber`
 	KeySuffix 	[]byte
 	Amount 		*big.Int
-}
\ No newline at end of file
+}
+
+// the Delegate information with its relates
+type DelegationEx struct {
+	Addr            common.Address
+	NodeId          discover.NodeID
+	StakingBlockNum uint64
+	*Delegation
+}
+
+type DelegationExQueue = []*DelegationEx
+
+// the pending unstake of the candidate
+type UnStakeItemEx struct {
+	NodeAddress     common.Address
+	NodeId          discover.NodeID
+	StakingBlockNum uint64
+	// The von which will be returned to the staking address
+	Amount *big.Int
+	// The epoch number at withdrew the candidate
+	Epoch uint64
+	// The epoch number at which the von will be returned
+	ReleaseEpoch uint64
+}
+
+type UnStakeQueue = []*UnStakeItemEx
+
+// the pending undelegate of the delegation
+type UnDelegateItemEx struct {
+	Addr            common.Address
+	NodeId          discover.NodeID
+	StakingBlockNum uint64
+	// The von which will be returned to the delegate address
+	Amount *big.Int
+	// The epoch number at withdrew the delegation
+	Epoch uint64
+	// The epoch number at which the von will be returned
+	ReleaseEpoch uint64
+}
+
+type UnDelegateQueue = []*UnDelegateItemEx
\ No newline at end of file
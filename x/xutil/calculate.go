@@ -86,4 +86,9 @@ func NodeId2Addr (nodeId discover.NodeID) (common.Address, error) {
 	} else {
 		return crypto.PubkeyToAddress(*pk), nil
 	}
-}
\ No newline at end of file
+}
+
+// the block number at the end of the epoch, the settlement of the epoch happens at it
+func CalculateLastBlockOfEpoch(epoch uint64) uint64 {
+	return epoch * xcom.ConsensusSize * xcom.EpochSize
+}